@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -21,15 +22,11 @@ func main() {
 	app.BuildTime = buildTime
 	app.GitCommit = gitCommit
 
-	// Initialize application directories on first run
-	if err := app.InitApp(); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to initialize application: %v\n", err)
-		os.Exit(1)
-	}
-
 	// Execute CLI commands
 	if err := cli.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if !errors.Is(err, cli.ErrSilent) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
 		os.Exit(1)
 	}
 }
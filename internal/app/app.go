@@ -11,27 +11,52 @@ import (
 
 // App represents the main application instance
 type App struct {
-	config *Config
-	store  *models.Store
+	config     *Config
+	store      *models.Store
+	configLoad time.Duration
+	storeLoad  time.Duration
+	demoDir    string // Scratch directory of a demo, removed by Close
 }
 
-// New creates a new application instance
+// New creates a new application instance. With shared.read_only set, the
+// store rejects writes.
 func New() (*App, error) {
+	return newApp(false)
+}
+
+// NewReadOnly creates an application instance whose store rejects writes, for
+// --read-only
+func NewReadOnly() (*App, error) {
+	return newApp(true)
+}
+
+// newApp loads the config and the store, read-only if readOnly or the config
+// says so
+func newApp(readOnly bool) (*App, error) {
 	// Load configuration
+	start := time.Now()
 	config, err := LoadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
+	configLoaded := time.Now()
 
 	// Initialize data store
-	store, err := models.NewStore(config.GetDataDir())
+	open := models.NewStore
+	if readOnly || config.Shared.ReadOnly {
+		open = models.NewReadOnlyStore
+	}
+	store, err := open(config.GetDataDir())
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize store: %w", err)
 	}
+	store.SetBackups(config.Backups)
 
 	app := &App{
-		config: config,
-		store:  store,
+		config:     config,
+		store:      store,
+		configLoad: configLoaded.Sub(start),
+		storeLoad:  time.Since(configLoaded),
 	}
 
 	return app, nil
@@ -47,6 +72,24 @@ func (a *App) GetStore() *models.Store {
 	return a.store
 }
 
+// ConnectRemote replaces the local store with the reminders remote keeps,
+// for commands run with --remote
+func (a *App) ConnectRemote(remote models.Remote) error {
+	start := time.Now()
+	store, err := models.NewRemoteStore(remote)
+	if err != nil {
+		return fmt.Errorf("failed to open the remote store: %w", err)
+	}
+	a.store = store
+	a.storeLoad = time.Since(start)
+	return nil
+}
+
+// LoadTimes returns how long loading the config and the store took
+func (a *App) LoadTimes() (config, store time.Duration) {
+	return a.configLoad, a.storeLoad
+}
+
 // AddReminder adds a new reminder to the store
 func (a *App) AddReminder(title string, dueTime interface{}, priority models.Priority) (*models.Reminder, error) {
 	// TODO: Parse dueTime (could be time.Time, string, etc.)
@@ -5,41 +5,93 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 )
 
 // Config holds all application configuration
 type Config struct {
+	Version       int                `mapstructure:"version"` // Config file format, see ConfigVersion
 	DataDir       string             `mapstructure:"data_dir"`
+	Backups       int                `mapstructure:"backups"` // Copies of the reminders file to keep, reminders.json.1 (newest) to .N
 	Default       DefaultConfig      `mapstructure:"default"`
 	Notifications NotificationConfig `mapstructure:"notifications"`
 	Appearance    AppearanceConfig   `mapstructure:"appearance"`
 	WorkHours     WorkHoursConfig    `mapstructure:"workhours"`
 	Daemon        DaemonConfig       `mapstructure:"daemon"`
+	Shared        SharedConfig       `mapstructure:"shared"`
+	TimesOfDay    models.TimesOfDay  `mapstructure:"times_of_day"` // e.g. morning: "09:00"
+	Integrations  IntegrationsConfig `mapstructure:"integrations"`
+	Location      LocationConfig     `mapstructure:"location"`
+	Remote        RemoteConfig       `mapstructure:"remote"`
+	UsageLog      bool               `mapstructure:"usage_log"` // Record commands locally for 'nancy stats --usage'
+	Rules         []string           `mapstructure:"rules"`     // Housekeeping rules the daemon applies daily, e.g. "completed > 7d -> archive"
+	Include       string             `mapstructure:"include"`   // Shared team config (URL or file) merged under this one
+
+	included   map[string]interface{} // Settings that came from the include
+	includeErr error                  // Why the include couldn't be read, if it couldn't
+	backup     string                 // Copy of the file from before it was migrated
+	migrated   []string               // What migrating the file changed
+	configDir  string                 // Used instead of the usual config directory, if set
 }
 
 // DefaultConfig holds default settings for new reminders
 type DefaultConfig struct {
 	Priority       string `mapstructure:"priority"`
 	AdvanceMinutes int    `mapstructure:"advance_minutes"`
+	STTCommand     string `mapstructure:"stt_command"`         // Speech-to-text for 'add --audio', e.g. "whisper-cli -nt -f {file}"
+	MaxPerDay      int    `mapstructure:"max_per_day"`         // 'add' warns when a day would have more reminders, 0 = off
+	CheckDuplicate bool   `mapstructure:"check_duplicates"`    // 'add' and 'import' warn about similar reminders due the same day
+	PastGrace      int    `mapstructure:"past_grace_minutes"`  // How far in the past a new due time may be, unless --past-ok
+	MaxYearsAhead  int    `mapstructure:"max_years_ahead"`     // How far ahead a due time may be, 0 = no limit
+	InferPriority  bool   `mapstructure:"infer_priority"`      // 'add' picks high/low from how close the due time is when none is given
+	InviteLead     int    `mapstructure:"invite_lead_minutes"` // 'add --ics' makes the reminder due this long before the meeting
+	UntimedTime    string `mapstructure:"untimed_time"`        // Time for a date without one, unless learned from similar reminders
 }
 
 // NotificationConfig holds notification settings
 type NotificationConfig struct {
-	Enabled        bool `mapstructure:"enabled"`
-	Sound          bool `mapstructure:"sound"`
-	AdvanceMinutes int  `mapstructure:"advance_minutes"`
-	QuietHours     bool `mapstructure:"quiet_hours"`
+	Enabled           bool           `mapstructure:"enabled"`
+	Sound             bool           `mapstructure:"sound"`
+	AdvanceMinutes    int            `mapstructure:"advance_minutes"`
+	QuietHours        bool           `mapstructure:"quiet_hours"`
+	DueSoonMinutes    int            `mapstructure:"due_soon_minutes"`     // How long before due a reminder is "due soon"
+	DueSoonByPriority map[string]int `mapstructure:"due_soon_by_priority"` // Per-priority overrides, e.g. high: 120
+	HighIsCritical    bool           `mapstructure:"high_is_critical"`     // High priority reminders notify even in quiet hours
+	DedupMinutes      int            `mapstructure:"dedup_minutes"`        // Drop a notification identical to one sent this recently, 0 = off
+	RateLimits        map[string]int `mapstructure:"rate_limits"`          // Max notifications per hour per channel, e.g. desktop: 20
+	WhenLocked        string         `mapstructure:"when_locked"`          // "queue" until unlocked, or "notify" anyway
+	IdleMinutes       int            `mapstructure:"idle_minutes"`         // Count as away after this long without input, 0 = only when locked
+	RetryMaxMinutes   int            `mapstructure:"retry_max_minutes"`    // Keep retrying failed desktop notifications this long, 0 = don't retry
+	OverdueGrace      int            `mapstructure:"overdue_grace"`        // Minutes a reminder may be overdue before the first nag, 0 = none
 }
 
 // AppearanceConfig holds UI appearance settings
 type AppearanceConfig struct {
-	Theme         string `mapstructure:"theme"` // "light", "dark", "auto"
-	ShowCompleted bool   `mapstructure:"show_completed"`
-	CompactMode   bool   `mapstructure:"compact_mode"`
-	ShowIcons     bool   `mapstructure:"show_icons"`
+	Theme         string            `mapstructure:"theme"` // "light", "dark", "auto"
+	ShowCompleted bool              `mapstructure:"show_completed"`
+	CompactMode   bool              `mapstructure:"compact_mode"`
+	ShowIcons     bool              `mapstructure:"show_icons"`
+	Accessible    bool              `mapstructure:"accessible"` // Text labels instead of emoji, screen-reader friendly
+	Language      string            `mapstructure:"language"`   // "auto" (from LANG), "en" or "de"
+	Feedback      FeedbackConfig    `mapstructure:"feedback"`
+	TagColors     map[string]string `mapstructure:"tag_colors"`  // Label color per tag, e.g. work: blue
+	DateFormat    string            `mapstructure:"date_format"` // "auto" (from the language), "mdy" or "dmy"
+	TimeFormat    string            `mapstructure:"time_format"` // "auto" (from the language), "12h" or "24h"
+	FirstDay      string            `mapstructure:"first_day"`   // First day of the week: "monday" or "sunday"
+}
+
+// FeedbackConfig sets the TUI feedback per action: "none", "bell", "flash" or "both"
+type FeedbackConfig struct {
+	Complete   string `mapstructure:"complete"`
+	Uncomplete string `mapstructure:"uncomplete"`
+	Delete     string `mapstructure:"delete"`
 }
 
 // WorkHoursConfig defines working hours for quiet notifications
@@ -56,6 +108,44 @@ type DaemonConfig struct {
 	CheckInterval int    `mapstructure:"check_interval"` // minutes
 	AutoStart     bool   `mapstructure:"auto_start"`
 	LogLevel      string `mapstructure:"log_level"`
+	WeeklyDigest  bool   `mapstructure:"weekly_digest"` // Monday summary notification
+	DigestStale   bool   `mapstructure:"digest_stale"`  // Include stale reminders in the digest
+	StaleDays     int    `mapstructure:"stale_days"`
+	JournalFile   string `mapstructure:"journal_file"`  // Append yesterday's done list here each night
+	HTMLFile      string `mapstructure:"html_file"`     // Rewrite this HTML page of reminders each morning
+	CheckUpdates  bool   `mapstructure:"check_updates"` // Notify once a day when a new release is out
+	PlanTime      string `mapstructure:"plan_time"`     // Morning "plan your day" notification, e.g. "08:30"
+	WrapUpTime    string `mapstructure:"wrap_up_time"`  // Evening summary of what rolled over, e.g. "18:00"
+}
+
+// SharedConfig holds settings for data directories shared between users
+type SharedConfig struct {
+	ReadOnly bool   `mapstructure:"read_only"`
+	User     string `mapstructure:"user"` // Name used for assignments (defaults to $USER)
+}
+
+// IntegrationsConfig holds API settings for linked GitHub and Jira issues
+type IntegrationsConfig struct {
+	GitHubToken    string `mapstructure:"github_token"` // Falls back to $GITHUB_TOKEN
+	JiraURL        string `mapstructure:"jira_url"`     // e.g. https://example.atlassian.net
+	JiraEmail      string `mapstructure:"jira_email"`
+	JiraToken      string `mapstructure:"jira_token"`
+	CloseWithIssue bool   `mapstructure:"close_with_issue"`    // Daemon completes reminders whose issue closed
+	ReviewSync     int    `mapstructure:"review_sync_minutes"` // Daemon imports GitHub review requests every N minutes, 0 = off
+	CalendarURL    string `mapstructure:"calendar_url"`        // ICS feed or file; busy events hold back notifications
+	DuringMeetings string `mapstructure:"during_meetings"`     // "defer" or "suppress" non-critical notifications
+}
+
+// LocationConfig holds the coordinates used for "at sunrise" and "at sunset"
+type LocationConfig struct {
+	Latitude  float64 `mapstructure:"latitude"`  // Degrees north, negative for south
+	Longitude float64 `mapstructure:"longitude"` // Degrees east, negative for west
+}
+
+// RemoteConfig points Nancy at a 'nancy serve' instance holding the reminders
+type RemoteConfig struct {
+	URL   string `mapstructure:"url"`   // e.g. https://home.example.com:8080; empty = use the local store
+	Token string `mapstructure:"token"` // Bearer token the server requires, also the default for 'nancy serve --token'
 }
 
 // getConfigDir returns the appropriate config directory for the OS
@@ -105,22 +195,51 @@ func getDataDir() string {
 // DefaultConfig returns a config with sensible defaults
 func NewDefaultConfig() *Config {
 	return &Config{
-		DataDir: getDataDir(),
+		Version:  ConfigVersion,
+		DataDir:  getDataDir(),
+		Backups:  models.DefaultBackups,
+		UsageLog: false,
+		Rules:    []string{},
+		Include:  "",
 		Default: DefaultConfig{
 			Priority:       "medium",
 			AdvanceMinutes: 10,
+			MaxPerDay:      8,
+			PastGrace:      60,
+			MaxYearsAhead:  10,
+			InferPriority:  false,
+			InviteLead:     15,
+			UntimedTime:    "09:00",
 		},
 		Notifications: NotificationConfig{
-			Enabled:        true,
-			Sound:          true,
-			AdvanceMinutes: 15,
-			QuietHours:     true,
+			Enabled:           true,
+			Sound:             true,
+			AdvanceMinutes:    15,
+			QuietHours:        true,
+			DueSoonMinutes:    60,
+			DueSoonByPriority: map[string]int{},
+			DedupMinutes:      10,
+			RateLimits:        map[string]int{},
+			WhenLocked:        "queue",
+			IdleMinutes:       0,
+			RetryMaxMinutes:   60,
 		},
 		Appearance: AppearanceConfig{
 			Theme:         "auto",
 			ShowCompleted: false,
 			CompactMode:   false,
 			ShowIcons:     true,
+			Accessible:    false,
+			Language:      "auto",
+			Feedback: FeedbackConfig{
+				Complete:   "flash",
+				Uncomplete: "flash",
+				Delete:     "flash",
+			},
+			TagColors:  map[string]string{},
+			DateFormat: "auto",
+			TimeFormat: "auto",
+			FirstDay:   "monday",
 		},
 		WorkHours: WorkHoursConfig{
 			Enabled:      true,
@@ -133,6 +252,23 @@ func NewDefaultConfig() *Config {
 			CheckInterval: 5, // check every 5 minutes
 			AutoStart:     false,
 			LogLevel:      "info",
+			WeeklyDigest:  false,
+			DigestStale:   true,
+			StaleDays:     14,
+			JournalFile:   "",
+			HTMLFile:      "",
+			CheckUpdates:  false,
+			PlanTime:      "",
+			WrapUpTime:    "",
+		},
+		Shared: SharedConfig{
+			ReadOnly: false,
+			User:     "",
+		},
+		TimesOfDay: models.DefaultTimesOfDay(),
+		Integrations: IntegrationsConfig{
+			CloseWithIssue: false,
+			DuringMeetings: "defer",
 		},
 	}
 }
@@ -155,6 +291,15 @@ func LoadConfig() (*Config, error) {
 	config := NewDefaultConfig()
 	setViperDefaults(config)
 
+	defaults := viper.AllSettings()
+
+	// Bring files written by older versions up to date
+	backup, migrated, err := migrateConfigFile(filepath.Join(configDir, "config.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate config file: %w", err)
+	}
+	config.backup, config.migrated = backup, migrated
+
 	// Try to read config file
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
@@ -167,6 +312,10 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// Settings the local file doesn't set come from the shared team config.
+	// Nancy still starts when it can't be read; 'nancy doctor' reports why.
+	config.included, config.includeErr = applyInclude(configDir, defaults)
+
 	// Unmarshal into config struct
 	if err := viper.Unmarshal(config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
@@ -182,17 +331,47 @@ func LoadConfig() (*Config, error) {
 
 // setViperDefaults sets default values in viper
 func setViperDefaults(config *Config) {
+	viper.SetDefault("version", config.Version)
 	viper.SetDefault("data_dir", config.DataDir)
+	viper.SetDefault("backups", config.Backups)
+	viper.SetDefault("usage_log", config.UsageLog)
+	viper.SetDefault("rules", config.Rules)
+	viper.SetDefault("include", config.Include)
 	viper.SetDefault("default.priority", config.Default.Priority)
 	viper.SetDefault("default.advance_minutes", config.Default.AdvanceMinutes)
+	viper.SetDefault("default.stt_command", config.Default.STTCommand)
+	viper.SetDefault("default.max_per_day", config.Default.MaxPerDay)
+	viper.SetDefault("default.check_duplicates", config.Default.CheckDuplicate)
+	viper.SetDefault("default.past_grace_minutes", config.Default.PastGrace)
+	viper.SetDefault("default.max_years_ahead", config.Default.MaxYearsAhead)
+	viper.SetDefault("default.infer_priority", config.Default.InferPriority)
+	viper.SetDefault("default.invite_lead_minutes", config.Default.InviteLead)
+	viper.SetDefault("default.untimed_time", config.Default.UntimedTime)
 	viper.SetDefault("notifications.enabled", config.Notifications.Enabled)
 	viper.SetDefault("notifications.sound", config.Notifications.Sound)
 	viper.SetDefault("notifications.advance_minutes", config.Notifications.AdvanceMinutes)
 	viper.SetDefault("notifications.quiet_hours", config.Notifications.QuietHours)
+	viper.SetDefault("notifications.due_soon_minutes", config.Notifications.DueSoonMinutes)
+	viper.SetDefault("notifications.high_is_critical", config.Notifications.HighIsCritical)
+	viper.SetDefault("notifications.dedup_minutes", config.Notifications.DedupMinutes)
+	viper.SetDefault("notifications.rate_limits", config.Notifications.RateLimits)
+	viper.SetDefault("notifications.when_locked", config.Notifications.WhenLocked)
+	viper.SetDefault("notifications.idle_minutes", config.Notifications.IdleMinutes)
+	viper.SetDefault("notifications.overdue_grace", config.Notifications.OverdueGrace)
+	viper.SetDefault("notifications.retry_max_minutes", config.Notifications.RetryMaxMinutes)
 	viper.SetDefault("appearance.theme", config.Appearance.Theme)
 	viper.SetDefault("appearance.show_completed", config.Appearance.ShowCompleted)
 	viper.SetDefault("appearance.compact_mode", config.Appearance.CompactMode)
 	viper.SetDefault("appearance.show_icons", config.Appearance.ShowIcons)
+	viper.SetDefault("appearance.accessible", config.Appearance.Accessible)
+	viper.SetDefault("appearance.language", config.Appearance.Language)
+	viper.SetDefault("appearance.feedback.complete", config.Appearance.Feedback.Complete)
+	viper.SetDefault("appearance.feedback.uncomplete", config.Appearance.Feedback.Uncomplete)
+	viper.SetDefault("appearance.feedback.delete", config.Appearance.Feedback.Delete)
+	viper.SetDefault("appearance.tag_colors", config.Appearance.TagColors)
+	viper.SetDefault("appearance.date_format", config.Appearance.DateFormat)
+	viper.SetDefault("appearance.time_format", config.Appearance.TimeFormat)
+	viper.SetDefault("appearance.first_day", config.Appearance.FirstDay)
 	viper.SetDefault("workhours.enabled", config.WorkHours.Enabled)
 	viper.SetDefault("workhours.start", config.WorkHours.Start)
 	viper.SetDefault("workhours.end", config.WorkHours.End)
@@ -201,6 +380,31 @@ func setViperDefaults(config *Config) {
 	viper.SetDefault("daemon.check_interval", config.Daemon.CheckInterval)
 	viper.SetDefault("daemon.auto_start", config.Daemon.AutoStart)
 	viper.SetDefault("daemon.log_level", config.Daemon.LogLevel)
+	viper.SetDefault("daemon.weekly_digest", config.Daemon.WeeklyDigest)
+	viper.SetDefault("daemon.digest_stale", config.Daemon.DigestStale)
+	viper.SetDefault("daemon.stale_days", config.Daemon.StaleDays)
+	viper.SetDefault("daemon.journal_file", config.Daemon.JournalFile)
+	viper.SetDefault("daemon.html_file", config.Daemon.HTMLFile)
+	viper.SetDefault("daemon.check_updates", config.Daemon.CheckUpdates)
+	viper.SetDefault("daemon.plan_time", config.Daemon.PlanTime)
+	viper.SetDefault("daemon.wrap_up_time", config.Daemon.WrapUpTime)
+	viper.SetDefault("shared.read_only", config.Shared.ReadOnly)
+	viper.SetDefault("shared.user", config.Shared.User)
+	for name, clock := range config.TimesOfDay {
+		viper.SetDefault("times_of_day."+name, clock)
+	}
+	viper.SetDefault("integrations.github_token", config.Integrations.GitHubToken)
+	viper.SetDefault("integrations.jira_url", config.Integrations.JiraURL)
+	viper.SetDefault("integrations.jira_email", config.Integrations.JiraEmail)
+	viper.SetDefault("integrations.jira_token", config.Integrations.JiraToken)
+	viper.SetDefault("integrations.close_with_issue", config.Integrations.CloseWithIssue)
+	viper.SetDefault("integrations.review_sync_minutes", config.Integrations.ReviewSync)
+	viper.SetDefault("integrations.calendar_url", config.Integrations.CalendarURL)
+	viper.SetDefault("integrations.during_meetings", config.Integrations.DuringMeetings)
+	viper.SetDefault("location.latitude", config.Location.Latitude)
+	viper.SetDefault("location.longitude", config.Location.Longitude)
+	viper.SetDefault("remote.url", config.Remote.URL)
+	viper.SetDefault("remote.token", config.Remote.Token)
 }
 
 // saveDefaultConfig creates a default config file
@@ -214,13 +418,39 @@ func saveDefaultConfig(configDir string) error {
 
 	configContent := `# Nagging Nancy Configuration
 
+# Config format, updated by Nancy; don't change it
+version: 1
+
 # Data storage directory (leave empty for auto-detection)
 data_dir: ""
 
+# Hourly copies of the reminders file to keep; recover with
+# 'nancy restore --from-backup' (0 = none)
+backups: 5
+
+# Record the commands you run for 'nancy stats --usage' (stays on this machine)
+usage_log: false
+
+# Housekeeping the daemon does once a day; preview with 'nancy rules test'
+# e.g. "completed > 7d -> archive" or "tag=errand and overdue > 30d -> delete"
+rules: []
+
+# Shared team config (https URL or file) for settings you leave at their
+# defaults here; refreshed every 6 hours
+include: ""
+
 # Default settings for new reminders
 default:
   priority: medium          # low, medium, high
   advance_minutes: 10       # Default notification advance time
+  stt_command: ""           # Transcriber for 'add --audio'; {file} is the audio file
+  max_per_day: 8            # Warn when adding makes a day busier than this (0 = off)
+  check_duplicates: false   # Warn about a similar reminder due the same day
+  past_grace_minutes: 60    # Reject due times further in the past (unless --past-ok)
+  max_years_ahead: 10       # Reject due times further ahead (0 = no limit)
+  infer_priority: false     # No priority given: high when due within 2h, low beyond 2 weeks
+  invite_lead_minutes: 15   # 'add --ics' reminds this long before the meeting starts
+  untimed_time: "09:00"     # Time for a date given without one, unless learned (empty = in an hour)
 
 # Notification settings
 notifications:
@@ -228,6 +458,15 @@ notifications:
   sound: true               # Play notification sound
   advance_minutes: 15       # How many minutes before due time to notify
   quiet_hours: true         # Respect working hours for notifications
+  due_soon_minutes: 60      # Highlight and notify this long before due time
+  due_soon_by_priority: {}  # Per-priority overrides, e.g. {high: 120, low: 15}
+  high_is_critical: false   # High priority reminders notify even in quiet hours and meetings
+  dedup_minutes: 10         # Drop a notification identical to one sent this recently (0 = off)
+  rate_limits: {}           # Max notifications per hour per channel, e.g. {desktop: 20, bell: 5}
+  when_locked: queue        # queue (deliver after unlocking) or notify while the screen is locked
+  idle_minutes: 0           # Also queue after this long without input (0 = only when locked)
+  overdue_grace: 0          # Minutes overdue before the first nag, per reminder with --grace (0 = none)
+  retry_max_minutes: 60     # Retry failed desktop notifications for this long (0 = don't retry)
 
 # Appearance settings
 appearance:
@@ -235,6 +474,16 @@ appearance:
   show_completed: false     # Show completed tasks in main list
   compact_mode: false       # Use compact display mode
   show_icons: true          # Show priority and status icons
+  accessible: false         # Text labels instead of emoji (screen-reader friendly)
+  language: auto            # auto (from LANG), en, de
+  feedback:                 # TUI feedback per action: none, bell, flash, both
+    complete: flash
+    uncomplete: flash
+    delete: flash
+  tag_colors: {}            # Label color per tag, e.g. {work: blue, home: green}
+  date_format: auto         # auto (from language), mdy (Mar 20) or dmy (20 Mar)
+  time_format: auto         # auto (from language), 12h (3:04 PM) or 24h (15:04)
+  first_day: monday         # First day of the week: monday or sunday
 
 # Working hours (for quiet notifications)
 workhours:
@@ -249,6 +498,48 @@ daemon:
   check_interval: 5         # Check for due reminders every N minutes
   auto_start: false         # Auto-start daemon on system boot
   log_level: "info"         # Logging level: debug, info, warn, error
+  weekly_digest: false      # Send a summary notification every Monday
+  digest_stale: true        # Include stale reminders in the weekly digest
+  stale_days: 14            # Days without updates before a reminder is stale
+  html_file: ""             # Rewrite this HTML page of reminders each morning
+  check_updates: false      # Notify once a day when a new release is out
+  plan_time: ""             # Morning "plan your day" notification, e.g. "08:30"
+  wrap_up_time: ""          # Evening summary of what rolled over, e.g. "18:00"
+
+# Shared data directory settings
+shared:
+  read_only: false          # Open the data directory without writing to it
+  user: ""                  # Your name for assignments (defaults to $USER)
+
+# Clock times for "tomorrow morning", "tonight", "at noon", ...
+times_of_day:
+  morning: "09:00"
+  noon: "12:00"
+  afternoon: "15:00"
+  evening: "18:00"
+  tonight: "20:00"
+  midnight: "00:00"
+
+# Linked GitHub and Jira issues ('nancy add --issue')
+integrations:
+  github_token: ""          # Needed for private repos (defaults to $GITHUB_TOKEN)
+  jira_url: ""              # e.g. https://example.atlassian.net
+  jira_email: ""            # Jira Cloud account email
+  jira_token: ""            # Jira API token
+  close_with_issue: false   # Daemon completes reminders when their issue closes
+  review_sync_minutes: 0    # Import GitHub review requests every N minutes (0 = off)
+  calendar_url: ""          # ICS/CalDAV feed URL or file; hold back notifications during meetings
+  during_meetings: "defer"  # defer (deliver afterwards) or suppress non-high-priority notifications
+
+# Coordinates for "at sunrise" and "at sunset" (0, 0 = not set)
+location:
+  latitude: 0               # e.g. 52.52 (negative for south)
+  longitude: 0              # e.g. 13.40 (negative for west)
+
+# Use the reminders of a 'nancy serve' instance instead of the local store
+remote:
+  url: ""                   # e.g. https://home.example.com:8080 (same as --remote)
+  token: ""                 # Token the server requires; also the default for 'nancy serve'
 `
 
 	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
@@ -260,23 +551,60 @@ daemon:
 
 // Save saves the current configuration to file
 func (c *Config) Save() error {
-	configDir := getConfigDir()
+	configDir := c.GetConfigDir()
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
 	// Set values in viper
+	viper.Set("version", ConfigVersion)
 	viper.Set("data_dir", c.DataDir)
+	viper.Set("backups", c.Backups)
+	viper.Set("usage_log", c.UsageLog)
+	viper.Set("rules", c.Rules)
+	viper.Set("include", c.Include)
 	viper.Set("default.priority", c.Default.Priority)
 	viper.Set("default.advance_minutes", c.Default.AdvanceMinutes)
+	viper.Set("default.stt_command", c.Default.STTCommand)
+	viper.Set("default.max_per_day", c.Default.MaxPerDay)
+	viper.Set("default.check_duplicates", c.Default.CheckDuplicate)
+	viper.Set("default.past_grace_minutes", c.Default.PastGrace)
+	viper.Set("default.max_years_ahead", c.Default.MaxYearsAhead)
+	viper.Set("default.infer_priority", c.Default.InferPriority)
+	viper.Set("default.invite_lead_minutes", c.Default.InviteLead)
+	viper.Set("default.untimed_time", c.Default.UntimedTime)
 	viper.Set("notifications.enabled", c.Notifications.Enabled)
 	viper.Set("notifications.sound", c.Notifications.Sound)
 	viper.Set("notifications.advance_minutes", c.Notifications.AdvanceMinutes)
 	viper.Set("notifications.quiet_hours", c.Notifications.QuietHours)
+	viper.Set("notifications.due_soon_minutes", c.Notifications.DueSoonMinutes)
+	viper.Set("notifications.high_is_critical", c.Notifications.HighIsCritical)
+	viper.Set("notifications.dedup_minutes", c.Notifications.DedupMinutes)
+	viper.Set("notifications.when_locked", c.Notifications.WhenLocked)
+	viper.Set("notifications.idle_minutes", c.Notifications.IdleMinutes)
+	viper.Set("notifications.overdue_grace", c.Notifications.OverdueGrace)
+	viper.Set("notifications.retry_max_minutes", c.Notifications.RetryMaxMinutes)
+	for channel, perHour := range c.Notifications.RateLimits {
+		viper.Set("notifications.rate_limits."+channel, perHour)
+	}
+	for priority, minutes := range c.Notifications.DueSoonByPriority {
+		viper.Set("notifications.due_soon_by_priority."+priority, minutes)
+	}
 	viper.Set("appearance.theme", c.Appearance.Theme)
 	viper.Set("appearance.show_completed", c.Appearance.ShowCompleted)
 	viper.Set("appearance.compact_mode", c.Appearance.CompactMode)
 	viper.Set("appearance.show_icons", c.Appearance.ShowIcons)
+	viper.Set("appearance.accessible", c.Appearance.Accessible)
+	viper.Set("appearance.language", c.Appearance.Language)
+	viper.Set("appearance.feedback.complete", c.Appearance.Feedback.Complete)
+	viper.Set("appearance.feedback.uncomplete", c.Appearance.Feedback.Uncomplete)
+	viper.Set("appearance.feedback.delete", c.Appearance.Feedback.Delete)
+	for tag, color := range c.Appearance.TagColors {
+		viper.Set("appearance.tag_colors."+tag, color)
+	}
+	viper.Set("appearance.date_format", c.Appearance.DateFormat)
+	viper.Set("appearance.time_format", c.Appearance.TimeFormat)
+	viper.Set("appearance.first_day", c.Appearance.FirstDay)
 	viper.Set("workhours.enabled", c.WorkHours.Enabled)
 	viper.Set("workhours.start", c.WorkHours.Start)
 	viper.Set("workhours.end", c.WorkHours.End)
@@ -285,10 +613,39 @@ func (c *Config) Save() error {
 	viper.Set("daemon.check_interval", c.Daemon.CheckInterval)
 	viper.Set("daemon.auto_start", c.Daemon.AutoStart)
 	viper.Set("daemon.log_level", c.Daemon.LogLevel)
+	viper.Set("daemon.weekly_digest", c.Daemon.WeeklyDigest)
+	viper.Set("daemon.digest_stale", c.Daemon.DigestStale)
+	viper.Set("daemon.stale_days", c.Daemon.StaleDays)
+	viper.Set("daemon.journal_file", c.Daemon.JournalFile)
+	viper.Set("daemon.html_file", c.Daemon.HTMLFile)
+	viper.Set("daemon.check_updates", c.Daemon.CheckUpdates)
+	viper.Set("daemon.plan_time", c.Daemon.PlanTime)
+	viper.Set("daemon.wrap_up_time", c.Daemon.WrapUpTime)
+	viper.Set("shared.read_only", c.Shared.ReadOnly)
+	viper.Set("shared.user", c.Shared.User)
+	for name, clock := range c.TimesOfDay {
+		viper.Set("times_of_day."+name, clock)
+	}
+	viper.Set("integrations.github_token", c.Integrations.GitHubToken)
+	viper.Set("integrations.jira_url", c.Integrations.JiraURL)
+	viper.Set("integrations.jira_email", c.Integrations.JiraEmail)
+	viper.Set("integrations.jira_token", c.Integrations.JiraToken)
+	viper.Set("integrations.close_with_issue", c.Integrations.CloseWithIssue)
+	viper.Set("integrations.review_sync_minutes", c.Integrations.ReviewSync)
+	viper.Set("integrations.calendar_url", c.Integrations.CalendarURL)
+	viper.Set("integrations.during_meetings", c.Integrations.DuringMeetings)
+	viper.Set("location.latitude", c.Location.Latitude)
+	viper.Set("location.longitude", c.Location.Longitude)
+	viper.Set("remote.url", c.Remote.URL)
+	viper.Set("remote.token", c.Remote.Token)
 
-	// Write to file
+	// Write to file, leaving out what the include set
 	configPath := filepath.Join(configDir, "config.yaml")
-	if err := viper.WriteConfigAs(configPath); err != nil {
+	out := viper.New()
+	if err := out.MergeConfigMap(withoutIncluded(viper.AllSettings(), c.included)); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := out.WriteConfigAs(configPath); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
@@ -297,6 +654,14 @@ func (c *Config) Save() error {
 
 // Validate validates the configuration values
 func (c *Config) Validate() error {
+	if scheme, _, ok := strings.Cut(c.Include, "://"); ok && scheme != "https" {
+		return fmt.Errorf("invalid include: %s (use an https URL or a file)", c.Include)
+	}
+
+	if c.Backups < 0 || c.Backups > models.MaxBackups {
+		return fmt.Errorf("invalid backups: %d (must be 0-%d)", c.Backups, models.MaxBackups)
+	}
+
 	// Validate priority
 	if c.Default.Priority != "low" && c.Default.Priority != "medium" && c.Default.Priority != "high" {
 		return fmt.Errorf("invalid default priority: %s", c.Default.Priority)
@@ -307,15 +672,112 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid default advance minutes: %d", c.Default.AdvanceMinutes)
 	}
 
+	if c.Default.MaxPerDay < 0 {
+		return fmt.Errorf("invalid max per day: %d", c.Default.MaxPerDay)
+	}
+
+	if c.Default.PastGrace < 0 {
+		return fmt.Errorf("invalid past grace minutes: %d", c.Default.PastGrace)
+	}
+
+	if c.Default.MaxYearsAhead < 0 {
+		return fmt.Errorf("invalid max years ahead: %d", c.Default.MaxYearsAhead)
+	}
+
+	if c.Default.InviteLead < 0 || c.Default.InviteLead > 1440 {
+		return fmt.Errorf("invalid invite lead minutes: %d (must be 0-1440)", c.Default.InviteLead)
+	}
+
+	if c.Default.UntimedTime != "" {
+		if err := c.validateTimeFormat(c.Default.UntimedTime); err != nil {
+			return fmt.Errorf("invalid untimed time: %w", err)
+		}
+	}
+
 	if c.Notifications.AdvanceMinutes < 0 || c.Notifications.AdvanceMinutes > 1440 {
 		return fmt.Errorf("invalid notification advance minutes: %d", c.Notifications.AdvanceMinutes)
 	}
 
+	if c.Notifications.DueSoonMinutes < 1 || c.Notifications.DueSoonMinutes > 1440 {
+		return fmt.Errorf("invalid due soon minutes: %d (must be 1-1440)", c.Notifications.DueSoonMinutes)
+	}
+
+	for priority, minutes := range c.Notifications.DueSoonByPriority {
+		if priority != "low" && priority != "medium" && priority != "high" {
+			return fmt.Errorf("invalid priority in due_soon_by_priority: %s", priority)
+		}
+		if minutes < 0 || minutes > 1440 {
+			return fmt.Errorf("invalid due soon minutes for %s: %d (must be 0-1440)", priority, minutes)
+		}
+	}
+
+	if c.Notifications.DedupMinutes < 0 {
+		return fmt.Errorf("invalid dedup minutes: %d", c.Notifications.DedupMinutes)
+	}
+
+	for channel, perHour := range c.Notifications.RateLimits {
+		if channel != "desktop" && channel != "bell" && channel != "log" {
+			return fmt.Errorf("invalid channel in rate_limits: %s (use desktop, bell or log)", channel)
+		}
+		if perHour < 0 {
+			return fmt.Errorf("invalid rate limit for %s: %d", channel, perHour)
+		}
+	}
+
+	if c.Notifications.WhenLocked != "queue" && c.Notifications.WhenLocked != "notify" {
+		return fmt.Errorf("invalid when_locked: %s (must be queue or notify)", c.Notifications.WhenLocked)
+	}
+
+	if c.Notifications.IdleMinutes < 0 {
+		return fmt.Errorf("invalid idle minutes: %d", c.Notifications.IdleMinutes)
+	}
+
+	if c.Notifications.RetryMaxMinutes < 0 {
+		return fmt.Errorf("invalid retry max minutes: %d", c.Notifications.RetryMaxMinutes)
+	}
+
+	if c.Notifications.OverdueGrace < 0 || c.Notifications.OverdueGrace > 1440 {
+		return fmt.Errorf("invalid overdue grace: %d (must be 0-1440 minutes)", c.Notifications.OverdueGrace)
+	}
+
 	// Validate theme
 	if c.Appearance.Theme != "light" && c.Appearance.Theme != "dark" && c.Appearance.Theme != "auto" {
 		return fmt.Errorf("invalid theme: %s", c.Appearance.Theme)
 	}
 
+	// Validate language
+	if !validLanguage(c.Appearance.Language) {
+		return fmt.Errorf("invalid language: %s (use auto or one of %s)", c.Appearance.Language, strings.Join(i18n.Languages(), ", "))
+	}
+
+	// Validate feedback modes
+	for action, mode := range map[string]string{
+		"complete":   c.Appearance.Feedback.Complete,
+		"uncomplete": c.Appearance.Feedback.Uncomplete,
+		"delete":     c.Appearance.Feedback.Delete,
+	} {
+		if !isValidFeedback(mode) {
+			return fmt.Errorf("invalid %s feedback: %s", action, mode)
+		}
+	}
+
+	// Validate tag colors
+	for tag, color := range c.Appearance.TagColors {
+		if _, err := models.ParseColor(color); err != nil {
+			return fmt.Errorf("invalid color for tag %s: %w", tag, err)
+		}
+	}
+
+	// Validate housekeeping rules
+	if _, err := models.ParseRules(c.Rules); err != nil {
+		return err
+	}
+
+	// Validate date and time formats
+	if err := validateFormats(c.Appearance.DateFormat, c.Appearance.TimeFormat, c.Appearance.FirstDay); err != nil {
+		return err
+	}
+
 	// Validate working hours
 	if c.WorkHours.Enabled {
 		if err := c.validateTimeFormat(c.WorkHours.Start); err != nil {
@@ -326,11 +788,55 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate the daily planning notifications
+	if c.Daemon.PlanTime != "" {
+		if err := c.validateTimeFormat(c.Daemon.PlanTime); err != nil {
+			return fmt.Errorf("invalid plan time: %w", err)
+		}
+	}
+	if c.Daemon.WrapUpTime != "" {
+		if err := c.validateTimeFormat(c.Daemon.WrapUpTime); err != nil {
+			return fmt.Errorf("invalid wrap-up time: %w", err)
+		}
+	}
+
+	// Validate times of day
+	for name, clock := range c.TimesOfDay {
+		if err := c.validateTimeFormat(clock); err != nil {
+			return fmt.Errorf("invalid time for %s: %w", name, err)
+		}
+	}
+
+	// Validate location
+	if c.Location.Latitude < -90 || c.Location.Latitude > 90 {
+		return fmt.Errorf("invalid latitude: %g (must be -90 to 90)", c.Location.Latitude)
+	}
+	if c.Location.Longitude < -180 || c.Location.Longitude > 180 {
+		return fmt.Errorf("invalid longitude: %g (must be -180 to 180)", c.Location.Longitude)
+	}
+
+	// Validate remote
+	if url := c.Remote.URL; url != "" && !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("invalid remote url: %s (must start with http:// or https://)", url)
+	}
+
 	// Validate daemon settings
 	if c.Daemon.CheckInterval < 1 || c.Daemon.CheckInterval > 60 {
 		return fmt.Errorf("invalid daemon check interval: %d (must be 1-60 minutes)", c.Daemon.CheckInterval)
 	}
 
+	if c.Daemon.StaleDays < 1 {
+		return fmt.Errorf("invalid stale days: %d (must be at least 1)", c.Daemon.StaleDays)
+	}
+
+	if c.Integrations.ReviewSync < 0 {
+		return fmt.Errorf("invalid review sync minutes: %d", c.Integrations.ReviewSync)
+	}
+
+	if c.Integrations.DuringMeetings != "defer" && c.Integrations.DuringMeetings != "suppress" {
+		return fmt.Errorf("invalid during_meetings: %s (must be defer or suppress)", c.Integrations.DuringMeetings)
+	}
+
 	logLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
 	if !logLevels[c.Daemon.LogLevel] {
 		return fmt.Errorf("invalid log level: %s", c.Daemon.LogLevel)
@@ -339,6 +845,38 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// isValidFeedback checks a TUI feedback mode
+func isValidFeedback(mode string) bool {
+	return mode == "none" || mode == "bell" || mode == "flash" || mode == "both"
+}
+
+// validLanguage reports whether lang is "auto" or a supported language code
+func validLanguage(lang string) bool {
+	if lang == "auto" {
+		return true
+	}
+	for _, supported := range i18n.Languages() {
+		if lang == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// validateFormats checks the date format, time format and first day of the
+// week
+func validateFormats(dateFormat, timeFormat, firstDay string) error {
+	switch {
+	case dateFormat != "auto" && dateFormat != "mdy" && dateFormat != "dmy":
+		return fmt.Errorf("invalid date format: %s (must be auto, mdy or dmy)", dateFormat)
+	case timeFormat != "auto" && timeFormat != "12h" && timeFormat != "24h":
+		return fmt.Errorf("invalid time format: %s (must be auto, 12h or 24h)", timeFormat)
+	case firstDay != "monday" && firstDay != "sunday":
+		return fmt.Errorf("invalid first day: %s (must be monday or sunday)", firstDay)
+	}
+	return nil
+}
+
 // validateTimeFormat validates time format (HH:MM)
 func (c *Config) validateTimeFormat(timeStr string) error {
 	_, err := time.Parse("15:04", timeStr)
@@ -396,8 +934,18 @@ func (c *Config) ShouldNotify(t time.Time) bool {
 	return true
 }
 
+// Migrated returns what loading changed in a config file written by an
+// older version, and where the original was kept; nothing when it was
+// current
+func (c *Config) Migrated() (backup string, changes []string) {
+	return c.backup, c.migrated
+}
+
 // GetConfigDir returns the configuration directory path
 func (c *Config) GetConfigDir() string {
+	if c.configDir != "" {
+		return c.configDir
+	}
 	return getConfigDir()
 }
 
@@ -409,19 +957,121 @@ func (c *Config) GetDataDir() string {
 	return getDataDir()
 }
 
+// DueSoonWindows returns the global due-soon window and the per-priority
+// overrides keyed by priority name
+func (c *Config) DueSoonWindows() (time.Duration, map[string]time.Duration) {
+	byPriority := make(map[string]time.Duration)
+	for priority, minutes := range c.Notifications.DueSoonByPriority {
+		if minutes > 0 {
+			byPriority[priority] = time.Duration(minutes) * time.Minute
+		}
+	}
+	return time.Duration(c.Notifications.DueSoonMinutes) * time.Minute, byPriority
+}
+
+// Included returns how many settings came from the include and why it
+// couldn't be read, if it couldn't
+func (c *Config) Included() (int, error) {
+	return len(c.included), c.includeErr
+}
+
+// GitHubToken returns the token used for GitHub issue lookups
+func (c *Config) GitHubToken() string {
+	if c.Integrations.GitHubToken != "" {
+		return c.Integrations.GitHubToken
+	}
+	return os.Getenv("GITHUB_TOKEN")
+}
+
+// CurrentUser returns the name used for reminder assignments
+func (c *Config) CurrentUser() string {
+	if c.Shared.User != "" {
+		return c.Shared.User
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return os.Getenv("USERNAME")
+}
+
 // Set sets a configuration value by key
 func (c *Config) Set(key, value string) error {
+	if tag, ok := strings.CutPrefix(key, "appearance.tag_colors."); ok && tag != "" {
+		color, err := models.ParseColor(value)
+		if err != nil {
+			return err
+		}
+		if c.Appearance.TagColors == nil {
+			c.Appearance.TagColors = make(map[string]string)
+		}
+		if color == "" {
+			delete(c.Appearance.TagColors, tag)
+		} else {
+			c.Appearance.TagColors[tag] = color
+		}
+		return nil
+	}
+
 	switch key {
 	case "default.priority":
 		if value != "low" && value != "medium" && value != "high" {
 			return fmt.Errorf("invalid priority: %s", value)
 		}
 		c.Default.Priority = value
+	case "default.stt_command":
+		c.Default.STTCommand = value
+	case "default.max_per_day":
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 0 {
+			return fmt.Errorf("invalid max per day: %s (0 turns the warning off)", value)
+		}
+		c.Default.MaxPerDay = count
+	case "default.check_duplicates":
+		c.Default.CheckDuplicate = value == "true"
+	case "default.past_grace_minutes":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 {
+			return fmt.Errorf("invalid past grace minutes: %s", value)
+		}
+		c.Default.PastGrace = minutes
+	case "default.max_years_ahead":
+		years, err := strconv.Atoi(value)
+		if err != nil || years < 0 {
+			return fmt.Errorf("invalid max years ahead: %s (0 means no limit)", value)
+		}
+		c.Default.MaxYearsAhead = years
+	case "default.infer_priority":
+		c.Default.InferPriority = value == "true"
+	case "default.invite_lead_minutes":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 || minutes > 1440 {
+			return fmt.Errorf("invalid invite lead minutes: %s (must be 0-1440)", value)
+		}
+		c.Default.InviteLead = minutes
+	case "default.untimed_time":
+		if value != "" {
+			if err := c.validateTimeFormat(value); err != nil {
+				return err
+			}
+		}
+		c.Default.UntimedTime = value
 	case "appearance.theme":
 		if value != "light" && value != "dark" && value != "auto" {
 			return fmt.Errorf("invalid theme: %s", value)
 		}
 		c.Appearance.Theme = value
+	case "appearance.feedback.complete", "appearance.feedback.uncomplete", "appearance.feedback.delete":
+		if !isValidFeedback(value) {
+			return fmt.Errorf("invalid feedback: %s (use none, bell, flash or both)", value)
+		}
+		switch key {
+		case "appearance.feedback.complete":
+			c.Appearance.Feedback.Complete = value
+		case "appearance.feedback.uncomplete":
+			c.Appearance.Feedback.Uncomplete = value
+		default:
+			c.Appearance.Feedback.Delete = value
+		}
 	case "workhours.start":
 		if err := c.validateTimeFormat(value); err != nil {
 			return err
@@ -436,18 +1086,188 @@ func (c *Config) Set(key, value string) error {
 		c.Notifications.Enabled = value == "true"
 	case "notifications.sound":
 		c.Notifications.Sound = value == "true"
+	case "notifications.high_is_critical":
+		c.Notifications.HighIsCritical = value == "true"
 	case "appearance.show_completed":
 		c.Appearance.ShowCompleted = value == "true"
 	case "appearance.compact_mode":
 		c.Appearance.CompactMode = value == "true"
 	case "appearance.show_icons":
 		c.Appearance.ShowIcons = value == "true"
+	case "appearance.accessible":
+		c.Appearance.Accessible = value == "true"
+	case "appearance.language":
+		if !validLanguage(value) {
+			return fmt.Errorf("invalid language: %s (use auto or one of %s)", value, strings.Join(i18n.Languages(), ", "))
+		}
+		c.Appearance.Language = value
+	case "appearance.date_format":
+		if err := validateFormats(value, "auto", "monday"); err != nil {
+			return err
+		}
+		c.Appearance.DateFormat = value
+	case "appearance.time_format":
+		if err := validateFormats("auto", value, "monday"); err != nil {
+			return err
+		}
+		c.Appearance.TimeFormat = value
+	case "appearance.first_day":
+		if err := validateFormats("auto", "auto", value); err != nil {
+			return err
+		}
+		c.Appearance.FirstDay = value
 	case "workhours.enabled":
 		c.WorkHours.Enabled = value == "true"
 	case "workhours.quiet_outside":
 		c.WorkHours.QuietOutside = value == "true"
 	case "daemon.auto_start":
 		c.Daemon.AutoStart = value == "true"
+	case "daemon.weekly_digest":
+		c.Daemon.WeeklyDigest = value == "true"
+	case "daemon.digest_stale":
+		c.Daemon.DigestStale = value == "true"
+	case "daemon.stale_days":
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 1 {
+			return fmt.Errorf("invalid stale days: %s (must be at least 1)", value)
+		}
+		c.Daemon.StaleDays = days
+	case "daemon.journal_file":
+		c.Daemon.JournalFile = value
+	case "daemon.html_file":
+		c.Daemon.HTMLFile = value
+	case "daemon.check_updates":
+		c.Daemon.CheckUpdates = value == "true"
+	case "daemon.plan_time", "daemon.wrap_up_time":
+		if value != "" {
+			if err := c.validateTimeFormat(value); err != nil {
+				return err
+			}
+		}
+		if key == "daemon.plan_time" {
+			c.Daemon.PlanTime = value
+		} else {
+			c.Daemon.WrapUpTime = value
+		}
+	case "notifications.due_soon_minutes":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 1 || minutes > 1440 {
+			return fmt.Errorf("invalid due soon minutes: %s (must be 1-1440)", value)
+		}
+		c.Notifications.DueSoonMinutes = minutes
+	case "notifications.dedup_minutes":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 {
+			return fmt.Errorf("invalid dedup minutes: %s (0 turns deduplication off)", value)
+		}
+		c.Notifications.DedupMinutes = minutes
+	case "notifications.when_locked":
+		if value != "queue" && value != "notify" {
+			return fmt.Errorf("invalid when_locked: %s (must be queue or notify)", value)
+		}
+		c.Notifications.WhenLocked = value
+	case "notifications.idle_minutes":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 {
+			return fmt.Errorf("invalid idle minutes: %s (0 only counts a locked screen)", value)
+		}
+		c.Notifications.IdleMinutes = minutes
+	case "notifications.retry_max_minutes":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 {
+			return fmt.Errorf("invalid retry max minutes: %s (0 turns retries off)", value)
+		}
+		c.Notifications.RetryMaxMinutes = minutes
+	case "notifications.overdue_grace":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 || minutes > 1440 {
+			return fmt.Errorf("invalid overdue grace: %s (must be 0-1440 minutes)", value)
+		}
+		c.Notifications.OverdueGrace = minutes
+	case "notifications.rate_limits.desktop", "notifications.rate_limits.bell", "notifications.rate_limits.log":
+		perHour, err := strconv.Atoi(value)
+		if err != nil || perHour < 0 {
+			return fmt.Errorf("invalid rate limit: %s (0 means no limit)", value)
+		}
+		if c.Notifications.RateLimits == nil {
+			c.Notifications.RateLimits = make(map[string]int)
+		}
+		c.Notifications.RateLimits[strings.TrimPrefix(key, "notifications.rate_limits.")] = perHour
+	case "notifications.due_soon_by_priority.low", "notifications.due_soon_by_priority.medium",
+		"notifications.due_soon_by_priority.high":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 || minutes > 1440 {
+			return fmt.Errorf("invalid due soon minutes: %s (must be 0-1440)", value)
+		}
+		if c.Notifications.DueSoonByPriority == nil {
+			c.Notifications.DueSoonByPriority = make(map[string]int)
+		}
+		c.Notifications.DueSoonByPriority[strings.TrimPrefix(key, "notifications.due_soon_by_priority.")] = minutes
+	case "integrations.github_token":
+		c.Integrations.GitHubToken = value
+	case "integrations.jira_url":
+		c.Integrations.JiraURL = value
+	case "integrations.jira_email":
+		c.Integrations.JiraEmail = value
+	case "integrations.jira_token":
+		c.Integrations.JiraToken = value
+	case "integrations.close_with_issue":
+		c.Integrations.CloseWithIssue = value == "true"
+	case "integrations.review_sync_minutes":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 0 {
+			return fmt.Errorf("invalid review sync minutes: %s", value)
+		}
+		c.Integrations.ReviewSync = minutes
+	case "integrations.calendar_url":
+		c.Integrations.CalendarURL = value
+	case "integrations.during_meetings":
+		if value != "defer" && value != "suppress" {
+			return fmt.Errorf("invalid during_meetings: %s (must be defer or suppress)", value)
+		}
+		c.Integrations.DuringMeetings = value
+	case "usage_log":
+		c.UsageLog = value == "true"
+	case "backups":
+		backups, err := strconv.Atoi(value)
+		if err != nil || backups < 0 || backups > models.MaxBackups {
+			return fmt.Errorf("invalid backups: %s (must be 0-%d)", value, models.MaxBackups)
+		}
+		c.Backups = backups
+	case "include":
+		c.Include = value
+	case "shared.read_only":
+		c.Shared.ReadOnly = value == "true"
+	case "shared.user":
+		c.Shared.User = value
+	case "times_of_day.morning", "times_of_day.noon", "times_of_day.afternoon",
+		"times_of_day.evening", "times_of_day.tonight", "times_of_day.midnight":
+		if err := c.validateTimeFormat(value); err != nil {
+			return err
+		}
+		if c.TimesOfDay == nil {
+			c.TimesOfDay = make(models.TimesOfDay)
+		}
+		c.TimesOfDay[strings.TrimPrefix(key, "times_of_day.")] = value
+	case "location.latitude":
+		degrees, err := strconv.ParseFloat(value, 64)
+		if err != nil || degrees < -90 || degrees > 90 {
+			return fmt.Errorf("invalid latitude: %s (must be -90 to 90)", value)
+		}
+		c.Location.Latitude = degrees
+	case "location.longitude":
+		degrees, err := strconv.ParseFloat(value, 64)
+		if err != nil || degrees < -180 || degrees > 180 {
+			return fmt.Errorf("invalid longitude: %s (must be -180 to 180)", value)
+		}
+		c.Location.Longitude = degrees
+	case "remote.url":
+		if value != "" && !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			return fmt.Errorf("invalid remote url: %s (must start with http:// or https://)", value)
+		}
+		c.Remote.URL = value
+	case "remote.token":
+		c.Remote.Token = value
 	default:
 		return fmt.Errorf("unknown configuration key: %s", key)
 	}
@@ -457,11 +1277,51 @@ func (c *Config) Set(key, value string) error {
 
 // Get gets a configuration value by key
 func (c *Config) Get(key string) (string, error) {
+	if tag, ok := strings.CutPrefix(key, "appearance.tag_colors."); ok && tag != "" {
+		return c.Appearance.TagColors[tag], nil
+	}
+
 	switch key {
 	case "default.priority":
 		return c.Default.Priority, nil
+	case "default.stt_command":
+		return c.Default.STTCommand, nil
+	case "default.max_per_day":
+		return strconv.Itoa(c.Default.MaxPerDay), nil
+	case "default.check_duplicates":
+		if c.Default.CheckDuplicate {
+			return "true", nil
+		}
+		return "false", nil
+	case "default.past_grace_minutes":
+		return strconv.Itoa(c.Default.PastGrace), nil
+	case "default.max_years_ahead":
+		return strconv.Itoa(c.Default.MaxYearsAhead), nil
+	case "default.infer_priority":
+		if c.Default.InferPriority {
+			return "true", nil
+		}
+		return "false", nil
+	case "default.invite_lead_minutes":
+		return strconv.Itoa(c.Default.InviteLead), nil
+	case "default.untimed_time":
+		return c.Default.UntimedTime, nil
 	case "appearance.theme":
 		return c.Appearance.Theme, nil
+	case "appearance.language":
+		return c.Appearance.Language, nil
+	case "appearance.date_format":
+		return c.Appearance.DateFormat, nil
+	case "appearance.time_format":
+		return c.Appearance.TimeFormat, nil
+	case "appearance.first_day":
+		return c.Appearance.FirstDay, nil
+	case "appearance.feedback.complete":
+		return c.Appearance.Feedback.Complete, nil
+	case "appearance.feedback.uncomplete":
+		return c.Appearance.Feedback.Uncomplete, nil
+	case "appearance.feedback.delete":
+		return c.Appearance.Feedback.Delete, nil
 	case "workhours.start":
 		return c.WorkHours.Start, nil
 	case "workhours.end":
@@ -476,11 +1336,75 @@ func (c *Config) Get(key string) (string, error) {
 			return "true", nil
 		}
 		return "false", nil
+	case "notifications.high_is_critical":
+		if c.Notifications.HighIsCritical {
+			return "true", nil
+		}
+		return "false", nil
 	case "appearance.show_completed":
 		if c.Appearance.ShowCompleted {
 			return "true", nil
 		}
 		return "false", nil
+	case "notifications.due_soon_minutes":
+		return strconv.Itoa(c.Notifications.DueSoonMinutes), nil
+	case "notifications.dedup_minutes":
+		return strconv.Itoa(c.Notifications.DedupMinutes), nil
+	case "notifications.when_locked":
+		return c.Notifications.WhenLocked, nil
+	case "notifications.idle_minutes":
+		return strconv.Itoa(c.Notifications.IdleMinutes), nil
+	case "notifications.retry_max_minutes":
+		return strconv.Itoa(c.Notifications.RetryMaxMinutes), nil
+	case "notifications.overdue_grace":
+		return strconv.Itoa(c.Notifications.OverdueGrace), nil
+	case "notifications.rate_limits.desktop", "notifications.rate_limits.bell", "notifications.rate_limits.log":
+		return strconv.Itoa(c.Notifications.RateLimits[strings.TrimPrefix(key, "notifications.rate_limits.")]), nil
+	case "notifications.due_soon_by_priority.low", "notifications.due_soon_by_priority.medium",
+		"notifications.due_soon_by_priority.high":
+		return strconv.Itoa(c.Notifications.DueSoonByPriority[strings.TrimPrefix(key, "notifications.due_soon_by_priority.")]), nil
+	case "daemon.stale_days":
+		return strconv.Itoa(c.Daemon.StaleDays), nil
+	case "integrations.jira_url":
+		return c.Integrations.JiraURL, nil
+	case "integrations.jira_email":
+		return c.Integrations.JiraEmail, nil
+	case "integrations.close_with_issue":
+		if c.Integrations.CloseWithIssue {
+			return "true", nil
+		}
+		return "false", nil
+	case "integrations.review_sync_minutes":
+		return strconv.Itoa(c.Integrations.ReviewSync), nil
+	case "integrations.calendar_url":
+		return c.Integrations.CalendarURL, nil
+	case "integrations.during_meetings":
+		return c.Integrations.DuringMeetings, nil
+	case "include":
+		return c.Include, nil
+	case "usage_log":
+		if c.UsageLog {
+			return "true", nil
+		}
+		return "false", nil
+	case "backups":
+		return strconv.Itoa(c.Backups), nil
+	case "shared.read_only":
+		if c.Shared.ReadOnly {
+			return "true", nil
+		}
+		return "false", nil
+	case "shared.user":
+		return c.CurrentUser(), nil
+	case "times_of_day.morning", "times_of_day.noon", "times_of_day.afternoon",
+		"times_of_day.evening", "times_of_day.tonight", "times_of_day.midnight":
+		return c.TimesOfDay[strings.TrimPrefix(key, "times_of_day.")], nil
+	case "location.latitude":
+		return strconv.FormatFloat(c.Location.Latitude, 'f', -1, 64), nil
+	case "location.longitude":
+		return strconv.FormatFloat(c.Location.Longitude, 'f', -1, 64), nil
+	case "remote.url":
+		return c.Remote.URL, nil
 	default:
 		return "", fmt.Errorf("unknown configuration key: %s", key)
 	}
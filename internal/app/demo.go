@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// NewDemo creates an application instance for 'nancy --demo': the default
+// config and a sample set of reminders kept in memory. Files that commands
+// write next to the config or the data (focus, filters and the like) go to a
+// scratch directory that Close removes, so the real ones are never touched.
+func NewDemo() (*App, error) {
+	dir, err := os.MkdirTemp("", "nancy-demo-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create demo directory: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.DataDir = dir
+	config.configDir = dir
+
+	return &App{
+		config:  config,
+		store:   models.NewMemoryStore(DemoReminders(time.Now())),
+		demoDir: dir,
+	}, nil
+}
+
+// IsDemo reports whether the app was created by NewDemo
+func (a *App) IsDemo() bool {
+	return a.demoDir != ""
+}
+
+// Close removes what a demo left behind; it does nothing for other apps
+func (a *App) Close() error {
+	if a.demoDir == "" {
+		return nil
+	}
+	return os.RemoveAll(a.demoDir)
+}
+
+// DemoReminders returns the sample reminders of a demo, due around now: some
+// overdue, some today and later, a few recurring ones with history and a
+// couple already done
+func DemoReminders(now time.Time) []*models.Reminder {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	at := func(days, hour, minute int) time.Time {
+		return today.AddDate(0, 0, days).Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	reminder := func(title string, due time.Time, priority models.Priority, tags ...string) *models.Reminder {
+		r := models.NewReminder(title, due, priority)
+		for _, tag := range tags {
+			r.AddTag(tag)
+		}
+		r.CreatedAt = today.AddDate(0, 0, -14)
+		r.UpdatedAt = r.CreatedAt
+		return r
+	}
+	done := func(r *models.Reminder, at time.Time) *models.Reminder {
+		r.Completed = true
+		r.CompletedAt = &at
+		r.UpdatedAt = at
+		return r
+	}
+
+	weekend := func(t time.Time) bool {
+		return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	}
+	next := 1
+	for weekend(at(next, 9, 30)) {
+		next++
+	}
+	standup := reminder("Team standup", at(next, 9, 30), models.Medium, "work")
+	standup.Recurring = &models.RecurringRule{Frequency: "weekdays", Interval: 1}
+	for days := -7; days < 1; days++ {
+		due := at(days, 9, 30)
+		if weekend(due) || due.After(now) {
+			continue
+		}
+		occurrence := models.Occurrence{DueTime: due, At: due.Add(-2 * time.Minute), Status: models.OccurrenceOnTime}
+		if days == -3 {
+			occurrence.At, occurrence.Status = due.Add(20*time.Minute), models.OccurrenceLate
+		}
+		standup.History = append(standup.History, occurrence)
+	}
+
+	plants := reminder("Water the plants", at(0, 19, 0), models.Low, "home")
+	plants.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 3}
+	plants.History = []models.Occurrence{
+		{DueTime: at(-6, 19, 0), At: at(-6, 18, 45), Status: models.OccurrenceOnTime},
+		{DueTime: at(-3, 19, 0), At: at(-3, 19, 0), Status: models.OccurrenceSkipped},
+	}
+
+	rent := reminder("Pay rent", time.Date(today.Year(), today.Month()+1, 1, 10, 0, 0, 0, today.Location()), models.High, "finance")
+	rent.Recurring = &models.RecurringRule{Frequency: "monthly", Interval: 1}
+
+	gym := reminder("Gym", at(2, 18, 0), models.Low, "health")
+	gym.Recurring = &models.RecurringRule{Frequency: "weekly", Interval: 1}
+
+	expenses := reminder("Submit expense report", at(-1, 17, 0), models.High, "work", "finance")
+	expenses.Nag = 30
+
+	dentist := reminder("Call the dentist", at(0, 14, 0), models.Medium, "health")
+	dentist.Description = "Ask about moving the cleaning to a morning slot."
+
+	review := reminder("Prepare sprint review", at(1, 11, 0), models.Medium, "work")
+	review.Color = "blue"
+	review.Description = "- [x] Collect demo links\n- [ ] Update the burndown chart\n- [ ] Draft talking points"
+
+	passport := reminder("Renew passport", at(21, 12, 0), models.High, "admin", "travel")
+	passport.Description = "Needs a new photo and the old passport. Appointments book up about two weeks ahead."
+
+	birthday := reminder("Mom's birthday", at(10, 9, 0), models.High, "family")
+	birthday.Color = "pink"
+
+	release := reminder("Read the Go release notes", at(1, 20, 0), models.Low, "readlater")
+	release.URL = "https://go.dev/doc/devel/release"
+
+	blog := reminder("Write a blog post about the trip", at(14, 18, 0), models.Low, "writing")
+	blog.CheckIn = "weekly"
+
+	return []*models.Reminder{
+		expenses,
+		standup,
+		dentist,
+		plants,
+		review,
+		release,
+		gym,
+		birthday,
+		passport,
+		blog,
+		rent,
+		done(reminder("Book flights", at(-2, 12, 0), models.High, "travel"), at(-2, 11, 15)),
+		done(reminder("Fix bike tire", at(-4, 18, 0), models.Medium, "home"), at(-3, 10, 5)),
+		done(reminder("Send invoice to Acme", at(-1, 9, 0), models.Medium, "work", "finance"), at(-1, 8, 40)),
+	}
+}
@@ -0,0 +1,222 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// includeCacheFile is the copy of a remote include kept in the config
+// directory, so Nancy starts offline and doesn't fetch it on every command.
+// %x is a hash of the URL, so a different include never gets another's copy.
+const includeCacheFile = "include-cache-%x.yaml"
+
+// IncludeRefresh is how old the cached copy of a remote include may get
+// before it is fetched again
+const IncludeRefresh = 6 * time.Hour
+
+// maxIncludeSize caps the size of an included config
+const maxIncludeSize = 1 << 20
+
+// includeAllowed are the settings an include may set: conventions a team
+// shares, like defaults for new reminders, tag colors and housekeeping rules
+// (only those that archive; see includedRules). Anything else, like where data lives, commands Nancy runs, files the daemon
+// writes, servers and credentials, stays local; an entry covers the keys
+// under it.
+var includeAllowed = []string{
+	"default",
+	"notifications",
+	"appearance",
+	"workhours",
+	"times_of_day",
+	"rules",
+	"daemon.weekly_digest",
+	"daemon.digest_stale",
+	"daemon.stale_days",
+	"daemon.plan_time",
+	"daemon.wrap_up_time",
+}
+
+// includeExcluded are settings under includeAllowed an include still can't
+// set, because they run a command
+var includeExcluded = []string{
+	"default.stt_command",
+}
+
+// applyInclude merges the shared config named by the local config's include
+// setting under it: every setting the local file doesn't change from the
+// built-in defaults comes from the include. It returns those settings, which
+// Save keeps out of the local file.
+func applyInclude(configDir string, defaults map[string]interface{}) (map[string]interface{}, error) {
+	source := strings.TrimSpace(viper.GetString("include"))
+	if source == "" {
+		return nil, nil
+	}
+
+	data, err := readInclude(source, configDir, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	shared := viper.New()
+	shared.SetConfigType("yaml")
+	if err := shared.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to parse included config %s: %w", source, err)
+	}
+
+	included := make(map[string]interface{})
+	for _, key := range shared.AllKeys() {
+		if !includable(key) {
+			continue
+		}
+		if viper.InConfig(key) {
+			if def, ok := setting(defaults, key); !ok || fmt.Sprint(def) != fmt.Sprint(viper.Get(key)) {
+				continue
+			}
+		}
+		value := shared.Get(key)
+		if key == "rules" {
+			value = includedRules(shared.GetStringSlice(key))
+		}
+		viper.Set(key, value)
+		included[key] = value
+	}
+	return included, nil
+}
+
+// includedRules returns the housekeeping rules of an include that archive.
+// One that deletes could wipe every reminder of everyone sharing it, so
+// those, and rules that don't parse, are left out.
+func includedRules(rules []string) []string {
+	archiving := []string{}
+	for _, text := range rules {
+		if rule, err := models.ParseRule(text); err == nil && rule.Action == models.RuleArchive {
+			archiving = append(archiving, text)
+		}
+	}
+	return archiving
+}
+
+// includable reports whether key is a setting includes can set
+func includable(key string) bool {
+	covers := func(entries []string) bool {
+		for _, entry := range entries {
+			if key == entry || strings.HasPrefix(key, entry+".") {
+				return true
+			}
+		}
+		return false
+	}
+	return covers(includeAllowed) && !covers(includeExcluded)
+}
+
+// readInclude returns the contents of an included config, a file or an
+// https URL. URLs are cached for IncludeRefresh; when fetching fails, the
+// cached copy is used however old it is.
+func readInclude(source, configDir string, now time.Time) ([]byte, error) {
+	if strings.HasPrefix(source, "http://") {
+		return nil, fmt.Errorf("included config %s must use https", source)
+	}
+	if !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(expandHome(source))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read included config: %w", err)
+		}
+		return data, nil
+	}
+
+	sum := sha256.Sum256([]byte(source))
+	cachePath := filepath.Join(configDir, fmt.Sprintf(includeCacheFile, sum[:8]))
+	cached, cacheErr := os.ReadFile(cachePath)
+	if info, err := os.Stat(cachePath); cacheErr == nil && err == nil && now.Sub(info.ModTime()) < IncludeRefresh {
+		return cached, nil
+	}
+
+	data, err := fetchInclude(source)
+	if err != nil {
+		if cacheErr == nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+	if err := os.WriteFile(cachePath, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to cache included config: %w", err)
+	}
+	return data, nil
+}
+
+// fetchInclude downloads an included config
+func fetchInclude(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch included config: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch included config %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIncludeSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch included config: %w", err)
+	}
+	return data, nil
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// withoutIncluded returns settings without the values that came from the
+// include, so saving doesn't copy them into the local file and later
+// changes to the include still apply. A setting changed locally is kept.
+func withoutIncluded(settings map[string]interface{}, included map[string]interface{}) map[string]interface{} {
+	for key, value := range included {
+		if parent, name := settingParent(settings, key); parent != nil && fmt.Sprint(parent[name]) == fmt.Sprint(value) {
+			delete(parent, name)
+		}
+	}
+	return settings
+}
+
+// setting returns the value of a dotted key like "appearance.theme" in
+// nested settings
+func setting(settings map[string]interface{}, key string) (interface{}, bool) {
+	parent, name := settingParent(settings, key)
+	if parent == nil {
+		return nil, false
+	}
+	value, ok := parent[name]
+	return value, ok
+}
+
+// settingParent returns the map holding a dotted key in nested settings and
+// the key's last part, or nil if there is no such map
+func settingParent(settings map[string]interface{}, key string) (map[string]interface{}, string) {
+	path := strings.Split(key, ".")
+	parent := settings
+	for _, part := range path[:len(path)-1] {
+		next, ok := parent[part].(map[string]interface{})
+		if !ok {
+			return nil, ""
+		}
+		parent = next
+	}
+	return parent, path[len(path)-1]
+}
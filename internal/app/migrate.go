@@ -0,0 +1,200 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+)
+
+// ConfigVersion is the config file format this build writes. Files with an
+// older version, or none, are migrated when loaded.
+const ConfigVersion = 1
+
+// configMigration brings a config file up to version. Settings a file
+// doesn't have take their defaults anyway, so migrations only deal with
+// settings that were renamed or whose values changed meaning.
+type configMigration struct {
+	version int
+	migrate func(root *yaml.Node) []string // Returns what changed, for the changelog
+}
+
+// configMigrations are applied in order to files older than their version
+var configMigrations = []configMigration{
+	{version: 1, migrate: migrateToV1},
+}
+
+// migrateToV1 fixes what earlier versions rejected as invalid but meant
+// something obvious: work_hours for workhours, and choices in the wrong
+// case or spelled out
+func migrateToV1(root *yaml.Node) []string {
+	var changes []string
+	if change, ok := renameSetting(root, "work_hours", "workhours"); ok {
+		changes = append(changes, change)
+	}
+
+	for _, key := range []string{
+		"default.priority",
+		"notifications.when_locked",
+		"appearance.theme",
+		"appearance.language",
+		"appearance.date_format",
+		"appearance.time_format",
+		"appearance.first_day",
+		"appearance.feedback.complete",
+		"appearance.feedback.uncomplete",
+		"appearance.feedback.delete",
+		"integrations.during_meetings",
+		"daemon.log_level",
+	} {
+		if change, ok := normalizeSetting(root, key, map[string]string{"warning": "warn"}); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// migrateConfigFile migrates the config file at path to ConfigVersion,
+// keeping a copy of the original next to it. It returns the backup's path
+// and what changed, or nothing when the file is current. Files that don't
+// parse are left for loading to report.
+func migrateConfigFile(path string) (backup string, changes []string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return "", nil, nil
+	}
+	root := doc.Content[0]
+
+	version := 0
+	if node := lookupSetting(root, "version"); node != nil {
+		if err := node.Decode(&version); err != nil {
+			return "", nil, fmt.Errorf("invalid config version %q", node.Value)
+		}
+	}
+	if version >= ConfigVersion {
+		return "", nil, nil
+	}
+
+	for _, migration := range configMigrations {
+		if migration.version > version {
+			changes = append(changes, migration.migrate(root)...)
+		}
+	}
+	setVersion(root, ConfigVersion)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return "", nil, fmt.Errorf("failed to encode migrated config: %w", err)
+	}
+
+	backup = fmt.Sprintf("%s.v%d.bak", path, version)
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		return "", nil, fmt.Errorf("failed to back up config before migrating: %w", err)
+	}
+	if err := os.WriteFile(path, spaceSections(buf.Bytes()), 0644); err != nil {
+		return "", nil, fmt.Errorf("failed to write migrated config: %w", err)
+	}
+	return backup, changes, nil
+}
+
+// spaceSections puts back the blank line before each commented top-level
+// setting, which encoding drops
+func spaceSections(data []byte) []byte {
+	lines := strings.Split(string(data), "\n")
+	spaced := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if i > 0 && strings.HasPrefix(line, "#") && !strings.HasPrefix(lines[i-1], "#") {
+			spaced = append(spaced, "")
+		}
+		spaced = append(spaced, line)
+	}
+	return []byte(strings.Join(spaced, "\n"))
+}
+
+// lookupSetting returns the value node of a dotted key, or nil
+func lookupSetting(root *yaml.Node, key string) *yaml.Node {
+	node := root
+	for _, part := range strings.Split(key, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == part {
+				next = node.Content[i+1]
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+// renameSetting moves a top-level setting or section to a new name, unless
+// the new name is already set
+func renameSetting(root *yaml.Node, from, to string) (string, bool) {
+	if lookupSetting(root, to) != nil {
+		return "", false
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == from {
+			root.Content[i].Value = to
+			return i18n.T("renamed %s to %s", from, to), true
+		}
+	}
+	return "", false
+}
+
+// normalizeSetting lowercases a choice setting and replaces the spellings
+// given with the names Nancy uses
+func normalizeSetting(root *yaml.Node, key string, spellings map[string]string) (string, bool) {
+	node := lookupSetting(root, key)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return "", false
+	}
+	value := strings.ToLower(strings.TrimSpace(node.Value))
+	if replacement, ok := spellings[value]; ok {
+		value = replacement
+	}
+	if value == node.Value {
+		return "", false
+	}
+	old := node.Value
+	node.Value = value
+	return i18n.T("changed %s from %q to %q", key, old, value), true
+}
+
+// setVersion records the config format at the top of the file
+func setVersion(root *yaml.Node, version int) {
+	value := fmt.Sprint(version)
+	if node := lookupSetting(root, "version"); node != nil {
+		node.Value, node.Tag = value, "!!int"
+		return
+	}
+	// Below the comment heading the file, which is the first paragraph of
+	// the first setting's comment
+	key := &yaml.Node{Kind: yaml.ScalarNode, Value: "version"}
+	if len(root.Content) > 0 {
+		first := root.Content[0]
+		if heading, rest, ok := strings.Cut(first.HeadComment, "\n\n"); ok {
+			key.HeadComment, first.HeadComment = heading, rest
+		}
+	}
+	root.Content = append([]*yaml.Node{key, {Kind: yaml.ScalarNode, Tag: "!!int", Value: value}}, root.Content...)
+}
@@ -1,10 +1,15 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
 	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 	"github.com/spf13/cobra"
@@ -19,25 +24,203 @@ Examples:
   nancy add "Call mom"
   nancy add "Meeting" --time "2pm" --priority high
   nancy add "Buy groceries tomorrow at 5pm"
-  nancy add "Submit report urgent" --date "2024-03-20"`,
-	Args: cobra.MinimumNArgs(1),
+  nancy add "Submit report urgent" --date "2024-03-20"
+  nancy add "Write blog post" --when auto
+  nancy add --from-clipboard
+  nancy add --url https://go.dev/blog/
+  nancy add --issue golang/go#12345
+  nancy add --audio note.wav
+  nancy add --from-share nancy://share/...
+  nancy add --ics invite.ics
+  echo "Call mom tomorrow at 5pm" | nancy add -`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		fromClipboard, _ := cmd.Flags().GetBool("from-clipboard")
+		url, _ := cmd.Flags().GetString("url")
+		issue, _ := cmd.Flags().GetString("issue")
+		audio, _ := cmd.Flags().GetString("audio")
+		fromShare, _ := cmd.Flags().GetString("from-share")
+		ics, _ := cmd.Flags().GetString("ics")
+		if fromClipboard || url != "" || issue != "" || audio != "" || fromShare != "" || ics != "" {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
 		timeFlag, _ := cmd.Flags().GetString("time")
 		dateFlag, _ := cmd.Flags().GetString("date")
 		priorityFlag, _ := cmd.Flags().GetString("priority")
 		tagsFlag, _ := cmd.Flags().GetStringSlice("tags")
+		assignee, _ := cmd.Flags().GetString("for")
+		whenFlag, _ := cmd.Flags().GetString("when")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+		recurring, err := recurringFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		notifyBefore, err := notifyBeforeFromFlag(cmd)
+		if err != nil {
+			return err
+		}
+		nag, err := nagFromFlag(cmd)
+		if err != nil {
+			return err
+		}
+		grace, err := graceFromFlag(cmd)
+		if err != nil {
+			return err
+		}
+		doneWhen, err := doneWhenFromFlag(cmd)
+		if err != nil {
+			return err
+		}
+		estimate, err := estimateFromFlag(cmd)
+		if err != nil {
+			return err
+		}
 
 		// Join all arguments as the reminder text
 		reminderText := strings.Join(args, " ")
 
+		// Dictated reminders: "-" reads a transcript from stdin, --audio
+		// transcribes a voice note
+		if reminderText == "-" {
+			input, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed to read stdin: %w", err)
+			}
+			if reminderText = utils.CleanTranscript(string(input)); reminderText == "" {
+				return fmt.Errorf("no reminder text on stdin")
+			}
+		}
+		if audio, _ := cmd.Flags().GetString("audio"); audio != "" {
+			if reminderText != "" {
+				return fmt.Errorf("--audio cannot be combined with reminder text")
+			}
+			stt, _ := cmd.Flags().GetString("stt")
+			if stt == "" {
+				stt = getApp().GetConfig().Default.STTCommand
+			}
+			if reminderText, err = utils.Transcribe(stt, audio); err != nil {
+				return err
+			}
+			fmt.Println(utils.Symbol("🎙️  ", "") + i18n.T("Heard: %s", reminderText))
+		}
+
+		// Turn a copied message into a reminder: first line is the title, the
+		// rest the description. Text given on the command line wins as title.
+		description := ""
+		if fromClipboard, _ := cmd.Flags().GetBool("from-clipboard"); fromClipboard {
+			clipboard, err := utils.ReadClipboard()
+			if err != nil {
+				return err
+			}
+			firstLine, rest := utils.SplitClipboard(clipboard)
+			if reminderText == "" {
+				if firstLine == "" {
+					return fmt.Errorf("clipboard is empty")
+				}
+				reminderText, description = firstLine, rest
+			} else {
+				description = strings.TrimSpace(clipboard)
+			}
+		}
+
 		// Parse the reminder text for natural language time/priority
 		config := getApp().GetConfig()
 		defaultPriority := models.ParsePriority(config.Default.Priority)
 
-		parsed, err := utils.ParseReminder(reminderText, defaultPriority)
+		// Link an issue; its title stands in for missing reminder text
+		var issue *utils.IssueInfo
+		issueRef, _ := cmd.Flags().GetString("issue")
+		if issueRef != "" {
+			ref, err := utils.ParseIssueRef(issueRef)
+			if err != nil {
+				return err
+			}
+			issueRef = ref.String()
+			if issue, err = utils.FetchIssue(ref, issueCredentials()); err != nil {
+				if reminderText == "" {
+					return err
+				}
+				fmt.Fprintln(os.Stderr, utils.Symbol("⚠️  ", i18n.T("Warning: "))+i18n.T("Could not fetch the issue: %v", err))
+			} else if reminderText == "" {
+				reminderText = issue.Title
+			}
+		}
+
+		var parsed *utils.ParsedReminder
+		var input string // The text parsed, kept for 'nancy edit --reparse'
+		url, _ := cmd.Flags().GetString("url")
+		if url != "" && !models.IsWebLink(url) {
+			return fmt.Errorf("invalid --url '%s' (must start with http:// or https://)", url)
+		}
+		colorFlag, _ := cmd.Flags().GetString("color")
+		color, err := models.ParseColor(colorFlag)
 		if err != nil {
-			return fmt.Errorf("failed to parse reminder: %w", err)
+			return err
+		}
+		checkInFlag, _ := cmd.Flags().GetString("check-in")
+		checkIn, err := models.ParseCheckIn(checkInFlag)
+		if err != nil {
+			return err
+		}
+		fromShare, _ := cmd.Flags().GetString("from-share")
+		ics, _ := cmd.Flags().GetString("ics")
+		if fromShare != "" && ics != "" {
+			return fmt.Errorf("--from-share cannot be combined with --ics")
+		}
+		var inviteURL string // A link to join the meeting
+		if ics != "" {
+			if reminderText != "" || url != "" {
+				return fmt.Errorf("--ics cannot be combined with reminder text or --url")
+			}
+			// The meeting's details are taken as they are; flags still override
+			var inviteDescription string
+			lead := time.Duration(config.Default.InviteLead) * time.Minute
+			parsed, inviteDescription, inviteURL, err = inviteReminder(ics, lead, defaultPriority, time.Now())
+			if err != nil {
+				return err
+			}
+			if description == "" {
+				description = inviteDescription
+			}
+		} else if fromShare != "" {
+			if reminderText != "" || url != "" {
+				return fmt.Errorf("--from-share cannot be combined with reminder text or --url")
+			}
+			// Taken as shared rather than parsed; flags still override
+			shared, err := utils.DecodeShare(fromShare)
+			if err != nil {
+				return err
+			}
+			parsed = &utils.ParsedReminder{
+				Title:       shared.Title,
+				DueTime:     shared.Due.Local(),
+				Priority:    shared.Priority,
+				Tags:        shared.Tags,
+				HasTime:     true,
+				HasPriority: true,
+			}
+			if description == "" {
+				description = shared.Notes
+			}
+		} else if url != "" && reminderText == "" {
+			// Page titles are used verbatim rather than parsed for times and tags
+			parsed = &utils.ParsedReminder{
+				Title:    readLaterTitle(url),
+				DueTime:  time.Now().Add(time.Hour),
+				Priority: defaultPriority,
+			}
+		} else {
+			parsed, err = textParser(defaultPriority).Parse(reminderText, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to parse reminder: %w", err)
+			}
+			input = reminderText
+		}
+		if url != "" {
+			parsed.Tags = append(parsed.Tags, "readlater")
 		}
 
 		// Override with explicit flags if provided
@@ -66,35 +249,9 @@ Examples:
 
 		// Handle explicit date flag
 		if dateFlag != "" {
-			var targetDate time.Time
-			var err error
-
-			// Try parsing different date formats
-			dateFormats := []string{
-				"2006-01-02",  // 2024-03-20
-				"01/02/2006",  // 03/20/2024
-				"01-02-2006",  // 03-20-2024
-				"Jan 2, 2006", // Mar 20, 2024
-				"Jan 2 2006",  // Mar 20 2024
-				"2 Jan 2006",  // 20 Mar 2024
-			}
-
-			// Handle relative dates
-			switch strings.ToLower(dateFlag) {
-			case "today":
-				targetDate = time.Now()
-			case "tomorrow":
-				targetDate = time.Now().AddDate(0, 0, 1)
-			default:
-				// Try parsing as explicit date
-				for _, format := range dateFormats {
-					if targetDate, err = time.Parse(format, dateFlag); err == nil {
-						break
-					}
-				}
-				if err != nil {
-					return fmt.Errorf("invalid date format '%s'", dateFlag)
-				}
+			targetDate, err := utils.ParseDateString(dateFlag)
+			if err != nil {
+				return err
 			}
 
 			// Combine date with existing time
@@ -102,11 +259,52 @@ Examples:
 				dueTime.Hour(), dueTime.Minute(), 0, 0, dueTime.Location())
 		}
 
+		// A date without a time: when similar reminders usually get done, or
+		// else default.untimed_time
+		timeSource := ""
+		if dateFlag != "" && timeFlag == "" && !parsed.HasTime {
+			similar := append(append([]string{}, tags...), tagsFlag...)
+			dueTime, timeSource = untimedDueTime(dueTime, similar, config.Default.UntimedTime, time.Now())
+		}
+
+		// Suggest a slot based on existing load
+		if whenFlag != "" {
+			if strings.ToLower(whenFlag) != "auto" {
+				return fmt.Errorf("invalid --when value '%s' (only 'auto' is supported)", whenFlag)
+			}
+			if parsed.HasTime || timeFlag != "" || dateFlag != "" {
+				return fmt.Errorf("--when auto cannot be combined with an explicit time or date")
+			}
+
+			suggested, ok, err := suggestDueTime(title, estimate, assumeYes)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println(utils.Symbol("❌ ", "") + i18n.T("Reminder not added."))
+				return nil
+			}
+			dueTime = suggested
+		} else if estimate > 0 {
+			return fmt.Errorf("--estimate only applies to --when auto")
+		}
+
 		// Handle explicit priority flag
 		if priorityFlag != "" {
 			priority = utils.ParsePriorityString(priorityFlag)
 		}
 
+		// With no priority given, optionally infer one from how close the
+		// deadline is
+		inferred := false
+		if config.Default.InferPriority && priorityFlag == "" && !parsed.HasPriority &&
+			(parsed.HasTime || timeFlag != "" || dateFlag != "") {
+			priority, inferred = utils.InferPriority(dueTime, time.Now())
+			if !inferred {
+				priority = parsed.Priority
+			}
+		}
+
 		// Handle explicit tags flag
 		if len(tagsFlag) > 0 {
 			// Merge with parsed tags
@@ -128,10 +326,15 @@ Examples:
 		}
 
 		// Validate input
-		if err := utils.ValidateReminderInput(title, dueTime); err != nil {
+		pastOK, _ := cmd.Flags().GetBool("past-ok")
+		if err := utils.ValidateReminderInput(title, dueTime, pastOK); err != nil {
 			return err
 		}
 
+		if recurring != nil && recurring.EndDate != nil && recurring.EndDate.AddDate(0, 0, 1).Before(dueTime) {
+			return fmt.Errorf("--until date is before the first occurrence")
+		}
+
 		// Create reminder
 		reminder := models.NewReminder(title, dueTime, priority)
 
@@ -139,6 +342,55 @@ Examples:
 		for _, tag := range tags {
 			reminder.AddTag(tag)
 		}
+		reminder.Assignee = strings.TrimSpace(assignee)
+		reminder.Recurring = recurring
+		reminder.NotifyBefore = notifyBefore
+		reminder.Nag = nag
+		reminder.Grace = grace
+		reminder.DoneWhen = doneWhen
+		reminder.Critical, _ = cmd.Flags().GetBool("critical")
+		reminder.Sticky, _ = cmd.Flags().GetBool("sticky")
+		reminder.Color = color
+		reminder.CheckIn = checkIn
+		reminder.URL = url
+		if inviteURL != "" {
+			reminder.URL = inviteURL
+		}
+		reminder.Input = input
+		if timeFlag == "" {
+			reminder.SunEvent = parsed.SunEvent
+		}
+		if issueRef != "" {
+			reminder.Issue = issueRef
+			if issue != nil && reminder.URL == "" {
+				reminder.URL = issue.URL
+			}
+		}
+		if description != "" {
+			reminder.SetDescription(description)
+		}
+		if reminder.SkipExcludedDates() {
+			fmt.Println(utils.Symbol("ℹ️  ", "") + i18n.T("The first date is excluded; starting at the next occurrence."))
+		}
+
+		// Opt-in duplicate check: warn, or refuse with --strict
+		strict, _ := cmd.Flags().GetBool("strict")
+		force, _ := cmd.Flags().GetBool("force")
+		if !force && (strict || config.Default.CheckDuplicate) {
+			if duplicates := getApp().GetStore().FindDuplicates(reminder.Title, reminder.DueTime, ""); len(duplicates) > 0 {
+				if strict {
+					return fmt.Errorf("'%s' looks like a duplicate of #%s '%s' (use --force to add it anyway)",
+						reminder.Title, duplicates[0].DisplayID(), duplicates[0].Title)
+				}
+				for _, duplicate := range duplicates {
+					fmt.Fprintln(os.Stderr, utils.Symbol("⚠️  ", i18n.T("Warning: "))+i18n.T("Looks like a duplicate of #%s %s (%s)",
+						duplicate.DisplayID(), duplicate.Title, duplicate.FormattedDueTime()))
+				}
+			}
+		}
+
+		// Look for clashes before the reminder joins the others
+		nearby, sameDay := getApp().GetStore().Conflicts(reminder.DueTime, models.ConflictWindow)
 
 		// Save to store
 		if err := getApp().GetStore().Add(reminder); err != nil {
@@ -146,17 +398,80 @@ Examples:
 		}
 
 		// Output confirmation
-		fmt.Printf("✅ Added reminder: %s\n", reminder.Title)
-		fmt.Printf("   Due: %s\n", reminder.FormattedDueTime())
-		fmt.Printf("   Priority: %s %s\n", priority.Icon(), priority.String())
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Added reminder: %s", reminder.Title))
+		if timeSource != "" {
+			fmt.Printf("   %s %s %s\n", i18n.T("Due:"), reminder.FormattedDueTime(), timeSource)
+		} else {
+			fmt.Printf("   %s %s\n", i18n.T("Due:"), reminder.FormattedDueTime())
+		}
+		if inferred {
+			fmt.Printf("   %s %s %s %s\n", i18n.T("Priority:"), utils.PriorityIcon(priority), i18n.T(priority.String()),
+				i18n.T("(inferred from the due time; set it with --priority)"))
+		} else {
+			fmt.Printf("   %s %s %s\n", i18n.T("Priority:"), utils.PriorityIcon(priority), i18n.T(priority.String()))
+		}
 
 		if len(tags) > 0 {
-			fmt.Printf("   Tags: %s\n", strings.Join(tags, ", "))
+			fmt.Printf("   %s %s\n", i18n.T("Tags:"), strings.Join(tags, ", "))
+		}
+
+		if reminder.Assignee != "" {
+			fmt.Printf("   %s %s\n", i18n.T("For:"), reminder.Assignee)
+		}
+
+		if reminder.Critical {
+			fmt.Printf("   %s %s\n", i18n.T("Critical:"), i18n.T("notifies even in quiet hours and meetings"))
+		}
+		if reminder.Sticky {
+			fmt.Printf("   %s %s\n", i18n.T("Sticky:"), i18n.T("stays on screen until you act on it"))
+		}
+		if reminder.Grace != nil {
+			fmt.Printf("   %s %s\n", i18n.T("Grace:"), graceLabel(reminder.OverdueGrace()))
+		}
+		if reminder.DoneWhen != "" {
+			fmt.Printf("   %s %s\n", i18n.T("Done when:"), i18n.T("%s succeeds", reminder.DoneWhen))
+		}
+
+		if color := reminder.LabelColor(); color != "" {
+			fmt.Printf("   %s %s\n", i18n.T("Color:"), utils.ColorLabel(color))
+		}
+
+		if reminder.CheckIn != "" {
+			fmt.Printf("   %s %s\n", i18n.T("Check-in:"), i18n.T(reminder.CheckIn))
+		}
+
+		if reminder.Nag > 0 {
+			fmt.Printf("   %s %s\n", i18n.T("Nag:"), i18n.T("every %s once overdue", utils.FormatDuration(reminder.NagInterval())))
+		}
+
+		if reminder.SunEvent != "" {
+			fmt.Printf("   %s\n", i18n.T("Follows: %s (adjusted daily)", i18n.T(reminder.SunEvent)))
+		}
+
+		if reminder.Issue != "" {
+			fmt.Printf("   %s %s\n", i18n.T("Issue:"), reminder.Issue)
+		}
+
+		if reminder.URL != "" {
+			fmt.Printf("   %s %s\n", i18n.T("Link:"), reminder.URL)
+		}
+
+		if reminder.Description != "" {
+			summary, _, more := strings.Cut(reminder.Description, "\n")
+			if more {
+				summary += " …"
+			}
+			fmt.Printf("   %s %s\n", i18n.T("Description:"), summary)
+		}
+
+		if reminder.Recurring != nil {
+			fmt.Printf("   %s %s\n", i18n.T("Repeats:"), reminder.Recurring)
 		}
 
 		// Show ID for reference
-		fmt.Printf("   ID: %s\n", reminder.ID[:8])
+		fmt.Printf("   %s %s\n", i18n.T("ID:"), reminder.DisplayID())
 
+		warnConflicts(reminder, nearby, sameDay)
 		return nil
 	},
 }
@@ -166,6 +481,33 @@ func init() {
 	addCmd.Flags().StringP("date", "d", "", "Due date (e.g., tomorrow, 2024-03-20, 'Mar 20')")
 	addCmd.Flags().StringP("priority", "p", "", "Priority level (low, medium, high)")
 	addCmd.Flags().StringSliceP("tags", "", []string{}, "Tags for the reminder (e.g., work,urgent)")
+	addCmd.Flags().String("for", "", "Assign the reminder to a user of a shared store")
+	addCmd.Flags().String("when", "", "Let Nancy pick a due time ('auto' suggests the least busy slot)")
+	addCmd.Flags().String("estimate", "", "How long it will take, so --when auto finds a slot with room for it (e.g. 45m, 2h)")
+	addCmd.Flags().BoolP("yes", "y", false, "Accept the suggested due time without prompting")
+	addCmd.Flags().String("repeat", "", "Repeat the reminder (daily, weekdays, weekly, monthly)")
+	addCmd.Flags().Int("every", 1, "Repeat every N days/weeks/months")
+	addCmd.Flags().String("until", "", "Stop repeating after this date (YYYY-MM-DD)")
+	addCmd.Flags().Int("count", 0, "Stop repeating after this many occurrences")
+	addCmd.Flags().String("url", "", "Save a link to read later (the page title becomes the reminder title)")
+	addCmd.Flags().String("issue", "", "Link a GitHub (OWNER/REPO#123) or Jira (PROJ-123) issue; its title is used if no text is given")
+	addCmd.Flags().Bool("from-clipboard", false, "Use the clipboard: first line as title, the rest as description")
+	addCmd.Flags().String("from-share", "", "Add a reminder someone shared with 'nancy share' (the link or its blob)")
+	addCmd.Flags().String("ics", "", "Add a reminder for a meeting invite (.ics file, or - for stdin), due default.invite_lead_minutes before it starts")
+	addCmd.Flags().String("notify-before", "", "How long before the due time it counts as due soon (e.g. 30m, 2h)")
+	addCmd.Flags().String("nag", "", "Once overdue, notify again at this cadence until done or snoozed (e.g. 'every 30m'; default hourly)")
+	addCmd.Flags().String("grace", "", "How long it may be overdue before the first nag (e.g. 10m, 0 for none; default notifications.overdue_grace)")
+	addCmd.Flags().String("done-when", "", "Shell command the daemon runs now and then; the reminder is completed once it exits 0")
+	addCmd.Flags().Bool("critical", false, "Always notify, even in quiet hours, meetings and Do Not Disturb")
+	addCmd.Flags().Bool("sticky", false, "Keep the notification on screen until acted on, and show it again if dismissed")
+	addCmd.Flags().String("color", "", "Label color: red, orange, yellow, green, blue, purple, pink or gray")
+	addCmd.Flags().String("check-in", "", "Ask now and then whether you're still working on it: daily, weekly, biweekly or monthly")
+	addCmd.Flags().StringSlice("except", []string{}, "Dates to leave out of the repetition (YYYY-MM-DD)")
+	addCmd.Flags().String("audio", "", "Transcribe a voice note and add it (see --stt)")
+	addCmd.Flags().Bool("past-ok", false, "Allow a due time in the past, e.g. to log overdue items from paper notes")
+	addCmd.Flags().Bool("strict", false, "Refuse to add a reminder like an active one due the same day")
+	addCmd.Flags().Bool("force", false, "Add even if it looks like a duplicate")
+	addCmd.Flags().String("stt", "", "Speech-to-text command for --audio; {file} is replaced by the audio file (default: default.stt_command)")
 
 	// Add examples to help
 	addCmd.Example = `  # Simple reminder
@@ -181,5 +523,388 @@ func init() {
   nancy add "Doctor appointment tomorrow at 3pm urgent"
 
   # With tags
-  nancy add "Review code" --tags "work,coding" --priority medium`
+  nancy add "Review code" --tags "work,coding" --priority medium
+
+  # Assign to someone sharing the data directory
+  nancy add "Take out the bins" --for alice
+
+  # Let Nancy find a quiet slot in your working hours with room for 2 hours
+  nancy add "Write blog post" --when auto --estimate 2h
+
+  # A long-running task that asks every week whether it's still in progress
+  nancy add "Write thesis" --date "jun 30" --check-in weekly
+
+  # Medication that keeps nagging every 30 minutes once it's due
+  nancy add "Take pills" --time 08:00 --nag "every 30m"
+
+  # Give yourself 10 minutes after the due time before it starts nagging
+  nancy add "Leave for the station" --time 17:30 --grace 10m
+
+  # Done by itself once the release is published
+  nancy add "Deploy release" --done-when "gh release view v1.2 --json publishedAt"
+
+  # Medication that must not be missed: the notification stays put
+  nancy add "Insulin" --time 08:00 --sticky
+
+  # Dictate a reminder
+  nancy add --audio note.wav --stt "whisper-cli -nt -m ggml-base.en.bin -f {file}"
+
+  # Pipe text in from another tool
+  echo "Call the dentist tomorrow at 10am" | nancy add -`
+}
+
+// suggestDueTime proposes a due slot based on existing load and lets the user
+// accept it, adjust it, or cancel
+func suggestDueTime(title string, estimate time.Duration, assumeYes bool) (time.Time, bool, error) {
+	config := getApp().GetConfig()
+	existing := getApp().GetStore().GetActive()
+
+	suggested := utils.SuggestDueTime(existing, config.WorkHours.Start, config.WorkHours.End, estimate, time.Now())
+	if assumeYes {
+		return suggested, true, nil
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Println(utils.Symbol("💡 ", "") + i18n.T("Suggested time for '%s': %s", title, i18n.FormatTime(suggested, "Mon Jan 2 3:04 PM")))
+		fmt.Print("   " + i18n.T("Accept? [Y/n, or enter another time like '3pm' or '2024-03-20 15:04']: "))
+
+		response, err := reader.ReadString('\n')
+		if err != nil && response == "" {
+			return time.Time{}, false, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		response = strings.TrimSpace(response)
+		switch strings.ToLower(response) {
+		case "", "y", "yes":
+			return suggested, true, nil
+		case "n", "no":
+			return time.Time{}, false, nil
+		}
+
+		adjusted, err := utils.ParseTimeString(response)
+		if err != nil {
+			fmt.Printf("   %s%v\n", utils.Symbol("❌ ", "[ERROR] "), err)
+			continue
+		}
+
+		// Time-only answers keep the suggested day
+		if adjusted.Year() == time.Now().Year() && adjusted.YearDay() == time.Now().YearDay() {
+			adjusted = time.Date(suggested.Year(), suggested.Month(), suggested.Day(),
+				adjusted.Hour(), adjusted.Minute(), 0, 0, suggested.Location())
+		}
+		suggested = adjusted
+	}
+}
+
+// warnConflicts points out reminders due around the same time and days with
+// more than default.max_per_day reminders, and offers free slots instead
+func warnConflicts(reminder *models.Reminder, nearby []*models.Reminder, sameDay int) {
+	maxPerDay := getApp().GetConfig().Default.MaxPerDay
+	overloaded := maxPerDay > 0 && sameDay >= maxPerDay
+	if len(nearby) == 0 && !overloaded {
+		return
+	}
+
+	warning := utils.Symbol("⚠️  ", i18n.T("Warning: "))
+	for _, other := range nearby {
+		fmt.Fprintln(os.Stderr, warning+i18n.T("Due within %d minutes of: %s (%s)",
+			int(models.ConflictWindow/time.Minute), other.Title, other.FormattedDueTime()))
+	}
+	if overloaded {
+		fmt.Fprintln(os.Stderr, warning+i18n.T("%s now has %d reminders (more than %d)",
+			i18n.FormatTime(reminder.DueTime, "Mon Jan 2"), sameDay+1, maxPerDay))
+		if day, ok := nextDayWithRoom(reminder.DueTime, maxPerDay); ok {
+			fmt.Fprintf(os.Stderr, "   %s %s\n", i18n.T("Next day with room:"), i18n.FormatTime(day, "Mon Jan 2"))
+		}
+	}
+
+	var others []*models.Reminder
+	for _, other := range getApp().GetStore().GetActive() {
+		if other.ID != reminder.ID {
+			others = append(others, other)
+		}
+	}
+	slots := utils.AlternateSlots(others, reminder.DueTime, models.ConflictWindow, maxPerDay, time.Now())
+	if len(slots) == 0 {
+		return
+	}
+
+	labels := make([]string, len(slots))
+	for i, slot := range slots {
+		labels[i] = i18n.FormatTime(slot, "Mon Jan 2 3:04 PM")
+	}
+	fmt.Fprintf(os.Stderr, "   %s %s\n", i18n.T("Free slots:"), strings.Join(labels, ", "))
+	fmt.Fprintf(os.Stderr, "   %s nancy edit %s --date %s --time %s\n", i18n.T("To move it:"),
+		reminder.DisplayID(), slots[0].Format("2006-01-02"), slots[0].Format("15:04"))
+}
+
+// dayWithRoomHorizon is how many days after an overloaded one
+// nextDayWithRoom looks at
+const dayWithRoomHorizon = 28
+
+// nextDayWithRoom finds the first day after due's with fewer than maxPerDay
+// reminders
+func nextDayWithRoom(due time.Time, maxPerDay int) (time.Time, bool) {
+	from := time.Date(due.Year(), due.Month(), due.Day()+1, 0, 0, 0, 0, due.Location())
+	for i, load := range getApp().GetStore().DayLoads(from, dayWithRoomHorizon) {
+		if load < maxPerDay {
+			return from.AddDate(0, 0, i), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// untimedDueTime sets the time of due, a date given without one, to the
+// hour reminders sharing a tag are most often completed at, or else to
+// untimed. It returns the due time and, for the confirmation, what picked
+// it; due is kept as it is when neither applies or the time has passed.
+func untimedDueTime(due time.Time, tags []string, untimed string, now time.Time) (time.Time, string) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(due.Year(), due.Month(), due.Day(), hour, minute, 0, 0, due.Location())
+	}
+
+	all := getApp().GetStore().GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})
+	if hour, count, ok := utils.PreferredHour(all, tags, due.Location()); ok {
+		if learned := at(hour, 0); learned.After(now) {
+			tagged := make([]string, len(tags))
+			for i, tag := range tags {
+				tagged[i] = "#" + tag
+			}
+			return learned, i18n.T("(you completed %d reminders tagged %s around then; set it with --time)",
+				count, strings.Join(tagged, ", "))
+		}
+	}
+
+	if clock, err := time.Parse("15:04", untimed); err == nil {
+		if fallback := at(clock.Hour(), clock.Minute()); fallback.After(now) {
+			return fallback, i18n.T("(default.untimed_time; set it with --time)")
+		}
+	}
+	return due, ""
+}
+
+// recurringFromFlags builds a recurrence rule from --repeat, --every, --until
+// and --count. It returns nil when --repeat is not given.
+func recurringFromFlags(cmd *cobra.Command) (*models.RecurringRule, error) {
+	repeat, _ := cmd.Flags().GetString("repeat")
+	every, _ := cmd.Flags().GetInt("every")
+	until, _ := cmd.Flags().GetString("until")
+	count, _ := cmd.Flags().GetInt("count")
+	except, _ := cmd.Flags().GetStringSlice("except")
+
+	if repeat == "" {
+		if until != "" || count != 0 || len(except) > 0 || cmd.Flags().Changed("every") {
+			return nil, fmt.Errorf("--every, --until, --count and --except require --repeat")
+		}
+		return nil, nil
+	}
+
+	frequency, err := models.ParseFrequency(strings.ToLower(repeat))
+	if err != nil {
+		return nil, err
+	}
+	if every < 1 || every > models.MaxInterval {
+		return nil, fmt.Errorf("--every must be between 1 and %d", models.MaxInterval)
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("--count must not be negative")
+	}
+
+	rule := &models.RecurringRule{Frequency: frequency, Interval: every, Count: count, Occurrence: 1}
+	if until != "" {
+		endDate, err := time.ParseInLocation("2006-01-02", until, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --until date '%s' (use YYYY-MM-DD)", until)
+		}
+		rule.EndDate = &endDate
+	}
+	for _, date := range except {
+		if err := rule.AddExclusion(strings.TrimSpace(date)); err != nil {
+			return nil, fmt.Errorf("invalid --except: %w", err)
+		}
+	}
+	if count == 1 {
+		rule.Final = true
+	}
+	return rule, nil
+}
+
+// notifyBeforeFromFlag parses --notify-before into whole minutes
+func notifyBeforeFromFlag(cmd *cobra.Command) (int, error) {
+	value, _ := cmd.Flags().GetString("notify-before")
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < time.Minute || d > 24*time.Hour {
+		return 0, fmt.Errorf("invalid --notify-before '%s' (use a duration between 1m and 24h, e.g. 30m or 2h)", value)
+	}
+	return int(d / time.Minute), nil
+}
+
+// estimateFromFlag parses --estimate, how long the reminder will take
+func estimateFromFlag(cmd *cobra.Command) (time.Duration, error) {
+	value, _ := cmd.Flags().GetString("estimate")
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < time.Minute || d > 24*time.Hour {
+		return 0, fmt.Errorf("invalid --estimate '%s' (use a duration between 1m and 24h, e.g. 45m or 2h)", value)
+	}
+	return d, nil
+}
+
+// nagFromFlag parses --nag, "every 30m" or just "30m", into whole minutes
+func nagFromFlag(cmd *cobra.Command) (int, error) {
+	value, _ := cmd.Flags().GetString("nag")
+	cadence := strings.TrimSpace(strings.ToLower(value))
+	if cadence == "every" {
+		return 0, fmt.Errorf("--nag every needs a duration; quote it, e.g. --nag \"every 30m\"")
+	}
+	cadence = strings.TrimSpace(strings.TrimPrefix(cadence, "every "))
+	if cadence == "" || cadence == "default" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(cadence)
+	if err != nil || d < time.Minute || d > 24*time.Hour {
+		return 0, fmt.Errorf("invalid --nag '%s' (use a duration between 1m and 24h, e.g. 'every 30m')", value)
+	}
+	return int(d / time.Minute), nil
+}
+
+// graceFromFlag parses --grace into whole minutes, or nil to use
+// notifications.overdue_grace
+func graceFromFlag(cmd *cobra.Command) (*int, error) {
+	value, _ := cmd.Flags().GetString("grace")
+	grace := strings.TrimSpace(strings.ToLower(value))
+	switch grace {
+	case "", "default":
+		return nil, nil
+	case "0", "none":
+		minutes := 0
+		return &minutes, nil
+	}
+
+	d, err := time.ParseDuration(grace)
+	if err != nil || d < 0 || d > 24*time.Hour {
+		return nil, fmt.Errorf("invalid --grace '%s' (use a duration up to 24h, e.g. 10m, or 0 for none)", value)
+	}
+	minutes := int(d / time.Minute)
+	return &minutes, nil
+}
+
+// doneWhenFromFlag reads --done-when and records the command as entered on
+// this machine, which the daemon requires before running it
+func doneWhenFromFlag(cmd *cobra.Command) (string, error) {
+	value, _ := cmd.Flags().GetString("done-when")
+	command := strings.TrimSpace(value)
+	if command == "" {
+		return "", nil
+	}
+	if err := utils.TrustCommand(getApp().GetConfig().GetConfigDir(), command); err != nil {
+		return "", fmt.Errorf("invalid --done-when: %w", err)
+	}
+	return command, nil
+}
+
+// graceLabel describes an overdue grace period for show and add
+func graceLabel(grace time.Duration) string {
+	if grace <= 0 {
+		return i18n.T("nags as soon as it's overdue")
+	}
+	return i18n.T("nags once %s overdue", utils.FormatDuration(grace))
+}
+
+// inviteReminder reads a meeting invite from a file or, for "-", stdin.
+// The reminder is due lead before the meeting starts, or now when that has
+// passed but the meeting hasn't, and its description says where the
+// meeting is and who organized it. A link to join becomes its URL.
+func inviteReminder(source string, lead time.Duration, priority models.Priority, now time.Time) (*utils.ParsedReminder, string, string, error) {
+	var data []byte
+	var err error
+	if source == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to read invite: %w", err)
+	}
+	invite, err := utils.ParseInvite(data)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	title := strings.TrimSpace(invite.Summary)
+	if title == "" {
+		return nil, "", "", fmt.Errorf("the invite has no title")
+	}
+	due := invite.Start.Add(-lead).Local()
+	if due.Before(now) && invite.Start.After(now) {
+		due = now
+	}
+
+	var details []string
+	if invite.Location != "" {
+		details = append(details, i18n.T("Location: %s", invite.Location))
+	}
+	if invite.Organizer != "" {
+		details = append(details, i18n.T("Organizer: %s", invite.Organizer))
+	}
+	details = append(details, i18n.T("Starts: %s", i18n.FormatTime(invite.Start.Local(), "Mon Jan 2, 3:04 PM")))
+	description := strings.Join(details, "\n")
+	if invite.Description != "" {
+		description += "\n\n" + invite.Description
+	}
+
+	link := ""
+	for _, candidate := range []string{invite.URL, invite.Location} {
+		if strings.HasPrefix(candidate, "http://") || strings.HasPrefix(candidate, "https://") {
+			link = candidate
+			break
+		}
+	}
+
+	parsed := &utils.ParsedReminder{Title: title, DueTime: due, Priority: priority, HasTime: true}
+	return parsed, description, link, nil
+}
+
+// readLaterTitle fetches the page title for a read-later link, falling back
+// to the link itself
+func readLaterTitle(url string) string {
+	title, err := utils.FetchPageTitle(url)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, utils.Symbol("⚠️  ", i18n.T("Warning: "))+i18n.T("Could not fetch the page title: %v", err))
+		return url
+	}
+	return title
+}
+
+// issueCredentials returns the configured API settings for issue lookups
+func issueCredentials() utils.IssueCredentials {
+	config := getApp().GetConfig()
+	return utils.IssueCredentials{
+		GitHubToken: config.GitHubToken(),
+		JiraURL:     config.Integrations.JiraURL,
+		JiraEmail:   config.Integrations.JiraEmail,
+		JiraToken:   config.Integrations.JiraToken,
+	}
+}
+
+// textParser parses reminder text through the parser plugin in the config
+// directory, warning on stderr when it fails
+func textParser(defaultPriority models.Priority) utils.TextParser {
+	return utils.TextParser{
+		Plugin:          filepath.Join(getApp().GetConfig().GetConfigDir(), utils.ParserPluginFile),
+		DefaultPriority: defaultPriority,
+		TimesOfDay:      getApp().GetConfig().TimesOfDay,
+		Warn: func(err error) {
+			fmt.Fprintln(os.Stderr, utils.Symbol("⚠️  ", i18n.T("Warning: "))+i18n.T("Ignoring the parser plugin: %v", err))
+		},
+	}
 }
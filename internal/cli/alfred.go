@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// alfredItem is one result in an Alfred/Raycast script filter
+type alfredItem struct {
+	UID      string     `json:"uid,omitempty"`
+	Title    string     `json:"title"`
+	Subtitle string     `json:"subtitle,omitempty"`
+	Arg      string     `json:"arg,omitempty"`
+	Valid    bool       `json:"valid"`
+	Icon     alfredIcon `json:"icon"`
+}
+
+// alfredIcon points at an image relative to the workflow folder
+type alfredIcon struct {
+	Path string `json:"path"`
+}
+
+// writeAlfredItems writes reminders in the script filter JSON format.
+// The arg is the reminder's short ID and the icon is icons/<priority>.png.
+func writeAlfredItems(w io.Writer, reminders []*models.Reminder) error {
+	items := make([]alfredItem, 0, len(reminders))
+	for _, reminder := range reminders {
+		subtitle := []string{reminder.FormattedDueTime()}
+		if reminder.IsOverdue() {
+			subtitle = append(subtitle, "overdue")
+		}
+		if len(reminder.Tags) > 0 {
+			subtitle = append(subtitle, "#"+strings.Join(reminder.Tags, " #"))
+		}
+
+		items = append(items, alfredItem{
+			UID:      reminder.ID,
+			Title:    reminder.Title,
+			Subtitle: strings.Join(subtitle, " · "),
+			Arg:      reminder.DisplayID(),
+			Valid:    true,
+			Icon:     alfredIcon{Path: fmt.Sprintf("icons/%s.png", reminder.Priority)},
+		})
+	}
+
+	if len(items) == 0 {
+		items = append(items, alfredItem{
+			Title: "All caught up! No active reminders.",
+			Valid: false,
+			Icon:  alfredIcon{Path: "icon.png"},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string][]alfredItem{"items": items})
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Change many reminders at once",
+	Long: `Change every active reminder matching a filter at once, for example to
+move a whole project when it slips by a week.
+
+Select reminders with --tags, --priority, --today or --overdue (or --all),
+then say what to change. You are asked to confirm unless --yes is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shiftFlag, _ := cmd.Flags().GetString("shift")
+		priorityFlag, _ := cmd.Flags().GetString("priority")
+		tagsFlag, _ := cmd.Flags().GetStringSlice("tags")
+		today, _ := cmd.Flags().GetBool("today")
+		overdue, _ := cmd.Flags().GetBool("overdue")
+		all, _ := cmd.Flags().GetBool("all")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+
+		if shiftFlag == "" {
+			return fmt.Errorf("nothing to change (use --shift)")
+		}
+		shift, err := utils.ParseShift(shiftFlag)
+		if err != nil {
+			return err
+		}
+
+		filter := &models.FilterOptions{
+			DueToday: today,
+			Overdue:  overdue,
+			Tags:     tagsFlag,
+			Assignee: getApp().GetConfig().CurrentUser(),
+		}
+		if priorityFlag != "" {
+			priority := utils.ParsePriorityString(priorityFlag)
+			filter.Priority = &priority
+		}
+		if !all && filter.Priority == nil && len(filter.Tags) == 0 && !today && !overdue {
+			return fmt.Errorf("select reminders with --tags, --priority, --today or --overdue, or use --all")
+		}
+
+		store := getApp().GetStore()
+		reminders := store.GetAll(filter)
+		if len(reminders) == 0 {
+			fmt.Println(i18n.T("No reminders match."))
+			return nil
+		}
+
+		for _, reminder := range reminders {
+			fmt.Printf("  %s  %s → %s\n", reminder.Title, reminder.FormattedDueTime(),
+				i18n.FormatTime(shift.Apply(reminder.DueTime), "Mon Jan 2 3:04 PM"))
+		}
+
+		if !assumeYes {
+			fmt.Print(i18n.T("Shift %d reminders? [y/N]: ", len(reminders)))
+			var response string
+			fmt.Scanln(&response)
+			if response = strings.ToLower(strings.TrimSpace(response)); response != "y" && response != "yes" {
+				fmt.Println(utils.Symbol("❌ ", "") + i18n.T("Nothing changed."))
+				return nil
+			}
+		}
+
+		for _, reminder := range reminders {
+			reminder.DueTime = shift.Apply(reminder.DueTime)
+			// Only whole days keep a reminder on sunrise or sunset
+			if shift.Duration != 0 {
+				reminder.SunEvent = ""
+			}
+		}
+		if err := store.UpdateAll(reminders); err != nil {
+			return fmt.Errorf("failed to update reminders: %w", err)
+		}
+
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Shifted %d reminders.", len(reminders)))
+		return nil
+	},
+}
+
+func init() {
+	bulkCmd.Flags().String("shift", "", "Move the due times relative to their current values (e.g. +1w, -2d, +3h)")
+	bulkCmd.Flags().StringSliceP("tags", "t", []string{}, "Only reminders with these tags")
+	bulkCmd.Flags().StringP("priority", "p", "", "Only reminders with this priority (low, medium, high)")
+	bulkCmd.Flags().Bool("today", false, "Only reminders due today")
+	bulkCmd.Flags().Bool("overdue", false, "Only overdue reminders")
+	bulkCmd.Flags().Bool("all", false, "All active reminders")
+	bulkCmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+
+	bulkCmd.Example = `  # The launch slipped a week
+  nancy bulk --tags launch --shift +1w
+
+  # Give everything overdue another day
+  nancy bulk --overdue --shift +1d --yes`
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var checkInCmd = &cobra.Command{
+	Use:   "check-in [reminder-id]",
+	Short: "Answer a check-in on a long-running reminder",
+	Long: `Tell Nancy you're still working on a reminder.
+
+Reminders added with --check-in (daily, weekly, biweekly or monthly) ask now
+and then, through the daemon, whether they're still in progress. Answering
+records the check-in, which also keeps the reminder out of 'nancy stale'.
+Without an ID, lists the reminders waiting for a check-in.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return listCheckIns()
+		}
+
+		reminder, err := findReminderByID(args[0])
+		if err != nil {
+			return err
+		}
+		return checkIn(reminder)
+	},
+}
+
+func init() {
+	checkInCmd.Example = `  # Reminders waiting for an answer
+  nancy check-in
+
+  # Still working on reminder 4
+  nancy check-in 4
+
+  # Ask every week from now on
+  nancy edit 4 --check-in weekly`
+}
+
+// checkIn records a check-in on a reminder and says when the next one is
+func checkIn(reminder *models.Reminder) error {
+	store := getApp().GetStore()
+	if err := store.CheckIn(reminder.ID); err != nil {
+		return fmt.Errorf("failed to check in: %w", err)
+	}
+	if updated, err := store.Get(reminder.ID); err == nil {
+		reminder = updated
+	}
+
+	fmt.Println(utils.Symbol("👍 ", "") + i18n.T("Checked in: %s", reminder.Title))
+	if next, ok := reminder.NextCheckIn(); ok {
+		fmt.Printf("   %s %s\n", i18n.T("Next check-in:"), i18n.FormatTime(next, "Mon Jan 2"))
+	}
+	return nil
+}
+
+// listCheckIns prints the active reminders whose check-in is due
+func listCheckIns() error {
+	now := time.Now()
+	var due []*models.Reminder
+	for _, reminder := range getApp().GetReminders(&models.FilterOptions{
+		Assignee: getApp().GetConfig().CurrentUser(),
+	}) {
+		if reminder.CheckInDue(now) {
+			due = append(due, reminder)
+		}
+	}
+
+	if len(due) == 0 {
+		fmt.Println(utils.Symbol("✨ ", "") + i18n.T("No check-ins waiting."))
+		return nil
+	}
+
+	fmt.Println(utils.Symbol("🙋 ", "") + i18n.T("Still working on these?"))
+	fmt.Println(strings.Repeat("─", 50))
+	for _, reminder := range due {
+		fmt.Printf("#%-4s %s  (%s)\n", reminder.DisplayID(), reminder.Title, reminder.CheckInText())
+	}
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Println(i18n.T("Answer with 'nancy check-in <id>'."))
+	return nil
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var compactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Remove duplicate records and tidy up the reminders file",
+	Long: `Rewrite reminders.json without duplicate records, with every time stored in
+UTC and the reminders in a stable order, then report what was fixed.
+
+Duplicates turn up when a synced data directory is merged by hand or a sync
+tool keeps both copies. Exact copies are removed; of differing records with
+the same ID, the most recently updated one is kept. A stable file keeps the
+diffs of synced and backed-up copies small.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{localOnly: "true", noDemo: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := getApp().GetStore().Compact()
+		if err != nil {
+			return err
+		}
+
+		if report.Duplicates > 0 {
+			fmt.Println("   " + i18n.T("Removed %d exact duplicate records", report.Duplicates))
+		}
+		if report.Conflicts > 0 {
+			fmt.Println("   " + i18n.T("Resolved %d conflicting copies, keeping the most recently updated", report.Conflicts))
+		}
+		if report.Timestamps > 0 {
+			fmt.Println("   " + i18n.T("Stored %d times in UTC", report.Timestamps))
+		}
+		if !report.Changed() {
+			fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Nothing to fix."))
+			return nil
+		}
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Compacted reminders.json: %d → %d bytes", report.SizeBefore, report.SizeAfter))
+		return nil
+	},
+}
@@ -2,9 +2,12 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
 	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -14,14 +17,40 @@ var completeCmd = &cobra.Command{
 	Long: `Mark one or more reminders as completed by their ID.
 
 You can find reminder IDs by running 'nancy list'.
-You can specify multiple IDs separated by spaces.`,
+You can specify multiple IDs separated by spaces.
+
+Instead of IDs, --filter and --tags complete every active reminder matching
+them, for tidying up things that were done but never ticked off. --filter
+takes a due date range in words: "due before today", "yesterday",
+"since 2024-03-01" or "between 2024-03-01 and 2024-03-10". The matches
+are listed and you are asked to confirm unless --yes is given.`,
 	Aliases: []string{"done", "finish"},
-	Args:    cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		store := getApp().GetStore()
 		var errors []string
 		var completed []string
 
+		filterFlag, _ := cmd.Flags().GetString("filter")
+		tagsFlag, _ := cmd.Flags().GetStringSlice("tags")
+		if filterFlag != "" || len(tagsFlag) > 0 {
+			if len(args) > 0 {
+				return fmt.Errorf("give reminder IDs or --filter/--tags, not both")
+			}
+			reminders, err := confirmMatching(cmd, filterFlag, tagsFlag)
+			if err != nil || reminders == nil {
+				return err
+			}
+			for _, reminder := range reminders {
+				item, err := completeReminder(reminder)
+				if err != nil {
+					errors = append(errors, fmt.Sprintf("ID %s: failed to complete - %v", reminder.DisplayID(), err))
+					continue
+				}
+				completed = append(completed, item)
+			}
+		} else if len(args) == 0 {
+			return fmt.Errorf("give the IDs of the reminders to complete, or select them with --filter or --tags")
+		}
+
 		for _, idArg := range args {
 			// Find reminder by partial ID match
 			reminder, err := findReminderByID(idArg)
@@ -32,39 +61,39 @@ You can specify multiple IDs separated by spaces.`,
 
 			// Check if already completed
 			if reminder.Completed {
-				errors = append(errors, fmt.Sprintf("ID %s: already completed", idArg))
+				errors = append(errors, i18n.T("ID %s: already completed", idArg))
 				continue
 			}
 
 			// Mark as completed
-			if err := store.CompleteReminder(reminder.ID); err != nil {
+			item, err := completeReminder(reminder)
+			if err != nil {
 				errors = append(errors, fmt.Sprintf("ID %s: failed to complete - %v", idArg, err))
 				continue
 			}
-
-			completed = append(completed, fmt.Sprintf("✅ %s", reminder.Title))
+			completed = append(completed, item)
 		}
 
 		// Display results
 		if len(completed) > 0 {
-			fmt.Println("Completed reminders:")
+			fmt.Println(i18n.T("Completed reminders:"))
 			for _, item := range completed {
 				fmt.Println("  " + item)
 			}
 		}
 
 		if len(errors) > 0 {
-			fmt.Println("\nErrors:")
+			fmt.Println("\n" + i18n.T("Errors:"))
 			for _, err := range errors {
-				fmt.Println("  ❌ " + err)
+				fmt.Println("  " + utils.Symbol("❌ ", "[ERROR] ") + err)
 			}
 			return fmt.Errorf("some reminders could not be completed")
 		}
 
 		if len(completed) == 1 {
-			fmt.Println("\n🎉 Great job getting that done!")
+			fmt.Println("\n" + utils.Symbol("🎉 ", "") + i18n.T("Great job getting that done!"))
 		} else if len(completed) > 1 {
-			fmt.Printf("\n🎉 Wow! You completed %d reminders. You're on fire!\n", len(completed))
+			fmt.Println("\n" + utils.Symbol("🎉 ", "") + i18n.T("Wow! You completed %d reminders. You're on fire!", len(completed)))
 		}
 
 		return nil
@@ -91,7 +120,7 @@ Warning: This action cannot be undone!`,
 		force, _ := cmd.Flags().GetBool("force")
 
 		if !force && len(args) > 1 {
-			fmt.Printf("⚠️  You are about to delete %d reminders. Use --force to confirm.\n", len(args))
+			fmt.Println(utils.Symbol("⚠️  ", i18n.T("Warning: ")) + i18n.T("You are about to delete %d reminders. Use --force to confirm.", len(args)))
 			return nil
 		}
 
@@ -105,13 +134,13 @@ Warning: This action cannot be undone!`,
 
 			// Confirm deletion for single items (unless forced)
 			if !force && len(args) == 1 {
-				fmt.Printf("⚠️  Delete reminder: %s? [y/N]: ", reminder.Title)
+				fmt.Print(utils.Symbol("⚠️  ", i18n.T("Warning: ")) + i18n.T("Delete reminder: %s? [y/N]: ", reminder.Title))
 				var response string
 				fmt.Scanln(&response)
 
 				if strings.ToLower(strings.TrimSpace(response)) != "y" &&
 					strings.ToLower(strings.TrimSpace(response)) != "yes" {
-					fmt.Println("❌ Deletion cancelled.")
+					fmt.Println(utils.Symbol("❌ ", "") + i18n.T("Deletion cancelled."))
 					return nil
 				}
 			}
@@ -122,21 +151,21 @@ Warning: This action cannot be undone!`,
 				continue
 			}
 
-			deleted = append(deleted, fmt.Sprintf("🗑️  %s", reminder.Title))
+			deleted = append(deleted, utils.Symbol("🗑️  ", "")+reminder.Title)
 		}
 
 		// Display results
 		if len(deleted) > 0 {
-			fmt.Println("Deleted reminders:")
+			fmt.Println(i18n.T("Deleted reminders:"))
 			for _, item := range deleted {
 				fmt.Println("  " + item)
 			}
 		}
 
 		if len(errors) > 0 {
-			fmt.Println("\nErrors:")
+			fmt.Println("\n" + i18n.T("Errors:"))
 			for _, err := range errors {
-				fmt.Println("  ❌ " + err)
+				fmt.Println("  " + utils.Symbol("❌ ", "") + err)
 			}
 			return fmt.Errorf("some reminders could not be deleted")
 		}
@@ -145,8 +174,68 @@ Warning: This action cannot be undone!`,
 	},
 }
 
+// completeReminder completes a reminder and describes it for the summary
+func completeReminder(reminder *models.Reminder) (string, error) {
+	store := getApp().GetStore()
+	if err := store.CompleteReminder(reminder.ID); err != nil {
+		return "", err
+	}
+
+	item := utils.Symbol("✅ ", i18n.T("[DONE]")+" ") + reminder.Title
+	if updated, err := store.Get(reminder.ID); err == nil && !updated.Completed {
+		// Recurring reminders roll forward instead of completing
+		item += i18n.T(" (next: %s)", updated.FormattedDueTime())
+		if updated.Recurring != nil && updated.Recurring.Final {
+			item += i18n.T(" - last occurrence")
+		}
+	}
+	return item, nil
+}
+
+// confirmMatching lists the active reminders matching the due date range
+// and tags and asks whether to complete them. It returns nil when there are
+// none or the answer is no.
+func confirmMatching(cmd *cobra.Command, dueRange string, tags []string) ([]*models.Reminder, error) {
+	filter := &models.FilterOptions{
+		Tags:     tags,
+		Assignee: getApp().GetConfig().CurrentUser(),
+	}
+	if dueRange != "" {
+		from, before, err := utils.ParseDueRange(dueRange)
+		if err != nil {
+			return nil, err
+		}
+		filter.DueFrom, filter.DueBefore = from, before
+	}
+
+	reminders := getApp().GetStore().GetAll(filter)
+	if len(reminders) == 0 {
+		fmt.Println(i18n.T("No reminders match."))
+		return nil, nil
+	}
+
+	for _, reminder := range reminders {
+		fmt.Printf("  #%s %s  %s\n", reminder.DisplayID(), reminder.Title, reminder.FormattedDueTime())
+	}
+
+	if assumeYes, _ := cmd.Flags().GetBool("yes"); !assumeYes {
+		fmt.Print(i18n.T("Complete %d reminders? [y/N]: ", len(reminders)))
+		var response string
+		fmt.Scanln(&response)
+		if response = strings.ToLower(strings.TrimSpace(response)); response != "y" && response != "yes" {
+			fmt.Println(utils.Symbol("❌ ", "") + i18n.T("Nothing changed."))
+			return nil, nil
+		}
+	}
+	fmt.Println()
+	return reminders, nil
+}
+
 func init() {
 	deleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompts")
+	completeCmd.Flags().String("filter", "", "Complete the active reminders due in this range (e.g. \"due before today\")")
+	completeCmd.Flags().StringSliceP("tags", "t", []string{}, "Complete the active reminders with any of these tags")
+	completeCmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
 
 	completeCmd.Example = `  # Complete a reminder by ID
   nancy complete a1b2c3d4
@@ -154,8 +243,17 @@ func init() {
   # Complete multiple reminders
   nancy complete a1b2c3d4 e5f6g7h8
 
-  # Using short ID (first 8 characters)
-  nancy done a1b2c3d4`
+  # Using the short number shown by 'nancy list'
+  nancy done 7
+
+  # Using a UUID prefix (at least 4 characters)
+  nancy done a1b2c3d4
+
+  # Tick off the chores that were done but never completed
+  nancy complete --filter "due before today" --tags chores
+
+  # Everything from yesterday, without asking
+  nancy done --filter yesterday --yes`
 
 	deleteCmd.Example = `  # Delete a reminder (with confirmation)
   nancy delete a1b2c3d4
@@ -176,9 +274,16 @@ func findReminderByID(idArg string) (*models.Reminder, error) {
 		return reminder, nil
 	}
 
+	// Then short numeric IDs like "7" or "#7"
+	if shortID, err := strconv.Atoi(strings.TrimPrefix(idArg, "#")); err == nil {
+		if reminder, err := store.GetByShortID(shortID); err == nil {
+			return reminder, nil
+		}
+	}
+
 	// If not found and it's a short ID, try to find by prefix
 	if len(idArg) >= 4 { // Minimum 4 characters for partial match
-		allReminders := store.GetAll(&models.FilterOptions{ShowCompleted: true})
+		allReminders := store.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})
 
 		var matches []*models.Reminder
 		for _, reminder := range allReminders {
@@ -2,27 +2,31 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
 	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 )
 
 var daemonCmd = &cobra.Command{
-	Use:   "daemon",
-	Short: "Daemon management commands",
-	Long:  `Start, stop, and manage the Nancy daemon for background reminder notifications.`,
+	Use:         "daemon",
+	Short:       "Daemon management commands",
+	Long:        `Start, stop, and manage the Nancy daemon for background reminder notifications.`,
+	Annotations: map[string]string{noDemo: "true"},
 }
 
 var daemonStartCmd = &cobra.Command{
@@ -72,25 +76,102 @@ type Daemon struct {
 	cancel        context.CancelFunc
 	notifier      *utils.Notifier
 	lastNotified  map[string]time.Time // Track last notification time per reminder ID
+	sent          utils.DaemonState    // When the digest, plan and wrap-up last went out, kept across restarts
+	lastJournal   time.Time
+	lastMirror    time.Time
+	lastMetrics   time.Time
+	lastRules     time.Time
+	lastUpdate    time.Time
+	newVersion    string // Latest release already announced
+	lastIssueSync time.Time
+	lastDoneWhen  time.Time
+	lastReviews   time.Time
+	calendar      *utils.Calendar
+	lastCalendar  time.Time
+	deferred      map[string]string    // Reminder ID -> title held back during a meeting
+	queued        map[string]string    // Reminder ID -> title held back while away
+	unfocused     map[string]string    // Reminder ID -> title held back during a focus session
+	retries       *utils.RetryQueue    // Notifications that didn't reach the main channel
+	lastArrival   time.Time            // Last arrival nagged about
+	checkInAsked  map[string]time.Time // Reminder ID -> when its check-in was last asked
+	sticky        map[string]time.Time // Reminder ID -> due time its sticky notification is for
+	dismissed     []string             // Sticky reminders whose notification was closed unanswered
 }
 
+// issueSyncInterval limits how often linked issues are checked, to stay
+// well within API rate limits
+const issueSyncInterval = 15 * time.Minute
+
+// doneWhenInterval is how often --done-when commands are run
+const doneWhenInterval = 5 * time.Minute
+
+// calendarRefreshInterval is how often the busy calendar feed is re-read
+const calendarRefreshInterval = 15 * time.Minute
+
+// storeWatchInterval is how often the daemon looks for changes other
+// processes made to the reminders file
+const storeWatchInterval = 2 * time.Second
+
+// arrivalCheckInterval is how often the daemon looks for an arrival posted
+// to the location webhook
+const arrivalCheckInterval = 15 * time.Second
+
+// retryCheckInterval is how often queued notifications are looked at; each
+// waits out its own backoff
+const retryCheckInterval = 10 * time.Second
+
+// stickyRepostDelay is how long a dismissed sticky notification stays away,
+// so a server that won't keep it on screen isn't flooded
+const stickyRepostDelay = 30 * time.Second
+
+// storeWakeDelay gathers a burst of changes, like an import or a sync,
+// into one check
+const storeWakeDelay = 3 * time.Second
+
 // NewDaemon creates a new daemon instance
 func NewDaemon(app *app.App, checkInterval time.Duration) (*Daemon, error) {
 	notifier, err := utils.NewNotifier()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize notifier: %w", err)
 	}
+	return NewDaemonWithNotifier(app, checkInterval, notifier), nil
+}
+
+// NewDaemonWithNotifier creates a daemon that notifies through notifier,
+// e.g. one that only records what it is given, in tests
+func NewDaemonWithNotifier(app *app.App, checkInterval time.Duration, notifier *utils.Notifier) *Daemon {
+	notifications := app.GetConfig().Notifications
+	notifier.SetDedupWindow(time.Duration(notifications.DedupMinutes) * time.Minute)
+	limits := make(map[utils.NotificationMethod]int)
+	for name, perHour := range notifications.RateLimits {
+		if channel, err := utils.ParseChannel(name); err == nil {
+			limits[channel] = perHour
+		}
+	}
+	notifier.SetRateLimits(limits)
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	sent, err := utils.LoadDaemonState(daemonStatePath(app))
+	if err != nil {
+		log.Printf("Starting without the daemon state: %v", err)
+	}
+
 	return &Daemon{
 		app:           app,
+		sent:          sent,
 		checkInterval: checkInterval,
 		ctx:           ctx,
 		cancel:        cancel,
 		notifier:      notifier,
 		lastNotified:  make(map[string]time.Time),
-	}, nil
+		deferred:      make(map[string]string),
+		queued:        make(map[string]string),
+		unfocused:     make(map[string]string),
+		checkInAsked:  make(map[string]time.Time),
+		sticky:        make(map[string]time.Time),
+		retries:       utils.NewRetryQueue(time.Duration(notifications.RetryMaxMinutes) * time.Minute),
+	}
 }
 
 // Run starts the daemon monitoring loop
@@ -99,9 +180,39 @@ func (d *Daemon) Run() error {
 
 	ticker := time.NewTicker(d.checkInterval)
 	defer ticker.Stop()
+	retryTicker := time.NewTicker(retryCheckInterval)
+	defer retryTicker.Stop()
+	arrivalTicker := time.NewTicker(arrivalCheckInterval)
+	defer arrivalTicker.Stop()
+
+	// Follow changes made by other processes as they happen rather than
+	// at the next check
+	store := d.app.GetStore()
+	events, unsubscribe := store.Subscribe()
+	defer unsubscribe()
+	go store.Watch(d.ctx, storeWatchInterval)
+	var wake <-chan time.Time
+
+	// Sticky notifications come back when dismissed; only D-Bus says so
+	closed := make(chan utils.NotificationClosed)
+	go func() {
+		if err := utils.WatchNotificationsClosed(d.ctx, closed); err != nil && !errors.Is(err, utils.ErrNoSessionBus) {
+			log.Printf("Not watching for dismissed notifications: %v", err)
+		}
+	}()
+	var repost <-chan time.Time
+
+	check := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Recovered from panic in checkReminders: %v", r)
+			}
+		}()
+		d.checkReminders(time.Now())
+	}
 
 	// Immediate check on startup
-	d.checkReminders()
+	check()
 
 	for {
 		select {
@@ -109,14 +220,29 @@ func (d *Daemon) Run() error {
 			log.Println("Nancy daemon stopped")
 			return nil
 		case <-ticker.C:
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Printf("Recovered from panic in checkReminders: %v", r)
-					}
-				}()
-				d.checkReminders()
-			}()
+			check()
+		case <-retryTicker.C:
+			if d.retries.Len() > 0 {
+				d.retryNotifications(time.Now())
+			}
+		case <-arrivalTicker.C:
+			d.notifyArrival(time.Now())
+		case event := <-events:
+			// The daemon's own changes don't need another check
+			if event.Kind == models.EventReloaded && wake == nil {
+				wake = time.After(storeWakeDelay)
+			}
+		case <-wake:
+			wake = nil
+			log.Println("Reminders changed, checking again")
+			check()
+		case notification := <-closed:
+			if d.stickyClosed(notification) && repost == nil {
+				repost = time.After(stickyRepostDelay)
+			}
+		case <-repost:
+			repost = nil
+			d.repostSticky(time.Now())
 		}
 	}
 }
@@ -128,25 +254,50 @@ func (d *Daemon) Stop() {
 	}
 }
 
+// Check runs one check as if it were now, the way the daemon does every
+// check interval. Tests step through time with it.
+func (d *Daemon) Check(now time.Time) {
+	d.checkReminders(now)
+}
+
 // checkReminders checks for due reminders and sends notifications
-func (d *Daemon) checkReminders() {
-	log.Printf("Checking reminders at %v", time.Now())
+func (d *Daemon) checkReminders(now time.Time) {
+	log.Printf("Checking reminders at %v", now)
 
-	// Reload reminders from storage to get any updates made by other processes
-	store := d.app.GetStore()
-	if err := store.Load(); err != nil {
-		log.Printf("Failed to reload reminders from storage: %v", err)
-		return
-	}
+	// Housekeeping first, so archived and deleted reminders don't nag
+	d.applyRules(now)
 
 	filter := &models.FilterOptions{
 		ShowCompleted: false,
+		Assignee:      d.app.GetConfig().CurrentUser(),
 	}
 
 	reminders := d.app.GetReminders(filter)
-	now := time.Now()
 
-	log.Printf("Found %d active reminders to check (reloaded from storage)", len(reminders))
+	log.Printf("Found %d active reminders to check", len(reminders))
+
+	d.sendWeeklyDigest(reminders, now)
+	d.sendDailyPlan(reminders, now)
+	d.sendWrapUp(now)
+	d.appendJournal(now)
+	d.writeMirror(now)
+	d.recordMetrics(now)
+	d.checkForUpdate(now)
+	d.syncReviews(now)
+	reminders = d.syncIssues(reminders, now)
+	reminders = d.runDoneWhen(reminders, now)
+	d.followSun(reminders)
+	meeting, busy := d.inMeeting(now)
+	away := d.isAway()
+	focus, err := utils.LoadFocus(focusPath(d.app), now)
+	if err != nil {
+		log.Printf("Failed to read focus session: %v", err)
+	}
+
+	// Outside working hours (with quiet hours on) only critical reminders
+	// get through
+	config := d.app.GetConfig()
+	quiet := !config.ShouldNotify(now)
 
 	// Clean up notification tracking for reminders that no longer exist
 	currentReminderIDs := make(map[string]bool)
@@ -158,13 +309,44 @@ func (d *Daemon) checkReminders() {
 	for reminderID := range d.lastNotified {
 		if !currentReminderIDs[reminderID] {
 			delete(d.lastNotified, reminderID)
+			d.retries.Remove(reminderID)
 			log.Printf("Cleaned up notification tracking for deleted reminder: %s", reminderID)
+
+			// Completed or deleted, so its last nag is no longer needed
+			if err := d.notifier.Dismiss(reminderID); err != nil {
+				log.Printf("Failed to close notification for reminder %s: %v", reminderID, err)
+			}
+		}
+	}
+	for reminderID := range d.deferred {
+		if !currentReminderIDs[reminderID] {
+			delete(d.deferred, reminderID)
+		}
+	}
+	for reminderID := range d.queued {
+		if !currentReminderIDs[reminderID] {
+			delete(d.queued, reminderID)
+		}
+	}
+	for reminderID := range d.unfocused {
+		if !currentReminderIDs[reminderID] {
+			delete(d.unfocused, reminderID)
+		}
+	}
+	for reminderID := range d.checkInAsked {
+		if !currentReminderIDs[reminderID] {
+			delete(d.checkInAsked, reminderID)
+		}
+	}
+	for reminderID := range d.sticky {
+		if !currentReminderIDs[reminderID] {
+			delete(d.sticky, reminderID)
 		}
 	}
 
 	for _, reminder := range reminders {
-		// Skip if already completed
-		if reminder.Completed {
+		// Skip if already completed or its recurrence is on hold
+		if reminder.Completed || (reminder.Recurring != nil && reminder.Recurring.Paused) {
 			continue
 		}
 
@@ -173,9 +355,11 @@ func (d *Daemon) checkReminders() {
 		notificationType := ""
 
 		if reminder.IsOverdue() {
-			// Check if we haven't notified about overdue in the last hour
+			// Nag once the grace period is over, then again whenever the
+			// reminder's cadence (hourly by default) has passed
 			lastNotified, exists := d.lastNotified[reminder.ID]
-			if !exists || now.Sub(lastNotified) > time.Hour {
+			if now.Sub(reminder.DueTime) >= reminder.OverdueGrace() &&
+				(!exists || now.Sub(lastNotified) >= reminder.NagInterval()) {
 				shouldNotify = true
 				notificationType = "overdue"
 			}
@@ -196,48 +380,730 @@ func (d *Daemon) checkReminders() {
 			}
 		}
 
+		if shouldNotify && (!config.Notifications.Enabled || (quiet && !reminder.IsCritical())) {
+			// Not marked as notified, so it goes out once quiet hours end
+			continue
+		}
+
+		// Nobody would see a notification on a locked screen
+		if shouldNotify && away {
+			d.lastNotified[reminder.ID] = now
+			d.queued[reminder.ID] = reminder.Title
+			log.Printf("Queued %s notification while away: %s", notificationType, reminder.Title)
+			continue
+		}
+
+		// During a focus session only the focus tags get through
+		if shouldNotify && focus != nil && !focus.Allows(reminder) {
+			d.lastNotified[reminder.ID] = now
+			d.unfocused[reminder.ID] = reminder.Title
+			log.Printf("Held back %s notification during focus: %s", notificationType, reminder.Title)
+			continue
+		}
+
+		// Hold back non-critical notifications while in a meeting
+		if shouldNotify && busy && reminder.Priority != models.High && !reminder.IsCritical() {
+			d.lastNotified[reminder.ID] = now
+			if config.Integrations.DuringMeetings == "suppress" {
+				log.Printf("Suppressed %s notification during '%s': %s", notificationType, meeting.Summary, reminder.Title)
+			} else {
+				d.deferred[reminder.ID] = reminder.Title
+				log.Printf("Deferred %s notification until '%s' ends: %s", notificationType, meeting.Summary, reminder.Title)
+			}
+			continue
+		}
+
 		if shouldNotify {
-			if err := d.sendNotification(reminder, notificationType); err != nil {
+			err := d.sendNotification(reminder, notificationType)
+			switch {
+			case errors.Is(err, utils.ErrDuplicate):
+				d.lastNotified[reminder.ID] = now
+				log.Printf("Skipped duplicate %s notification for: %s", notificationType, reminder.Title)
+			case err != nil:
 				log.Printf("Failed to send notification for reminder %s: %v", reminder.ID, err)
-			} else {
+				d.queueRetry(reminder, notificationType, now)
+			default:
 				d.lastNotified[reminder.ID] = now
-				log.Printf("Sent %s notification for: %s", notificationType, reminder.Title)
+				log.Printf("Sent %s notification for: %s (%s)", notificationType, reminder.Title, d.deliveredBy())
+				if d.notifier.FallbackError() != nil {
+					d.queueRetry(reminder, notificationType, now)
+				}
 			}
 		}
 	}
+
+	// Check-ins are never urgent, so they wait for a quiet moment
+	if config.Notifications.Enabled && !quiet && !away && !busy && focus == nil {
+		d.askCheckIns(reminders, now)
+	}
+
+	if !busy && d.sendCatchUp(d.deferred, "While you were in a meeting (%d)") {
+		d.deferred = make(map[string]string)
+	}
+	if !away && d.sendCatchUp(d.queued, "While you were away (%d)") {
+		d.queued = make(map[string]string)
+	}
+	if focus == nil && d.sendCatchUp(d.unfocused, "While you were focusing (%d)") {
+		d.unfocused = make(map[string]string)
+	}
+}
+
+// notifyArrival nags about the active reminders tagged with the place the
+// phone last reported arriving at, once per arrival
+func (d *Daemon) notifyArrival(now time.Time) {
+	arrival, err := utils.LoadArrival(arrivalPath(d.app), now)
+	if err != nil {
+		log.Printf("Failed to read arrival: %v", err)
+		return
+	}
+	if arrival == nil || !arrival.At.After(d.lastArrival) {
+		return
+	}
+	d.lastArrival = arrival.At
+	config := d.app.GetConfig()
+	if !config.Notifications.Enabled {
+		return
+	}
+
+	tag := utils.PlaceTag(arrival.Place)
+	for _, reminder := range d.app.GetStore().GetAll(&models.FilterOptions{Tags: []string{tag}}) {
+		if !config.ShouldNotify(now) && !reminder.IsCritical() {
+			continue
+		}
+		if err := d.sendNotification(reminder, "arrived"); err != nil && !errors.Is(err, utils.ErrDuplicate) {
+			log.Printf("Failed to send arrival notification for reminder %s: %v", reminder.ID, err)
+			continue
+		}
+		log.Printf("Sent arrival notification at %s for: %s (%s)", tag, reminder.Title, d.deliveredBy())
+	}
+}
+
+// checkInRepeat is how long an unanswered check-in waits before it is
+// asked again
+const checkInRepeat = 24 * time.Hour
+
+// askCheckIns asks whether the user is still working on the long-running
+// reminders whose check-in is due, at most once a day until answered
+func (d *Daemon) askCheckIns(reminders []*models.Reminder, now time.Time) {
+	for _, reminder := range reminders {
+		if !reminder.CheckInDue(now) {
+			continue
+		}
+		if asked, ok := d.checkInAsked[reminder.ID]; ok && now.Sub(asked) < checkInRepeat {
+			continue
+		}
+
+		if err := d.sendNotification(reminder, "check_in"); err != nil && !errors.Is(err, utils.ErrDuplicate) {
+			log.Printf("Failed to send check-in for reminder %s: %v", reminder.ID, err)
+			continue
+		}
+		d.checkInAsked[reminder.ID] = now
+		log.Printf("Asked for a check-in on: %s (%s)", reminder.Title, d.deliveredBy())
+	}
+}
+
+// isAway reports whether notifications should be queued because the screen
+// is locked or, with idle_minutes set, the user has been idle. When the state
+// can't be read, notifications go out as usual.
+func (d *Daemon) isAway() bool {
+	notifications := d.app.GetConfig().Notifications
+	if notifications.WhenLocked != "queue" {
+		return false
+	}
+	away, err := utils.Away(time.Duration(notifications.IdleMinutes) * time.Minute)
+	if err != nil && !errors.Is(err, utils.ErrSessionStateUnknown) {
+		log.Printf("Failed to check whether the screen is locked: %v", err)
+	}
+	return away
+}
+
+// followSun moves reminders anchored to sunrise or sunset to that day's exact
+// time, which shifts a little every day
+func (d *Daemon) followSun(reminders []*models.Reminder) {
+	for _, reminder := range reminders {
+		if reminder.SunEvent == "" {
+			continue
+		}
+
+		sunTime, err := utils.SunEventTime(reminder.SunEvent, reminder.DueTime)
+		if err != nil {
+			log.Printf("Failed to compute %s for '%s': %v", reminder.SunEvent, reminder.Title, err)
+			continue
+		}
+		if diff := sunTime.Sub(reminder.DueTime); diff > -time.Minute && diff < time.Minute {
+			continue
+		}
+
+		if err := d.app.GetStore().SetDueTime(reminder.ID, sunTime); err != nil {
+			log.Printf("Failed to move '%s' to %s: %v", reminder.Title, reminder.SunEvent, err)
+			continue
+		}
+		reminder.DueTime = sunTime
+		log.Printf("Moved '%s' to %s at %s", reminder.Title, reminder.SunEvent, sunTime.Format("15:04"))
+	}
+}
+
+// inMeeting reports whether the configured calendar has a busy event now.
+// The feed is re-read every calendarRefreshInterval; a failed refresh keeps
+// the previous copy.
+func (d *Daemon) inMeeting(now time.Time) (utils.CalendarEvent, bool) {
+	source := d.app.GetConfig().Integrations.CalendarURL
+	if source == "" {
+		return utils.CalendarEvent{}, false
+	}
+
+	if d.calendar == nil || now.Sub(d.lastCalendar) >= calendarRefreshInterval {
+		d.lastCalendar = now
+		calendar, err := utils.LoadCalendar(source)
+		if err != nil {
+			log.Printf("Failed to load calendar: %v", err)
+		} else {
+			d.calendar = calendar
+		}
+	}
+	if d.calendar == nil {
+		return utils.CalendarEvent{}, false
+	}
+	return d.calendar.BusyAt(now)
+}
+
+// sendCatchUp delivers a single notification summarizing the held reminders,
+// headed by heading (a format taking their count), and reports whether it
+// went out
+func (d *Daemon) sendCatchUp(held map[string]string, heading string) bool {
+	if len(held) == 0 {
+		return false
+	}
+
+	titles := make([]string, 0, len(held))
+	for _, title := range held {
+		titles = append(titles, "• "+title)
+	}
+	sort.Strings(titles)
+
+	message := strings.Join(titles, "\n")
+	title := i18n.T(heading, len(titles))
+	if err := d.notifier.Send(title, message, models.Medium); err != nil {
+		log.Printf("Failed to send catch-up notification: %v", err)
+		return false
+	}
+
+	log.Printf("Sent catch-up notification for %d held reminders", len(titles))
+	return true
+}
+
+// syncReviews imports GitHub review requests every review_sync_minutes.
+// New reminders are picked up on the next check.
+func (d *Daemon) syncReviews(now time.Time) {
+	minutes := d.app.GetConfig().Integrations.ReviewSync
+	if minutes <= 0 || now.Sub(d.lastReviews) < time.Duration(minutes)*time.Minute {
+		return
+	}
+	d.lastReviews = now
+
+	added, completed, err := syncGitHubReviews()
+	if err != nil {
+		log.Printf("Failed to sync GitHub review requests: %v", err)
+		return
+	}
+	if added > 0 || completed > 0 {
+		log.Printf("Synced GitHub review requests: %d added, %d completed", added, completed)
+	}
+}
+
+// syncIssues completes reminders whose linked issue has been closed and
+// returns the reminders that are still active
+func (d *Daemon) syncIssues(reminders []*models.Reminder, now time.Time) []*models.Reminder {
+	if !d.app.GetConfig().Integrations.CloseWithIssue || now.Sub(d.lastIssueSync) < issueSyncInterval {
+		return reminders
+	}
+	d.lastIssueSync = now
+
+	active := reminders[:0]
+	for _, reminder := range reminders {
+		if reminder.Issue == "" || reminder.Completed {
+			active = append(active, reminder)
+			continue
+		}
+
+		ref, err := utils.ParseIssueRef(reminder.Issue)
+		if err != nil {
+			active = append(active, reminder)
+			continue
+		}
+		issue, err := utils.FetchIssue(ref, issueCredentials())
+		if err != nil {
+			log.Printf("Failed to check issue %s: %v", reminder.Issue, err)
+			active = append(active, reminder)
+			continue
+		}
+		if !issue.Closed {
+			active = append(active, reminder)
+			continue
+		}
+
+		if err := d.app.GetStore().CompleteReminder(reminder.ID); err != nil {
+			log.Printf("Failed to complete reminder for closed issue %s: %v", reminder.Issue, err)
+			active = append(active, reminder)
+			continue
+		}
+		log.Printf("Completed '%s': issue %s was closed", reminder.Title, reminder.Issue)
+	}
+	return active
+}
+
+// runDoneWhen completes reminders whose --done-when command succeeds and
+// returns the reminders that are still active. Only commands entered on
+// this machine are run. A repeating reminder's command only runs once the
+// current occurrence is due, so one success doesn't complete them all.
+func (d *Daemon) runDoneWhen(reminders []*models.Reminder, now time.Time) []*models.Reminder {
+	if now.Sub(d.lastDoneWhen) < doneWhenInterval {
+		return reminders
+	}
+	d.lastDoneWhen = now
+
+	configDir := d.app.GetConfig().GetConfigDir()
+	active := reminders[:0]
+	for _, reminder := range reminders {
+		if reminder.DoneWhen == "" || reminder.Completed || (reminder.Recurring != nil && reminder.DueTime.After(now)) {
+			active = append(active, reminder)
+			continue
+		}
+		if !utils.IsTrustedCommand(configDir, reminder.DoneWhen) {
+			log.Printf("Not running --done-when for '%s': the command wasn't entered on this machine; "+
+				"set it again with 'nancy edit %d --done-when' to allow it", reminder.Title, reminder.ShortID)
+			active = append(active, reminder)
+			continue
+		}
+
+		done, err := utils.RunDoneWhen(reminder.DoneWhen)
+		if err != nil {
+			log.Printf("Failed to check --done-when for '%s': %v", reminder.Title, err)
+		}
+		if !done {
+			active = append(active, reminder)
+			continue
+		}
+
+		if err := d.app.GetStore().CompleteReminder(reminder.ID); err != nil {
+			log.Printf("Failed to complete '%s' after its --done-when command succeeded: %v", reminder.Title, err)
+			active = append(active, reminder)
+			continue
+		}
+		log.Printf("Completed '%s': %s succeeded", reminder.Title, reminder.DoneWhen)
+	}
+	return active
 }
 
 // sendNotification sends a notification for the given reminder
 func (d *Daemon) sendNotification(reminder *models.Reminder, notificationType string) error {
-	var title, message string
+	title, message := notificationText(reminder, notificationType)
+	if err := d.notifier.SendReminder(reminder, title, message); err != nil {
+		return err
+	}
+	if err := d.notifier.FallbackError(); err != nil {
+		log.Printf("Desktop notification failed, used a fallback instead: %v", err)
+	}
+	if reminder.Sticky {
+		d.sticky[reminder.ID] = reminder.DueTime
+	}
+	return nil
+}
+
+// stickyClosed notes a sticky notification closed without Nancy closing
+// it, and reports whether it should come back
+func (d *Daemon) stickyClosed(notification utils.NotificationClosed) bool {
+	if notification.Reason != utils.ClosedExpired && notification.Reason != utils.ClosedDismissed {
+		return false
+	}
+	reminderID, ok := d.notifier.ShownReminder(notification.ID)
+	if !ok {
+		return false
+	}
+	if _, ok := d.sticky[reminderID]; !ok {
+		return false
+	}
+	d.dismissed = append(d.dismissed, reminderID)
+	return true
+}
+
+// repostSticky shows the dismissed sticky notifications again, unless their
+// reminder was acted on meanwhile: completed, snoozed or rescheduled
+func (d *Daemon) repostSticky(now time.Time) {
+	dismissed := d.dismissed
+	d.dismissed = nil
+	for _, reminderID := range dismissed {
+		dueTime, ok := d.sticky[reminderID]
+		if !ok {
+			continue
+		}
+		reminder, err := d.app.GetStore().Get(reminderID)
+		if err != nil || reminder.Completed || !reminder.Sticky || !reminder.DueTime.Equal(dueTime) {
+			delete(d.sticky, reminderID)
+			continue
+		}
+
+		notificationType := "due_today"
+		switch {
+		case reminder.IsOverdue():
+			notificationType = "overdue"
+		case reminder.IsDueSoon():
+			notificationType = "due_soon"
+		}
+		title, message := notificationText(reminder, notificationType)
+		if err := d.notifier.RetryReminder(reminder, title, message); err != nil {
+			log.Printf("Failed to re-post sticky notification for '%s': %v", reminder.Title, err)
+			continue
+		}
+		d.lastNotified[reminder.ID] = now
+		log.Printf("Re-posted dismissed sticky notification: %s", reminder.Title)
+	}
+}
 
+// notificationText returns the title and message of a notification
+func notificationText(reminder *models.Reminder, notificationType string) (title, message string) {
 	switch notificationType {
 	case "overdue":
-		title = "Overdue Reminder"
-		message = fmt.Sprintf("⚠️ %s\nDue: %s", reminder.Title, reminder.FormattedDueTime())
+		title = i18n.T("Overdue Reminder")
+		message = fmt.Sprintf("%s%s\n%s %s", utils.Symbol("⚠️ ", ""), reminder.Title, i18n.T("Due:"), reminder.FormattedDueTime())
 	case "due_soon":
-		title = "Reminder Due Soon"
-		message = fmt.Sprintf("⏰ %s\nDue: %s", reminder.Title, reminder.FormattedDueTime())
+		title = i18n.T("Reminder Due Soon")
+		message = fmt.Sprintf("%s%s\n%s %s", utils.Symbol("⏰ ", ""), reminder.Title, i18n.T("Due:"), reminder.FormattedDueTime())
 	case "due_today":
-		title = "Reminder Due Today"
-		message = fmt.Sprintf("📅 %s\nDue: %s", reminder.Title, reminder.FormattedDueTime())
+		title = i18n.T("Reminder Due Today")
+		message = fmt.Sprintf("%s%s\n%s %s", utils.Symbol("📅 ", ""), reminder.Title, i18n.T("Due:"), reminder.FormattedDueTime())
+	case "arrived":
+		title = i18n.T("Reminder for This Place")
+		message = utils.Symbol("📍 ", "") + reminder.Title
+	case "check_in":
+		title = i18n.T("Check-in")
+		message = fmt.Sprintf("%s%s\n%s", utils.Symbol("🙋 ", ""), i18n.T("Still working on '%s'?", reminder.Title),
+			i18n.T("Run 'nancy check-in %s' to say so.", reminder.DisplayID()))
 	default:
-		title = "Nancy Reminder"
+		title = i18n.T("Nancy Reminder")
 		message = reminder.Title
 	}
+	return title, message
+}
+
+// queueRetry queues a notification that didn't reach the main channel, so
+// it is retried with backoff rather than lost. The queue takes over from
+// the regular checks until it gives up.
+func (d *Daemon) queueRetry(reminder *models.Reminder, notificationType string, now time.Time) {
+	if d.app.GetConfig().Notifications.RetryMaxMinutes <= 0 {
+		return
+	}
+	title, message := notificationText(reminder, notificationType)
+	d.retries.Add(reminder.ID, title, message, now)
+	d.lastNotified[reminder.ID] = now
+	log.Printf("Queued %s notification for a retry: %s", notificationType, reminder.Title)
+}
+
+// retryNotifications retries the queued notifications that are due, on the
+// main channel only
+func (d *Daemon) retryNotifications(now time.Time) {
+	due, expired := d.retries.Due(now)
+	for _, pending := range expired {
+		log.Printf("Gave up on the notification for reminder %s after %s and %d retries", pending.ReminderID,
+			utils.FormatDuration(now.Sub(pending.Since)), pending.Attempts)
+	}
+
+	for _, pending := range due {
+		reminder, err := d.app.GetStore().Get(pending.ReminderID)
+		if err != nil || reminder.Completed {
+			d.retries.Remove(pending.ReminderID)
+			continue
+		}
+
+		if err := d.notifier.RetryReminder(reminder, pending.Title, pending.Message); err != nil {
+			d.retries.Failed(pending.ReminderID, now)
+			log.Printf("Retry %d for '%s' failed: %v", pending.Attempts+1, reminder.Title, err)
+			continue
+		}
+		d.retries.Remove(pending.ReminderID)
+		log.Printf("Delivered notification for '%s' on retry %d", reminder.Title, pending.Attempts+1)
+	}
+}
+
+// deliveredBy names the channel that delivered the last notification
+func (d *Daemon) deliveredBy() string {
+	if channel, ok := d.notifier.LastChannel(); ok {
+		return "via " + utils.ChannelName(channel)
+	}
+	return "not delivered"
+}
+
+// sendWeeklyDigest sends a summary notification on the first check each Monday
+func (d *Daemon) sendWeeklyDigest(reminders []*models.Reminder, now time.Time) {
+	config := d.app.GetConfig()
+	if !config.Daemon.WeeklyDigest || now.Weekday() != time.Monday {
+		return
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !d.sent.Digest.Before(today) {
+		return
+	}
+
+	var overdue, thisWeek, stale int
+	weekEnd := today.AddDate(0, 0, 7)
+	for _, reminder := range reminders {
+		if reminder.IsOverdue() {
+			overdue++
+		} else if reminder.DueTime.Before(weekEnd) {
+			thisWeek++
+		}
+		if reminder.IsStale(config.Daemon.StaleDays) {
+			stale++
+		}
+	}
+
+	message := i18n.T("%s%d active | %s%d overdue | %s%d due this week",
+		utils.Symbol("📋 ", ""), len(reminders), utils.Symbol("⚠️ ", ""), overdue, utils.Symbol("📆 ", ""), thisWeek)
+	if config.Daemon.DigestStale && stale > 0 {
+		message += "\n" + i18n.T("%s%d stale (run 'nancy stale --review')", utils.Symbol("🕸️ ", ""), stale)
+	}
+
+	if err := d.notifier.Send(i18n.T("Nancy Weekly Digest"), message, models.Medium); err != nil {
+		log.Printf("Failed to send weekly digest: %v", err)
+		return
+	}
+
+	d.sent.Digest = now
+	d.saveState()
+	log.Printf("Sent weekly digest")
+}
+
+// planLookahead is how many days, today included, the daily plan checks
+// for more than default.max_per_day reminders
+const planLookahead = 7
+
+// sendDailyPlan sends the "plan your day" notification on the first check
+// after daemon.plan_time
+func (d *Daemon) sendDailyPlan(reminders []*models.Reminder, now time.Time) {
+	at, ok := clockToday(d.app.GetConfig().Daemon.PlanTime, now)
+	if !ok || now.Before(at) || !d.sent.Plan.Before(at) {
+		return
+	}
+	d.sent.Plan = now
+	d.saveState()
+
+	loads := d.app.GetStore().DayLoads(now, planLookahead)
+	message := utils.PlanMessage(reminders, now, loads, d.app.GetConfig().Default.MaxPerDay)
+	if err := d.notifier.Send(i18n.T("Plan Your Day"), message, models.Medium); err != nil {
+		log.Printf("Failed to send daily plan: %v", err)
+		return
+	}
+	log.Printf("Sent daily plan")
+}
+
+// sendWrapUp sends the evening summary of what got done and what rolled
+// over on the first check after daemon.wrap_up_time
+func (d *Daemon) sendWrapUp(now time.Time) {
+	at, ok := clockToday(d.app.GetConfig().Daemon.WrapUpTime, now)
+	if !ok || now.Before(at) || !d.sent.WrapUp.Before(at) {
+		return
+	}
+	d.sent.WrapUp = now
+	d.saveState()
+
+	reminders := d.app.GetReminders(&models.FilterOptions{
+		ShowCompleted: true,
+		Assignee:      d.app.GetConfig().CurrentUser(),
+	})
+	if err := d.notifier.Send(i18n.T("Today's Wrap-Up"), utils.WrapUpMessage(reminders, now), models.Low); err != nil {
+		log.Printf("Failed to send wrap-up: %v", err)
+		return
+	}
+	log.Printf("Sent wrap-up")
+}
+
+// saveState records when the summaries were sent, so a restarted daemon
+// doesn't send them again
+func (d *Daemon) saveState() {
+	if err := utils.SaveDaemonState(daemonStatePath(d.app), d.sent); err != nil {
+		log.Printf("Failed to save the daemon state: %v", err)
+	}
+}
+
+// daemonStatePath returns the daemon state file, in the state directory or
+// else next to the config
+func daemonStatePath(a *app.App) string {
+	return filepath.Join(utils.StateDir(a.GetConfig().GetConfigDir()), utils.DaemonStateFile)
+}
 
-	return d.notifier.Send(title, message, reminder.Priority)
+// clockToday returns today's time for an "HH:MM" clock, or false if it is
+// empty or invalid
+func clockToday(clock string, now time.Time) (time.Time, bool) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), true
 }
 
-// getPIDFilePath returns the path to the daemon PID file
+// appendJournal appends yesterday's done list to the configured journal file
+// on the first check of each day
+func (d *Daemon) appendJournal(now time.Time) {
+	path := d.app.GetConfig().Daemon.JournalFile
+	if path == "" {
+		return
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !d.lastJournal.Before(today) {
+		return
+	}
+	d.lastJournal = now
+
+	yesterday := today.AddDate(0, 0, -1)
+
+	// Don't append the same day twice (e.g. after a daemon restart)
+	if existing, err := os.ReadFile(path); err == nil &&
+		strings.Contains(string(existing), utils.JournalHeading(yesterday)) {
+		return
+	}
+
+	journal, err := utils.BuildJournal(d.app.GetStore().GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true}), yesterday, "md")
+	if err != nil {
+		log.Printf("Failed to build journal: %v", err)
+		return
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Printf("Failed to open journal file: %v", err)
+		return
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(journal + "\n"); err != nil {
+		log.Printf("Failed to write journal: %v", err)
+		return
+	}
+	log.Printf("Appended journal for %s to %s", yesterday.Format("2006-01-02"), path)
+}
+
+// writeMirror rewrites the configured HTML page on the first check of each day
+func (d *Daemon) writeMirror(now time.Time) {
+	path := d.app.GetConfig().Daemon.HTMLFile
+	if path == "" {
+		return
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !d.lastMirror.Before(today) {
+		return
+	}
+	d.lastMirror = now
+
+	page, err := buildMirror(d.app, 0)
+	if err != nil {
+		log.Printf("Failed to build HTML page: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, page, 0644); err != nil {
+		log.Printf("Failed to write HTML page: %v", err)
+		return
+	}
+	log.Printf("Wrote HTML page to %s", path)
+}
+
+// recordMetrics samples the overdue backlog for 'nancy stats' on the first
+// check of each day
+func (d *Daemon) recordMetrics(now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !d.lastMetrics.Before(today) {
+		return
+	}
+	d.lastMetrics = now
+
+	overdue, active := backlogCounts(d.app)
+	sample := utils.MetricSample{Date: now.Format("2006-01-02"), Overdue: overdue, Active: active}
+	if err := utils.RecordMetric(metricsPath(d.app), sample); err != nil {
+		log.Printf("Failed to record metrics: %v", err)
+		return
+	}
+	log.Printf("Recorded %d overdue of %d active reminders", overdue, active)
+}
+
+// applyRules runs the housekeeping rules once a day
+func (d *Daemon) applyRules(now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !d.lastRules.Before(today) || len(d.app.GetConfig().Rules) == 0 {
+		return
+	}
+	d.lastRules = now
+
+	rules, err := models.ParseRules(d.app.GetConfig().Rules)
+	if err != nil {
+		log.Printf("Skipping housekeeping rules: %v", err)
+		return
+	}
+	matches, err := d.app.GetStore().ApplyRules(rules, now)
+	if err != nil {
+		log.Printf("Failed to apply housekeeping rules: %v", err)
+		return
+	}
+	for _, match := range matches {
+		log.Printf("Rule '%s': %sd %s", match.Rule.Text, match.Rule.Action, match.Reminder.Title)
+	}
+}
+
+// checkForUpdate looks for a new release once a day and announces each new
+// version once
+func (d *Daemon) checkForUpdate(now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !d.app.GetConfig().Daemon.CheckUpdates || !d.lastUpdate.Before(today) {
+		return
+	}
+	d.lastUpdate = now
+
+	release, err := utils.LatestRelease()
+	if err != nil {
+		log.Printf("Failed to check for updates: %v", err)
+		return
+	}
+	newer, err := utils.NewerVersion(app.Version, release.Version)
+	if err != nil || !newer || release.Version == d.newVersion {
+		return
+	}
+
+	title := i18n.T("Nancy %s is available", release.Version)
+	if err := d.notifier.Send(title, i18n.T("Run 'nancy self-update' to install it."), models.Low); err != nil {
+		log.Printf("Failed to send update notification: %v", err)
+		return
+	}
+	d.newVersion = release.Version
+	log.Printf("Nancy %s is available (running %s)", release.Version, app.Version)
+}
+
+// pidFileName is the daemon's PID file in the runtime directory
+const pidFileName = "daemon.pid"
+
+// getPIDFilePath returns the path to the daemon PID file, in the user's
+// runtime directory so other users and sessions don't share it
 func getPIDFilePath() (string, error) {
 	app, err := app.New()
 	if err != nil {
 		return "", err
 	}
 
-	configDir := app.GetConfig().GetConfigDir()
-	return filepath.Join(configDir, "daemon.pid"), nil
+	dir := utils.RuntimeDir(app.GetConfig().GetConfigDir())
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create runtime directory: %w", err)
+	}
+	return filepath.Join(dir, pidFileName), nil
+}
+
+// legacyPIDFilePath returns where older versions kept the PID file, so a
+// daemon they started can still be stopped
+func legacyPIDFilePath() (string, error) {
+	app, err := app.New()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(app.GetConfig().GetConfigDir(), pidFileName), nil
 }
 
 // writePIDFile writes the current process ID to the PID file
@@ -258,39 +1124,65 @@ func removePIDFile() error {
 		return err
 	}
 
+	if legacy, err := legacyPIDFilePath(); err == nil && legacy != pidFile {
+		os.Remove(legacy)
+	}
 	return os.Remove(pidFile)
 }
 
-// isDaemonRunning checks if the daemon is currently running
-func isDaemonRunning() (bool, int, error) {
+// readPIDFile returns the PID in the PID file, or 0 if there is none
+func readPIDFile() (int, error) {
 	pidFile, err := getPIDFilePath()
 	if err != nil {
-		return false, 0, err
+		return 0, err
 	}
 
 	data, err := os.ReadFile(pidFile)
 	if os.IsNotExist(err) {
-		return false, 0, nil
+		legacy, legacyErr := legacyPIDFilePath()
+		if legacyErr != nil {
+			return 0, legacyErr
+		}
+		data, err = os.ReadFile(legacy)
+	}
+	if os.IsNotExist(err) {
+		return 0, nil
 	}
 	if err != nil {
-		return false, 0, err
+		return 0, err
 	}
 
-	pid, err := strconv.Atoi(string(data))
-	if err != nil {
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// isDaemonRunning checks if the daemon is currently running
+func isDaemonRunning() (bool, int, error) {
+	pid, err := readPIDFile()
+	if err != nil || pid == 0 {
 		return false, 0, err
 	}
 
-	// Check if process is running
-	process, err := os.FindProcess(pid)
-	if err != nil {
+	// The PID file is ours, so another user's process with that PID means
+	// the PID was reused, like after a reboot
+	running, err := utils.CheckProcess(pid)
+	if errors.Is(err, utils.ErrForeignProcess) {
+		running = false
+	} else if err != nil {
+		return false, pid, err
+	}
+	if !running {
+		// Process doesn't exist, clean up stale PID file
+		removePIDFile()
 		return false, pid, nil
 	}
 
-	// On Unix systems, sending signal 0 checks if process exists
-	err = process.Signal(syscall.Signal(0))
+	// Nor is another program of ours that got the PID, so it is never
+	// signalled in the daemon's place
+	daemon, err := utils.IsNancyDaemon(pid)
 	if err != nil {
-		// Process doesn't exist, clean up stale PID file
+		return false, pid, err
+	}
+	if !daemon {
 		removePIDFile()
 		return false, pid, nil
 	}
@@ -353,18 +1245,15 @@ func daemonizeProcess(interval time.Duration) error {
 		"--foreground", // The child process will run in foreground mode
 		"--interval", interval.String(),
 	}
-
-	// Start the process in background
-	cmd := exec.Command(executable, args...)
-	cmd.Stdin = nil
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
-		Setctty: false, // Create new session (detach from terminal)
+	// Started with --remote, it nags about the remote's reminders too
+	if remote, _ := rootCmd.PersistentFlags().GetString("remote"); remote != "" {
+		args = append(args, "--remote", remote)
 	}
 
-	if err := cmd.Start(); err != nil {
+	// Start the process in background, detached from the terminal
+	logPath := daemonLogPath()
+	process, err := utils.StartDetached(executable, args, logPath)
+	if err != nil {
 		return fmt.Errorf("failed to start daemon process: %w", err)
 	}
 
@@ -374,18 +1263,25 @@ func daemonizeProcess(interval time.Duration) error {
 		return fmt.Errorf("failed to get PID file path: %w", err)
 	}
 
-	if err := os.WriteFile(pidFile, []byte(strconv.Itoa(cmd.Process.Pid)), 0644); err != nil {
+	if err := os.WriteFile(pidFile, []byte(strconv.Itoa(process.Pid)), 0644); err != nil {
 		return fmt.Errorf("failed to write PID file: %w", err)
 	}
 
-	fmt.Printf("Nancy daemon started with PID %d\n", cmd.Process.Pid)
+	fmt.Println(i18n.T("Nancy daemon started with PID %d", process.Pid))
+	fmt.Println(i18n.T("Logging to %s", logPath))
 	return nil
 }
 
+// daemonLogPath returns the log of the background daemon, in the state
+// directory or else next to the config
+func daemonLogPath() string {
+	return filepath.Join(utils.StateDir(getApp().GetConfig().GetConfigDir()), utils.DaemonLogFile)
+}
+
 // runDaemonForeground runs the daemon in the current process
 func runDaemonForeground(daemon *Daemon, interval time.Duration) error {
-	fmt.Println("Nancy daemon started in foreground mode")
-	fmt.Printf("Check interval: %v\n", interval)
+	fmt.Println(i18n.T("Nancy daemon started in foreground mode"))
+	fmt.Println(i18n.T("Check interval: %v", interval))
 
 	// Set up signal handling
 	sigChan := make(chan os.Signal, 1)
@@ -419,33 +1315,29 @@ func stopDaemon(cmd *cobra.Command, args []string) error {
 	}
 
 	if !running {
-		fmt.Println("Daemon is not running")
+		fmt.Println(i18n.T("Daemon is not running"))
 		return nil
 	}
 
 	// Send TERM signal to the process
-	process, err := os.FindProcess(pid)
-	if err != nil {
-		return fmt.Errorf("failed to find process %d: %w", pid, err)
-	}
-
-	if err := process.Signal(syscall.SIGTERM); err != nil {
+	if err := utils.SignalProcess(pid, syscall.SIGTERM); err != nil {
 		return fmt.Errorf("failed to send TERM signal to process %d: %w", pid, err)
 	}
 
-	// Wait a bit and check if process stopped
+	// Wait a bit and check if process stopped. This checks again that the
+	// PID is still the daemon's before it is killed.
 	time.Sleep(time.Second)
 	if running, _, _ := isDaemonRunning(); !running {
-		fmt.Println("Daemon stopped")
+		fmt.Println(i18n.T("Daemon stopped"))
 		return nil
 	}
 
 	// If still running, force kill
-	if err := process.Signal(syscall.SIGKILL); err != nil {
+	if err := utils.SignalProcess(pid, syscall.SIGKILL); err != nil {
 		return fmt.Errorf("failed to force kill process %d: %w", pid, err)
 	}
 
-	fmt.Println("Daemon force stopped")
+	fmt.Println(i18n.T("Daemon force stopped"))
 	return nil
 }
 
@@ -457,9 +1349,10 @@ func daemonStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	if running {
-		fmt.Printf("Daemon is running with PID %d\n", pid)
+		fmt.Println(i18n.T("Daemon is running with PID %d", pid))
+		fmt.Println(i18n.T("Log: %s", daemonLogPath()))
 	} else {
-		fmt.Println("Daemon is not running")
+		fmt.Println(i18n.T("Daemon is not running"))
 	}
 
 	return nil
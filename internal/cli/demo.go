@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+)
+
+// noDemo marks commands that reach past the sample reminders of --demo, e.g.
+// by starting processes that would open the real store, so they refuse it
+const noDemo = "no-demo"
+
+// startDemo switches to sample reminders kept in memory when --demo is given
+func startDemo(cmd *cobra.Command) error {
+	if demo, _ := cmd.Flags().GetBool("demo"); !demo {
+		return nil
+	}
+	if cmd.Flags().Changed("remote") {
+		return fmt.Errorf("--demo and --remote can't be used together")
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[noDemo] == "true" {
+			return fmt.Errorf("'%s' is not available with --demo", cmd.CommandPath())
+		}
+	}
+
+	demo, err := app.NewDemo()
+	if err != nil {
+		return err
+	}
+	appInstance = demo
+	return nil
+}
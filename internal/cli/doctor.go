@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that Nancy is set up correctly",
+	Long: `Check the configuration, data directory, notifications and daemon, and
+explain how to fix anything that isn't working.
+
+On macOS this also reports whether notifications for Nancy Notifier have
+been turned off in System Settings.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true, // Failed checks aren't usage errors
+	RunE:         runDoctor,
+}
+
+// doctorReport prints check results and counts the failures
+type doctorReport struct {
+	problems int
+	warnings int
+}
+
+func (r *doctorReport) ok(label, detail string) {
+	fmt.Printf("%s %s %s\n", utils.Symbol("✅", "[OK]"), label, detail)
+}
+
+func (r *doctorReport) warn(label, detail, hint string) {
+	r.warnings++
+	fmt.Printf("%s %s %s\n", utils.Symbol("⚠️ ", "[WARN]"), label, detail)
+	if hint != "" {
+		fmt.Printf("   %s\n", hint)
+	}
+}
+
+func (r *doctorReport) fail(label, detail, hint string) {
+	r.problems++
+	fmt.Printf("%s %s %s\n", utils.Symbol("❌", "[FAIL]"), label, detail)
+	if hint != "" {
+		fmt.Printf("   %s\n", hint)
+	}
+}
+
+// runDoctor runs every check and fails if any of them did
+func runDoctor(cmd *cobra.Command, args []string) error {
+	config := getApp().GetConfig()
+	report := &doctorReport{}
+
+	// Configuration
+	if err := config.Validate(); err != nil {
+		report.fail(i18n.T("Config:"), err.Error(), i18n.T("Fix %s/config.yaml", config.GetConfigDir()))
+	} else {
+		report.ok(i18n.T("Config:"), config.GetConfigDir())
+	}
+	if config.Include != "" {
+		if included, err := config.Included(); err != nil {
+			report.warn(i18n.T("Team config:"), err.Error(), i18n.T("Check the include setting and your connection"))
+		} else {
+			report.ok(i18n.T("Team config:"), i18n.T("%d settings from %s", included, config.Include))
+		}
+	}
+
+	// Data directory
+	store := getApp().GetStore()
+	total, _, _, _ := store.Count()
+	dataDir := config.GetDataDir()
+	if store.IsReadOnly() {
+		report.ok(i18n.T("Data:"), i18n.T("%d reminders in %s (read-only)", total, dataDir))
+	} else if file, err := os.CreateTemp(dataDir, ".doctor-*"); err != nil {
+		report.fail(i18n.T("Data:"), i18n.T("%s is not writable", dataDir), err.Error())
+	} else {
+		file.Close()
+		os.Remove(file.Name())
+		report.ok(i18n.T("Data:"), i18n.T("%d reminders in %s", total, dataDir))
+	}
+	if duplicates := store.Duplicates(); duplicates > 0 {
+		report.warn(i18n.T("Data:"), i18n.T("%d duplicate records in reminders.json", duplicates),
+			i18n.T("Remove them with 'nancy compact'"))
+	}
+
+	// Notifications
+	notifier, err := utils.NewNotifier()
+	if err != nil {
+		report.fail(i18n.T("Notifications:"), err.Error(), "")
+	} else if !config.Notifications.Enabled {
+		report.warn(i18n.T("Notifications:"), i18n.T("turned off in the config"), "")
+	} else if notifier.GetMethod() != utils.DesktopNotification {
+		report.warn(i18n.T("Notifications:"), utils.GetMethodName(notifier.GetMethod()),
+			i18n.T("No desktop notification tool found; run 'make install-notifications'"))
+	} else {
+		report.ok(i18n.T("Notifications:"), utils.GetMethodName(notifier.GetMethod()))
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		checkMacNotifier(report)
+	case "linux":
+		checkNotificationServer(report)
+	}
+
+	// Daemon
+	if running, pid, err := isDaemonRunning(); err != nil {
+		report.fail(i18n.T("Daemon:"), err.Error(), "")
+	} else if running {
+		report.ok(i18n.T("Daemon:"), i18n.T("running with PID %d", pid))
+	} else {
+		report.warn(i18n.T("Daemon:"), i18n.T("not running"), i18n.T("Start it with 'nancy daemon start' to get notifications"))
+	}
+
+	if report.problems > 0 {
+		return fmt.Errorf("found %d problem(s)", report.problems)
+	}
+	if report.warnings > 0 {
+		fmt.Println("\n" + i18n.T("No problems found, but check the warnings above."))
+	} else {
+		fmt.Println("\n" + i18n.T("Everything looks good!"))
+	}
+	return nil
+}
+
+// checkMacNotifier checks the helper app and whether macOS lets it notify
+func checkMacNotifier(report *doctorReport) {
+	label := i18n.T("Notification permission:")
+
+	path, ok := utils.MacNotifierPath()
+	if !ok {
+		report.warn(label, i18n.T("%s is not installed", utils.MacNotifierApp),
+			i18n.T("Build it with 'make macos-notifier' and copy it to ~/Applications"))
+		return
+	}
+
+	permission, err := utils.MacNotificationPermission()
+	switch {
+	case err != nil:
+		report.fail(label, err.Error(), path)
+	case permission == utils.PermissionDenied:
+		report.fail(label, i18n.T("denied"),
+			i18n.T("Allow notifications for Nagging Nancy in System Settings › Notifications"))
+	case permission == utils.PermissionNotDetermined:
+		report.warn(label, i18n.T("not asked yet"),
+			i18n.T("macOS asks the first time Nancy sends a notification; try 'nancy test notification'"))
+	default:
+		report.ok(label, i18n.T("granted"))
+	}
+}
+
+// checkNotificationServer checks that something on the D-Bus session bus
+// shows notifications
+func checkNotificationServer(report *doctorReport) {
+	label := i18n.T("Notification server:")
+
+	server, err := utils.NotificationServer()
+	switch {
+	case errors.Is(err, utils.ErrNoSessionBus):
+		report.warn(label, i18n.T("no session bus; using notify-send if installed"), "")
+	case errors.Is(err, utils.ErrNoNotificationServer):
+		report.fail(label, err.Error(),
+			i18n.T("Log in to a desktop session or start a notification daemon such as dunst or mako"))
+	case err != nil:
+		report.fail(label, err.Error(), "")
+	default:
+		report.ok(label, server)
+	}
+}
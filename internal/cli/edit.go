@@ -5,23 +5,36 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 var editCmd = &cobra.Command{
-	Use:   "edit <reminder-id>",
+	Use:   "edit <reminder-id> [changes]",
 	Short: "Edit an existing reminder",
 	Long: `Edit the title, due time, or priority of an existing reminder.
 
 You can find reminder IDs by running 'nancy list'.
 
+Instead of flags, describe the changes in plain words: a day or a clock
+time ("to friday", "at 3pm"), a shift ("push back 2 days"), a priority
+("make it high priority"), tags ("#work", "remove #home") and a new title
+("rename to Call mom", last). Flags win over the description.
+
+--reparse runs the text the reminder was added with through the parser
+again, as of when it was added, and applies its title, due time, priority
+and tags. Use it after a Nancy update parses something better, or to undo
+edits. Flags win over the reparsed text.
+
 Examples:
   nancy edit a1b2c3d4 --title "New title"
   nancy edit a1b2c3d4 --time "3pm"
   nancy edit a1b2c3d4 --priority high
-  nancy edit a1b2c3d4 --title "Call mom" --time "tomorrow 2pm" --priority high`,
-	Args: cobra.ExactArgs(1),
+  nancy edit a1b2c3d4 --title "Call mom" --time "tomorrow 2pm" --priority high
+  nancy edit 7 "push to friday 3pm and make it high priority"`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		idArg := args[0]
 
@@ -38,6 +51,57 @@ Examples:
 		priorityFlag, _ := cmd.Flags().GetString("priority")
 		addTags, _ := cmd.Flags().GetStringSlice("add-tags")
 		removeTags, _ := cmd.Flags().GetStringSlice("remove-tags")
+		shiftFlag, _ := cmd.Flags().GetString("shift")
+
+		if shiftFlag != "" && (timeFlag != "" || dateFlag != "") {
+			return fmt.Errorf("--shift cannot be combined with --time or --date")
+		}
+
+		var parsedDue *time.Time
+
+		// Re-run the parser on the text as typed at 'nancy add'
+		var reparsed *utils.ParsedReminder
+		if reparse, _ := cmd.Flags().GetBool("reparse"); reparse {
+			if len(args) > 1 {
+				return fmt.Errorf("--reparse cannot be combined with changes described in words")
+			}
+			if reminder.Input == "" {
+				return fmt.Errorf("reminder %s has no text to reparse; only reminders added from text since Nancy kept it have one", reminder.DisplayID())
+			}
+			reparsed, err = textParser(reminder.Priority).Parse(reminder.Input, reminder.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to reparse '%s': %w", reminder.Input, err)
+			}
+			if title == "" && reparsed.Title != reminder.Title {
+				title = reparsed.Title
+			}
+			if reparsed.HasTime && timeFlag == "" && dateFlag == "" && shiftFlag == "" {
+				parsedDue = &reparsed.DueTime
+			}
+			if priorityFlag == "" && reparsed.HasPriority {
+				priorityFlag = reparsed.Priority.String()
+			}
+			addTags = append(addTags, reparsed.Tags...)
+		}
+
+		// Changes described in words fill in for the flags not given
+		if len(args) > 1 {
+			parsed, err := utils.ParseEdit(strings.Join(args[1:], " "), reminder.DueTime, getApp().GetConfig().TimesOfDay)
+			if err != nil {
+				return err
+			}
+			if title == "" {
+				title = parsed.Title
+			}
+			if timeFlag == "" && dateFlag == "" && shiftFlag == "" {
+				parsedDue = parsed.DueTime
+			}
+			if priorityFlag == "" && parsed.Priority != nil {
+				priorityFlag = parsed.Priority.String()
+			}
+			addTags = append(addTags, parsed.AddTags...)
+			removeTags = append(removeTags, parsed.RemoveTags...)
+		}
 
 		// Track what changed
 		var changes []string
@@ -45,7 +109,7 @@ Examples:
 		// Update title
 		if title != "" {
 			reminder.Title = title
-			changes = append(changes, fmt.Sprintf("title → '%s'", title))
+			changes = append(changes, i18n.T("title → '%s'", title))
 		}
 
 		// Update time
@@ -59,46 +123,35 @@ Examples:
 			// If only time provided, use current date
 			newDueTime = time.Date(newDueTime.Year(), newDueTime.Month(), newDueTime.Day(),
 				parsedTime.Hour(), parsedTime.Minute(), 0, 0, newDueTime.Location())
-			changes = append(changes, fmt.Sprintf("time → %s", parsedTime.Format("3:04 PM")))
+			changes = append(changes, i18n.T("time → %s", i18n.FormatTime(parsedTime, "3:04 PM")))
 		}
 
 		// Update date
 		if dateFlag != "" {
-			var targetDate time.Time
-			var err error
-
-			// Try parsing different date formats
-			dateFormats := []string{
-				"2006-01-02",  // 2024-03-20
-				"01/02/2006",  // 03/20/2024
-				"01-02-2006",  // 03-20-2024
-				"Jan 2, 2006", // Mar 20, 2024
-				"Jan 2 2006",  // Mar 20 2024
-				"2 Jan 2006",  // 20 Mar 2024
-			}
-
-			// Handle relative dates
-			switch strings.ToLower(dateFlag) {
-			case "today":
-				targetDate = time.Now()
-			case "tomorrow":
-				targetDate = time.Now().AddDate(0, 0, 1)
-			default:
-				// Try parsing as explicit date
-				for _, format := range dateFormats {
-					if targetDate, err = time.Parse(format, dateFlag); err == nil {
-						break
-					}
-				}
-				if err != nil {
-					return fmt.Errorf("invalid date format '%s'", dateFlag)
-				}
+			targetDate, err := utils.ParseDateString(dateFlag)
+			if err != nil {
+				return err
 			}
 
 			// Combine date with existing time
 			newDueTime = time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(),
 				newDueTime.Hour(), newDueTime.Minute(), 0, 0, newDueTime.Location())
-			changes = append(changes, fmt.Sprintf("date → %s", targetDate.Format("Jan 2, 2006")))
+			changes = append(changes, i18n.T("date → %s", i18n.FormatTime(targetDate, "Jan 2, 2006")))
+		}
+
+		// Move relative to the current due time
+		if shiftFlag != "" {
+			shift, err := utils.ParseShift(shiftFlag)
+			if err != nil {
+				return err
+			}
+			shifted := shift.Apply(reminder.DueTime)
+			parsedDue = &shifted
+		}
+
+		if parsedDue != nil && !parsedDue.Equal(reminder.DueTime) {
+			newDueTime = *parsedDue
+			changes = append(changes, i18n.T("due → %s", i18n.FormatTime(newDueTime, "Mon Jan 2 3:04 PM")))
 		}
 
 		// Update due time if it changed
@@ -106,14 +159,150 @@ Examples:
 			reminder.DueTime = newDueTime
 		}
 
+		// An explicit clock time replaces "at sunset"
+		if timeFlag != "" || parsedDue != nil {
+			reminder.SunEvent = ""
+			if reparsed != nil && timeFlag == "" {
+				reminder.SunEvent = reparsed.SunEvent
+			}
+		}
+
 		// Update priority
 		if priorityFlag != "" {
 			oldPriority := reminder.Priority
 			newPriority := utils.ParsePriorityString(priorityFlag)
 			if newPriority != oldPriority {
 				reminder.Priority = newPriority
-				changes = append(changes, fmt.Sprintf("priority → %s %s", 
-					newPriority.Icon(), newPriority.String()))
+				changes = append(changes, i18n.T("priority → %s %s",
+					utils.PriorityIcon(newPriority), i18n.T(newPriority.String())))
+			}
+		}
+
+		// Update due-soon window
+		if cmd.Flags().Changed("notify-before") {
+			notifyBefore, err := notifyBeforeFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+			if notifyBefore != reminder.NotifyBefore {
+				reminder.NotifyBefore = notifyBefore
+				if notifyBefore == 0 {
+					changes = append(changes, i18n.T("notify before → default"))
+				} else {
+					changes = append(changes, i18n.T("notify before → %d min", notifyBefore))
+				}
+			}
+		}
+
+		// Update nag cadence
+		if cmd.Flags().Changed("nag") {
+			nag, err := nagFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+			if nag != reminder.Nag {
+				reminder.Nag = nag
+				if nag == 0 {
+					changes = append(changes, i18n.T("nag → hourly"))
+				} else {
+					changes = append(changes, i18n.T("nag → every %s", utils.FormatDuration(reminder.NagInterval())))
+				}
+			}
+		}
+
+		// Update overdue grace
+		if cmd.Flags().Changed("grace") {
+			grace, err := graceFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+			switch {
+			case grace == nil && reminder.Grace != nil:
+				reminder.Grace = nil
+				changes = append(changes, i18n.T("grace → default"))
+			case grace != nil && (reminder.Grace == nil || *grace != *reminder.Grace):
+				reminder.Grace = grace
+				if *grace == 0 {
+					changes = append(changes, i18n.T("grace → none"))
+				} else {
+					changes = append(changes, i18n.T("grace → %s", utils.FormatDuration(reminder.OverdueGrace())))
+				}
+			}
+		}
+
+		// Update completion command
+		if cmd.Flags().Changed("done-when") {
+			doneWhen, err := doneWhenFromFlag(cmd)
+			if err != nil {
+				return err
+			}
+			if doneWhen != reminder.DoneWhen {
+				reminder.DoneWhen = doneWhen
+				if doneWhen == "" {
+					changes = append(changes, i18n.T("done when → off"))
+				} else {
+					changes = append(changes, i18n.T("done when → %s", doneWhen))
+				}
+			}
+		}
+
+		// Update critical flag
+		if cmd.Flags().Changed("critical") {
+			critical, _ := cmd.Flags().GetBool("critical")
+			if critical != reminder.Critical {
+				reminder.Critical = critical
+				if critical {
+					changes = append(changes, i18n.T("critical → on"))
+				} else {
+					changes = append(changes, i18n.T("critical → off"))
+				}
+			}
+		}
+
+		// Update sticky flag
+		if cmd.Flags().Changed("sticky") {
+			sticky, _ := cmd.Flags().GetBool("sticky")
+			if sticky != reminder.Sticky {
+				reminder.Sticky = sticky
+				if sticky {
+					changes = append(changes, i18n.T("sticky → on"))
+				} else {
+					changes = append(changes, i18n.T("sticky → off"))
+				}
+			}
+		}
+
+		// Update label color
+		if cmd.Flags().Changed("color") {
+			colorFlag, _ := cmd.Flags().GetString("color")
+			color, err := models.ParseColor(colorFlag)
+			if err != nil {
+				return err
+			}
+			if color != reminder.Color {
+				reminder.Color = color
+				if color == "" {
+					changes = append(changes, i18n.T("color → none"))
+				} else {
+					changes = append(changes, i18n.T("color → %s", i18n.T(color)))
+				}
+			}
+		}
+
+		// Update check-in frequency
+		if cmd.Flags().Changed("check-in") {
+			checkInFlag, _ := cmd.Flags().GetString("check-in")
+			checkIn, err := models.ParseCheckIn(checkInFlag)
+			if err != nil {
+				return err
+			}
+			if checkIn != reminder.CheckIn {
+				reminder.CheckIn = checkIn
+				if checkIn == "" {
+					changes = append(changes, i18n.T("check-in → off"))
+				} else {
+					changes = append(changes, i18n.T("check-in → %s", i18n.T(checkIn)))
+				}
 			}
 		}
 
@@ -122,7 +311,7 @@ Examples:
 			tag = strings.TrimSpace(tag)
 			if tag != "" && !reminder.HasTag(tag) {
 				reminder.AddTag(tag)
-				changes = append(changes, fmt.Sprintf("added tag '%s'", tag))
+				changes = append(changes, i18n.T("added tag '%s'", tag))
 			}
 		}
 
@@ -131,18 +320,23 @@ Examples:
 			tag = strings.TrimSpace(tag)
 			if tag != "" && reminder.HasTag(tag) {
 				reminder.RemoveTag(tag)
-				changes = append(changes, fmt.Sprintf("removed tag '%s'", tag))
+				changes = append(changes, i18n.T("removed tag '%s'", tag))
 			}
 		}
 
 		// Validate changes
+		if len(changes) == 0 && reparsed != nil {
+			fmt.Println(i18n.T("Reparsing '%s' gives the same reminder.", reminder.Input))
+			return nil
+		}
 		if len(changes) == 0 {
-			fmt.Println("No changes specified. Use --title, --time, --date, --priority, --add-tags, or --remove-tags")
+			fmt.Println(i18n.T("No changes specified. Use --title, --time, --date, --shift, --priority, --notify-before, --nag, --grace, --done-when, --critical, --sticky, --color, --check-in, --add-tags, or --remove-tags"))
 			return nil
 		}
 
 		// Validate the updated reminder
-		if err := utils.ValidateReminderInput(reminder.Title, reminder.DueTime); err != nil {
+		pastOK, _ := cmd.Flags().GetBool("past-ok")
+		if err := utils.ValidateReminderInput(reminder.Title, reminder.DueTime, pastOK); err != nil {
 			return err
 		}
 
@@ -152,17 +346,17 @@ Examples:
 		}
 
 		// Show confirmation
-		fmt.Printf("✅ Updated reminder: %s\n", reminder.Title)
-		fmt.Printf("   Due: %s\n", reminder.FormattedDueTime())
-		fmt.Printf("   Priority: %s %s\n", reminder.Priority.Icon(), reminder.Priority.String())
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Updated reminder: %s", reminder.Title))
+		fmt.Printf("   %s %s\n", i18n.T("Due:"), reminder.FormattedDueTime())
+		fmt.Printf("   %s %s %s\n", i18n.T("Priority:"), utils.PriorityIcon(reminder.Priority), i18n.T(reminder.Priority.String()))
 
 		if len(reminder.Tags) > 0 {
-			fmt.Printf("   Tags: %s\n", strings.Join(reminder.Tags, ", "))
+			fmt.Printf("   %s %s\n", i18n.T("Tags:"), strings.Join(reminder.Tags, ", "))
 		}
 
-		fmt.Printf("   ID: %s\n\n", reminder.ID[:8])
+		fmt.Printf("   %s %s\n\n", i18n.T("ID:"), reminder.DisplayID())
 
-		fmt.Println("Changes made:")
+		fmt.Println(i18n.T("Changes made:"))
 		for _, change := range changes {
 			fmt.Printf("  • %s\n", change)
 		}
@@ -176,8 +370,19 @@ func init() {
 	editCmd.Flags().StringP("time", "t", "", "New due time (e.g., 2pm, 14:30, '3:30 PM')")
 	editCmd.Flags().StringP("date", "d", "", "New due date (e.g., tomorrow, 2024-03-20, 'Mar 20')")
 	editCmd.Flags().StringP("priority", "p", "", "New priority level (low, medium, high)")
+	editCmd.Flags().String("notify-before", "", "How long before the due time it counts as due soon (e.g. 30m, 2h; empty for default)")
+	editCmd.Flags().String("nag", "", "Once overdue, notify again at this cadence (e.g. 'every 30m'; empty for hourly)")
+	editCmd.Flags().String("grace", "", "How long it may be overdue before the first nag (e.g. 10m, 0 for none; empty for default)")
+	editCmd.Flags().String("done-when", "", "Shell command that completes the reminder once it exits 0 (empty to remove)")
+	editCmd.Flags().Bool("critical", false, "Always notify, even in quiet hours and meetings (--critical=false to undo)")
+	editCmd.Flags().Bool("sticky", false, "Keep the notification on screen until acted on (--sticky=false to undo)")
+	editCmd.Flags().String("color", "", "Label color: red, orange, yellow, green, blue, purple, pink, gray, or none")
+	editCmd.Flags().String("check-in", "", "Ask now and then whether you're still working on it: daily, weekly, biweekly, monthly, or off")
 	editCmd.Flags().StringSliceP("add-tags", "", []string{}, "Tags to add (e.g., work,urgent)")
 	editCmd.Flags().StringSliceP("remove-tags", "", []string{}, "Tags to remove")
+	editCmd.Flags().Bool("past-ok", false, "Allow a due time in the past")
+	editCmd.Flags().String("shift", "", "Move the due time relative to its current value (e.g. +2d, -3h, +1w)")
+	editCmd.Flags().Bool("reparse", false, "Parse the text the reminder was added with again")
 
 	editCmd.Example = `  # Edit title
   nancy edit a1b2c3d4 --title "New reminder title"
@@ -191,6 +396,31 @@ func init() {
   # Add and remove tags
   nancy edit a1b2c3d4 --add-tags "work,urgent" --remove-tags "personal"
 
+  # Always notify, even in quiet hours
+  nancy edit a1b2c3d4 --critical
+
+  # A day later, same time
+  nancy edit a1b2c3d4 --shift +1d
+
+  # Label it blue
+  nancy edit a1b2c3d4 --color blue
+
+  # Ask every week whether it's still in progress
+  nancy edit a1b2c3d4 --check-in weekly
+
+  # Nag every 15 minutes once overdue
+  nancy edit a1b2c3d4 --nag "every 15m"
+
+  # Start nagging as soon as it's overdue, whatever notifications.overdue_grace says
+  nancy edit a1b2c3d4 --grace 0
+
+  # Apply what a newer parser makes of the original text
+  nancy edit a1b2c3d4 --reparse
+
   # Multiple changes at once
-  nancy edit a1b2c3d4 --title "Call mom" --time "2pm" --priority high`
+  nancy edit a1b2c3d4 --title "Call mom" --time "2pm" --priority high
+
+  # Describe the changes instead
+  nancy edit a1b2c3d4 "push to friday 3pm and make it high priority"
+  nancy edit a1b2c3d4 "push back 2 days, remove #home"`
 }
@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export reminders as JSON, CSV, Markdown, iCalendar or HTML",
+	Long: `Export all reminders, or print the JSON Schema describing the JSON format.
+
+JSON is what 'nancy import' reads back, and the schema lets other tools
+produce data it will accept. CSV suits spreadsheets, Markdown a task list in
+your notes, and iCalendar (ics) a to-do list in calendar apps. Without
+--format, the extension of --output picks the format, falling back to JSON.
+
+HTML is a read-only page of your active reminders grouped by day, for a
+tablet or a wall display. With --serve it is served on --addr instead and
+rebuilt on every visit; browsers reload it every minute.
+
+The server also takes location webhooks from phone automation apps (Tasker,
+Shortcuts) on POST /location with a place and an event (enter or exit).
+Arriving somewhere makes the daemon nag about the reminders tagged with the
+place, e.g. @home or @store. Set --location-token to require a token.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schema, _ := cmd.Flags().GetBool("schema")
+		output, _ := cmd.Flags().GetString("output")
+		format, _ := cmd.Flags().GetString("format")
+
+		if format == "" {
+			format = utils.ExportFormatFromPath(output)
+		}
+		if format == "" {
+			format = "json"
+		}
+
+		if serve, _ := cmd.Flags().GetBool("serve"); serve {
+			if format != "html" {
+				return fmt.Errorf("--serve only works with --format html")
+			}
+			addr, _ := cmd.Flags().GetString("addr")
+			token, _ := cmd.Flags().GetString("location-token")
+			return serveMirror(getApp(), addr, token)
+		}
+
+		var data []byte
+		if schema {
+			data = models.ReminderSchema
+		} else if format == "json" {
+			exported, err := getApp().GetStore().Export()
+			if err != nil {
+				return fmt.Errorf("failed to export reminders: %w", err)
+			}
+			data = append(exported, '\n')
+		} else if format == "html" {
+			exported, err := buildMirror(getApp(), 0)
+			if err != nil {
+				return err
+			}
+			data = exported
+		} else {
+			reminders := getApp().GetStore().GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})
+			exported, err := utils.ExportReminders(reminders, format)
+			if err != nil {
+				return err
+			}
+			data = exported
+		}
+
+		if output == "" || output == "-" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+
+		if err := os.WriteFile(output, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+
+		fmt.Fprintln(os.Stderr, utils.Symbol("✅ ", "")+i18n.T("Exported to %s", output))
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import reminders from JSON",
+	Long: `Import reminders from a JSON file produced by 'nancy export' (or any tool
+following 'nancy export --schema'). Reminders whose IDs already exist are skipped.
+
+Exports also list deleted reminders (their ID and when they were deleted), so
+importing an older export doesn't bring them back: a reminder deleted here
+stays deleted unless the file's copy changed after that, and one the file
+says was deleted is deleted here unless it changed here since.
+
+The data is validated before anything is imported; problems are reported
+with their line numbers. Use '-' to read from stdin.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read import data: %w", err)
+		}
+
+		store := getApp().GetStore()
+
+		strict, _ := cmd.Flags().GetBool("strict")
+		force, _ := cmd.Flags().GetBool("force")
+		if !force && (strict || getApp().GetConfig().Default.CheckDuplicate) {
+			if duplicates := importDuplicates(store, data); duplicates > 0 && strict {
+				return fmt.Errorf("%d reminders look like duplicates; nothing imported (use --force to import anyway)", duplicates)
+			}
+		}
+
+		report, err := store.Import(data)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Imported %d reminders", report.Added))
+		if report.Deleted > 0 {
+			fmt.Println("   " + i18n.T("Deleted %d reminders that were deleted there", report.Deleted))
+		}
+		if report.Skipped > 0 {
+			fmt.Println("   " + i18n.T("Left out %d reminders deleted here since", report.Skipped))
+		}
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().Bool("schema", false, "Print the JSON Schema for the export format")
+	exportCmd.Flags().StringP("output", "o", "", "Write to a file instead of stdout")
+	exportCmd.Flags().StringP("format", "f", "", "Export format: json, csv, md, ics or html (default: from the --output extension, else json)")
+	exportCmd.Flags().Bool("serve", false, "Serve the HTML page instead of writing it")
+	exportCmd.Flags().String("addr", "localhost:8080", "Address to serve the HTML page on")
+	exportCmd.Flags().String("location-token", "", "Token location webhooks must send")
+
+	exportCmd.Example = `  # Back up all reminders
+  nancy export -o reminders.json
+
+  # Open in a spreadsheet
+  nancy export -o reminders.csv
+
+  # Import your reminders as to-dos into a calendar app
+  nancy export --format ics > reminders.ics
+
+  # A page for the tablet on the fridge
+  nancy export -o ~/Public/reminders.html
+  nancy export --format html --serve --addr :8080
+
+  # Also take location webhooks from your phone
+  nancy export --format html --serve --addr :8080 --location-token s3cret
+
+  # Publish the schema for other tools
+  nancy export --schema > reminders.schema.json`
+
+	importCmd.Flags().Bool("strict", false, "Import nothing if a reminder is like an active one due the same day")
+	importCmd.Flags().Bool("force", false, "Import without checking for duplicates")
+	importCmd.AddCommand(importReviewsCmd)
+
+	importCmd.Example = `  # Restore a backup
+  nancy import reminders.json
+
+  # Import from another tool
+  other-tool --json | nancy import -`
+}
+
+// importDuplicates reports the reminders in import data that look like active
+// reminders due the same day and returns how many there are. Data that
+// doesn't parse is left for Import to report.
+func importDuplicates(store *models.Store, data []byte) int {
+	var reminders []*models.Reminder
+	if err := json.Unmarshal(data, &reminders); err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, reminder := range reminders {
+		// Tombstones of deleted reminders have only an ID and deleted_at
+		if reminder == nil || reminder.Completed || reminder.Title == "" {
+			continue
+		}
+		// Reminders already in the store are skipped by the import anyway
+		if _, err := store.Get(reminder.ID); err == nil {
+			continue
+		}
+		if duplicates := store.FindDuplicates(reminder.Title, reminder.DueTime, reminder.ID); len(duplicates) > 0 {
+			count++
+			fmt.Fprintln(os.Stderr, utils.Symbol("⚠️  ", i18n.T("Warning: "))+i18n.T("%s looks like a duplicate of #%s %s (%s)",
+				reminder.Title, duplicates[0].DisplayID(), duplicates[0].Title, duplicates[0].FormattedDueTime()))
+		}
+	}
+	return count
+}
+
+// mirrorRefresh is how often browsers reload the served HTML page, in seconds
+const mirrorRefresh = 60
+
+// buildMirror renders the current user's active reminders as an HTML page
+func buildMirror(a *app.App, refresh int) ([]byte, error) {
+	reminders := a.GetStore().GetAll(&models.FilterOptions{Assignee: a.GetConfig().CurrentUser()})
+	return utils.ExportHTML(reminders, time.Now(), refresh)
+}
+
+// serveMirror serves the HTML page on addr, watching the store so the page
+// follows changes made elsewhere
+func serveMirror(a *app.App, addr, locationToken string) error {
+	go a.GetStore().Watch(context.Background(), time.Second)
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		page, err := buildMirror(a, mirrorRefresh)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+	handler.HandleFunc("/location", locationHandler(a, locationToken))
+
+	fmt.Fprintln(os.Stderr, i18n.T("Serving reminders on http://%s (Ctrl+C to stop)", addr))
+	return http.ListenAndServe(addr, handler)
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var focusCmd = &cobra.Command{
+	Use:   "focus",
+	Short: "Only get notified about some tags for a while",
+	Long: `Start a focus session: until it ends, the daemon only sends notifications
+for reminders with one of the given tags (or a tag nested below one). The
+rest are held back and summarized in one notification when the session
+ends. Without flags, shows the running session.
+
+Examples:
+  nancy focus --tags deepwork --for 2h    # Two hours of deep work
+  nancy focus -t writing,review --for 45m
+  nancy focus                             # What am I focusing on?
+  nancy focus --off                       # End the session early`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := focusPath(getApp())
+		now := time.Now()
+
+		if off, _ := cmd.Flags().GetBool("off"); off {
+			if err := utils.EndFocus(path); err != nil {
+				return err
+			}
+			fmt.Println(utils.Symbol("🔔 ", "") + i18n.T("Focus ended, all notifications are back on"))
+			return nil
+		}
+
+		tags, _ := cmd.Flags().GetStringSlice("tags")
+		if len(tags) == 0 {
+			focus, err := utils.LoadFocus(path, now)
+			if err != nil {
+				return err
+			}
+			if focus == nil {
+				fmt.Println(i18n.T("Not focusing. Start with: nancy focus --tags deepwork --for 2h"))
+				return nil
+			}
+			fmt.Println(utils.Symbol("🎯 ", "") + focus.Banner(now))
+			return nil
+		}
+
+		duration, _ := cmd.Flags().GetDuration("for")
+		if duration <= 0 {
+			return fmt.Errorf("--for must be a positive duration, e.g. 2h or 45m")
+		}
+		for i, tag := range tags {
+			tags[i] = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		}
+
+		focus := &utils.Focus{Tags: tags, Until: now.Add(duration)}
+		if err := utils.SaveFocus(path, focus); err != nil {
+			return err
+		}
+		fmt.Println(utils.Symbol("🎯 ", "") + focus.Banner(now))
+		return nil
+	},
+}
+
+func init() {
+	focusCmd.Flags().StringSliceP("tags", "t", []string{}, "Tags that may still notify")
+	focusCmd.Flags().Duration("for", time.Hour, "How long to focus, e.g. 2h or 45m")
+	focusCmd.Flags().Bool("off", false, "End the running focus session")
+}
+
+// focusPath returns the focus session file in the data directory
+func focusPath(a *app.App) string {
+	return filepath.Join(a.GetConfig().GetDataDir(), utils.FocusFile)
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var heatmapCmd = &cobra.Command{
+	Use:   "heatmap",
+	Short: "Show a calendar heatmap of your reminder activity",
+	Long: `Show a calendar of the last weeks, one column per week, shaded by how
+many reminders you completed each day. A second calendar compares reminders
+created and completed, showing when your load grew and when it shrank.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		weeks, _ := cmd.Flags().GetInt("weeks")
+		if weeks < 1 || weeks > 53 {
+			return fmt.Errorf("--weeks must be between 1 and 53")
+		}
+
+		activity := getApp().GetStore().Activity()
+		now := time.Now()
+
+		// Totals over the days shown
+		created, completed := 0, 0
+		end := now.Format("2006-01-02")
+		first := utils.HeatmapStart(weeks, now).Format("2006-01-02")
+		for day, counts := range activity {
+			if day >= first && day <= end {
+				created += counts.Created
+				completed += counts.Completed
+			}
+		}
+
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Completed: %d in the last %d weeks", completed, weeks))
+		fmt.Println()
+		fmt.Print(utils.CompletionHeatmap(activity, weeks, now))
+		fmt.Println()
+		fmt.Println(utils.Symbol("📈 ", "") + i18n.T("Load: %d created, %d completed", created, completed))
+		fmt.Println()
+		fmt.Print(utils.LoadHeatmap(activity, weeks, now))
+		return nil
+	},
+}
+
+func init() {
+	heatmapCmd.Flags().IntP("weeks", "w", 12, "Number of weeks to show (1-53)")
+}
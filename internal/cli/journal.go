@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Show what you got done on a day",
+	Long: `Print the reminders completed on a day, grouped by tag, as a "done list"
+ready to paste into standup notes.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dateFlag, _ := cmd.Flags().GetString("date")
+		format, _ := cmd.Flags().GetString("format")
+
+		day, err := parseJournalDate(dateFlag)
+		if err != nil {
+			return err
+		}
+
+		journal, err := utils.BuildJournal(getApp().GetStore().GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true}), day, format)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(journal)
+		return nil
+	},
+}
+
+func init() {
+	journalCmd.Flags().StringP("date", "d", "today", "Day to report (today, yesterday, 2024-03-20)")
+	journalCmd.Flags().StringP("format", "f", "md", "Output format (md, text)")
+
+	journalCmd.Example = `  # Yesterday's done list for standup
+  nancy journal --date yesterday
+
+  # Plain text for a specific day
+  nancy journal --date 2024-03-20 --format text`
+}
+
+// parseJournalDate parses the --date flag of the journal command
+func parseJournalDate(value string) (time.Time, error) {
+	now := time.Now()
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "today":
+		return now, nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1), nil
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return now, fmt.Errorf("invalid date '%s' (use today, yesterday or YYYY-MM-DD)", value)
+	}
+	return day, nil
+}
@@ -2,9 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
 	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 	"github.com/spf13/cobra"
@@ -21,7 +23,7 @@ Examples:
   nancy list --priority high   # High priority only
   nancy list --completed       # Completed reminders
   nancy list --all             # All reminders including completed`,
-	Aliases: []string{"ls", "show"},
+	Aliases: []string{"ls"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
 		showToday, _ := cmd.Flags().GetBool("today")
@@ -32,12 +34,30 @@ Examples:
 		priorityFlag, _ := cmd.Flags().GetString("priority")
 		tagsFlag, _ := cmd.Flags().GetStringSlice("tags")
 		limit, _ := cmd.Flags().GetInt("limit")
+		page, _ := cmd.Flags().GetInt("page")
+		forUser, _ := cmd.Flags().GetString("for")
+		everyone, _ := cmd.Flags().GetBool("everyone")
+		flat, _ := cmd.Flags().GetBool("flat")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		format, _ := cmd.Flags().GetString("format")
+		color := useColor(cmd)
+
+		if sortBy != "due" && sortBy != "overdue-age" {
+			return fmt.Errorf("invalid sort '%s' (use due or overdue-age)", sortBy)
+		}
+		if format != "text" && format != "alfred" {
+			return fmt.Errorf("invalid format '%s' (use text or alfred)", format)
+		}
+		if page < 1 || (page > 1 && limit <= 0) {
+			return fmt.Errorf("--page must be 1 or more, with --limit setting the page size")
+		}
 
 		// Build filter options
 		filter := &models.FilterOptions{
 			ShowCompleted: showCompleted || showAll,
 			DueToday:      showToday,
 			Overdue:       showOverdue,
+			Offset:        (page - 1) * limit,
 			Limit:         limit,
 		}
 
@@ -52,6 +72,14 @@ Examples:
 			filter.Tags = tagsFlag
 		}
 
+		// Only show the current user's assignments unless asked otherwise
+		if !everyone {
+			filter.Assignee = forUser
+			if filter.Assignee == "" {
+				filter.Assignee = getApp().GetConfig().CurrentUser()
+			}
+		}
+
 		// Get reminders from store
 		store := getApp().GetStore()
 		reminders := store.GetAll(filter)
@@ -67,39 +95,64 @@ Examples:
 			reminders = weekReminders
 		}
 
+		if sortBy == "overdue-age" {
+			models.SortByOverdueAge(reminders)
+			flat = true
+		}
+
+		// Script filter JSON for Alfred and Raycast
+		if format == "alfred" {
+			return writeAlfredItems(os.Stdout, reminders)
+		}
+
 		// Display results
 		if len(reminders) == 0 {
 			if showCompleted {
-				fmt.Println("📝 No completed reminders found.")
+				fmt.Println(utils.Symbol("📝 ", "") + i18n.T("No completed reminders found."))
 			} else if showToday {
-				fmt.Println("📅 No reminders due today.")
+				fmt.Println(utils.Symbol("📅 ", "") + i18n.T("No reminders due today."))
 			} else if showOverdue {
-				fmt.Println("⏰ No overdue reminders.")
+				fmt.Println(utils.Symbol("⏰ ", "") + i18n.T("No overdue reminders."))
 			} else {
-				fmt.Println("🎉 All caught up! No active reminders.")
+				fmt.Println(utils.Symbol("🎉 ", "") + i18n.T("All caught up! No active reminders."))
 			}
-			fmt.Println("\nAdd a new reminder with: nancy add \"Your reminder\"")
+			fmt.Println("\n" + i18n.T("Add a new reminder with: nancy add \"Your reminder\""))
 			return nil
 		}
 
 		// Display header
 		if showCompleted {
-			fmt.Println("📝 Completed Reminders")
+			fmt.Println(utils.Symbol("📝 ", "") + i18n.T("Completed Reminders"))
 		} else if showToday {
-			fmt.Println("📅 Today's Reminders")
+			fmt.Println(utils.Symbol("📅 ", "") + i18n.T("Today's Reminders"))
 		} else if showOverdue {
-			fmt.Println("⚠️  Overdue Reminders")
+			fmt.Println(utils.Symbol("⚠️  ", "") + i18n.T("Overdue Reminders"))
 		} else if showWeek {
-			fmt.Println("📆 This Week's Reminders")
+			fmt.Println(utils.Symbol("📆 ", "") + i18n.T("This Week's Reminders"))
 		} else {
-			fmt.Println("📋 Reminders")
+			fmt.Println(utils.Symbol("📋 ", "") + i18n.T("Reminders"))
 		}
 
 		fmt.Println(strings.Repeat("─", 50))
 
-		// Display reminders
-		for i, reminder := range reminders {
-			displayReminder(reminder, i+1)
+		// Display reminders; sorting by overdue age implies a flat list
+		if flat {
+			for i, reminder := range reminders {
+				displayReminder(reminder, filter.Offset+i+1, color)
+			}
+		} else {
+			index := filter.Offset + 1
+			for _, group := range models.GroupByDue(reminders, time.Now()) {
+				name := i18n.T(group.Name)
+				if group.Name == models.GroupOverdue {
+					name = utils.Symbol(name, i18n.T("Overdue"))
+				}
+				fmt.Printf("%s (%d)\n", name, len(group.Reminders))
+				for _, reminder := range group.Reminders {
+					displayReminder(reminder, index, color)
+					index++
+				}
+			}
 		}
 
 		// Display summary
@@ -108,14 +161,15 @@ Examples:
 		// Get counts
 		total, active, completed, overdue := store.Count()
 
+		fmt.Print(utils.Symbol("📊 ", ""))
 		if showAll {
-			fmt.Printf("📊 Total: %d | Active: %d | Completed: %d | Overdue: %d\n",
-				total, active, completed, overdue)
+			fmt.Println(i18n.T("Total: %d | Active: %d | Completed: %d | Overdue: %d",
+				total, active, completed, overdue))
 		} else if showCompleted {
-			fmt.Printf("📊 Showing %d completed reminders\n", len(reminders))
+			fmt.Println(i18n.T("Showing %d completed reminders", len(reminders)))
 		} else {
-			fmt.Printf("📊 Showing %d reminders | Active: %d | Overdue: %d\n",
-				len(reminders), active, overdue)
+			fmt.Println(i18n.T("Showing %d reminders | Active: %d | Overdue: %d",
+				len(reminders), active, overdue))
 		}
 
 		return nil
@@ -131,11 +185,20 @@ func init() {
 	listCmd.Flags().StringP("priority", "p", "", "Filter by priority (low, medium, high)")
 	listCmd.Flags().StringSliceP("tags", "t", []string{}, "Filter by tags")
 	listCmd.Flags().IntP("limit", "l", 0, "Limit number of results (0 = no limit)")
+	listCmd.Flags().Int("page", 1, "Show this page of results, --limit per page")
+	listCmd.Flags().String("for", "", "Show reminders assigned to this user (default: you)")
+	listCmd.Flags().Bool("flat", false, "Show a single list instead of grouping by due day")
+	listCmd.Flags().String("format", "text", "Output format: text, or alfred (script filter JSON for Alfred/Raycast)")
+	listCmd.Flags().String("sort", "due", "Sort order: due, or overdue-age (most neglected first)")
+	listCmd.Flags().Bool("everyone", false, "Show reminders for all users of a shared store")
 
 	// Add examples
-	listCmd.Example = `  # List active reminders
+	listCmd.Example = `  # List active reminders grouped by due day
   nancy list
 
+  # Without the Overdue/Today/Tomorrow/... headers
+  nancy list --flat
+
   # Today's reminders only
   nancy list --today
 
@@ -145,23 +208,38 @@ func init() {
   # Overdue reminders
   nancy list --overdue
 
+  # Most neglected reminders first
+  nancy list --sort overdue-age
+
+  # Alfred/Raycast script filter
+  nancy list --format alfred
+
   # Completed reminders
   nancy list --completed
 
+  # Completed reminders 51-100
+  nancy list --completed --limit 50 --page 2
+
   # All reminders with tags
-  nancy list --tags work,urgent --all`
+  nancy list --tags work,urgent --all
+
+  # Everyone's reminders in a shared store
+  nancy list --everyone`
+}
+
+// useColor reports whether CLI output may be colored
+func useColor(cmd *cobra.Command) bool {
+	noColor, _ := cmd.Flags().GetBool("no-color")
+	return !noColor && !utils.AccessibleMode()
 }
 
 // displayReminder formats and displays a single reminder
-func displayReminder(reminder *models.Reminder, index int) {
+func displayReminder(reminder *models.Reminder, index int, color bool) {
 	// Status icon
-	status := "●"
-	if reminder.Completed {
-		status = "✓"
-	}
+	status := utils.CompletionIcon(reminder.Completed)
 
 	// Priority icon and color would go here in a real TUI
-	priorityIcon := reminder.Priority.Icon()
+	priorityIcon := utils.PriorityIcon(reminder.Priority)
 
 	// Time information
 	timeStr := reminder.FormattedDueTime()
@@ -169,42 +247,55 @@ func displayReminder(reminder *models.Reminder, index int) {
 	// Status information
 	statusInfo := ""
 	if reminder.IsOverdue() {
-		statusInfo = " ⚠️ OVERDUE"
+		age := reminder.OverdueAge()
+		statusInfo = " " + utils.OverdueText(age)
+		if color {
+			statusInfo = " " + utils.OverdueStyle(age).Render(utils.OverdueText(age))
+		}
 	} else if reminder.IsDueSoon() {
-		statusInfo = " ⏰ DUE SOON"
+		statusInfo = " " + utils.Symbol("⏰ "+i18n.T("DUE SOON"), "["+i18n.T("DUE SOON")+"]")
+	}
+
+	// Label color swatch, spelled out without colors
+	label := ""
+	if labelColor := reminder.LabelColor(); labelColor != "" {
+		if color {
+			label = utils.ColorSwatch(labelColor) + " "
+		} else {
+			label = "[" + i18n.T(labelColor) + "] "
+		}
 	}
 
 	// Build the line
-	fmt.Printf("%2d. %s %s %s%s\n", index, status, priorityIcon, reminder.Title, statusInfo)
+	fmt.Printf("%2d. %s %s %s%s%s\n", index, status, priorityIcon, label, reminder.Title, statusInfo)
 
 	// Show due time and additional info
-	fmt.Printf("    📅 %s", timeStr)
+	fmt.Printf("    %s %s", utils.Symbol("📅", i18n.T("Due:")), timeStr)
 
 	if len(reminder.Tags) > 0 {
-		fmt.Printf(" | 🏷️  %s", strings.Join(reminder.Tags, ", "))
+		fmt.Printf(" | %s %s", utils.Symbol("🏷️ ", i18n.T("Tags:")), strings.Join(reminder.Tags, ", "))
+	}
+
+	if reminder.Assignee != "" {
+		fmt.Printf(" | %s %s", utils.Symbol("👤", i18n.T("For:")), reminder.Assignee)
 	}
 
 	// Show time until due for active reminders
 	if !reminder.Completed {
 		timeUntil := reminder.TimeUntilDue()
 		if timeUntil > 0 {
-			fmt.Printf(" | ⏳ %s", utils.FormatDuration(timeUntil))
+			fmt.Printf(" | %s %s", utils.Symbol("⏳", i18n.T("In:")), utils.FormatDuration(timeUntil))
 		}
 	}
 
-	fmt.Printf(" | 🆔 %s\n", reminder.ID[:8])
+	fmt.Printf(" | %s %s\n", utils.Symbol("🆔", "ID:"), reminder.DisplayID())
 	fmt.Println()
 }
 
 // isThisWeek checks if a time falls within the current week
 func isThisWeek(t time.Time) bool {
-	now := time.Now()
-
-	// Find the start of this week (Sunday)
-	weekStart := now.AddDate(0, 0, -int(now.Weekday()))
-	weekStart = time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, weekStart.Location())
-
-	// Find the end of this week (Saturday)
+	// The week starts on the configured first day
+	weekStart := i18n.WeekStart(time.Now())
 	weekEnd := weekStart.AddDate(0, 0, 7)
 
 	return t.After(weekStart) && t.Before(weekEnd)
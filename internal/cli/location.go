@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// locationRequest is what phone automation apps post to /location, either as
+// JSON or as form fields
+type locationRequest struct {
+	Place string `json:"place"`
+	Event string `json:"event"` // "enter" (the default) or "exit"
+	Token string `json:"token"`
+}
+
+// locationHandler records arrivals posted by Tasker, Shortcuts and the like.
+// The daemon then nags about the reminders tagged with the place, e.g. @home.
+// With a token set, requests must carry it as a token field or a bearer
+// token.
+func locationHandler(a *app.App, token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "use POST", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req locationRequest
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+				http.Error(w, "invalid JSON: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		} else {
+			req = locationRequest{Place: r.FormValue("place"), Event: r.FormValue("event"), Token: r.FormValue("token")}
+		}
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			req.Token = bearer
+		}
+
+		if token != "" && subtle.ConstantTimeCompare([]byte(req.Token), []byte(token)) != 1 {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		if utils.PlaceTag(req.Place) == "@" {
+			http.Error(w, "no place given", http.StatusBadRequest)
+			return
+		}
+
+		path := arrivalPath(a)
+		switch strings.ToLower(req.Event) {
+		case "", "enter", "arrive":
+			if err := utils.SaveArrival(path, req.Place, time.Now()); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		case "exit", "leave":
+			if err := utils.ClearArrival(path, req.Place); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		default:
+			http.Error(w, "event must be enter or exit", http.StatusBadRequest)
+			return
+		}
+
+		tag := utils.PlaceTag(req.Place)
+		reminders := a.GetStore().GetAll(&models.FilterOptions{Tags: []string{tag}})
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"place": strings.TrimPrefix(tag, "@"), "reminders": len(reminders)})
+	}
+}
+
+// arrivalPath returns the arrival file, in the state directory or else next
+// to the config, out of the data directory that may be synced or shared
+func arrivalPath(a *app.App) string {
+	return filepath.Join(utils.StateDir(a.GetConfig().GetConfigDir()), utils.ArrivalFile)
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// menuBackends maps each supported picker to its command line
+var menuBackends = map[string]func(prompt string) []string{
+	"rofi":  func(prompt string) []string { return []string{"rofi", "-dmenu", "-i", "-p", prompt} },
+	"dmenu": func(prompt string) []string { return []string{"dmenu", "-i", "-p", prompt} },
+	"fzf":   func(prompt string) []string { return []string{"fzf", "--prompt", prompt + "> "} },
+}
+
+// menuActions are offered after a reminder has been picked
+var menuActions = []string{"complete", "snooze", "checkin", "open"}
+
+var menuCmd = &cobra.Command{
+	Use:   "menu",
+	Short: "Pick a reminder and an action with rofi, dmenu or fzf",
+	Long: `Pipe active reminders into a picker and run an action on the selected one.
+
+Bind it to a hotkey for keyboard-driven desktop workflows without the TUI.
+Actions: complete, snooze (push the due time back), checkin (still working
+on it) and open (launch the first link in the reminder).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backend, _ := cmd.Flags().GetString("backend")
+		action, _ := cmd.Flags().GetString("action")
+		snooze, _ := cmd.Flags().GetDuration("snooze")
+
+		if _, ok := menuBackends[backend]; !ok {
+			return fmt.Errorf("unknown backend '%s' (use rofi, dmenu or fzf)", backend)
+		}
+		if action != "" && !containsAction(action) {
+			return fmt.Errorf("unknown action '%s' (use %s)", action, strings.Join(menuActions, ", "))
+		}
+		if snooze <= 0 {
+			return fmt.Errorf("--snooze must be positive")
+		}
+
+		reminders := getApp().GetReminders(&models.FilterOptions{
+			Assignee: getApp().GetConfig().CurrentUser(),
+		})
+		if len(reminders) == 0 {
+			fmt.Println(i18n.T("No active reminders."))
+			return nil
+		}
+
+		lines := make([]string, len(reminders))
+		for i, reminder := range reminders {
+			lines[i] = menuLine(reminder)
+		}
+
+		choice, err := runPicker(backend, "reminder", lines)
+		if err != nil || choice == "" {
+			return err
+		}
+		reminder, err := findReminderByID(strings.Fields(choice)[0])
+		if err != nil {
+			return err
+		}
+
+		if action == "" {
+			if action, err = runPicker(backend, "action", menuActions); err != nil || action == "" {
+				return err
+			}
+		}
+
+		return runMenuAction(reminder, action, snooze)
+	},
+}
+
+func init() {
+	menuCmd.Flags().String("backend", "fzf", "Picker to use (rofi, dmenu, fzf)")
+	menuCmd.Flags().String("action", "", "Action to run without asking (complete, snooze, checkin, open)")
+	menuCmd.Flags().Duration("snooze", time.Hour, "How long the snooze action postpones a reminder")
+
+	menuCmd.Example = `  # Pick in the terminal
+  nancy menu
+
+  # From a window manager hotkey
+  nancy menu --backend rofi
+
+  # Complete straight away
+  nancy menu --backend dmenu --action complete`
+}
+
+// menuLine renders a reminder as a single picker line starting with its ID
+func menuLine(reminder *models.Reminder) string {
+	line := fmt.Sprintf("#%s  %s  (%s)", reminder.DisplayID(), reminder.Title, reminder.FormattedDueTime())
+	if reminder.IsOverdue() {
+		line += "  [OVERDUE]"
+	}
+	return line
+}
+
+// runPicker feeds lines to the backend and returns the selected line.
+// A cancelled picker returns an empty choice and no error.
+func runPicker(backend, prompt string, lines []string) (string, error) {
+	argv := menuBackends[backend](prompt)
+	if _, err := exec.LookPath(argv[0]); err != nil {
+		return "", fmt.Errorf("%s is not installed", argv[0])
+	}
+
+	var out bytes.Buffer
+	picker := exec.Command(argv[0], argv[1:]...)
+	picker.Stdin = strings.NewReader(strings.Join(lines, "\n") + "\n")
+	picker.Stdout = &out
+	picker.Stderr = os.Stderr
+
+	if err := picker.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to run %s: %w", argv[0], err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// runMenuAction applies the chosen action to a reminder
+func runMenuAction(reminder *models.Reminder, action string, snooze time.Duration) error {
+	store := getApp().GetStore()
+
+	switch action {
+	case "complete":
+		if err := store.CompleteReminder(reminder.ID); err != nil {
+			return fmt.Errorf("failed to complete reminder: %w", err)
+		}
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Completed: %s", reminder.Title))
+
+	case "snooze":
+		reminder.Snooze(snooze)
+		if err := store.Update(reminder); err != nil {
+			return fmt.Errorf("failed to snooze reminder: %w", err)
+		}
+		fmt.Println(utils.Symbol("💤 ", "") + i18n.T("Snoozed: %s until %s", reminder.Title, reminder.FormattedDueTime()))
+
+	case "checkin":
+		return checkIn(reminder)
+
+	case "open":
+		return openReminderLink(reminder)
+
+	default:
+		return fmt.Errorf("unknown action '%s'", action)
+	}
+	return nil
+}
+
+// containsAction reports whether action is one of menuActions
+func containsAction(action string) bool {
+	for _, a := range menuActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open <reminder-id>",
+	Short: "Open a reminder's link in the browser",
+	Long: `Open the link saved with 'nancy add --url', or the first link in the
+reminder's title or description.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminder, err := findReminderByID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := openReminderLink(reminder); err != nil {
+			return err
+		}
+		fmt.Println(utils.Symbol("🔗 ", "") + i18n.T("Opened: %s", reminder.Link()))
+		return nil
+	},
+}
+
+// openReminderLink launches the reminder's link with the system handler
+func openReminderLink(reminder *models.Reminder) error {
+	link := reminder.Link()
+	if link == "" {
+		return fmt.Errorf("'%s' has no link to open", reminder.Title)
+	}
+	return utils.OpenURL(link)
+}
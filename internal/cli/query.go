@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var countCmd = &cobra.Command{
+	Use:   "count",
+	Short: "Print the number of matching reminders",
+	Long: `Print just the number of reminders matching the filters, for use in
+shell scripts and prompts.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminders := getApp().GetStore().GetAll(queryFilter(cmd))
+		fmt.Println(len(reminders))
+		return nil
+	},
+}
+
+var existsCmd = &cobra.Command{
+	Use:   "exists <query>",
+	Short: "Exit 0 if a matching reminder exists, 1 otherwise",
+	Long: `Check whether an active reminder matches the query without printing anything.
+
+The query matches a short ID like 7 or #7, a reminder ID prefix or,
+case-insensitively, part of its title. A number is a short ID, never the
+start of an ID. The exit status is 0 when there is a
+match and 1 when there is none.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := strings.ToLower(strings.Join(args, " "))
+		shortID, err := strconv.Atoi(strings.TrimPrefix(query, "#"))
+		if err != nil {
+			shortID = 0
+		}
+
+		for _, reminder := range getApp().GetStore().GetAll(queryFilter(cmd)) {
+			if (shortID > 0 && reminder.ShortID == shortID) ||
+				(shortID == 0 && strings.HasPrefix(reminder.ID, query)) ||
+				strings.Contains(strings.ToLower(reminder.Title), query) {
+				return nil
+			}
+		}
+
+		// No match is the answer, not an error to print
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		return ErrSilent
+	},
+}
+
+// ErrSilent makes nancy exit with status 1 without printing anything, for
+// commands like exists whose answer is the exit status
+var ErrSilent = errors.New("exit status 1")
+
+func init() {
+	for _, cmd := range []*cobra.Command{countCmd, existsCmd} {
+		cmd.Flags().Bool("today", false, "Only reminders due today")
+		cmd.Flags().Bool("overdue", false, "Only overdue reminders")
+		cmd.Flags().Bool("all", false, "Include completed reminders")
+		cmd.Flags().StringP("priority", "p", "", "Only reminders with this priority (low, medium, high)")
+		cmd.Flags().StringSliceP("tags", "t", []string{}, "Only reminders with any of these tags")
+		cmd.Flags().Bool("everyone", false, "Include reminders assigned to other users")
+	}
+
+	countCmd.Example = `  # Number of overdue reminders for a shell prompt
+  nancy count --overdue
+
+  # Work reminders due today
+  nancy count --today --tags work`
+
+	existsCmd.Example = `  # Branch in a script
+  if nancy exists "dentist"; then echo "Already booked"; fi
+
+  # Any overdue high priority reminder?
+  nancy exists --overdue --priority high ""`
+}
+
+// queryFilter builds filter options from the shared count/exists flags
+func queryFilter(cmd *cobra.Command) *models.FilterOptions {
+	today, _ := cmd.Flags().GetBool("today")
+	overdue, _ := cmd.Flags().GetBool("overdue")
+	all, _ := cmd.Flags().GetBool("all")
+	priorityFlag, _ := cmd.Flags().GetString("priority")
+	tags, _ := cmd.Flags().GetStringSlice("tags")
+	everyone, _ := cmd.Flags().GetBool("everyone")
+
+	filter := &models.FilterOptions{
+		ShowCompleted: all,
+		DueToday:      today,
+		Overdue:       overdue,
+		Tags:          tags,
+	}
+
+	if priorityFlag != "" {
+		priority := utils.ParsePriorityString(priorityFlag)
+		filter.Priority = &priority
+	}
+
+	if !everyone {
+		filter.Assignee = getApp().GetConfig().CurrentUser()
+	}
+
+	return filter
+}
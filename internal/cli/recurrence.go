@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var skipCmd = &cobra.Command{
+	Use:   "skip <reminder-id>",
+	Short: "Skip to the next occurrence of a recurring reminder",
+	Long: `Move a recurring reminder to its next occurrence without marking the
+current one as completed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminder, err := findReminderByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		store := getApp().GetStore()
+		if err := store.SkipReminder(reminder.ID); err != nil {
+			return fmt.Errorf("cannot skip '%s': %w", reminder.Title, err)
+		}
+
+		updated, err := store.Get(reminder.ID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(utils.Symbol("⏭ ", "") + i18n.T("Skipped: %s", updated.Title))
+		fmt.Printf("   %s %s\n", i18n.T("Next:"), updated.FormattedDueTime())
+		if updated.Recurring.Final {
+			fmt.Println("   " + i18n.T("This is the last occurrence."))
+		}
+		return nil
+	},
+}
+
+var recurrenceCmd = &cobra.Command{
+	Use:   "recurrence",
+	Short: "Pause, resume and add exceptions to recurring reminders",
+	Long: `Put a recurring reminder on hold without deleting it, or leave out
+specific dates.
+
+Paused reminders don't send notifications and don't roll forward. Resuming
+an overdue reminder moves it to its next upcoming occurrence.`,
+}
+
+var recurrencePauseCmd = &cobra.Command{
+	Use:   "pause <reminder-id>",
+	Short: "Pause a recurring reminder",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setRecurrencePaused(args[0], true)
+	},
+}
+
+var recurrenceResumeCmd = &cobra.Command{
+	Use:   "resume <reminder-id>",
+	Short: "Resume a paused recurring reminder",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setRecurrencePaused(args[0], false)
+	},
+}
+
+var recurrenceExceptCmd = &cobra.Command{
+	Use:   "except <reminder-id> <date>...",
+	Short: "Leave specific dates out of a recurring reminder",
+	Long: `Add exception dates (YYYY-MM-DD) on which a recurring reminder doesn't occur,
+such as public holidays. Use --remove to take dates off the list again.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remove, _ := cmd.Flags().GetBool("remove")
+
+		reminder, err := findReminderByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		dates := args[1:]
+		store := getApp().GetStore()
+		if remove {
+			err = store.SetExclusions(reminder.ID, nil, dates)
+		} else {
+			err = store.SetExclusions(reminder.ID, dates, nil)
+		}
+		if err != nil {
+			return fmt.Errorf("cannot update '%s': %w", reminder.Title, err)
+		}
+
+		updated, err := store.Get(reminder.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Updated: %s", updated.Title))
+		fmt.Printf("   %s %s\n", i18n.T("Repeats:"), updated.Recurring)
+		fmt.Printf("   %s %s\n", i18n.T("Next:"), updated.FormattedDueTime())
+		return nil
+	},
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history <reminder-id>",
+	Short: "Show past occurrences of a recurring reminder",
+	Long: `Show when each past occurrence of a recurring reminder was completed or
+skipped, and how often it was done on time.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminder, err := findReminderByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		if len(reminder.History) == 0 {
+			fmt.Println(i18n.T("No past occurrences recorded for '%s'.", reminder.Title))
+			return nil
+		}
+
+		fmt.Println(i18n.T("History for %s:", reminder.Title) + "\n")
+		for _, occurrence := range reminder.History {
+			fmt.Printf("  %s  %-8s  %s\n",
+				i18n.FormatTime(occurrence.DueTime, "Mon Jan 2 15:04"),
+				utils.OccurrenceLabel(occurrence.Status),
+				i18n.FormatTime(occurrence.At, "Jan 2 15:04"))
+		}
+
+		onTime, late, skipped, missed := reminder.Adherence()
+		total := onTime + late + skipped + missed
+		fmt.Println("\n" + i18n.T("On time: %d  Late: %d  Skipped: %d  Missed: %d  (%d%% on time)",
+			onTime, late, skipped, missed, onTime*100/total))
+		return nil
+	},
+}
+
+func init() {
+	recurrenceCmd.AddCommand(recurrencePauseCmd)
+	recurrenceCmd.AddCommand(recurrenceResumeCmd)
+	recurrenceCmd.AddCommand(recurrenceExceptCmd)
+
+	recurrenceExceptCmd.Flags().Bool("remove", false, "Remove the dates from the exception list")
+
+	recurrenceCmd.Example = `  # Stop the daily standup reminder while on holiday
+  nancy recurrence pause 4
+
+  # Pick it up again when back
+  nancy recurrence resume 4
+
+  # No standup on public holidays
+  nancy recurrence except 4 2025-07-04 2025-12-25`
+}
+
+// setRecurrencePaused pauses or resumes a recurring reminder and reports the result
+func setRecurrencePaused(id string, paused bool) error {
+	reminder, err := findReminderByID(id)
+	if err != nil {
+		return err
+	}
+
+	store := getApp().GetStore()
+	if err := store.PauseReminder(reminder.ID, paused); err != nil {
+		return fmt.Errorf("cannot update '%s': %w", reminder.Title, err)
+	}
+
+	if paused {
+		fmt.Println(utils.Symbol("⏸ ", "") + i18n.T("Paused: %s", reminder.Title))
+		return nil
+	}
+
+	updated, err := store.Get(reminder.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Println(utils.Symbol("▶ ", "") + i18n.T("Resumed: %s", updated.Title))
+	fmt.Printf("   %s %s\n", i18n.T("Next:"), updated.FormattedDueTime())
+	return nil
+}
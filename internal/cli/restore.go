@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [--from-backup [N]]",
+	Short: "List the backups of the reminders file, or go back to one",
+	Long: `Saving the reminders keeps copies of reminders.json as it was, at most one
+an hour: reminders.json.1 is the newest, up to the number set by 'backups' in
+the config (default 5).
+
+Without flags, list the backups. With --from-backup, replace the reminders with
+backup N (default 1, the newest). The reminders as they were become backup 1,
+so a restore can be undone the same way.`,
+	Example: `  # Which backups are there?
+  nancy restore
+
+  # Go back to the newest one
+  nancy restore --from-backup
+
+  # Go back further
+  nancy restore --from-backup 3`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{localOnly: "true", noDemo: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := getApp().GetStore()
+		fromBackup, _ := cmd.Flags().GetBool("from-backup")
+
+		if !fromBackup {
+			if len(args) > 0 {
+				return fmt.Errorf("use --from-backup %s to restore a backup", args[0])
+			}
+			backups := store.Backups()
+			if len(backups) == 0 {
+				fmt.Println(i18n.T("No backups yet; one is kept each hour the reminders are saved."))
+				return nil
+			}
+			fmt.Println(i18n.T("Backups of reminders.json, newest first:"))
+			for _, backup := range backups {
+				count := i18n.T("unreadable")
+				if backup.Reminders >= 0 {
+					count = i18n.T("%d reminders", backup.Reminders)
+				}
+				fmt.Printf("  %2d  %s  %s\n", backup.Number, i18n.FormatTime(backup.ModTime, "Mon Jan 2 3:04 PM"), count)
+			}
+			fmt.Println(utils.Symbol("💡 ", "") + i18n.T("Restore one with 'nancy restore --from-backup N'"))
+			return nil
+		}
+
+		n := 1
+		if len(args) > 0 {
+			var err error
+			if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+				return fmt.Errorf("invalid backup number: %s", args[0])
+			}
+		}
+		if err := store.RestoreBackup(n); err != nil {
+			return err
+		}
+
+		total, _, _, _ := store.Count()
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Restored backup %d: %d reminders", n, total))
+		fmt.Println("   " + i18n.T("The reminders as they were are now backup 1."))
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.Flags().Bool("from-backup", false, "Replace the reminders with backup N (default 1, the newest)")
+}
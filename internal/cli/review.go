@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// reviewDays is how far ahead 'nancy review' looks without --today
+const reviewDays = 7
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Go through what's coming up and decide what happens to each",
+	Long: `Go through overdue reminders and the ones due this week one by one, and
+mark each done, move it to tomorrow, reschedule it or keep it as is.
+
+With --today only overdue reminders and today's are reviewed. The daemon's
+morning "plan your day" notification (daemon.plan_time) and evening wrap-up
+(daemon.wrap_up_time) point here.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		today, _ := cmd.Flags().GetBool("today")
+
+		store := getApp().GetStore()
+		reminders := store.GetAll(&models.FilterOptions{Assignee: getApp().GetConfig().CurrentUser()})
+		now := time.Now()
+
+		overdue, dueToday := utils.PlanDay(reminders, now)
+		plan := append(overdue, dueToday...)
+		heading := i18n.T("Plan for %s", i18n.FormatTime(now, "Monday, Jan 2"))
+		if !today {
+			heading = i18n.T("Review of the next %d days", reviewDays)
+			until := time.Date(now.Year(), now.Month(), now.Day()+reviewDays, 0, 0, 0, 0, now.Location())
+			for _, reminder := range reminders {
+				if reminder.DueTime.Before(until) && !reminder.IsDueToday() && !reminder.IsOverdue() &&
+					!reminder.Completed && !reminder.Archived && (reminder.Recurring == nil || !reminder.Recurring.Paused) {
+					plan = append(plan, reminder)
+				}
+			}
+		}
+
+		fmt.Println(utils.Symbol("🗓️  ", "") + heading)
+		fmt.Println(strings.Repeat("─", 50))
+		if len(plan) == 0 {
+			fmt.Println(utils.Symbol("✨ ", "") + i18n.T("Nothing to review. Enjoy the free time!"))
+			return nil
+		}
+
+		return reviewPlan(store, plan, useColor(cmd))
+	},
+}
+
+func init() {
+	reviewCmd.Flags().Bool("today", false, "Only review overdue reminders and today's")
+
+	reviewCmd.Example = `  # Plan the day
+  nancy review --today
+
+  # Look over the coming week
+  nancy review`
+}
+
+// reviewPlan walks through reminders and applies a quick action to each
+func reviewPlan(store *models.Store, reminders []*models.Reminder, color bool) error {
+	reader := bufio.NewReader(os.Stdin)
+	var done, moved, kept int
+
+	defer func() {
+		fmt.Println(utils.Symbol("📊 ", "") + i18n.T("%d done, %d moved, %d kept", done, moved, kept))
+	}()
+
+	for i, reminder := range reminders {
+		displayReminder(reminder, i+1, color)
+
+		for answered := false; !answered; {
+			fmt.Print("   " + i18n.T("[d]one, [t]omorrow, [r]eschedule, [k]eep, [q]uit: "))
+			response, err := reader.ReadString('\n')
+			if err != nil && response == "" {
+				return nil
+			}
+
+			switch strings.ToLower(strings.TrimSpace(response)) {
+			case "d", "done":
+				if err := store.CompleteReminder(reminder.ID); err != nil {
+					return fmt.Errorf("failed to complete reminder: %w", err)
+				}
+				fmt.Println("   " + utils.Symbol("✅ ", "") + i18n.T("Done"))
+				done++
+				answered = true
+			case "t", "tomorrow":
+				// Same time of day, tomorrow
+				now := time.Now()
+				due := reminder.DueTime
+				reminder.DueTime = time.Date(now.Year(), now.Month(), now.Day()+1,
+					due.Hour(), due.Minute(), due.Second(), 0, due.Location())
+				if err := store.Update(reminder); err != nil {
+					return fmt.Errorf("failed to reschedule reminder: %w", err)
+				}
+				fmt.Println("   " + utils.Symbol("📅 ", "") + i18n.T("Rescheduled to %s", reminder.FormattedDueTime()))
+				moved++
+				answered = true
+			case "r", "reschedule":
+				fmt.Print("   " + i18n.T("New due time (e.g. 'tomorrow at 3pm', 'in 2 hours'): "))
+				answer, _ := reader.ReadString('\n')
+				dueTime, err := utils.ParseDueTime(answer, getApp().GetConfig().TimesOfDay)
+				if err != nil {
+					fmt.Printf("   %s%v\n", utils.Symbol("❌ ", "[ERROR] "), err)
+					continue
+				}
+				reminder.DueTime = dueTime
+				if err := store.Update(reminder); err != nil {
+					return fmt.Errorf("failed to reschedule reminder: %w", err)
+				}
+				fmt.Println("   " + utils.Symbol("📅 ", "") + i18n.T("Rescheduled to %s", reminder.FormattedDueTime()))
+				moved++
+				answered = true
+			case "k", "keep", "":
+				kept++
+				answered = true
+			case "q", "quit":
+				return nil
+			}
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
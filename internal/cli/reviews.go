@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// reviewTag marks reminders created from GitHub review requests
+const reviewTag = "review"
+
+var importReviewsCmd = &cobra.Command{
+	Use:   "github-reviews",
+	Short: "Create reminders for pull requests awaiting your review",
+	Long: `Create a reminder for every open pull request where your review is
+requested, using GITHUB_TOKEN (or integrations.github_token).
+
+Pull requests that already have a reminder are skipped. Reminders whose
+review has been submitted (the request is gone) are completed. Set
+integrations.review_sync_minutes to let the daemon do this on an interval.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		added, completed, err := syncGitHubReviews()
+		if err != nil {
+			return err
+		}
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Added %d review reminders, completed %d", added, completed))
+		return nil
+	},
+}
+
+// syncGitHubReviews adds reminders for new review requests and completes
+// the ones whose review is no longer requested
+func syncGitHubReviews() (added, completed int, err error) {
+	prs, err := utils.FetchReviewRequests(getApp().GetConfig().GitHubToken())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	store := getApp().GetStore()
+	existing := store.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})
+	byURL := make(map[string]bool, len(existing))
+	for _, reminder := range existing {
+		if reminder.URL != "" {
+			byURL[reminder.URL] = true
+		}
+	}
+
+	pending := make(map[string]bool, len(prs))
+	var reviews []*models.Reminder
+	for _, pr := range prs {
+		pending[pr.URL] = true
+		if byURL[pr.URL] {
+			continue
+		}
+
+		reminder := models.NewReminder("Review: "+pr.Title, time.Now().Add(time.Hour), models.Medium)
+		reminder.AddTag(reviewTag)
+		reminder.URL = pr.URL
+		reminder.Issue = pr.Ref.String()
+		reviews = append(reviews, reminder)
+	}
+	if err := store.AddAll(reviews); err != nil {
+		return 0, 0, fmt.Errorf("failed to add review reminders: %w", err)
+	}
+	added = len(reviews)
+
+	for _, reminder := range existing {
+		if reminder.Completed || !reminder.HasTag(reviewTag) || pending[reminder.URL] ||
+			!strings.Contains(reminder.URL, "/pull/") {
+			continue
+		}
+		if err := store.CompleteReminder(reminder.ID); err != nil {
+			return added, completed, fmt.Errorf("failed to complete review reminder: %w", err)
+		}
+		completed++
+	}
+
+	return added, completed, nil
+}
@@ -1,20 +1,29 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
 	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 	"github.com/ivyascorp-net/nagging-nancy/internal/tui"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 )
 
 var (
 	appInstance *app.App
+	profiler    *utils.Profiler // Set by --profile
+	commandRun  time.Time       // When the command itself started, for --profile
 	rootCmd     = &cobra.Command{
 		Use:   "nancy",
 		Short: "Nagging Nancy - Your friendly terminal reminders app",
@@ -23,6 +32,88 @@ manage reminders and tasks without leaving your command line.
 
 Built with Go and Bubble Tea for a smooth, responsive experience.`,
 		Version: app.GetVersion(),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			setupStart := time.Now()
+
+			// With --demo, sample reminders in memory instead of the user's
+			if err := startDemo(cmd); err != nil {
+				return err
+			}
+
+			// Open shared data directories without writing to them, not even
+			// to migrate an old file while loading it
+			readOnly, _ := cmd.Flags().GetBool("read-only")
+			if readOnly && appInstance == nil {
+				a, err := loadApp(app.NewReadOnly)
+				if err != nil {
+					return err
+				}
+				appInstance = a
+			}
+
+			// With --remote, the reminders are those of a 'nancy serve' instance
+			if err := connectRemote(cmd); err != nil {
+				return err
+			}
+
+			if readOnly || getApp().GetConfig().Shared.ReadOnly {
+				getApp().GetStore().SetReadOnly(true)
+			}
+
+			// How far in the past or future due times may be
+			defaults := getApp().GetConfig().Default
+			utils.SetDueLimits(time.Duration(defaults.PastGrace)*time.Minute, defaults.MaxYearsAhead)
+
+			// Coordinates for "at sunrise" and "at sunset"
+			location := getApp().GetConfig().Location
+			utils.SetLocation(location.Latitude, location.Longitude)
+
+			// Due-soon highlighting and notifications share one window
+			window, byName := getApp().GetConfig().DueSoonWindows()
+			byPriority := make(map[models.Priority]time.Duration, len(byName))
+			for name, d := range byName {
+				byPriority[models.ParsePriority(name)] = d
+			}
+			models.SetDueSoonWindows(window, byPriority)
+
+			// Overdue reminders without their own --grace nag after this long
+			models.SetOverdueGrace(time.Duration(getApp().GetConfig().Notifications.OverdueGrace) * time.Minute)
+
+			// High priority reminders may notify like --critical ones
+			models.SetHighPriorityCritical(getApp().GetConfig().Notifications.HighIsCritical)
+
+			// Label colors for tagged reminders without their own
+			models.SetTagColors(getApp().GetConfig().Appearance.TagColors)
+
+			// Messages in the configured language, or the one from LANG
+			i18n.SetLanguage(getApp().GetConfig().Appearance.Language)
+			if getApp().IsDemo() {
+				fmt.Fprintln(os.Stderr, utils.Symbol("🎭 ", "")+i18n.T("Demo mode: sample reminders, nothing is saved"))
+			}
+
+			// Date order, 12/24-hour clock and first day of the week
+			appearance := getApp().GetConfig().Appearance
+			if err := i18n.SetFormats(appearance.DateFormat, appearance.TimeFormat, appearance.FirstDay); err != nil {
+				return err
+			}
+
+			// Text labels instead of emoji for screen readers
+			accessible, _ := cmd.Flags().GetBool("accessible")
+			if accessible || getApp().GetConfig().Appearance.Accessible {
+				utils.SetAccessibleMode(true)
+			}
+
+			// Opt-in, local only: which commands are run when
+			recordUsage(getApp(), cmd)
+
+			// CPU and heap profiles plus timings for diagnosing slow commands
+			if profile, _ := cmd.Flags().GetBool("profile"); profile {
+				if err := startProfile(cmd, setupStart); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Default action - launch TUI
 			return runTUI()
@@ -31,13 +122,6 @@ Built with Go and Bubble Tea for a smooth, responsive experience.`,
 )
 
 func init() {
-	// Initialize the app instance
-	var err error
-	appInstance, err = app.New()
-	if err != nil {
-		log.Fatalf("Failed to initialize app: %v", err)
-	}
-
 	// Add subcommands
 	rootCmd.AddCommand(addCmd)
 	rootCmd.AddCommand(listCmd)
@@ -46,6 +130,45 @@ func init() {
 	rootCmd.AddCommand(editCmd)
 	rootCmd.AddCommand(daemonCmd)
 	rootCmd.AddCommand(testCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(countCmd)
+	rootCmd.AddCommand(existsCmd)
+	rootCmd.AddCommand(staleCmd)
+	rootCmd.AddCommand(reviewCmd)
+	rootCmd.AddCommand(journalCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(tagsCmd)
+	rootCmd.AddCommand(skipCmd)
+	rootCmd.AddCommand(recurrenceCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(menuCmd)
+	rootCmd.AddCommand(openCmd)
+	rootCmd.AddCommand(shareCmd)
+	rootCmd.AddCommand(toastActionCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(checkInCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(heatmapCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(focusCmd)
+	rootCmd.AddCommand(rulesCmd)
+	rootCmd.AddCommand(selfUpdateCmd)
+	rootCmd.AddCommand(compactCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.AddCommand(bulkCmd)
+
+	// Complete existing (and nested) tags in tag flags
+	registerTagCompletion(addCmd, "tags")
+	registerTagCompletion(listCmd, "tags")
+	registerTagCompletion(editCmd, "add-tags", "remove-tags")
+	registerTagCompletion(countCmd, "tags")
+	registerTagCompletion(existsCmd, "tags")
+	registerTagCompletion(bulkCmd, "tags")
 	// rootCmd.AddCommand(tuiCmd)
 	// rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(versionCmd)
@@ -53,17 +176,100 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().Bool("debug", false, "Enable debug mode")
 	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().Bool("accessible", false, "Use text labels instead of emoji (screen-reader friendly)")
+	rootCmd.PersistentFlags().Bool("read-only", false, "Open the data directory read-only (for shared stores)")
+	rootCmd.PersistentFlags().Bool("profile", false, "Write CPU/heap profiles and timings to the data directory")
+	rootCmd.PersistentFlags().String("remote", "", "Use the reminders of a 'nancy serve' instance (e.g. https://host:8080) instead of the local store")
+	rootCmd.PersistentFlags().Bool("demo", false, "Try Nancy on sample reminders kept in memory; your own data and files are left alone")
 }
 
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	previous := appInstance
+	err := rootCmd.Execute()
+	if profiler != nil {
+		stopProfile()
+	}
+	// The sample reminders of --demo last for one command
+	if appInstance != nil && appInstance.IsDemo() {
+		appInstance.Close()
+		appInstance = previous
+	}
+	return err
+}
+
+// Run runs nancy with args in-process, each flag back at its default first
+// since cobra keeps flag values between runs. Tests drive commands with it.
+func Run(args ...string) error {
+	resetFlags(rootCmd)
+	rootCmd.SetArgs(args)
+	defer rootCmd.SetArgs(nil)
+	return Execute()
+}
+
+// resetFlags sets the flags of cmd and its subcommands back to their defaults
+func resetFlags(cmd *cobra.Command) {
+	reset := func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
 }
 
+// startProfile starts profiling the command and records the phases so far:
+// loading the config and the store, and the setup before the command runs
+func startProfile(cmd *cobra.Command, setupStart time.Time) error {
+	label := strings.TrimPrefix(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()), " ")
+	if label == "" {
+		label = "tui"
+	}
+
+	p, err := utils.StartProfile(filepath.Join(getApp().GetConfig().GetDataDir(), utils.ProfileDir), label, time.Now())
+	if err != nil {
+		return err
+	}
+	configLoad, storeLoad := getApp().LoadTimes()
+	total, _, _, _ := getApp().GetStore().Count()
+	p.Phase("config load", configLoad)
+	p.Phase(fmt.Sprintf("store load (%d reminders)", total), storeLoad)
+	p.Phase("setup", time.Since(setupStart))
+
+	profiler, commandRun = p, time.Now()
+	return nil
+}
+
+// stopProfile records how long the command ran and writes the profiles
+func stopProfile() {
+	profiler.Phase("run", time.Since(commandRun))
+	path, err := profiler.Stop()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Profiling failed: %v\n", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, i18n.T("Profile written to %s", strings.TrimSuffix(path, "-timings.txt")+"-*"))
+	fmt.Fprint(os.Stderr, profiler.Timings())
+}
+
+// tuiWatchInterval is how often the TUI looks for changes to the reminders
+// file made by other processes
+const tuiWatchInterval = time.Second
+
 // runTUI launches the terminal user interface
 func runTUI() error {
-	// Create TUI model
-	model := tui.NewModel(appInstance.GetStore(), appInstance.GetConfig())
+	// Create TUI model, following changes other processes make to the store
+	store := getApp().GetStore()
+	model := tui.NewModel(store, getApp().GetConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.Watch(ctx, tuiWatchInterval)
 
 	// Create Bubble Tea program
 	p := tea.NewProgram(
@@ -89,11 +295,53 @@ var versionCmd = &cobra.Command{
 	},
 }
 
-// getApp returns the global app instance
+// getApp returns the global app instance, loading the config and the store
+// on first use
 func getApp() *app.App {
+	if appInstance == nil {
+		var err error
+		appInstance, err = loadApp(app.New)
+		if err != nil {
+			log.Fatalf("Failed to initialize app: %v", err)
+		}
+	}
 	return appInstance
 }
 
+// loadApp creates the directories and the default config on first run, then
+// the app with newApp. A demo never gets here, so it leaves the filesystem
+// alone.
+func loadApp(newApp func() (*app.App, error)) (*app.App, error) {
+	if err := app.InitApp(); err != nil {
+		return nil, fmt.Errorf("failed to initialize application: %w", err)
+	}
+	a, err := newApp()
+	if err != nil {
+		return nil, err
+	}
+	printConfigMigration(a.GetConfig())
+	return a, nil
+}
+
+// printConfigMigration tells the user what loading changed in a config file
+// from an older version
+func printConfigMigration(config *app.Config) {
+	backup, changes := config.Migrated()
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, utils.Symbol("⚙️  ", "")+i18n.T("Updated your config for this version of Nancy (the original is in %s):", backup))
+	for _, change := range changes {
+		fmt.Fprintln(os.Stderr, "   • "+change)
+	}
+}
+
+// SetApp makes the commands work on a instead of the app loaded from the
+// user's config, e.g. one with temporary directories in tests
+func SetApp(a *app.App) {
+	appInstance = a
+}
+
 // checkError is a helper function for error handling in commands
 func checkError(err error) {
 	if err != nil {
@@ -104,14 +352,10 @@ func checkError(err error) {
 
 // formatReminder formats a reminder for display in CLI
 func formatReminder(reminder *models.Reminder, index int) string {
-	status := "●"
-	if reminder.Completed {
-		status = "✓"
-	}
-
-	priorityIcon := reminder.Priority.Icon()
+	status := utils.CompletionIcon(reminder.Completed)
+	priorityIcon := utils.PriorityIcon(reminder.Priority)
 	timeStr := reminder.FormattedDueTime()
 
-	return fmt.Sprintf("%d. %s %s %s - %s",
-		index+1, status, priorityIcon, reminder.Title, timeStr)
+	return fmt.Sprintf("%d. %s %s #%s %s - %s",
+		index+1, status, priorityIcon, reminder.DisplayID(), reminder.Title, timeStr)
 }
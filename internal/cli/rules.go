@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Show the housekeeping rules that archive and delete old reminders",
+	Long: `Show the housekeeping rules from the 'rules' list in the config. The
+daemon applies them once a day.
+
+A rule is one or more conditions joined with "and", then "->" and an action:
+
+  completed > 7d -> archive
+  tag=errand and overdue > 30d -> delete
+  priority=low and untouched > 8w -> archive
+
+Conditions: completed, overdue and untouched (not updated), each with an
+optional "> AGE" in hours (h), days (d) or weeks (w); tag=NAME, which also
+matches nested tags; and priority=low|medium|high. Actions: archive, delete.
+The first rule that matches a reminder decides.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := configuredRules()
+		if err != nil || len(rules) == 0 {
+			return err
+		}
+		for i, rule := range rules {
+			fmt.Printf("%d. %s\n", i+1, rule.Text)
+		}
+		return nil
+	},
+}
+
+var rulesTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Show which reminders the rules would archive or delete",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := configuredRules()
+		if err != nil || len(rules) == 0 {
+			return err
+		}
+
+		reminders := getApp().GetStore().GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})
+		matches := models.MatchRules(reminders, rules, time.Now())
+		if len(matches) == 0 {
+			fmt.Println(i18n.T("No reminders match the rules."))
+			return nil
+		}
+
+		printRuleMatches(matches)
+		fmt.Println()
+		fmt.Println(i18n.T("Nothing was changed. Run 'nancy rules apply' to apply the rules now."))
+		return nil
+	},
+}
+
+var rulesApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply the rules now instead of waiting for the daemon",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := configuredRules()
+		if err != nil || len(rules) == 0 {
+			return err
+		}
+
+		matches, err := getApp().GetStore().ApplyRules(rules, time.Now())
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			fmt.Println(i18n.T("No reminders match the rules."))
+			return nil
+		}
+		printRuleMatches(matches)
+		return nil
+	},
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesTestCmd)
+	rulesCmd.AddCommand(rulesApplyCmd)
+
+	rulesCmd.Example = `  # What would the rules clean up?
+  nancy rules test
+
+  # Clean up now
+  nancy rules apply`
+}
+
+// configuredRules parses the rules from the config, telling the user how to
+// add some if there are none
+func configuredRules() ([]*models.Rule, error) {
+	texts := getApp().GetConfig().Rules
+	if len(texts) == 0 {
+		fmt.Println(i18n.T("No rules yet. Add some to 'rules' in the config, e.g. \"completed > 7d -> archive\""))
+		return nil, nil
+	}
+	return models.ParseRules(texts)
+}
+
+// printRuleMatches lists the reminders grouped under the action taken
+func printRuleMatches(matches []models.RuleMatch) {
+	for _, action := range []string{models.RuleArchive, models.RuleDelete} {
+		var matched []models.RuleMatch
+		for _, match := range matches {
+			if match.Rule.Action == action {
+				matched = append(matched, match)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		if action == models.RuleArchive {
+			fmt.Println(utils.Symbol("🗄 ", "") + i18n.T("Archive (%d):", len(matched)))
+		} else {
+			fmt.Println(utils.Symbol("🗑 ", "") + i18n.T("Delete (%d):", len(matched)))
+		}
+		for _, match := range matched {
+			fmt.Printf("   #%s %s  (%s)\n", match.Reminder.DisplayID(), match.Reminder.Title, match.Rule.Text)
+		}
+	}
+}
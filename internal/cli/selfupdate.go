@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update Nancy to the latest release",
+	Long: `Download the latest release from GitHub and replace this binary with it.
+
+The download is checked against the SHA-256 checksums published with the
+release before anything is replaced. That catches damaged downloads; the
+checksums aren't signed, so it relies on GitHub's HTTPS for where the
+release came from. Installs managed by Homebrew are left to 'brew upgrade'.
+With --check, only report whether a new version exists; set
+daemon.check_updates to have the daemon check once a day and notify you.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		check, _ := cmd.Flags().GetBool("check")
+		force, _ := cmd.Flags().GetBool("force")
+
+		release, err := utils.LatestRelease()
+		if err != nil {
+			return err
+		}
+
+		newer, err := utils.NewerVersion(app.Version, release.Version)
+		if err != nil && !force {
+			return fmt.Errorf("cannot compare this build (%s) with %s; use --force to install the release anyway", app.Version, release.Version)
+		}
+		if !newer && !force {
+			fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Nancy %s is up to date.", app.Version))
+			return nil
+		}
+
+		if check {
+			fmt.Println(utils.Symbol("🆕 ", "") + i18n.T("Nancy %s is available (you have %s).", release.Version, app.Version))
+			fmt.Println("   " + release.URL)
+			return nil
+		}
+
+		exe, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("cannot find the nancy binary: %w", err)
+		}
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		if strings.Contains(exe, "/Cellar/") {
+			return fmt.Errorf("this nancy is managed by Homebrew; update it with: brew upgrade nancy")
+		}
+
+		fmt.Println(i18n.T("Downloading Nancy %s...", release.Version))
+		binary, err := release.DownloadBinary()
+		if err != nil {
+			return err
+		}
+		if err := utils.ReplaceExecutable(exe, binary); err != nil {
+			return err
+		}
+
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Updated to Nancy %s.", release.Version))
+		if running, _, _ := isDaemonRunning(); running {
+			fmt.Println("   " + i18n.T("Restart the daemon to use it: nancy daemon restart"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	selfUpdateCmd.Flags().Bool("check", false, "Only check whether a new version exists")
+	selfUpdateCmd.Flags().Bool("force", false, "Install the latest release even if it isn't newer")
+}
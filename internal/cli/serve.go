@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// localOnly marks commands that work on the local store only, so they
+// refuse --remote
+const localOnly = "local-only"
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the reminders over a REST API, gRPC and a web UI",
+	Long: `Serve the reminders over a REST API, so a headless server can hold the
+canonical data and laptops use it with --remote (or remote.url in the config)
+instead of a local store.
+
+The API lives under /api/v1: GET, POST, PUT and DELETE on /reminders and
+/reminders/{id}, and POST /reminders/{id}/complete, with reminders as JSON in
+the export format. GET /events streams server-sent events as reminders are
+added, changed, completed or deleted, come due or go overdue, so dashboards
+can update live. POST /reminders/{id}/snooze?for=30m snoozes a reminder, and
+POST /reminders takes {"input": "..."} too, parsed like 'nancy add'. POST,
+PUT and DELETE must be sent as application/json. Set a token (--token or
+remote.token) so only clients with it can reach your reminders, and
+--cert/--key to serve HTTPS. Without a token, only requests addressed to
+this machine by name or IP are served, and pages from other sites are
+always refused.
+
+The root URL serves a small web UI with today's reminders, Done and Snooze
+buttons and an add box, handy on a home server or a phone browser.
+
+With --grpc-addr, it also serves the nancy.v1.Reminders gRPC service of
+proto/nancy/v1/reminders.proto: the same operations plus a Watch stream of
+every change to the store, for other services and long-lived integrations.
+It takes the same token, as "authorization: Bearer <token>" metadata, and the
+same certificate.
+
+Keep the daemon running next to it for notifications; changes clients make
+show up on the server straight away.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{localOnly: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		grpcAddr, _ := cmd.Flags().GetString("grpc-addr")
+		token, _ := cmd.Flags().GetString("token")
+		cert, _ := cmd.Flags().GetString("cert")
+		key, _ := cmd.Flags().GetString("key")
+
+		if (cert == "") != (key == "") {
+			return fmt.Errorf("--cert and --key go together")
+		}
+		if token == "" {
+			token = getApp().GetConfig().Remote.Token
+		}
+		for _, listen := range []string{addr, grpcAddr} {
+			if listen != "" && token == "" && !isLoopback(listen) {
+				fmt.Fprintln(os.Stderr, utils.Symbol("⚠️  ", i18n.T("Warning: "))+
+					i18n.T("No token set: anyone who can reach %s can read and change your reminders.", listen))
+			}
+		}
+		return serveAPI(getApp(), addr, grpcAddr, token, cert, key)
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("addr", "localhost:8080", "Address to listen on (e.g. :8080 for every interface)")
+	serveCmd.Flags().String("grpc-addr", "", "Also serve gRPC on this address (e.g. localhost:9090)")
+	serveCmd.Flags().String("token", "", "Token clients must send (default: remote.token)")
+	serveCmd.Flags().String("cert", "", "TLS certificate file, to serve HTTPS")
+	serveCmd.Flags().String("key", "", "TLS key file, to serve HTTPS")
+
+	serveCmd.Example = `  # On the server
+  nancy serve --addr :8080 --token s3cret --cert server.crt --key server.key
+
+  # On a laptop, with remote.token: s3cret in its config
+  nancy --remote https://home.example.com:8080 list
+  nancy --remote https://home.example.com:8080 add "Renew passport" --date friday
+
+  # With gRPC next to the REST API
+  nancy serve --grpc-addr localhost:9090
+
+  # Straight from curl
+  curl -H "Authorization: Bearer s3cret" https://home.example.com:8080/api/v1/reminders
+
+  # Follow changes live
+  curl -N -H "Authorization: Bearer s3cret" https://home.example.com:8080/api/v1/events`
+}
+
+// serveAPI serves the REST API on addr, and gRPC on grpcAddr if set,
+// watching the store so both follow changes the server's own commands and
+// daemon make
+func serveAPI(a *app.App, addr, grpcAddr, token, cert, key string) error {
+	go a.GetStore().Watch(context.Background(), time.Second)
+
+	errs := make(chan error, 2)
+	if grpcAddr != "" {
+		grpcServer, err := utils.NewGRPCServer(a.GetStore(), token, cert, key)
+		if err != nil {
+			return err
+		}
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", grpcAddr, err)
+		}
+		fmt.Fprintln(os.Stderr, i18n.T("Serving gRPC on %s", grpcAddr))
+		go func() { errs <- grpcServer.Serve(listener) }()
+	}
+
+	mux := http.NewServeMux()
+	parser := textParser(models.ParsePriority(a.GetConfig().Default.Priority))
+	mux.Handle(utils.APIPrefix+"/", utils.NewAPIHandler(a.GetStore(), token, parser))
+	mux.Handle("GET /{$}", utils.NewWebUIHandler())
+	var handler http.Handler = mux
+	if token == "" {
+		handler = utils.HostCheck(addr, mux)
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if cert != "" {
+		fmt.Fprintln(os.Stderr, i18n.T("Serving reminders on https://%s%s (Ctrl+C to stop)", addr, utils.APIPrefix))
+		fmt.Fprintln(os.Stderr, i18n.T("Web UI: https://%s/", addr))
+		go func() { errs <- server.ListenAndServeTLS(cert, key) }()
+	} else {
+		fmt.Fprintln(os.Stderr, i18n.T("Serving reminders on http://%s%s (Ctrl+C to stop)", addr, utils.APIPrefix))
+		fmt.Fprintln(os.Stderr, i18n.T("Web UI: http://%s/", addr))
+		go func() { errs <- server.ListenAndServe() }()
+	}
+	return <-errs
+}
+
+// connectRemote switches to the reminders of a 'nancy serve' instance when
+// --remote or remote.url names one
+func connectRemote(cmd *cobra.Command) error {
+	config := getApp().GetConfig()
+	remote, _ := cmd.Flags().GetString("remote")
+	if remote == "" {
+		remote = config.Remote.URL
+	}
+	if remote == "" {
+		return nil
+	}
+
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[localOnly] == "true" {
+			if cmd.Flags().Changed("remote") {
+				return fmt.Errorf("'%s' works on the local store only; leave out --remote", cmd.CommandPath())
+			}
+			// remote.url is for the commands that can use it
+			return nil
+		}
+	}
+
+	client, err := utils.NewRemoteClient(remote, config.Remote.Token)
+	if err != nil {
+		return err
+	}
+	return getApp().ConnectRemote(client)
+}
+
+// isLoopback reports whether addr only listens on this machine
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
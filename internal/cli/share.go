@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var shareCmd = &cobra.Command{
+	Use:   "share <reminder-id>",
+	Short: "Print a link another Nancy user can add the reminder from",
+	Long: `Print a link carrying the reminder's title, due time, notes, tags and
+priority. Whoever gets it adds the reminder with:
+
+  nancy add --from-share <link>
+
+With --qr, a QR code of the link is drawn in the terminal as well, to scan
+with a phone.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminder, err := findReminderByID(args[0])
+		if err != nil {
+			return err
+		}
+		link, err := utils.EncodeShare(reminder)
+		if err != nil {
+			return err
+		}
+
+		if showQR, _ := cmd.Flags().GetBool("qr"); showQR {
+			code, err := utils.EncodeQR([]byte(link))
+			if err != nil {
+				fmt.Fprintln(os.Stderr, utils.Symbol("⚠️  ", i18n.T("Warning: "))+i18n.T("No QR code: %v", err))
+			} else {
+				fmt.Print(code.Terminal())
+			}
+		}
+		fmt.Println(link)
+		return nil
+	},
+}
+
+func init() {
+	shareCmd.Flags().Bool("qr", false, "Also draw the link as a QR code")
+}
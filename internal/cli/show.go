@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show [reminder-id]",
+	Short: "Show everything about a reminder",
+	Long: `Show all details of a reminder: due time, priority, tags, recurrence,
+links, tracked time and description.
+
+With --render, the description is rendered as markdown: headings, lists,
+checklists, emphasis, code and links.
+
+Without a reminder ID, 'nancy show' lists reminders like 'nancy list'.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// 'show' used to be an alias of 'list'
+		if len(args) == 0 {
+			return listCmd.RunE(listCmd, args)
+		}
+
+		reminder, err := findReminderByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		field := func(label, value string) {
+			fmt.Printf("   %s %s\n", label, value)
+		}
+
+		fmt.Println(utils.CompletionIcon(reminder.Completed) + " " + reminder.Title)
+		field(i18n.T("ID:"), reminder.DisplayID())
+		field(i18n.T("Due:"), reminder.FormattedDueTime())
+		field(i18n.T("Priority:"), utils.PriorityIcon(reminder.Priority)+" "+i18n.T(reminder.Priority.String()))
+
+		if reminder.IsCritical() {
+			field(i18n.T("Critical:"), i18n.T("notifies even in quiet hours and meetings"))
+		}
+		if reminder.Sticky {
+			field(i18n.T("Sticky:"), i18n.T("stays on screen until you act on it"))
+		}
+		if reminder.Grace != nil {
+			field(i18n.T("Grace:"), graceLabel(reminder.OverdueGrace()))
+		}
+		if reminder.DoneWhen != "" {
+			field(i18n.T("Done when:"), i18n.T("%s succeeds", reminder.DoneWhen))
+		}
+		if reminder.Nag > 0 {
+			field(i18n.T("Nag:"), i18n.T("every %s once overdue", utils.FormatDuration(reminder.NagInterval())))
+		}
+		if color := reminder.LabelColor(); color != "" {
+			field(i18n.T("Color:"), utils.ColorLabel(color))
+		}
+		if len(reminder.Tags) > 0 {
+			field(i18n.T("Tags:"), strings.Join(reminder.Tags, ", "))
+		}
+		if reminder.Assignee != "" {
+			field(i18n.T("For:"), reminder.Assignee)
+		}
+		if reminder.Recurring != nil {
+			field(i18n.T("Repeats:"), reminder.Recurring.String())
+		}
+		if reminder.CheckIn != "" {
+			field(i18n.T("Check-in:"), reminder.CheckInText())
+		}
+		if reminder.SunEvent != "" {
+			field(i18n.T("Follows:"), i18n.T(reminder.SunEvent))
+		}
+		if reminder.Issue != "" {
+			field(i18n.T("Issue:"), reminder.Issue)
+		}
+		if reminder.URL != "" {
+			field(i18n.T("Link:"), reminder.URL)
+		}
+		if reminder.Input != "" && reminder.Input != reminder.Title {
+			field(i18n.T("Typed:"), reminder.Input)
+		}
+		if len(reminder.TimeLog) > 0 {
+			tracked := utils.TrackedText(reminder.TrackedTime(time.Now()))
+			if reminder.TimerRunning() {
+				tracked += " " + i18n.T("(timer running)")
+			}
+			field(i18n.T("Tracked:"), tracked)
+		}
+
+		if reminder.Description != "" {
+			description := reminder.Description
+			if render, _ := cmd.Flags().GetBool("render"); render {
+				description = utils.RenderMarkdown(description, 0)
+			}
+			fmt.Println("\n" + description)
+		}
+		return nil
+	},
+}
+
+func init() {
+	showCmd.Flags().Bool("render", false, "Render the description as markdown")
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "List reminders that look abandoned",
+	Long: `List active reminders that haven't been updated, rescheduled or checked
+in on (see 'nancy check-in') in a while.
+
+These are often dead tasks. Use --review to go through them one by one and
+archive, delete or reschedule each.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, _ := cmd.Flags().GetInt("days")
+		if !cmd.Flags().Changed("days") {
+			days = getApp().GetConfig().Daemon.StaleDays
+		}
+		review, _ := cmd.Flags().GetBool("review")
+
+		if days < 1 {
+			return fmt.Errorf("--days must be at least 1")
+		}
+
+		store := getApp().GetStore()
+		reminders := store.GetAll(&models.FilterOptions{
+			StaleDays: days,
+			Assignee:  getApp().GetConfig().CurrentUser(),
+		})
+
+		if len(reminders) == 0 {
+			fmt.Println(utils.Symbol("✨ ", "") + i18n.T("No reminders untouched for more than %d days.", days))
+			return nil
+		}
+
+		fmt.Println(utils.Symbol("🕸️  ", "") + i18n.T("Stale Reminders (untouched for %d+ days)", days))
+		fmt.Println(strings.Repeat("─", 50))
+
+		if !review {
+			for i, reminder := range reminders {
+				displayReminder(reminder, i+1, useColor(cmd))
+			}
+			fmt.Println(strings.Repeat("─", 50))
+			fmt.Println(utils.Symbol("📊 ", "") + i18n.T("%d stale reminders. Run 'nancy stale --review' to clean them up.", len(reminders)))
+			return nil
+		}
+
+		return reviewStale(store, reminders, useColor(cmd))
+	},
+}
+
+func init() {
+	staleCmd.Flags().Int("days", 0, "Days without updates before a reminder counts as stale (defaults to daemon.stale_days)")
+	staleCmd.Flags().Bool("review", false, "Archive, delete or reschedule each stale reminder interactively")
+
+	staleCmd.Example = `  # Reminders untouched for daemon.stale_days (two weeks unless changed)
+  nancy stale
+
+  # A stricter threshold
+  nancy stale --days 7
+
+  # Clean up interactively
+  nancy stale --review`
+}
+
+// reviewStale walks through stale reminders and applies a quick action to each
+func reviewStale(store *models.Store, reminders []*models.Reminder, color bool) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	for i, reminder := range reminders {
+		displayReminder(reminder, i+1, color)
+
+		for done := false; !done; {
+			fmt.Print("   " + i18n.T("[a]rchive, [d]elete, [r]eschedule, [s]kip, [q]uit: "))
+			response, err := reader.ReadString('\n')
+			if err != nil && response == "" {
+				return nil
+			}
+
+			switch strings.ToLower(strings.TrimSpace(response)) {
+			case "a", "archive":
+				if err := store.ArchiveReminder(reminder.ID); err != nil {
+					return fmt.Errorf("failed to archive reminder: %w", err)
+				}
+				fmt.Println("   " + utils.Symbol("📦 ", "") + i18n.T("Archived"))
+				done = true
+			case "d", "delete":
+				if err := store.Delete(reminder.ID); err != nil {
+					return fmt.Errorf("failed to delete reminder: %w", err)
+				}
+				fmt.Println("   " + utils.Symbol("🗑️  ", "") + i18n.T("Deleted"))
+				done = true
+			case "r", "reschedule":
+				fmt.Print("   " + i18n.T("New due time (e.g. 'tomorrow at 3pm', 'in 2 hours'): "))
+				answer, _ := reader.ReadString('\n')
+				dueTime, err := utils.ParseDueTime(answer, getApp().GetConfig().TimesOfDay)
+				if err != nil {
+					fmt.Printf("   %s%v\n", utils.Symbol("❌ ", "[ERROR] "), err)
+					continue
+				}
+				reminder.DueTime = dueTime
+				if err := store.Update(reminder); err != nil {
+					return fmt.Errorf("failed to reschedule reminder: %w", err)
+				}
+				fmt.Println("   " + utils.Symbol("📅 ", "") + i18n.T("Rescheduled to %s", reminder.FormattedDueTime()))
+				done = true
+			case "s", "skip", "":
+				done = true
+			case "q", "quit":
+				return nil
+			}
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
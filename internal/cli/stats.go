@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show reminder counts and the overdue backlog trend",
+	Long: `Show how many reminders you have and how the overdue backlog developed
+over the last days, as a sparkline of the daily overdue count.
+
+The daemon records the overdue count once a day, so the trend fills in
+while it runs.
+
+With --usage, show how you use Nancy instead: your most used commands, when
+you add reminders and how many never get done. This needs 'usage_log: true'
+in the config; the log is kept in usage.jsonl in the data directory and
+never leaves your machine.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usage, _ := cmd.Flags().GetBool("usage"); usage {
+			return showUsage(getApp())
+		}
+
+		days, _ := cmd.Flags().GetInt("days")
+		if days < 2 || days > 366 {
+			return fmt.Errorf("--days must be between 2 and 366")
+		}
+
+		total, active, completed, _ := getApp().GetStore().Count()
+		overdue, _ := backlogCounts(getApp())
+		fmt.Println(utils.Symbol("📋 ", "") + i18n.T("%d reminders: %d active, %d completed, %d overdue", total, active, completed, overdue))
+
+		samples, err := utils.LoadMetrics(metricsPath(getApp()))
+		if err != nil {
+			return err
+		}
+		samples = utils.RecentMetrics(samples, days, time.Now())
+		if len(samples) == 0 {
+			fmt.Println(i18n.T("No overdue history yet; the daemon records it once a day."))
+			return nil
+		}
+
+		values := make([]int, len(samples))
+		for i, sample := range samples {
+			values[i] = sample.Overdue
+		}
+
+		fmt.Println()
+		fmt.Println(utils.Symbol("📉 ", "") + i18n.T("Overdue over the last %d days:", days))
+		fmt.Printf("   %s  %s\n", utils.Sparkline(values), backlogTrend(values[0], values[len(values)-1]))
+		fmt.Printf("   %s – %s\n", samples[0].Date, samples[len(samples)-1].Date)
+		return nil
+	},
+}
+
+func init() {
+	statsCmd.Flags().IntP("days", "n", 30, "Number of days of overdue history to show (2-366)")
+	statsCmd.Flags().Bool("usage", false, "Show your command usage from the local usage log")
+}
+
+// metricsPath returns the daily metrics file in the data directory
+func metricsPath(a *app.App) string {
+	return filepath.Join(a.GetConfig().GetDataDir(), utils.MetricsFile)
+}
+
+// backlogCounts counts the current user's overdue and active reminders,
+// leaving out paused recurring ones
+func backlogCounts(a *app.App) (overdue, active int) {
+	for _, reminder := range a.GetStore().GetAll(&models.FilterOptions{Assignee: a.GetConfig().CurrentUser()}) {
+		if reminder.Recurring != nil && reminder.Recurring.Paused {
+			continue
+		}
+		active++
+		if reminder.IsOverdue() {
+			overdue++
+		}
+	}
+	return overdue, active
+}
+
+// backlogTrend describes how the overdue count moved from first to last
+func backlogTrend(first, last int) string {
+	switch {
+	case last < first:
+		return i18n.T("shrinking (%d → %d)", first, last)
+	case last > first:
+		return i18n.T("growing (%d → %d)", first, last)
+	}
+	return i18n.T("steady (%d)", last)
+}
+
+// usagePath returns the local usage log in the data directory
+func usagePath(a *app.App) string {
+	return filepath.Join(a.GetConfig().GetDataDir(), utils.UsageFile)
+}
+
+// recordUsage logs a command run when the usage log is on. Only the command
+// name is kept, never its arguments.
+func recordUsage(a *app.App, cmd *cobra.Command) {
+	if !a.GetConfig().UsageLog || a.GetStore().IsReadOnly() {
+		return
+	}
+	command := strings.TrimPrefix(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()), " ")
+	if command == "" {
+		command = "tui"
+	}
+	if err := utils.RecordUsage(usagePath(a), command, time.Now()); err != nil {
+		log.Printf("Failed to record usage: %v", err)
+	}
+}
+
+// showUsage prints what the usage log and the reminders say about how Nancy
+// is used
+func showUsage(a *app.App) error {
+	events, err := utils.LoadUsage(usagePath(a))
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		if !a.GetConfig().UsageLog {
+			fmt.Println(i18n.T("The usage log is off. Turn it on with: usage_log: true in the config"))
+		} else {
+			fmt.Println(i18n.T("Nothing in the usage log yet."))
+		}
+		return nil
+	}
+
+	since := events[0].At
+	fmt.Println(utils.Symbol("📈 ", "") + i18n.T("%d commands since %s", len(events), i18n.FormatTime(since, "January 2, 2006")))
+	for i, count := range utils.CommandCounts(events) {
+		if i == usageTopCommands {
+			break
+		}
+		fmt.Printf("   %-16s %4d\n", count.Command, count.Count)
+	}
+
+	if day, hour, count := utils.BusiestSlot(events, "add"); count > 1 {
+		at := time.Date(2000, 1, 1, hour, 0, 0, 0, time.Local)
+		fmt.Println()
+		fmt.Println(i18n.T("You add most reminders on %s around %s.", i18n.T(day.String()), i18n.FormatTime(at, "3:04 PM")))
+	}
+
+	var due, missed int
+	now := time.Now()
+	for _, reminder := range a.GetStore().GetAll(&models.FilterOptions{ShowCompleted: true, Assignee: a.GetConfig().CurrentUser()}) {
+		if reminder.CreatedAt.Before(since) || !reminder.DueTime.Before(now) {
+			continue
+		}
+		due++
+		if !reminder.Completed {
+			missed++
+		}
+	}
+	if due > 0 {
+		fmt.Println(i18n.T("%d%% of the reminders due since then were never completed.", missed*100/due))
+	}
+	return nil
+}
+
+// usageTopCommands is how many commands the usage report lists
+const usageTopCommands = 10
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show today's progress and what's next",
+	Long: `Show how many of today's reminders are done, how many are overdue and
+which one is due next, and the time tracked today.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := getApp().GetStore()
+		user := getApp().GetConfig().CurrentUser()
+
+		if done, total := store.TodayProgress(user); total > 0 {
+			fmt.Println(utils.Symbol("📊 ", "") + utils.TodayProgress(done, total))
+		} else {
+			fmt.Println(utils.Symbol("📊 ", "") + i18n.T("Nothing due today."))
+		}
+
+		var overdue int
+		var next *models.Reminder
+		for _, reminder := range store.GetAll(&models.FilterOptions{Assignee: user}) {
+			if reminder.Recurring != nil && reminder.Recurring.Paused {
+				continue
+			}
+			if reminder.IsOverdue() {
+				overdue++
+			} else if next == nil || reminder.DueTime.Before(next.DueTime) {
+				next = reminder
+			}
+		}
+
+		if overdue > 0 {
+			fmt.Printf("   %s %d\n", i18n.T("Overdue:"), overdue)
+		}
+		if next != nil {
+			fmt.Printf("   %s %s - %s\n", i18n.T("Next:"), next.Title, next.FormattedDueTime())
+		}
+		if running := store.RunningTimer(); running != nil {
+			fmt.Printf("   %s %s - %s\n", i18n.T("Timer:"), running.Title, utils.TrackedText(running.TrackedTime(time.Now())))
+		}
+		if tracked := store.TrackedToday(); tracked > 0 {
+			fmt.Printf("   %s %s\n", i18n.T("Tracked today:"), utils.TrackedText(tracked))
+		}
+		if focus, _ := utils.LoadFocus(focusPath(getApp()), time.Now()); focus != nil {
+			fmt.Println(utils.Symbol("🎯 ", "") + focus.Banner(time.Now()))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Rename, merge and remove tags across all reminders",
+	Long:  `Manage tags on all reminders at once instead of editing reminders one by one.`,
+}
+
+var tagRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a tag (and its nested tags) everywhere",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return replaceTags(args[:1], args[1])
+	},
+}
+
+var tagMergeCmd = &cobra.Command{
+	Use:   "merge <tag>... --into <tag>",
+	Short: "Merge several tags into one",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		into, _ := cmd.Flags().GetString("into")
+		if strings.TrimSpace(into) == "" {
+			return fmt.Errorf("--into is required")
+		}
+		return replaceTags(args, into)
+	},
+}
+
+var tagRemoveCmd = &cobra.Command{
+	Use:     "rm <tag>...",
+	Short:   "Remove tags from every reminder",
+	Aliases: []string{"remove", "delete"},
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return replaceTags(args, "")
+	},
+}
+
+func init() {
+	tagCmd.AddCommand(tagRenameCmd)
+	tagCmd.AddCommand(tagMergeCmd)
+	tagCmd.AddCommand(tagRemoveCmd)
+
+	tagMergeCmd.Flags().String("into", "", "Tag to merge into")
+
+	for _, cmd := range []*cobra.Command{tagRenameCmd, tagMergeCmd, tagRemoveCmd} {
+		cmd.ValidArgsFunction = completeTags
+	}
+	registerTagCompletion(tagMergeCmd, "into")
+
+	tagCmd.Example = `  # Fix a typo
+  nancy tag rename wrok work
+
+  # Combine similar tags
+  nancy tag merge home house --into home
+
+  # Drop a tag from everything
+  nancy tag rm someday`
+}
+
+// replaceTags replaces tags on all reminders and reports the result
+func replaceTags(tags []string, replacement string) error {
+	for i, tag := range tags {
+		tags[i] = strings.TrimSpace(tag)
+	}
+	replacement = strings.TrimSpace(replacement)
+
+	changed, err := getApp().GetStore().ReplaceTags(tags, replacement)
+	if err != nil {
+		return fmt.Errorf("failed to update tags: %w", err)
+	}
+
+	if changed == 0 {
+		fmt.Println(i18n.T("No reminders tagged %s.", strings.Join(tags, ", ")))
+		return nil
+	}
+
+	if replacement == "" {
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Removed %s from %d reminders", strings.Join(tags, ", "), changed))
+	} else {
+		fmt.Println(utils.Symbol("✅ ", "") + i18n.T("Retagged %d reminders: %s → %s", changed, strings.Join(tags, ", "), replacement))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Show all tags as a tree",
+	Long: `Show every tag in use with the number of active reminders carrying it.
+
+Tags can be nested with slashes (work/clientA/billing). Counts include
+nested tags, and filtering by a parent tag matches all of its descendants.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := buildTagTree(getApp().GetStore().GetAll(&models.FilterOptions{}))
+		if len(root.children) == 0 {
+			fmt.Println(i18n.T("No tags yet. Add one with: nancy add \"Task #work\""))
+			return nil
+		}
+
+		for i, child := range root.sortedChildren() {
+			printTagTree(child, "", i == len(root.children)-1, true)
+		}
+		return nil
+	},
+}
+
+// tagNode is one segment of a hierarchical tag
+type tagNode struct {
+	name     string
+	count    int // Reminders tagged with this node or its descendants
+	children map[string]*tagNode
+}
+
+// buildTagTree turns the tags of reminders into a tree of path segments. A
+// reminder counts once per node, even when tagged both #work and
+// #work/clientA.
+func buildTagTree(reminders []*models.Reminder) *tagNode {
+	root := &tagNode{children: make(map[string]*tagNode)}
+	for _, reminder := range reminders {
+		counted := make(map[*tagNode]bool)
+		for _, tag := range reminder.Tags {
+			node := root
+			for _, segment := range strings.Split(tag, "/") {
+				child, ok := node.children[segment]
+				if !ok {
+					child = &tagNode{name: segment, children: make(map[string]*tagNode)}
+					node.children[segment] = child
+				}
+				if !counted[child] {
+					counted[child] = true
+					child.count++
+				}
+				node = child
+			}
+		}
+	}
+	return root
+}
+
+func (n *tagNode) sortedChildren() []*tagNode {
+	children := make([]*tagNode, 0, len(n.children))
+	for _, child := range n.children {
+		children = append(children, child)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].name < children[j].name
+	})
+	return children
+}
+
+// printTagTree renders a node and its descendants with box-drawing branches
+func printTagTree(node *tagNode, prefix string, last, top bool) {
+	branch, nextPrefix := "├── ", prefix+"│   "
+	if last {
+		branch, nextPrefix = "└── ", prefix+"    "
+	}
+	if top {
+		branch, nextPrefix = utils.Symbol("🏷️  ", ""), ""
+	}
+
+	fmt.Printf("%s%s%s (%d)\n", prefix, branch, node.name, node.count)
+
+	children := node.sortedChildren()
+	for i, child := range children {
+		printTagTree(child, nextPrefix, i == len(children)-1, false)
+	}
+}
+
+// completeTags offers existing tags, including parent segments of nested tags
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// Complete the last entry of comma-separated values
+	done := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		done, toComplete = toComplete[:i+1], toComplete[i+1:]
+	}
+
+	seen := make(map[string]bool)
+	var suggestions []string
+	for _, tag := range getApp().GetStore().GetTags() {
+		segments := strings.Split(tag, "/")
+		for i := range segments {
+			candidate := strings.Join(segments[:i+1], "/")
+			if !seen[candidate] && strings.HasPrefix(candidate, toComplete) {
+				seen[candidate] = true
+				suggestions = append(suggestions, done+candidate)
+			}
+		}
+	}
+
+	sort.Strings(suggestions)
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerTagCompletion enables tag completion for the named flags
+func registerTagCompletion(cmd *cobra.Command, flags ...string) {
+	for _, flag := range flags {
+		_ = cmd.RegisterFlagCompletionFunc(flag, completeTags)
+	}
+}
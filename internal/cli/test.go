@@ -2,9 +2,13 @@ package cli
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 )
 
@@ -17,12 +21,23 @@ var testCmd = &cobra.Command{
 var testNotificationCmd = &cobra.Command{
 	Use:   "notification",
 	Short: "Test notification system",
-	Long:  `Send a test notification to verify the notification system is working.`,
-	RunE:  testNotification,
+	Long: `Send a test notification to verify the notification system is working.
+
+With --method, send one on each given channel on its own, without falling
+back to the next channel, and report whether each worked and how long it
+took. Use --method all for every channel available on this system.`,
+	Example: `  nancy test notification
+  nancy test notification --method desktop --priority high
+  nancy test notification --method desktop,bell,log
+  nancy test notification --method all`,
+	RunE: testNotification,
 }
 
 func init() {
 	testCmd.AddCommand(testNotificationCmd)
+
+	testNotificationCmd.Flags().StringSlice("method", nil, "Test these channels one by one (desktop, bell, log or all)")
+	testNotificationCmd.Flags().String("priority", "medium", "Priority of the test notification (low, medium, high)")
 }
 
 // testNotification sends a test notification
@@ -32,21 +47,76 @@ func testNotification(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create notifier: %w", err)
 	}
 
-	fmt.Printf("Using notification method: %s\n", utils.GetMethodName(notifier.GetMethod()))
-	fmt.Println("Sending test notification...")
+	priorityFlag, _ := cmd.Flags().GetString("priority")
+	priority := utils.ParsePriorityString(priorityFlag)
+	if priority.String() != strings.ToLower(strings.TrimSpace(priorityFlag)) {
+		return fmt.Errorf("invalid priority '%s' (use low, medium or high)", priorityFlag)
+	}
+	if methods, _ := cmd.Flags().GetStringSlice("method"); len(methods) > 0 {
+		return testChannels(notifier, methods, priority)
+	}
+
+	fmt.Println(i18n.T("Using notification method: %s", utils.GetMethodName(notifier.GetMethod())))
+	fmt.Println(i18n.T("Sending test notification..."))
 
-	if err := notifier.TestNotification(); err != nil {
+	if err := notifier.TestNotification(priority); err != nil {
 		return fmt.Errorf("failed to send test notification: %w", err)
 	}
 
-	fmt.Println("Test notification sent successfully!")
+	if err := notifier.FallbackError(); err != nil {
+		fmt.Println(i18n.T("Desktop notification failed, used a fallback instead: %v", err))
+	} else {
+		fmt.Println(i18n.T("Test notification sent successfully!"))
+	}
 	
 	// Show available methods
 	methods := utils.GetAvailableMethods()
-	fmt.Println("\nAvailable notification methods:")
+	fmt.Println("\n" + i18n.T("Available notification methods:"))
 	for _, method := range methods {
 		fmt.Printf("  - %s\n", utils.GetMethodName(method))
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// testChannels sends a test notification on each named channel on its own
+// and reports how each did
+func testChannels(notifier *utils.Notifier, names []string, priority models.Priority) error {
+	var methods []utils.NotificationMethod
+	seen := make(map[utils.NotificationMethod]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		candidates := utils.GetAvailableMethods()
+		if name != "all" {
+			method, err := utils.ParseChannel(name)
+			if err != nil {
+				return err
+			}
+			candidates = []utils.NotificationMethod{method}
+		}
+		for _, method := range candidates {
+			if !seen[method] {
+				seen[method] = true
+				methods = append(methods, method)
+			}
+		}
+	}
+
+	fmt.Println(i18n.T("Sending a %s priority test notification on each channel...", i18n.T(priority.String())))
+	failed := 0
+	for _, result := range notifier.TestChannels(methods, priority) {
+		latency := result.Latency.Round(time.Millisecond)
+		if result.Err != nil {
+			failed++
+			fmt.Printf("  %s %-8s %8s  %v\n", utils.Symbol("❌", "FAIL"), utils.ChannelName(result.Method), latency, result.Err)
+			continue
+		}
+		fmt.Printf("  %s %-8s %8s\n", utils.Symbol("✅", "OK"), utils.ChannelName(result.Method), latency)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d channels failed", failed, len(methods))
+	}
+	fmt.Println(i18n.T("All channels worked."))
+	return nil
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start <reminder-id>",
+	Short: "Start tracking time on a reminder",
+	Long: `Start a timer on a reminder to track how long you work on it.
+
+Only one timer runs at a time: starting another stops the running one.
+Completing a reminder stops its timer. Tracked time shows in 'nancy show'
+and 'nancy status', and 'nancy export --format csv' includes it.
+
+Examples:
+  nancy start 4                     # Track time on reminder 4
+  nancy stop                        # Done for now
+  nancy export -o timesheet.csv     # Timesheet for invoicing`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminder, err := findReminderByID(args[0])
+		if err != nil {
+			return err
+		}
+
+		stopped, err := getApp().GetStore().StartTimer(reminder.ID)
+		if err != nil {
+			return fmt.Errorf("cannot start the timer on '%s': %w", reminder.Title, err)
+		}
+
+		if stopped != nil {
+			fmt.Println(utils.Symbol("⏹ ", "") + i18n.T("Stopped: %s (%s total)", stopped.Title, utils.TrackedText(stopped.TrackedTime(time.Now()))))
+		}
+		fmt.Println(utils.Symbol("⏱ ", "") + i18n.T("Started: %s", reminder.Title))
+		return nil
+	},
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running timer",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reminder, session, err := getApp().GetStore().StopTimer()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(utils.Symbol("⏹ ", "") + i18n.T("Stopped: %s", reminder.Title))
+		fmt.Printf("   %s %s\n", i18n.T("This session:"), utils.TrackedText(session))
+		fmt.Printf("   %s %s\n", i18n.T("Tracked:"), utils.TrackedText(reminder.TrackedTime(time.Now())))
+		return nil
+	},
+}
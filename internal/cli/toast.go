@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var toastActionCmd = &cobra.Command{
+	Use:   "toast-action <nancy://verb/id>",
+	Short: "Handle a Done or Snooze button on a notification",
+	Long: `Windows runs this when a button on one of Nancy's toasts is clicked,
+through the nancy:// URL scheme registered with the first toast. On macOS
+Nancy Notifier.app runs it the same way. nancy://checkin/<id> answers a
+check-in.`,
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snooze, _ := cmd.Flags().GetDuration("snooze")
+
+		verb, id, err := utils.ParseToastAction(args[0])
+		if err != nil {
+			return err
+		}
+		if verb != "complete" && verb != "snooze" && verb != "checkin" {
+			return fmt.Errorf("unknown toast action '%s'", verb)
+		}
+
+		reminder, err := findReminderByID(id)
+		if err != nil {
+			return err
+		}
+		return runMenuAction(reminder, verb, snooze)
+	},
+}
+
+func init() {
+	toastActionCmd.Flags().Duration("snooze", time.Hour, "How long Snooze postpones the reminder")
+}
@@ -0,0 +1,514 @@
+package i18n
+
+// german holds the German translations
+var german = map[string]string{
+	// Messages
+	" (%d of %d)":                            " (%d von %d)",
+	" (last)":                                " (letzter)",
+	" (next: %s)":                            " (nächster: %s)",
+	" (paused)":                              " (pausiert)",
+	" - last occurrence":                     " – letzter Termin",
+	" except %s":                             " außer %s",
+	" until %s":                              " bis %s",
+	"%d commands since %s":                   "%d Befehle seit %s",
+	"%d days":                                "%d Tage",
+	"%d done, %d moved, %d kept":             "%d erledigt, %d verschoben, %d behalten",
+	"%d duplicate records in reminders.json": "%d doppelte Einträge in reminders.json",
+	"%d hours":                               "%d Stunden",
+	"%d minutes":                             "%d Minuten",
+	"%d of %d":                               "%d von %d",
+	"%d overdue":                             "%d überfällig",
+	"%d reminders in %s":                     "%d Erinnerungen in %s",
+	"%d reminders in %s (read-only)":         "%d Erinnerungen in %s (schreibgeschützt)",
+	"%d reminders":                           "%d Erinnerungen",
+	"%d reminders: %d active, %d completed, %d overdue":                "%d Erinnerungen: %d aktiv, %d erledigt, %d überfällig",
+	"%d settings from %s":                                              "%d Einstellungen aus %s",
+	"%d stale reminders. Run 'nancy stale --review' to clean them up.": "%d verwaiste Erinnerungen. Mit 'nancy stale --review' aufräumen.",
+	"%d today": "%d heute",
+	"%d%% of the reminders due since then were never completed.": "%d%% der seitdem fälligen Erinnerungen wurden nie erledigt.",
+	"%d/%d done today (%d%%)":                                    "%d/%d heute erledigt (%d%%)",
+	"%dh %dm":                                                    "%d Std. %d Min.",
+	"%dm":                                                        "%d Min.",
+	"%s (last %s)":                                               "%s (zuletzt %s)",
+	"%s is not installed":                                        "%s ist nicht installiert",
+	"%s is not writable":                                         "%s ist nicht beschreibbar",
+	"%s looks like a duplicate of #%s %s (%s)":                               "%s sieht aus wie ein Duplikat von #%s %s (%s)",
+	"%s now has %d reminders (more than %d)":                                 "%s hat jetzt %d Erinnerungen (mehr als %d)",
+	"%s succeeds":                                                            "%s erfolgreich ist",
+	"(default.untimed_time; set it with --time)":                             "(default.untimed_time; mit --time festlegen)",
+	"(inferred from the due time; set it with --priority)":                   "(aus der Fälligkeit abgeleitet; mit --priority festlegen)",
+	"(timer running)":                                                        "(Zeiterfassung läuft)",
+	"(you completed %d reminders tagged %s around then; set it with --time)": "(du hast %d Erinnerungen mit %s um diese Zeit erledigt; mit --time festlegen)",
+	"+ more added than done  - more done than added  = even":                 "+ mehr hinzugefügt als erledigt  - mehr erledigt als hinzugefügt  = ausgeglichen",
+	"+completed": "+erledigt",
+	"1 day":      "1 Tag",
+	"1 hour":     "1 Stunde",
+	"1 minute":   "1 Minute",
+	"Accept? [Y/n, or enter another time like '3pm' or '2024-03-20 15:04']: ": "Übernehmen? [Y/n, oder eine andere Zeit wie '15:00' oder '2024-03-20 15:04']: ",
+	"Active": "Aktiv",
+	"Add a new reminder with: nancy add \"Your reminder\"":                     "Neue Erinnerung hinzufügen mit: nancy add \"Deine Erinnerung\"",
+	"Add your first reminder":                                                  "Erste Erinnerung hinzufügen",
+	"Added %d review reminders, completed %d":                                  "%d Review-Erinnerungen hinzugefügt, %d erledigt",
+	"Added reminder: %s":                                                       "Erinnerung hinzugefügt: %s",
+	"Added sample reminders tagged #%s":                                        "Beispiel-Erinnerungen mit Tag #%s hinzugefügt",
+	"All caught up! No active reminders.":                                      "Alles erledigt! Keine aktiven Erinnerungen.",
+	"All channels worked.":                                                     "Alle Kanäle funktionieren.",
+	"Allow notifications for Nagging Nancy in System Settings › Notifications": "Benachrichtigungen für Nagging Nancy unter Systemeinstellungen › Mitteilungen erlauben",
+	"Answer with 'nancy check-in <id>'.":                                       "Mit 'nancy check-in <id>' antworten.",
+	"Archive (%d):":                                                            "Archivieren (%d):",
+	"Archived":                                                                 "Archiviert",
+	"Available notification methods:":                                          "Verfügbare Benachrichtigungsmethoden:",
+	"Backups of reminders.json, newest first:":                                 "Sicherungen von reminders.json, neueste zuerst:",
+	"Build it with 'make macos-notifier' and copy it to ~/Applications":        "Mit 'make macos-notifier' bauen und nach ~/Applications kopieren",
+	"Cannot add reminder: %v":                                                  "Erinnerung kann nicht hinzugefügt werden: %v",
+	"Cannot export: %v":                                                        "Export fehlgeschlagen: %v",
+	"Cannot save filter: %v":                                                   "Filter kann nicht gespeichert werden: %v",
+	"Cannot skip: %v":                                                          "Überspringen nicht möglich: %v",
+	"Cannot start the timer: %v":                                               "Zeiterfassung kann nicht gestartet werden: %v",
+	"Cannot stop the timer: %v":                                                "Zeiterfassung kann nicht gestoppt werden: %v",
+	"Changes made:":                                                            "Änderungen:",
+	"Check interval: %v":                                                       "Prüfintervall: %v",
+	"Check the include setting and your connection":                            "Prüfe die Einstellung include und deine Verbindung",
+	"Check-in":       "Nachfrage",
+	"Check-in:":      "Nachfrage:",
+	"Checked in: %s": "Rückmeldung gegeben: %s",
+	"Color:":         "Farbe:",
+	"Compacted reminders.json: %d → %d bytes":       "reminders.json verdichtet: %d → %d Bytes",
+	"Complete %d reminders? [y/N]: ":                "%d Erinnerungen erledigen? [y/N]: ",
+	"Completed Reminders":                           "Erledigte Erinnerungen",
+	"Completed reminders:":                          "Erledigte Erinnerungen:",
+	"Completed: %d in the last %d weeks":            "Erledigt: %d in den letzten %d Wochen",
+	"Completed: %s":                                 "Erledigt: %s",
+	"Config:":                                       "Konfiguration:",
+	"Could not fetch the issue: %v":                 "Issue konnte nicht abgerufen werden: %v",
+	"Could not fetch the page title: %v":            "Seitentitel konnte nicht abgerufen werden: %v",
+	"Critical:":                                     "Kritisch:",
+	"DUE SOON":                                      "BALD FÄLLIG",
+	"Daemon force stopped":                          "Daemon zwangsweise beendet",
+	"Daemon is not running":                         "Daemon läuft nicht",
+	"Daemon is running with PID %d":                 "Daemon läuft mit PID %d",
+	"Daemon stopped":                                "Daemon beendet",
+	"Daemon:":                                       "Daemon:",
+	"Data:":                                         "Daten:",
+	"Date (e.g., today, 2024-03-20)":                "Datum (z. B. today, 2024-03-20)",
+	"Date (e.g., tomorrow, 2024-03-20, %s)":         "Datum (z. B. tomorrow, 2024-03-20, %s)",
+	"Date:":                                         "Datum:",
+	"Delete (%d):":                                  "Löschen (%d):",
+	"Delete reminder: %s? [y/N]: ":                  "Erinnerung löschen: %s? [y/N]: ",
+	"Deleted %d reminders that were deleted there":  "%d Erinnerungen gelöscht, die dort gelöscht wurden",
+	"Deleted reminders:":                            "Gelöschte Erinnerungen:",
+	"Deleted":                                       "Gelöscht",
+	"Deleted: %s":                                   "Gelöscht: %s",
+	"Deletion cancelled.":                           "Löschen abgebrochen.",
+	"Demo mode: sample reminders, nothing is saved": "Demo-Modus: Beispielerinnerungen, nichts wird gespeichert",
+	"Description:":                                  "Beschreibung:",
+	"Desktop notification failed, used a fallback instead: %v": "Desktop-Benachrichtigung fehlgeschlagen, stattdessen Ersatzweg genutzt: %v",
+	"Done when:":                        "Erledigt, wenn:",
+	"Downloading Nancy %s...":           "Lade Nancy %s herunter...",
+	"Due from:":                         "Fällig ab:",
+	"Due until:":                        "Fällig bis:",
+	"Due within %d minutes of: %s (%s)": "Höchstens %d Minuten entfernt von: %s (%s)",
+	"Due:":                              "Fällig:",
+	"Done":                              "Erledigt",
+	"Edit Reminder":                     "Erinnerung bearbeiten",
+	"Error: %s":                         "Fehler: %s",
+	"Errors:":                           "Fehler:",
+	"Everything looks good!":            "Alles in Ordnung!",
+	"Export %d reminders":               "%d Erinnerungen exportieren",
+	"Exported %d reminders to %s":       "%d Erinnerungen nach %s exportiert",
+	"Exported to %s":                    "Exportiert nach %s",
+	"File":                              "Datei",
+	"File cannot be empty":              "Datei darf nicht leer sein",
+	"File:":                             "Datei:",
+	"Filter Reminders":                  "Erinnerungen filtern",
+	"First up: %s at %s":                "Als Erstes: %s um %s",
+	"Fix %s/config.yaml":                "%s/config.yaml korrigieren",
+	"Focus ended, all notifications are back on": "Fokus beendet, alle Benachrichtigungen sind wieder an",
+	"Focus: %s until %s (%s left)":               "Fokus: %s bis %s (noch %s)",
+	"Follows:":                                   "Folgt:",
+	"Follows: %s (adjusted daily)":               "Folgt: %s (täglich angepasst)",
+	"For:":                                       "Für:",
+	"Format:":                                    "Format:",
+	"Free slots:":                                "Freie Termine:",
+	"From the shell: nancy add \"Call mom tomorrow at 3pm\"": "In der Shell: nancy add \"Mama anrufen morgen um 15 Uhr\"",
+	"Grace:":                         "Schonfrist:",
+	"Great job getting that done!":   "Super, das ist erledigt!",
+	"Heard: %s":                      "Verstanden: %s",
+	"History for %s:":                "Verlauf von %s:",
+	"History:":                       "Verlauf:",
+	"ID %s: already completed":       "ID %s: bereits erledigt",
+	"ID:":                            "ID:",
+	"Ignoring the parser plugin: %v": "Parser-Plugin wird ignoriert: %v",
+	"Imported %d reminders":          "%d Erinnerungen importiert",
+	"In:":                            "In:",
+	"Invalid date format: %s":        "Ungültiges Datumsformat: %s",
+	"Invalid time format: %s":        "Ungültiges Zeitformat: %s",
+	"Issue:":                         "Issue:",
+	"Left out %d reminders deleted here since": "%d hier inzwischen gelöschte Erinnerungen ausgelassen",
+	"Less %s More": "Weniger %s Mehr",
+	"Link:":        "Link:",
+	"Load a few sample reminders to try things out": "Ein paar Beispiel-Erinnerungen zum Ausprobieren laden",
+	"Load: %d created, %d completed":                "Last: %d erstellt, %d erledigt",
+	"Location: %s":                                  "Ort: %s",
+	"Log in to a desktop session or start a notification daemon such as dunst or mako": "In einer Desktop-Sitzung anmelden oder einen Benachrichtigungsdienst wie dunst oder mako starten",
+	"Log: %s":                               "Protokoll: %s",
+	"Logging to %s":                         "Protokoll in %s",
+	"Looks like a duplicate of #%s %s (%s)": "Sieht aus wie ein Duplikat von #%s %s (%s)",
+	"Match: reminders with all marked tags (AND)": "Treffer: Erinnerungen mit allen markierten Tags (UND)",
+	"Match: reminders with any marked tag (OR)":   "Treffer: Erinnerungen mit einem der markierten Tags (ODER)",
+	"Nag:":                                    "Nörgeln:",
+	"Nancy %s is available (you have %s).":    "Nancy %s ist verfügbar (installiert: %s).",
+	"Nancy %s is available":                   "Nancy %s ist verfügbar",
+	"Nancy %s is up to date.":                 "Nancy %s ist aktuell.",
+	"Nancy Reminder":                          "Nancy-Erinnerung",
+	"Nancy Weekly Digest":                     "Nancys Wochenübersicht",
+	"Nancy daemon started in foreground mode": "Nancy-Daemon im Vordergrund gestartet",
+	"Nancy daemon started with PID %d":        "Nancy-Daemon mit PID %d gestartet",
+	"New Reminder":                            "Neue Erinnerung",
+	"New due time (e.g. 'tomorrow at 3pm', 'in 2 hours'): ": "Neue Fälligkeit (z. B. 'tomorrow at 3pm', 'in 2 hours'): ",
+	"Next check-in:":       "Nächste Nachfrage:",
+	"Next day with room:":  "Nächster Tag mit Platz:",
+	"Next:":                "Nächster:",
+	"No QR code: %v":       "Kein QR-Code: %v",
+	"No active reminders.": "Keine aktiven Erinnerungen.",
+	"No backups yet; one is kept each hour the reminders are saved.": "Noch keine Sicherungen; jede Stunde, in der die Erinnerungen gespeichert werden, wird eine angelegt.",
+	"No changes specified. Use --title, --time, --date, --shift, --priority, --notify-before, --nag, --grace, --critical, --sticky, --color, --check-in, --add-tags, or --remove-tags": "Keine Änderungen angegeben. Nutze --title, --time, --date, --shift, --priority, --notify-before, --nag, --grace, --critical, --sticky, --color, --check-in, --add-tags oder --remove-tags",
+	"No check-ins waiting.":         "Keine offenen Nachfragen.",
+	"No completed reminders found.": "Keine erledigten Erinnerungen gefunden.",
+	"No desktop notification tool found; run 'make install-notifications'": "Kein Benachrichtigungsprogramm gefunden; 'make install-notifications' ausführen",
+	"No overdue history yet; the daemon records it once a day.":            "Noch kein Verlauf überfälliger Erinnerungen; der Daemon zeichnet ihn einmal täglich auf.",
+	"No overdue reminders.":                            "Keine überfälligen Erinnerungen.",
+	"No past occurrences recorded for '%s'.":           "Keine vergangenen Termine für '%s' erfasst.",
+	"No problems found, but check the warnings above.": "Keine Probleme gefunden, aber die Warnungen oben beachten.",
+	"No reminders due today.":                          "Heute ist nichts fällig.",
+	"No reminders match '%s'.":                         "Keine Erinnerung passt zu '%s'.",
+	"No reminders match the rules.":                    "Keine Erinnerung passt zu den Regeln.",
+	"No reminders match.":                              "Keine passenden Erinnerungen.",
+	"No reminders tagged %s.":                          "Keine Erinnerungen mit dem Tag %s.",
+	"No reminders untouched for more than %d days.":    "Keine Erinnerungen, die seit mehr als %d Tagen unverändert sind.",
+	"No rules yet. Add some to 'rules' in the config, e.g. \"completed > 7d -> archive\"": "Noch keine Regeln. Trage welche unter 'rules' in der Konfiguration ein, z. B. \"completed > 7d -> archive\"",
+	"No tags yet. Add one with: nancy add \"Task #work\"":                                 "Noch keine Tags. Einen hinzufügen mit: nancy add \"Aufgabe #arbeit\"",
+	"No tags yet. Add some with #tag in a reminder.":                                      "Noch keine Tags. Füge welche mit #tag in einer Erinnerung hinzu.",
+	"No token set: anyone who can reach %s can read and change your reminders.":           "Kein Token gesetzt: Wer %s erreicht, kann deine Erinnerungen lesen und ändern.",
+	"Not focusing. Start with: nancy focus --tags deepwork --for 2h":                      "Kein Fokus aktiv. Starte mit: nancy focus --tags deepwork --for 2h",
+	"Nothing changed.":                        "Nichts geändert.",
+	"Nothing due today.":                      "Heute ist nichts fällig.",
+	"Nothing in the usage log yet.":           "Noch nichts im Nutzungsprotokoll.",
+	"Nothing left over. Enjoy your evening!":  "Nichts übrig. Schönen Feierabend!",
+	"Nothing to do.":                          "Nichts zu tun.",
+	"Nothing to fix.":                         "Nichts zu reparieren.",
+	"Nothing to review. Enjoy the free time!": "Nichts durchzusehen. Genieß die freie Zeit!",
+	"Nothing was changed. Run 'nancy rules apply' to apply the rules now.": "Nichts wurde geändert. Mit 'nancy rules apply' werden die Regeln jetzt angewendet.",
+	"Notification permission:": "Benachrichtigungsberechtigung:",
+	"Notification server:":     "Benachrichtigungsserver:",
+	"Notifications:":           "Benachrichtigungen:",
+	"OVERDUE":                  "ÜBERFÄLLIG",
+	"OVERDUE by %s":            "ÜBERFÄLLIG seit %s",
+	"On time: %d  Late: %d  Skipped: %d  Missed: %d  (%d%% on time)":                     "Pünktlich: %d  Verspätet: %d  Übersprungen: %d  Verpasst: %d  (%d%% pünktlich)",
+	"Once you have reminders: space completes, e edits, d deletes, enter shows details.": "Sobald es Erinnerungen gibt: Leertaste erledigt, e bearbeitet, d löscht, enter zeigt Details.",
+	"Opened: %s":                            "Geöffnet: %s",
+	"Organizer: %s":                         "Organisiert von: %s",
+	"Overdue Reminder":                      "Überfällige Erinnerung",
+	"Overdue Reminders":                     "Überfällige Erinnerungen",
+	"Overdue over the last %d days:":        "Überfällig in den letzten %d Tagen:",
+	"Overdue":                               "Überfällig",
+	"Overdue reminders stand out like this": "Überfällige Erinnerungen fallen so auf",
+	"Overdue:":                              "Überfällig:",
+	"Paused":                                "Pausiert",
+	"Paused: %s":                            "Pausiert: %s",
+	"Plan Your Day":                         "Plane deinen Tag",
+	"Plan for %s":                           "Plan für %s",
+	"Press 'q' to quit, '?' for help":       "'q' zum Beenden, '?' für Hilfe",
+	"Press space to complete me":            "Drück die Leertaste, um mich zu erledigen",
+	"Priority:":                             "Priorität:",
+	"Profile written to %s":                 "Profil geschrieben nach %s",
+	"Quit":                                  "Beenden",
+	"Recurring reminders move to the next day when completed. Press s to skip one.": "Wiederkehrende Erinnerungen springen beim Erledigen auf den nächsten Tag. Mit s überspringst du eine.",
+	"Reminder Due Soon":                       "Erinnerung bald fällig",
+	"Reminder Due Today":                      "Erinnerung heute fällig",
+	"Reminder for This Place":                 "Erinnerung für diesen Ort",
+	"Reminder not added.":                     "Erinnerung nicht hinzugefügt.",
+	"Reminders":                               "Erinnerungen",
+	"Remove them with 'nancy compact'":        "Mit 'nancy compact' entfernen",
+	"Removed %d exact duplicate records":      "%d exakte Duplikate entfernt",
+	"Removed %s from %d reminders":            "%s von %d Erinnerungen entfernt",
+	"Reopened: %s":                            "Wieder geöffnet: %s",
+	"Reparsing '%s' gives the same reminder.": "Erneutes Auswerten von '%s' ergibt dieselbe Erinnerung.",
+	"Repeats:":                                "Wiederholung:",
+	"Rescheduled to %s":                       "Verschoben auf %s",
+	"Resolved %d conflicting copies, keeping the most recently updated": "%d widersprüchliche Kopien bereinigt, die zuletzt geänderte wurde behalten",
+	"Restart the daemon to use it: nancy daemon restart":                "Starte den Daemon neu, um sie zu verwenden: nancy daemon restart",
+	"Restore one with 'nancy restore --from-backup N'":                  "Wiederherstellen mit 'nancy restore --from-backup N'",
+	"Restored backup %d: %d reminders":                                  "Sicherung %d wiederhergestellt: %d Erinnerungen",
+	"Resumed: %s":                                                       "Fortgesetzt: %s",
+	"Retagged %d reminders: %s → %s":                                    "%d Erinnerungen umgetaggt: %s → %s",
+	"Review of the next %d days":                                        "Durchsicht der nächsten %d Tage",
+	"Run 'nancy check-in %s' to say so.":                                "Mit 'nancy check-in %s' bestätigen.",
+	"Run 'nancy review --today' to plan your day.":                      "Plane deinen Tag mit 'nancy review --today'.",
+	"Run 'nancy review --today' to reschedule them.":                    "Verschiebe sie mit 'nancy review --today'.",
+	"Run 'nancy self-update' to install it.":                            "Installiere sie mit 'nancy self-update'.",
+	"See all keyboard shortcuts":                                        "Alle Tastenkürzel anzeigen",
+	"Send the weekly report":                                            "Wochenbericht senden",
+	"Sending a %s priority test notification on each channel...":        "Sende auf jedem Kanal eine Testbenachrichtigung mit Priorität %s...",
+	"Sending test notification...":                                      "Sende Testbenachrichtigung...",
+	"Serving gRPC on %s":                                                "gRPC unter %s",
+	"Serving reminders on http://%s (Ctrl+C to stop)":                   "Erinnerungen unter http://%s (Strg+C zum Beenden)",
+	"Serving reminders on http://%s%s (Ctrl+C to stop)":                 "Erinnerungen unter http://%s%s (Strg+C zum Beenden)",
+	"Serving reminders on https://%s%s (Ctrl+C to stop)":                "Erinnerungen unter https://%s%s (Strg+C zum Beenden)",
+	"Shift %d reminders? [y/N]: ":                                       "%d Erinnerungen verschieben? [y/N]: ",
+	"Shifted %d reminders.":                                             "%d Erinnerungen verschoben.",
+	"Show completed:":                                                   "Erledigte anzeigen:",
+	"Showing %d completed reminders":                                    "%d erledigte Erinnerungen",
+	"Showing %d reminders | Active: %d | Overdue: %d":                   "%d Erinnerungen | Aktiv: %d | Überfällig: %d",
+	"Skipped: %s":          "Übersprungen: %s",
+	"Snooze":               "Später",
+	"Snoozed: %s until %s": "Zurückgestellt: %s bis %s",
+	"Stale Reminders (untouched for %d+ days)":                "Verwaiste Erinnerungen (seit %d+ Tagen unverändert)",
+	"Start it with 'nancy daemon start' to get notifications": "Mit 'nancy daemon start' starten, um Benachrichtigungen zu erhalten",
+	"Started: %s":                          "Gestartet: %s",
+	"Starts: %s":                           "Beginn: %s",
+	"Status:":                              "Status:",
+	"Sticky:":                              "Hartnäckig:",
+	"Still working on '%s'?":               "Noch dran an '%s'?",
+	"Still working on these?":              "Noch dran?",
+	"Stopped: %s":                          "Gestoppt: %s",
+	"Stopped: %s (%s total)":               "Gestoppt: %s (%s insgesamt)",
+	"Stopped: %s (%s)":                     "Gestoppt: %s (%s)",
+	"Stored %d times in UTC":               "%d Zeitangaben in UTC gespeichert",
+	"Stretch and drink some water":         "Dehnen und etwas Wasser trinken",
+	"Suggested time for '%s': %s":          "Vorgeschlagene Zeit für '%s': %s",
+	"Tags":                                 "Tags",
+	"Tags:":                                "Tags:",
+	"Team config:":                         "Team-Konfiguration:",
+	"Test notification sent successfully!": "Testbenachrichtigung erfolgreich gesendet!",
+	"Thanks for using Nagging Nancy!":      "Danke, dass du Nagging Nancy benutzt!",
+	"The first date is excluded; starting at the next occurrence.":         "Das erste Datum ist ausgenommen; es geht mit dem nächsten Termin los.",
+	"The reminders as they were are now backup 1.":                         "Die bisherigen Erinnerungen sind jetzt Sicherung 1.",
+	"The usage log is off. Turn it on with: usage_log: true in the config": "Das Nutzungsprotokoll ist aus. Schalte es ein mit: usage_log: true in der Konfiguration",
+	"This Week's Reminders":        "Erinnerungen dieser Woche",
+	"This is the last occurrence.": "Das ist der letzte Termin.",
+	"This session:":                "Diese Sitzung:",
+	"Time (e.g., 3pm, 14:30)":      "Uhrzeit (z. B. 3pm, 14:30)",
+	"Time:":                        "Uhrzeit:",
+	"Timer:":                       "Zeiterfassung:",
+	"Title cannot be empty":        "Titel darf nicht leer sein",
+	"Title":                        "Titel",
+	"Title:":                       "Titel:",
+	"To move it:":                  "Zum Verschieben:",
+	"Today":                        "Heute",
+	"Today %s %d/%d":               "Heute %s %d/%d",
+	"Today's Reminders":            "Heutige Erinnerungen",
+	"Today's Wrap-Up":              "Tagesabschluss",
+	"Tomorrow":                     "Morgen",
+	"Total: %d | Active: %d | Completed: %d | Overdue: %d": "Gesamt: %d | Aktiv: %d | Erledigt: %d | Überfällig: %d",
+	"Tracked today:":       "Heute erfasst:",
+	"Tracked:":             "Erfasst:",
+	"Typed:":               "Eingegeben:",
+	"Updated %s":           "Aktualisiert %s",
+	"Updated reminder: %s": "Erinnerung aktualisiert: %s",
+	"Updated to Nancy %s.": "Auf Nancy %s aktualisiert.",
+	"Updated your config for this version of Nancy (the original is in %s):": "Deine Konfiguration wurde für diese Version von Nancy aktualisiert (das Original liegt in %s):",
+	"Updated: %s":                   "Aktualisiert: %s",
+	"Using notification method: %s": "Benachrichtigungsmethode: %s",
+	"Warning: ":                     "Warnung: ",
+	"Web UI: http://%s/":            "Web-Oberfläche: http://%s/",
+	"Web UI: https://%s/":           "Web-Oberfläche: https://%s/",
+	"Welcome! You don't have any reminders yet.":                    "Willkommen! Du hast noch keine Erinnerungen.",
+	"While you were away (%d)":                                      "Während du weg warst (%d)",
+	"While you were focusing (%d)":                                  "Während du fokussiert warst (%d)",
+	"While you were in a meeting (%d)":                              "Während deines Meetings (%d)",
+	"Wow! You completed %d reminders. You're on fire!":              "Wow! %d Erinnerungen erledigt. Du bist nicht zu bremsen!",
+	"You add most reminders on %s around %s.":                       "Die meisten Erinnerungen legst du am %s gegen %s an.",
+	"You are about to delete %d reminders. Use --force to confirm.": "Du bist dabei, %d Erinnerungen zu löschen. Mit --force bestätigen.",
+	"[DONE]": "[ERLEDIGT]",
+	"[TODO]": "[OFFEN]",
+	"[a]rchive, [d]elete, [r]eschedule, [s]kip, [q]uit: ": "[a]rchivieren, [d] löschen, [r] verschieben, [s] überspringen, [q] beenden: ",
+	"[d]one, [t]omorrow, [r]eschedule, [k]eep, [q]uit: ":  "[d] erledigt, [t] morgen, [r] verschieben, [k] behalten, [q] beenden: ",
+	"added tag '%s'":           "Tag '%s' hinzugefügt",
+	"all tags":                 "alle Tags",
+	"any":                      "alle",
+	"blue":                     "blau",
+	"changed %s from %q to %q": "%s von %q in %q geändert",
+	"check-in → %s":            "Nachfrage → %s",
+	"check-in → off":           "Nachfrage → aus",
+	"color → %s":               "Farbe → %s",
+	"color → none":             "Farbe → keine",
+	"critical → off":           "kritisch → aus",
+	"critical → on":            "kritisch → an",
+	"date → %s":                "Datum → %s",
+	"denied":                   "verweigert",
+	"done when → %s":           "erledigt, wenn → %s",
+	"done when → off":          "erledigt, wenn → aus",
+	"due → %s":                 "fällig → %s",
+	"esc: back":                "esc: zurück",
+	"every %s once overdue":    "alle %s, sobald überfällig",
+	"from %s":                  "ab %s",
+	"grace → %s":               "Schonfrist → %s",
+	"grace → default":          "Schonfrist → Standard",
+	"grace → none":             "Schonfrist → keine",
+	"granted":                  "erteilt",
+	"gray":                     "grau",
+	"green":                    "grün",
+	"growing (%d → %d)":        "wächst (%d → %d)",
+	"late":                     "verspätet",
+	"macOS asks the first time Nancy sends a notification; try 'nancy test notification'": "macOS fragt bei der ersten Benachrichtigung nach; 'nancy test notification' ausprobieren",
+	"missed":                       "verpasst",
+	"nag → every %s":               "nörgeln → alle %s",
+	"nag → hourly":                 "nörgeln → stündlich",
+	"nags as soon as it's overdue": "nörgelt, sobald sie überfällig ist",
+	"nags once %s overdue":         "nörgelt nach %s Verspätung",
+	"next due now":                 "nächste jetzt fällig",
+	"next in %s":                   "nächste in %s",
+	"no session bus; using notify-send if installed": "kein Session-Bus; notify-send wird genutzt, falls installiert",
+	"not asked yet": "noch nicht angefragt",
+	"not running":   "läuft nicht",
+	"notifies even in quiet hours and meetings": "benachrichtigt auch in Ruhezeiten und Meetings",
+	"notify before → %d min":                    "Vorwarnung → %d Min.",
+	"notify before → default":                   "Vorwarnung → Standard",
+	"now":                                       "jetzt",
+	"on time":                                   "pünktlich",
+	"orange":                                    "orange",
+	"overdue":                                   "überfällig",
+	"pink":                                      "rosa",
+	"priority → %s %s":                          "Priorität → %s %s",
+	"purple":                                    "lila",
+	"red":                                       "rot",
+	"removed tag '%s'":                          "Tag '%s' entfernt",
+	"renamed %s to %s":                          "%s in %s umbenannt",
+	"running with PID %d":                       "läuft mit PID %d",
+	"search":                                    "suchen",
+	"shrinking (%d → %d)":                       "schrumpft (%d → %d)",
+	"skipped":                                   "übersprungen",
+	"space=toggle enter=details s=skip e=edit d=delete /=search F=filter ?=help q=quit": "Leertaste=umschalten enter=Details s=überspringen e=bearbeiten d=löschen /=suchen F=Filter ?=Hilfe q=beenden",
+	"stays on screen until you act on it":                                               "bleibt stehen, bis du reagierst",
+	"steady (%d)":                                                                       "gleichbleibend (%d)",
+	"sticky → off":                                                                      "hartnäckig → aus",
+	"sticky → on":                                                                       "hartnäckig → an",
+	"tab: next field • shift+tab: prev field • enter: save • esc: cancel": "Tab: nächstes Feld • Umschalt+Tab: voriges Feld • Enter: speichern • Esc: abbrechen",
+	"tab: next field • ←/→: change format • enter: export • esc: cancel":  "tab: nächstes Feld • ←/→: Format ändern • enter: exportieren • esc: abbrechen",
+	"time → %s":                "Uhrzeit → %s",
+	"timer":                    "Zeiterfassung",
+	"title → '%s'":             "Titel → '%s'",
+	"to %s":                    "bis %s",
+	"turned off in the config": "in der Konfiguration ausgeschaltet",
+	"unreadable":               "unlesbar",
+	"work, home":               "arbeit, zuhause",
+	"yellow":                   "gelb",
+	"↑/↓: field • ←/→/space: change • tab: complete tag • ctrl+r: reset • enter: apply • esc: cancel": "↑/↓: Feld • ←/→/Leertaste: ändern • tab: Tag vervollständigen • ctrl+r: zurücksetzen • enter: anwenden • esc: abbrechen",
+	"↑/↓: move • space: mark • tab: AND/OR • c: clear • enter: filter • esc: cancel":                  "↑/↓: bewegen • Leertaste: markieren • tab: UND/ODER • c: leeren • enter: filtern • esc: abbrechen",
+	"%sOver %d a day: %s":                             "%sMehr als %d am Tag: %s",
+	"%s%d done today | %s%d rolling over":             "%s%d heute erledigt | %s%d bleiben liegen",
+	"%s%d due today | %s%d overdue":                   "%s%d heute fällig | %s%d überfällig",
+	"%s%d active | %s%d overdue | %s%d due this week": "%s%d aktiv | %s%d überfällig | %s%d diese Woche fällig",
+	"%s%d stale (run 'nancy stale --review')":         "%s%d verwaist ('nancy stale --review' ausführen)",
+	// Priorities, recurrence and due groups
+	"low":             "niedrig",
+	"medium":          "mittel",
+	"high":            "hoch",
+	"daily":           "täglich",
+	"weekdays":        "werktags",
+	"weekly":          "wöchentlich",
+	"biweekly":        "zweiwöchentlich",
+	"monthly":         "monatlich",
+	"every %d days":   "alle %d Tage",
+	"every %d weeks":  "alle %d Wochen",
+	"every %d months": "alle %d Monate",
+	"sunrise":         "Sonnenaufgang",
+	"sunset":          "Sonnenuntergang",
+	"⚠ Overdue":       "⚠ Überfällig",
+	"This week":       "Diese Woche",
+	"Later":           "Später",
+	"Completed":       "Erledigt",
+	// Date layouts (Go reference time) and names used by FormatTime
+	"3:04 PM":                 "15:04",
+	"Monday 3:04 PM":          "Monday 15:04",
+	"Jan 2 3:04 PM":           "2. Jan 15:04",
+	"Jan 2, 2006 3:04 PM":     "2. Jan 2006 15:04",
+	"Jan 2, 2006":             "2. Jan 2006",
+	"Mon Jan 2 3:04 PM":       "Mon 2. Jan 15:04",
+	"Mon Jan 2 15:04":         "Mon 2. Jan 15:04",
+	"Jan 2 15:04":             "2. Jan 15:04",
+	"Monday, January 2, 2006": "Monday, 2. January 2006",
+	"Monday":                  "Montag",
+	"Tuesday":                 "Dienstag",
+	"Wednesday":               "Mittwoch",
+	"Thursday":                "Donnerstag",
+	"Friday":                  "Freitag",
+	"Saturday":                "Samstag",
+	"Sunday":                  "Sonntag",
+	"Mon":                     "Mo",
+	"Tue":                     "Di",
+	"Wed":                     "Mi",
+	"Thu":                     "Do",
+	"Fri":                     "Fr",
+	"Sat":                     "Sa",
+	"Sun":                     "So",
+	"January":                 "Januar",
+	"February":                "Februar",
+	"March":                   "März",
+	"June":                    "Juni",
+	"July":                    "Juli",
+	"October":                 "Oktober",
+	"December":                "Dezember",
+	"Mar":                     "Mär",
+	"May":                     "Mai",
+	"Oct":                     "Okt",
+	"Dec":                     "Dez",
+
+	// TUI help screen
+	`Nagging Nancy - Help
+
+Navigation:
+  ↑/k      Move up
+  ↓/j      Move down
+  ←/→      Previous/next page
+  g/G      First/last reminder
+  /        Search (esc clears it)
+  
+Actions:
+  a/n      Add a reminder
+  space    Toggle reminder completion
+  s        Skip to next occurrence (recurring)
+  enter    Show/hide details
+  e        Edit selected reminder  
+  d        Delete selected reminder
+  r        Refresh list
+  f        Toggle show completed
+  F        Build a filter
+  T        Browse tags
+  x        Export the visible reminders
+  t        Start/stop the timer
+  
+Other:
+  ?/h      Show/hide help
+  q        Quit
+
+Press any key to return...`: `Nagging Nancy - Hilfe
+
+Navigation:
+  ↑/k      Nach oben
+  ↓/j      Nach unten
+  ←/→      Vorherige/nächste Seite
+  g/G      Erste/letzte Erinnerung
+  /        Suchen (esc leert die Suche)
+
+Aktionen:
+  a/n      Erinnerung hinzufügen
+  Leertaste  Erledigt umschalten
+  s        Zum nächsten Termin springen (wiederkehrend)
+  enter    Details ein-/ausblenden
+  e        Ausgewählte Erinnerung bearbeiten
+  d        Ausgewählte Erinnerung löschen
+  r        Liste aktualisieren
+  f        Erledigte ein-/ausblenden
+  F        Filter zusammenstellen
+  T        Tags durchsuchen
+  x        Sichtbare Erinnerungen exportieren
+  t        Zeiterfassung starten/stoppen
+
+Sonstiges:
+  ?/h      Hilfe ein-/ausblenden
+  q        Beenden
+
+Beliebige Taste zum Zurückkehren...`,
+}
@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateOrder is "mdy" or "dmy", or "" to follow the language
+var dateOrder string
+
+// clock is "12h" or "24h", or "" to follow the language
+var clock string
+
+// firstDay is the day weeks start on
+var firstDay = time.Monday
+
+// dmyLayouts rewrites month-first layouts day-first, longest match first
+var dmyLayouts = strings.NewReplacer(
+	"January 2, 2006", "2 January 2006",
+	"Jan 2, 2006", "2 Jan 2006",
+	"Jan 2", "2 Jan",
+)
+
+// SetFormats sets how dates and times are shown: date is "mdy", "dmy" or
+// "auto", clockFormat is "12h", "24h" or "auto", and weekStart is "monday"
+// or "sunday". "auto" follows the language.
+func SetFormats(date, clockFormat, weekStart string) error {
+	switch date {
+	case "mdy", "dmy":
+		dateOrder = date
+	case "", "auto":
+		dateOrder = ""
+	default:
+		return fmt.Errorf("invalid date format: %s (must be auto, mdy or dmy)", date)
+	}
+
+	switch clockFormat {
+	case "12h", "24h":
+		clock = clockFormat
+	case "", "auto":
+		clock = ""
+	default:
+		return fmt.Errorf("invalid time format: %s (must be auto, 12h or 24h)", clockFormat)
+	}
+
+	switch weekStart {
+	case "", "monday":
+		firstDay = time.Monday
+	case "sunday":
+		firstDay = time.Sunday
+	default:
+		return fmt.Errorf("invalid first day: %s (must be monday or sunday)", weekStart)
+	}
+	return nil
+}
+
+// DateOrder returns "dmy" or "mdy", the configured order or the language's
+func DateOrder() string {
+	if dateOrder != "" {
+		return dateOrder
+	}
+	if language == "de" {
+		return "dmy"
+	}
+	return "mdy"
+}
+
+// NumericDateLayout returns the layout of dates written with slashes in the
+// current date order, like 03/20/2024 or 20/03/2024
+func NumericDateLayout() string {
+	if DateOrder() == "dmy" {
+		return "02/01/2006"
+	}
+	return "01/02/2006"
+}
+
+// FirstWeekday returns the day weeks start on
+func FirstWeekday() time.Weekday {
+	return firstDay
+}
+
+// WeekStart returns midnight at the start of the week containing t
+func WeekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) - int(firstDay) + 7) % 7
+	start := t.AddDate(0, 0, -offset)
+	return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+}
+
+// layoutFor returns the layout to format with: translated, unless the date
+// order was set to one the language doesn't use, and with the configured clock
+func layoutFor(layout string) string {
+	switch {
+	case dateOrder == "" || (dateOrder == "dmy") == (language == "de"):
+		layout = T(layout)
+	case dateOrder == "dmy":
+		layout = dmyLayouts.Replace(layout)
+	}
+
+	switch clock {
+	case "24h":
+		layout = strings.ReplaceAll(layout, "3:04 PM", "15:04")
+	case "12h":
+		layout = strings.ReplaceAll(layout, "15:04", "3:04 PM")
+	}
+	return layout
+}
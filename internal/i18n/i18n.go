@@ -0,0 +1,99 @@
+// Package i18n translates user-facing messages.
+//
+// Messages are looked up by their English text, gettext style, so a string
+// without a translation is shown in English. Catalogs live next to this file,
+// one per language.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// catalogs maps a language code to its translations, keyed by English text
+var catalogs = map[string]map[string]string{
+	"de": german,
+}
+
+// current is the active language's catalog, nil for English
+var current map[string]string
+
+// language is the active language code
+var language = "en"
+
+// Languages lists the supported language codes
+func Languages() []string {
+	return []string{"en", "de"}
+}
+
+// SetLanguage selects the language by code, e.g. "de". "auto" or "" picks it
+// from LC_ALL, LC_MESSAGES or LANG. Unsupported languages fall back to English.
+func SetLanguage(lang string) {
+	if lang == "" || lang == "auto" {
+		lang = fromEnvironment()
+	}
+	lang = normalize(lang)
+
+	language, current = "en", nil
+	if catalog, ok := catalogs[lang]; ok {
+		language, current = lang, catalog
+	}
+}
+
+// Language returns the active language code
+func Language() string {
+	return language
+}
+
+// T translates a message. With arguments the translation is used as a
+// fmt format string.
+func T(message string, args ...any) string {
+	if translated, ok := current[message]; ok {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// FormatTime formats t with a translated layout and translated weekday and
+// month names, in the date order and clock set with SetFormats
+func FormatTime(t time.Time, layout string) string {
+	formatted := t.Format(layoutFor(layout))
+	if current == nil {
+		return formatted
+	}
+
+	for _, name := range []string{t.Weekday().String(), t.Month().String()} {
+		// Layouts use either the full name ("Monday") or its abbreviation ("Mon")
+		if !strings.Contains(formatted, name) {
+			name = name[:3]
+		}
+		if translated, ok := current[name]; ok {
+			formatted = strings.Replace(formatted, name, translated, 1)
+		}
+	}
+	return formatted
+}
+
+// fromEnvironment returns the language from the POSIX locale variables
+func fromEnvironment() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return "en"
+}
+
+// normalize turns locales like "de_DE.UTF-8" into language codes like "de"
+func normalize(locale string) string {
+	locale = strings.ToLower(locale)
+	if i := strings.IndexAny(locale, "_.@-"); i >= 0 {
+		locale = locale[:i]
+	}
+	return locale
+}
@@ -0,0 +1,195 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultBackups is how many copies of the reminders file a store keeps
+// unless SetBackups says otherwise
+const DefaultBackups = 5
+
+// MaxBackups is the most copies SetBackups allows
+const MaxBackups = 100
+
+// backupEvery is how old the newest backup gets before a save makes another.
+// Saving several times a minute would otherwise leave copies only seconds
+// apart, none from before the change that has to be undone.
+const backupEvery = time.Hour
+
+// Backup is a copy of the reminders file kept by Save
+type Backup struct {
+	Number    int // 1 is the newest
+	Path      string
+	ModTime   time.Time
+	Reminders int // Reminders in the file, including completed and archived ones
+}
+
+// SetBackups sets how many copies of the file saves keep, 0 for none
+func (s *Store) SetBackups(n int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.backups = min(max(n, 0), MaxBackups)
+}
+
+// backupPath returns where backup n of the file is kept
+func (s *Store) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", s.filePath, n)
+}
+
+// rotateBackups makes the file as it is on disk backup 1, moving the older
+// ones up and dropping the oldest. Unless force is set, it only does so once
+// backup 1 is backupEvery old. The caller must hold saveMutex.
+func (s *Store) rotateBackups(force bool) error {
+	if s.backups == 0 {
+		return nil
+	}
+	if !force {
+		if info, err := os.Stat(s.backupPath(1)); err == nil && time.Since(info.ModTime()) < backupEvery {
+			return nil
+		}
+	}
+	data, err := os.ReadFile(s.filePath)
+	if os.IsNotExist(err) || (err == nil && len(data) == 0) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up file: %w", err)
+	}
+
+	os.Remove(s.backupPath(s.backups))
+	for n := s.backups - 1; n >= 1; n-- {
+		if err := os.Rename(s.backupPath(n), s.backupPath(n+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate backups: %w", err)
+		}
+	}
+	if err := writeAtomic(s.backupPath(1), data, s.filePath); err != nil {
+		return fmt.Errorf("failed to back up file: %w", err)
+	}
+	return nil
+}
+
+// writeAtomic replaces the file at path with data. It writes a temporary file
+// next to it and renames that over it, so a crash or a full disk leaves either
+// the old file or the new one, never a mix. The new file gets the permissions
+// and, where allowed, the group of the file at like, or 0644 if there is none.
+func writeAtomic(path string, data []byte, like string) error {
+	// Replace what a symlink points to, not the link
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	perm := os.FileMode(0644)
+	info, statErr := os.Stat(like)
+	if statErr == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if statErr == nil {
+		keepGroup(tmp.Name(), info)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Backups returns the copies of the file that exist, newest first
+func (s *Store) Backups() []Backup {
+	if s.remote != nil || s.memory {
+		return nil
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var backups []Backup
+	for n := 1; n <= MaxBackups; n++ {
+		path := s.backupPath(n)
+		info, err := os.Stat(path)
+		if err != nil {
+			// Lowering backups leaves the copies past the new limit
+			if os.IsNotExist(err) && n > s.backups {
+				break
+			}
+			continue
+		}
+		backup := Backup{Number: n, Path: path, ModTime: info.ModTime(), Reminders: -1}
+		if data, err := os.ReadFile(path); err == nil {
+			var records []recordHeader
+			if json.Unmarshal(data, &records) == nil {
+				backup.Reminders = 0
+				for _, record := range records {
+					if record.DeletedAt == nil {
+						backup.Reminders++
+					}
+				}
+			}
+		}
+		backups = append(backups, backup)
+	}
+	return backups
+}
+
+// RestoreBackup replaces the reminders with those of backup n. The file as
+// it was becomes backup 1 first, so the restore can itself be undone.
+func (s *Store) RestoreBackup(n int) error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+	if s.remote != nil {
+		return ErrRemote
+	}
+	if s.memory {
+		return fmt.Errorf("a store in memory has no backups")
+	}
+
+	data, err := os.ReadFile(s.backupPath(n))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("there is no backup %d", n)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	var records []json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("backup %d is damaged: %w", n, err)
+	}
+
+	s.mutex.Lock()
+	s.saveMutex.Lock()
+	err = s.rotateBackups(true)
+	if err == nil {
+		err = writeAtomic(s.filePath, data, s.filePath)
+	}
+	s.saveMutex.Unlock()
+	s.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	if err := s.Load(); err != nil {
+		return err
+	}
+	s.publish(EventReloaded, "")
+	return nil
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// localTimestamp matches a stored time with a UTC offset instead of "Z"
+var localTimestamp = regexp.MustCompile(`"\d{4}-\d\d-\d\dT[\d:.]+[+-]\d\d:\d\d"`)
+
+// CompactReport describes what Compact fixed
+type CompactReport struct {
+	Duplicates int   // Exact copies of a record removed
+	Conflicts  int   // Differing records with the same ID; the most recently updated was kept
+	Timestamps int   // Times stored with a UTC offset, now stored in UTC
+	SizeBefore int64 // File size in bytes
+	SizeAfter  int64
+}
+
+// Changed reports whether Compact fixed anything
+func (r CompactReport) Changed() bool {
+	return r.Duplicates > 0 || r.Conflicts > 0 || r.Timestamps > 0 || r.SizeBefore != r.SizeAfter
+}
+
+// Compact rewrites the store file without duplicate records, with every
+// time in UTC and the records in short ID order, so diffs of synced or
+// backed-up copies stay small. Duplicates were already dropped by Load;
+// Compact makes it stick and reports them.
+func (s *Store) Compact() (CompactReport, error) {
+	if s.IsReadOnly() {
+		return CompactReport{}, ErrReadOnly
+	}
+	if s.remote != nil {
+		return CompactReport{}, ErrRemote
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	report := CompactReport{Duplicates: s.duplicates, Conflicts: s.conflicts}
+	if data, err := os.ReadFile(s.filePath); err == nil {
+		report.Timestamps = len(localTimestamp.FindAll(data, -1))
+		report.SizeBefore = int64(len(data))
+	} else if !os.IsNotExist(err) {
+		return report, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Old records are written back as they were read unless decoded
+	s.decodeCold()
+	if err := s.write(); err != nil {
+		return report, err
+	}
+	s.duplicates, s.conflicts = 0, 0
+
+	if info, err := os.Stat(s.filePath); err == nil {
+		report.SizeAfter = info.Size()
+	}
+	return report, nil
+}
+
+// Duplicates returns how many duplicate records Load dropped that are still
+// in the file
+func (s *Store) Duplicates() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.duplicates + s.conflicts
+}
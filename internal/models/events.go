@@ -0,0 +1,166 @@
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"time"
+)
+
+// Store event kinds
+const (
+	EventAdded     = "added"
+	EventUpdated   = "updated"
+	EventCompleted = "completed"
+	EventDeleted   = "deleted"
+	EventReloaded  = "reloaded" // Another process changed the file; anything may have changed
+)
+
+// Event tells subscribers that the store changed
+type Event struct {
+	Kind     string
+	ID       string    // Empty for EventReloaded
+	Reminder *Reminder // Copy of the reminder after the change; nil if deleted or reloaded
+}
+
+// eventBuffer is how many events a subscriber can fall behind by. Events
+// beyond that are dropped rather than blocking the store.
+const eventBuffer = 64
+
+// fileStamp identifies a version of the store file, or of a remote
+// store's reminders
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	sum     [sha256.Size]byte // Remote stores only
+}
+
+// Subscribe returns a channel receiving every change to the store and a
+// function that unsubscribes and closes it. A subscriber that falls behind
+// misses events, so consumers should refresh from the store rather than
+// replay events.
+func (s *Store) Subscribe() (<-chan Event, func()) {
+	s.eventMutex.Lock()
+	defer s.eventMutex.Unlock()
+
+	if s.subscribers == nil {
+		s.subscribers = make(map[int]chan Event)
+	}
+	id := s.nextSubscriber
+	s.nextSubscriber++
+	events := make(chan Event, eventBuffer)
+	s.subscribers[id] = events
+
+	return events, func() {
+		s.eventMutex.Lock()
+		defer s.eventMutex.Unlock()
+		if events, ok := s.subscribers[id]; ok {
+			delete(s.subscribers, id)
+			close(events)
+		}
+	}
+}
+
+// publish sends an event for each ID to every subscriber
+func (s *Store) publish(kind string, ids ...string) {
+	s.eventMutex.Lock()
+	listening := len(s.subscribers) > 0
+	s.eventMutex.Unlock()
+	if !listening {
+		return
+	}
+
+	// Copy the reminders before taking the event lock, which saves take
+	// while holding the store lock
+	events := make([]Event, len(ids))
+	for i, id := range ids {
+		events[i] = Event{Kind: kind, ID: id}
+		if kind != EventDeleted && kind != EventReloaded {
+			events[i].Reminder, _ = s.Get(id)
+		}
+	}
+
+	s.eventMutex.Lock()
+	defer s.eventMutex.Unlock()
+	for _, event := range events {
+		for _, subscriber := range s.subscribers {
+			select {
+			case subscriber <- event:
+			default:
+			}
+		}
+	}
+}
+
+// commit saves the reminders with the given IDs and tells subscribers
+func (s *Store) commit(kind string, ids ...string) error {
+	if err := s.SaveChanged(ids...); err != nil {
+		return err
+	}
+	s.publish(kind, ids...)
+	return nil
+}
+
+// Watch reloads the store whenever another process changes its file,
+// checking every interval until ctx is done, and publishes EventReloaded.
+// The store's own saves don't count as changes. A remote store is polled
+// instead, see watchRemote.
+func (s *Store) Watch(ctx context.Context, interval time.Duration) {
+	if s.remote != nil {
+		s.watchRemote(ctx, interval)
+		return
+	}
+	if s.memory {
+		// Nothing else can change a store in memory
+		<-ctx.Done()
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stamp, err := s.currentStamp()
+		if err != nil || stamp == s.lastStamp() {
+			continue
+		}
+		if err := s.Load(); err != nil {
+			// Probably caught mid-write; try again next time
+			continue
+		}
+		s.publish(EventReloaded, "")
+	}
+}
+
+// currentStamp returns the stamp of the file as it is on disk
+func (s *Store) currentStamp() (fileStamp, error) {
+	info, err := os.Stat(s.filePath)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// lastStamp returns the stamp of the file as last loaded or saved
+func (s *Store) lastStamp() fileStamp {
+	s.eventMutex.Lock()
+	defer s.eventMutex.Unlock()
+	return s.stamp
+}
+
+// recordStamp remembers the file as it is now, after loading or saving it
+func (s *Store) recordStamp() {
+	stamp, err := s.currentStamp()
+	if err != nil {
+		return
+	}
+	s.eventMutex.Lock()
+	s.stamp = stamp
+	s.eventMutex.Unlock()
+}
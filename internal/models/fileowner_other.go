@@ -0,0 +1,17 @@
+//go:build !windows
+
+package models
+
+import (
+	"os"
+	"syscall"
+)
+
+// keepGroup gives the file at path the group of the file described by info,
+// so a store shared through group permissions stays writable by the group.
+// It does nothing if that isn't allowed.
+func keepGroup(path string, info os.FileInfo) {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		_ = os.Chown(path, -1, int(stat.Gid))
+	}
+}
@@ -0,0 +1,6 @@
+package models
+
+import "os"
+
+// keepGroup does nothing on Windows, where files have no Unix group
+func keepGroup(path string, info os.FileInfo) {}
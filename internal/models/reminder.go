@@ -1,9 +1,19 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
 )
 
 // Priority represents reminder priority levels
@@ -40,6 +50,35 @@ func ParsePriority(s string) Priority {
 	}
 }
 
+// MarshalJSON encodes the priority as "low", "medium" or "high"
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON decodes a priority name, also accepting the legacy
+// integer encoding (0 = low, 1 = medium, 2 = high)
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		switch name {
+		case "low", "medium", "high":
+			*p = ParsePriority(name)
+			return nil
+		}
+		return fmt.Errorf("invalid priority %q", name)
+	}
+
+	var legacy int
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return fmt.Errorf("invalid priority %s", data)
+	}
+	if legacy < int(Low) || legacy > int(High) {
+		return fmt.Errorf("invalid priority %d", legacy)
+	}
+	*p = Priority(legacy)
+	return nil
+}
+
 // Color returns the color associated with the priority
 func (p Priority) Color() string {
 	switch p {
@@ -70,24 +109,267 @@ func (p Priority) Icon() string {
 
 // Reminder represents a single reminder
 type Reminder struct {
-	ID          string         `json:"id"`
-	Title       string         `json:"title"`
-	Description string         `json:"description,omitempty"`
-	DueTime     time.Time      `json:"due_time"`
-	Priority    Priority       `json:"priority"`
-	Completed   bool           `json:"completed"`
-	CompletedAt *time.Time     `json:"completed_at,omitempty"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	Tags        []string       `json:"tags,omitempty"`
-	Recurring   *RecurringRule `json:"recurring,omitempty"`
+	ID           string         `json:"id"`
+	ShortID      int            `json:"short_id,omitempty"` // Small number that is easy to type
+	Title        string         `json:"title"`
+	Description  string         `json:"description,omitempty"`
+	DueTime      time.Time      `json:"due_time"`
+	Priority     Priority       `json:"priority"`
+	Completed    bool           `json:"completed"`
+	CompletedAt  *time.Time     `json:"completed_at,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	Tags         []string       `json:"tags,omitempty"`
+	Recurring    *RecurringRule `json:"recurring,omitempty"`
+	Assignee     string         `json:"assignee,omitempty"`
+	Archived     bool           `json:"archived,omitempty"`
+	History      []Occurrence   `json:"history,omitempty"`       // past occurrences of a recurring reminder
+	NotifyBefore int            `json:"notify_before,omitempty"` // due-soon window in minutes, 0 = default
+	URL          string         `json:"url,omitempty"`           // link opened by 'nancy open'
+	Issue        string         `json:"issue,omitempty"`         // linked issue, e.g. owner/repo#123 or PROJ-123
+	SunEvent     string         `json:"sun_event,omitempty"`     // "sunrise" or "sunset"; the daemon keeps DueTime in step
+	Critical     bool           `json:"critical,omitempty"`      // always notify, even in quiet hours and meetings
+	Color        string         `json:"color,omitempty"`         // label color, one of LabelColors
+	TimeLog      []TimeEntry    `json:"time_log,omitempty"`      // work sessions from 'nancy start'/'nancy stop'
+	Input        string         `json:"input,omitempty"`         // text as typed at 'nancy add', for 'nancy edit --reparse'
+	CheckIn      string         `json:"check_in,omitempty"`      // how often to ask "still working on it?": daily, weekly, biweekly or monthly
+	LastCheckIn  *time.Time     `json:"last_check_in,omitempty"` // when the user last answered a check-in
+	Nag          int            `json:"nag,omitempty"`           // minutes between overdue notifications, 0 = hourly
+	Sticky       bool           `json:"sticky,omitempty"`        // notifications stay until acted on and come back if dismissed
+	Grace        *int           `json:"grace,omitempty"`         // minutes overdue before the first nag, nil = notifications.overdue_grace
+	DoneWhen     string         `json:"done_when,omitempty"`     // shell command; the daemon completes the reminder once it exits 0
+}
+
+// TimeEntry is one tracked work session; End is nil while the timer runs
+type TimeEntry struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// Duration returns how long the session lasted, or has lasted so far
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	if e.End != nil {
+		return e.End.Sub(e.Start)
+	}
+	return now.Sub(e.Start)
+}
+
+// Occurrence statuses recorded in a recurring reminder's history
+const (
+	OccurrenceOnTime  = "on_time"
+	OccurrenceLate    = "late"
+	OccurrenceSkipped = "skipped"
+	OccurrenceMissed  = "missed"
+)
+
+// Occurrence records how one past occurrence of a recurring reminder went
+type Occurrence struct {
+	DueTime time.Time `json:"due_time"`
+	At      time.Time `json:"at"`     // when it was completed, skipped or passed over
+	Status  string    `json:"status"` // on_time, late, skipped or missed
+}
+
+// Done reports whether the occurrence was completed, on time or late
+func (o Occurrence) Done() bool {
+	return o.Status == OccurrenceOnTime || o.Status == OccurrenceLate
 }
 
 // RecurringRule defines how often a reminder repeats
 type RecurringRule struct {
-	Frequency string     `json:"frequency"` // daily, weekly, monthly
-	Interval  int        `json:"interval"`  // every N days/weeks/months
-	EndDate   *time.Time `json:"end_date,omitempty"`
+	Frequency  string     `json:"frequency"` // daily, weekdays, weekly, monthly
+	Interval   int        `json:"interval"`  // every N days/weeks/months
+	EndDate    *time.Time `json:"end_date,omitempty"`
+	Count      int        `json:"count,omitempty"`      // total occurrences, 0 = unlimited
+	Occurrence int        `json:"occurrence,omitempty"` // 1-based number of the current occurrence
+	Final      bool       `json:"final,omitempty"`      // current occurrence is the last one
+	Paused     bool       `json:"paused,omitempty"`     // recurrence is on hold
+	Exclude    []string   `json:"exclude,omitempty"`    // dates (YYYY-MM-DD) with no occurrence
+	Day        int        `json:"day,omitempty"`        // day of the month "monthly" falls on, clamped to shorter months
+}
+
+// ParseFrequency validates a recurrence frequency
+func ParseFrequency(s string) (string, error) {
+	switch s {
+	case "daily", "weekdays", "weekly", "monthly":
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid repeat frequency '%s' (use daily, weekdays, weekly or monthly)", s)
+}
+
+// MaxInterval is the largest recurrence interval, e.g. every 1000 days;
+// beyond it the date arithmetic overflows
+const MaxInterval = 1000
+
+// maxOccurrenceSteps bounds the search for the next occurrence, which steps
+// through every occurrence in between; it covers a daily reminder some
+// 2,800 years behind
+const maxOccurrenceSteps = 1 << 20
+
+// NextOccurrence returns the first occurrence after both due and after, or
+// false when the rule's end date or count has been reached
+func (rule *RecurringRule) NextOccurrence(due, after time.Time) (time.Time, bool) {
+	next, _, ok := rule.nextOccurrence(due, after)
+	return next, ok
+}
+
+// nextOccurrence is NextOccurrence that also returns the new occurrence
+// number. Excluded dates and weekends (for "weekdays") don't count.
+func (rule *RecurringRule) nextOccurrence(due, after time.Time) (time.Time, int, bool) {
+	interval := rule.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	if interval > MaxInterval {
+		return time.Time{}, 0, false
+	}
+
+	occurrence := rule.Occurrence
+	if occurrence < 1 {
+		occurrence = 1
+	}
+
+	day := rule.monthDay(due)
+	next := due
+	for step := 0; step < maxOccurrenceSteps; step++ {
+		switch rule.Frequency {
+		case "daily":
+			next = next.AddDate(0, 0, interval)
+		case "weekdays":
+			next = next.AddDate(0, 0, 1)
+		case "weekly":
+			next = next.AddDate(0, 0, 7*interval)
+		case "monthly":
+			next = addMonths(next, interval, day)
+		default:
+			return time.Time{}, 0, false
+		}
+
+		if rule.EndDate != nil && next.After(endOfDay(*rule.EndDate)) {
+			return time.Time{}, 0, false
+		}
+		if rule.skips(next) {
+			continue
+		}
+
+		occurrence++
+		if rule.Count > 0 && occurrence > rule.Count {
+			return time.Time{}, 0, false
+		}
+		if next.After(after) {
+			return next, occurrence, true
+		}
+	}
+	return time.Time{}, 0, false
+}
+
+// monthDay returns the day of the month a monthly rule's occurrences fall on.
+// That is the rule's Day, unless due was moved to another day since: the
+// 31st falls on February 28th, but a due time on the 15th is on the 15th.
+func (rule *RecurringRule) monthDay(due time.Time) int {
+	if rule.Day > 0 && min(rule.Day, daysIn(due.Year(), due.Month())) == due.Day() {
+		return rule.Day
+	}
+	return due.Day()
+}
+
+// keepDay records the day of the month of a monthly rule whose occurrence
+// due is about to move, so later ones go back to it after a shorter month
+func (rule *RecurringRule) keepDay(due time.Time) {
+	if rule.Frequency == "monthly" {
+		rule.Day = rule.monthDay(due)
+	}
+}
+
+// addMonths returns t months later on day, or on the last day of that month
+// if it is shorter, so the 31st doesn't spill over into the next month
+func addMonths(t time.Time, months, day int) time.Time {
+	year, month := t.Year(), t.Month()+time.Month(months)
+	return time.Date(year, month, min(day, daysIn(year, month)), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// daysIn returns the number of days in a month; months past December roll
+// over into the next year
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// skips reports whether no occurrence falls on t's day
+func (rule *RecurringRule) skips(t time.Time) bool {
+	if rule.Frequency == "weekdays" && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return true
+	}
+	return rule.IsExcluded(t)
+}
+
+// IsExcluded reports whether t falls on one of the rule's exception dates
+func (rule *RecurringRule) IsExcluded(t time.Time) bool {
+	day := t.Format("2006-01-02")
+	for _, excluded := range rule.Exclude {
+		if excluded == day {
+			return true
+		}
+	}
+	return false
+}
+
+// AddExclusion adds an exception date (YYYY-MM-DD), keeping the list sorted
+func (rule *RecurringRule) AddExclusion(date string) error {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return fmt.Errorf("invalid date '%s' (use YYYY-MM-DD)", date)
+	}
+	for _, excluded := range rule.Exclude {
+		if excluded == date {
+			return nil
+		}
+	}
+	rule.Exclude = append(rule.Exclude, date)
+	sort.Strings(rule.Exclude)
+	return nil
+}
+
+// RemoveExclusion removes an exception date, reporting whether it was present
+func (rule *RecurringRule) RemoveExclusion(date string) bool {
+	for i, excluded := range rule.Exclude {
+		if excluded == date {
+			rule.Exclude = append(rule.Exclude[:i], rule.Exclude[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// endOfDay returns the last instant of t's day
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+}
+
+// String describes the rule, e.g. "every 2 weeks until Dec 31, 2025"
+func (rule *RecurringRule) String() string {
+	units := map[string]string{"daily": "every %d days", "weekly": "every %d weeks", "monthly": "every %d months"}
+	desc := i18n.T(rule.Frequency)
+	if rule.Interval > 1 && units[rule.Frequency] != "" {
+		desc = i18n.T(units[rule.Frequency], rule.Interval)
+	}
+	if rule.EndDate != nil {
+		desc += i18n.T(" until %s", i18n.FormatTime(*rule.EndDate, "Jan 2, 2006"))
+	}
+	if rule.Count > 0 {
+		occurrence := rule.Occurrence
+		if occurrence < 1 {
+			occurrence = 1
+		}
+		desc += i18n.T(" (%d of %d)", occurrence, rule.Count)
+	}
+	if len(rule.Exclude) > 0 {
+		desc += i18n.T(" except %s", strings.Join(rule.Exclude, ", "))
+	}
+	if rule.Final {
+		desc += i18n.T(" (last)")
+	}
+	if rule.Paused {
+		desc += i18n.T(" (paused)")
+	}
+	return desc
 }
 
 // NewReminder creates a new reminder with generated ID and timestamps
@@ -105,6 +387,18 @@ func NewReminder(title string, dueTime time.Time, priority Priority) *Reminder {
 	}
 }
 
+// DisplayID returns the short ID, or the first 8 characters of the UUID
+// for reminders that don't have one yet
+func (r *Reminder) DisplayID() string {
+	if r.ShortID > 0 {
+		return strconv.Itoa(r.ShortID)
+	}
+	if len(r.ID) > 8 {
+		return r.ID[:8]
+	}
+	return r.ID
+}
+
 // IsOverdue checks if the reminder is past due
 func (r *Reminder) IsOverdue() bool {
 	if r.Completed {
@@ -124,12 +418,147 @@ func (r *Reminder) IsDueToday() bool {
 		today.YearDay() == due.YearDay()
 }
 
-// IsDueSoon checks if the reminder is due within the next hour
+// highPriorityCritical makes every high priority reminder critical; see
+// SetHighPriorityCritical
+var highPriorityCritical = false
+
+// SetHighPriorityCritical sets whether high priority reminders count as
+// critical without their own Critical flag
+func SetHighPriorityCritical(critical bool) {
+	highPriorityCritical = critical
+}
+
+// IsCritical reports whether the reminder notifies regardless of quiet hours
+// and meetings, either by its own flag or by the high priority rule
+func (r *Reminder) IsCritical() bool {
+	return r.Critical || (highPriorityCritical && r.Priority == High)
+}
+
+// LabelColors are the colors a reminder can be labelled with
+var LabelColors = []string{"red", "orange", "yellow", "green", "blue", "purple", "pink", "gray"}
+
+// ParseColor validates a label color; "" and "none" clear the label
+func ParseColor(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "none" {
+		return "", nil
+	}
+	if s == "grey" {
+		s = "gray"
+	}
+	for _, color := range LabelColors {
+		if s == color {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid color '%s' (use %s)", s, strings.Join(LabelColors, ", "))
+}
+
+// tagColors labels reminders without their own color by tag; see SetTagColors
+var tagColors = map[string]string{}
+
+// SetTagColors sets the label color for reminders with each tag
+func SetTagColors(colors map[string]string) {
+	tagColors = make(map[string]string, len(colors))
+	for tag, color := range colors {
+		tagColors[strings.ToLower(tag)] = color
+	}
+}
+
+// LabelColor returns the reminder's own color, or else the color of its first
+// tag that has one, or ""
+func (r *Reminder) LabelColor() string {
+	if r.Color != "" {
+		return r.Color
+	}
+	for _, tag := range r.Tags {
+		if color, ok := tagColors[strings.ToLower(tag)]; ok {
+			return color
+		}
+	}
+	return ""
+}
+
+// dueSoonWindow and dueSoonByPriority decide how far ahead a reminder
+// counts as due soon; see SetDueSoonWindows
+var (
+	dueSoonWindow     = time.Hour
+	dueSoonByPriority = map[Priority]time.Duration{}
+)
+
+// SetDueSoonWindows sets the global due-soon window and optional
+// per-priority overrides. Non-positive durations are ignored.
+func SetDueSoonWindows(window time.Duration, byPriority map[Priority]time.Duration) {
+	if window > 0 {
+		dueSoonWindow = window
+	}
+	dueSoonByPriority = make(map[Priority]time.Duration)
+	for priority, d := range byPriority {
+		if d > 0 {
+			dueSoonByPriority[priority] = d
+		}
+	}
+}
+
+// DueSoonWindow returns how long before its due time the reminder counts as
+// due soon: its own NotifyBefore, else its priority's window, else the global one
+func (r *Reminder) DueSoonWindow() time.Duration {
+	if r.NotifyBefore > 0 {
+		return time.Duration(r.NotifyBefore) * time.Minute
+	}
+	if d, ok := dueSoonByPriority[r.Priority]; ok {
+		return d
+	}
+	return dueSoonWindow
+}
+
+// defaultNagInterval is how often an overdue reminder notifies again
+// unless it sets its own cadence
+const defaultNagInterval = time.Hour
+
+// NagInterval returns how long the daemon waits between notifications once
+// the reminder is overdue
+func (r *Reminder) NagInterval() time.Duration {
+	if r.Nag > 0 {
+		return time.Duration(r.Nag) * time.Minute
+	}
+	return defaultNagInterval
+}
+
+// overdueGrace is how long reminders without their own Grace may be overdue
+// before the daemon nags; see SetOverdueGrace
+var overdueGrace time.Duration
+
+// SetOverdueGrace sets the grace period for reminders without their own
+func SetOverdueGrace(grace time.Duration) {
+	overdueGrace = max(grace, 0)
+}
+
+// OverdueGrace returns how long the reminder may be overdue before the
+// daemon sends its first overdue notification: its own Grace, else the
+// global one
+func (r *Reminder) OverdueGrace() time.Duration {
+	if r.Grace != nil {
+		return time.Duration(*r.Grace) * time.Minute
+	}
+	return overdueGrace
+}
+
+// IsDueSoon checks if the reminder is due within its due-soon window
 func (r *Reminder) IsDueSoon() bool {
 	if r.Completed {
 		return false
 	}
-	return time.Until(r.DueTime) <= time.Hour && time.Until(r.DueTime) > 0
+	until := time.Until(r.DueTime)
+	return until <= r.DueSoonWindow() && until > 0
+}
+
+// OverdueAge returns how long the reminder has been overdue, or 0
+func (r *Reminder) OverdueAge() time.Duration {
+	if !r.IsOverdue() {
+		return 0
+	}
+	return time.Since(r.DueTime)
 }
 
 // TimeUntilDue returns the duration until the reminder is due
@@ -140,25 +569,198 @@ func (r *Reminder) TimeUntilDue() time.Duration {
 	return time.Until(r.DueTime)
 }
 
-// Complete marks the reminder as completed
+// Advance moves a recurring reminder to its next upcoming occurrence. The
+// ones already past on the way there are recorded as missed. It returns
+// false for non-recurring reminders and when the last occurrence is done.
+func (r *Reminder) Advance() bool {
+	return r.advance(true)
+}
+
+// advance is Advance, recording the occurrences passed over only if missed
+// is set
+func (r *Reminder) advance(missed bool) bool {
+	if r.Recurring == nil || r.Recurring.Final || r.Recurring.Paused {
+		return false
+	}
+
+	now := time.Now()
+	next, occurrence, ok := r.Recurring.nextOccurrence(r.DueTime, now)
+	if !ok {
+		return false
+	}
+
+	r.Recurring.keepDay(r.DueTime)
+	if missed {
+		for due := r.DueTime; ; {
+			due, _, ok = r.Recurring.nextOccurrence(due, due)
+			if !ok || !due.Before(next) {
+				break
+			}
+			r.History = append(r.History, Occurrence{DueTime: due, At: now, Status: OccurrenceMissed})
+		}
+	}
+	r.Recurring.Occurrence = occurrence
+	r.DueTime = next
+
+	// Mark the last occurrence so completing it doesn't respawn
+	r.refreshFinal()
+
+	r.UpdatedAt = now
+	return true
+}
+
+// SkipExcludedDates moves a recurring reminder whose current occurrence
+// falls on an excluded date to the next allowed one. It reports whether the
+// due time changed.
+func (r *Reminder) SkipExcludedDates() bool {
+	if r.Recurring == nil || r.Completed || !r.Recurring.skips(r.DueTime) {
+		return false
+	}
+
+	// The moved occurrence replaces the current one, so it keeps its number
+	next, _, ok := r.Recurring.nextOccurrence(r.DueTime, r.DueTime)
+	if !ok {
+		return false
+	}
+	r.Recurring.keepDay(r.DueTime)
+	r.DueTime = next
+	r.refreshFinal()
+	r.UpdatedAt = time.Now()
+	return true
+}
+
+// refreshFinal marks whether the current occurrence is the last one
+func (r *Reminder) refreshFinal() {
+	_, more := r.Recurring.NextOccurrence(r.DueTime, r.DueTime)
+	r.Recurring.Final = !more
+}
+
+// SetExclusions adds and removes exception dates on a recurring reminder,
+// moving it off its current occurrence if that is now excluded
+func (r *Reminder) SetExclusions(add, remove []string) error {
+	if r.Recurring == nil {
+		return fmt.Errorf("reminder is not recurring")
+	}
+	for _, date := range add {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return fmt.Errorf("invalid date '%s' (use YYYY-MM-DD)", date)
+		}
+	}
+	for _, date := range add {
+		r.Recurring.AddExclusion(date)
+	}
+	for _, date := range remove {
+		r.Recurring.RemoveExclusion(date)
+	}
+
+	if !r.SkipExcludedDates() {
+		r.refreshFinal()
+	}
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Skip moves a recurring reminder to its next occurrence without
+// completing the current one
+func (r *Reminder) Skip() error {
+	switch {
+	case r.Recurring == nil:
+		return fmt.Errorf("reminder is not recurring")
+	case r.Completed:
+		return fmt.Errorf("reminder is already completed")
+	case r.Recurring.Paused:
+		return fmt.Errorf("recurrence is paused")
+	}
+
+	due := r.DueTime
+	n := len(r.History)
+	if !r.Advance() {
+		return fmt.Errorf("no occurrences left to skip to")
+	}
+	// It comes before the later occurrences Advance recorded as missed
+	r.History = slices.Insert(r.History, n, Occurrence{DueTime: due, At: time.Now(), Status: OccurrenceSkipped})
+	return nil
+}
+
+// SetPaused pauses or resumes a recurring reminder. Resuming moves an
+// overdue reminder on to its next upcoming occurrence.
+func (r *Reminder) SetPaused(paused bool) error {
+	if r.Recurring == nil {
+		return fmt.Errorf("reminder is not recurring")
+	}
+	if r.Recurring.Paused == paused {
+		if paused {
+			return fmt.Errorf("recurrence is already paused")
+		}
+		return fmt.Errorf("recurrence is not paused")
+	}
+
+	r.Recurring.Paused = paused
+	r.UpdatedAt = time.Now()
+	// Occurrences that fell while it was on hold weren't missed
+	if !paused && !r.Completed && r.IsOverdue() {
+		r.advance(false)
+	}
+	return nil
+}
+
+// Complete marks the reminder as completed. Recurring reminders move on to
+// their next occurrence instead, until the last one is completed.
 func (r *Reminder) Complete() {
-	if !r.Completed {
-		now := time.Now()
-		r.Completed = true
-		r.CompletedAt = &now
-		r.UpdatedAt = now
+	if r.Completed {
+		return
 	}
+
+	now := time.Now()
+	r.StopTimer(now)
+	due := r.DueTime
+	if r.Recurring != nil {
+		status := OccurrenceOnTime
+		if now.After(due) {
+			status = OccurrenceLate
+		}
+		r.History = append(r.History, Occurrence{DueTime: due, At: now, Status: status})
+	}
+
+	if r.Advance() {
+		return
+	}
+	r.Completed = true
+	r.CompletedAt = &now
+	r.UpdatedAt = now
 }
 
 // Uncomplete marks the reminder as not completed
 func (r *Reminder) Uncomplete() {
 	if r.Completed {
+		// Forget the history entry recorded when the last occurrence was completed
+		if n := len(r.History); n > 0 && r.History[n-1].Done() && r.History[n-1].DueTime.Equal(r.DueTime) {
+			r.History = r.History[:n-1]
+		}
+
 		r.Completed = false
 		r.CompletedAt = nil
 		r.UpdatedAt = time.Now()
 	}
 }
 
+// Adherence counts how past occurrences of a recurring reminder went
+func (r *Reminder) Adherence() (onTime, late, skipped, missed int) {
+	for _, occurrence := range r.History {
+		switch occurrence.Status {
+		case OccurrenceOnTime:
+			onTime++
+		case OccurrenceLate:
+			late++
+		case OccurrenceSkipped:
+			skipped++
+		case OccurrenceMissed:
+			missed++
+		}
+	}
+	return onTime, late, skipped, missed
+}
+
 // Toggle toggles the completion status
 func (r *Reminder) Toggle() {
 	if r.Completed {
@@ -168,6 +770,38 @@ func (r *Reminder) Toggle() {
 	}
 }
 
+// linkPattern matches http(s) links in reminder text
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// IsWebLink reports whether link is an http(s) URL with a host, the only
+// kind of link Nancy hands to the system's URL handler
+func IsWebLink(link string) bool {
+	if strings.HasPrefix(link, "-") {
+		return false
+	}
+	u, err := url.Parse(link)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Link returns the reminder's URL, or else the first link in its title or
+// description. A URL that isn't an http(s) link is ignored.
+func (r *Reminder) Link() string {
+	if IsWebLink(r.URL) {
+		return r.URL
+	}
+	if link := linkPattern.FindString(r.Title); link != "" {
+		return link
+	}
+	return linkPattern.FindString(r.Description)
+}
+
+// Snooze pushes the due time to d from now
+func (r *Reminder) Snooze(d time.Duration) {
+	now := time.Now()
+	r.DueTime = now.Add(d)
+	r.UpdatedAt = now
+}
+
 // Update updates the reminder's title and due time
 func (r *Reminder) Update(title string, dueTime time.Time, priority Priority) {
 	r.Title = title
@@ -182,6 +816,42 @@ func (r *Reminder) SetDescription(description string) {
 	r.UpdatedAt = time.Now()
 }
 
+// TimerRunning reports whether a work session on the reminder is in progress
+func (r *Reminder) TimerRunning() bool {
+	n := len(r.TimeLog)
+	return n > 0 && r.TimeLog[n-1].End == nil
+}
+
+// StartTimer starts a work session, unless one is already running
+func (r *Reminder) StartTimer(now time.Time) bool {
+	if r.TimerRunning() {
+		return false
+	}
+	r.TimeLog = append(r.TimeLog, TimeEntry{Start: now})
+	return true
+}
+
+// StopTimer ends the running work session and returns its length, or false
+// if no timer was running
+func (r *Reminder) StopTimer(now time.Time) (time.Duration, bool) {
+	if !r.TimerRunning() {
+		return 0, false
+	}
+	entry := &r.TimeLog[len(r.TimeLog)-1]
+	entry.End = &now
+	return entry.Duration(now), true
+}
+
+// TrackedTime returns the total time tracked on the reminder, including a
+// running session
+func (r *Reminder) TrackedTime(now time.Time) time.Duration {
+	var total time.Duration
+	for _, entry := range r.TimeLog {
+		total += entry.Duration(now)
+	}
+	return total
+}
+
 // AddTag adds a tag to the reminder
 func (r *Reminder) AddTag(tag string) {
 	// Check if tag already exists
@@ -215,6 +885,106 @@ func (r *Reminder) HasTag(tag string) bool {
 	return false
 }
 
+// IsStale checks if an active reminder hasn't been touched, or checked in
+// on, for the given number of days
+func (r *Reminder) IsStale(days int) bool {
+	if r.Completed || r.Archived {
+		return false
+	}
+	touched := r.UpdatedAt
+	if r.LastCheckIn != nil && r.LastCheckIn.After(touched) {
+		touched = *r.LastCheckIn
+	}
+	return time.Since(touched) > time.Duration(days)*24*time.Hour
+}
+
+// CheckInFrequencies are how often a long-running reminder can ask for a
+// check-in
+var CheckInFrequencies = []string{"daily", "weekly", "biweekly", "monthly"}
+
+// ParseCheckIn validates a check-in frequency; "" and "off" turn check-ins off
+func ParseCheckIn(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "off" || s == "none" {
+		return "", nil
+	}
+	for _, frequency := range CheckInFrequencies {
+		if s == frequency {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid check-in '%s' (use %s or off)", s, strings.Join(CheckInFrequencies, ", "))
+}
+
+// NextCheckIn returns when the reminder next asks for a check-in: one
+// period after the last check-in, or after it was created. It returns false
+// for reminders without check-ins.
+func (r *Reminder) NextCheckIn() (time.Time, bool) {
+	last := r.CreatedAt
+	if r.LastCheckIn != nil {
+		last = *r.LastCheckIn
+	}
+	switch r.CheckIn {
+	case "daily":
+		return last.AddDate(0, 0, 1), true
+	case "weekly":
+		return last.AddDate(0, 0, 7), true
+	case "biweekly":
+		return last.AddDate(0, 0, 14), true
+	case "monthly":
+		return last.AddDate(0, 1, 0), true
+	}
+	return time.Time{}, false
+}
+
+// CheckInDue reports whether an active reminder is waiting for a check-in
+func (r *Reminder) CheckInDue(now time.Time) bool {
+	if r.Completed || r.Archived {
+		return false
+	}
+	next, ok := r.NextCheckIn()
+	return ok && !now.Before(next)
+}
+
+// CheckInText describes the check-in, e.g. "weekly (last Oct 3)"
+func (r *Reminder) CheckInText() string {
+	if r.LastCheckIn == nil {
+		return i18n.T(r.CheckIn)
+	}
+	return i18n.T("%s (last %s)", i18n.T(r.CheckIn), i18n.FormatTime(*r.LastCheckIn, "Jan 2"))
+}
+
+// RecordCheckIn notes that the user is still working on the reminder
+func (r *Reminder) RecordCheckIn(now time.Time) {
+	r.LastCheckIn = &now
+}
+
+// Archive hides the reminder from regular views without deleting it
+func (r *Reminder) Archive() {
+	if !r.Archived {
+		r.Archived = true
+		r.UpdatedAt = time.Now()
+	}
+}
+
+// IsAssignedTo checks if the reminder belongs to the given user.
+// Unassigned reminders belong to everyone.
+func (r *Reminder) IsAssignedTo(user string) bool {
+	return r.Assignee == "" || r.Assignee == user
+}
+
+// MatchesTag checks if the reminder has the tag or one of its descendants,
+// so "work" matches "work/clientA/billing"
+func (r *Reminder) MatchesTag(tag string) bool {
+	tag = strings.TrimSuffix(tag, "/")
+	for _, t := range r.Tags {
+		if t == tag || strings.HasPrefix(t, tag+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 // Status returns a human-readable status string
 func (r *Reminder) Status() string {
 	if r.Completed {
@@ -239,25 +1009,92 @@ func (r *Reminder) FormattedDueTime() string {
 
 	// Same day
 	if now.Year() == due.Year() && now.YearDay() == due.YearDay() {
-		return "Today " + due.Format("3:04 PM")
+		return i18n.T("Today") + " " + i18n.FormatTime(due, "3:04 PM")
 	}
 
 	// Tomorrow
 	tomorrow := now.AddDate(0, 0, 1)
 	if tomorrow.Year() == due.Year() && tomorrow.YearDay() == due.YearDay() {
-		return "Tomorrow " + due.Format("3:04 PM")
+		return i18n.T("Tomorrow") + " " + i18n.FormatTime(due, "3:04 PM")
 	}
 
 	// This week
 	if due.Sub(now) < 7*24*time.Hour && due.Sub(now) > 0 {
-		return due.Format("Monday 3:04 PM")
+		return i18n.FormatTime(due, "Monday 3:04 PM")
 	}
 
 	// This year
 	if now.Year() == due.Year() {
-		return due.Format("Jan 2 3:04 PM")
+		return i18n.FormatTime(due, "Jan 2 3:04 PM")
 	}
 
 	// Different year
-	return due.Format("Jan 2, 2006 3:04 PM")
+	return i18n.FormatTime(due, "Jan 2, 2006 3:04 PM")
+}
+
+// setLocation converts every time of the reminder to loc
+func (r *Reminder) setLocation(loc *time.Location) {
+	in := func(t *time.Time) {
+		if t != nil && !t.IsZero() {
+			*t = t.In(loc)
+		}
+	}
+
+	in(&r.DueTime)
+	in(r.CompletedAt)
+	in(&r.CreatedAt)
+	in(&r.UpdatedAt)
+	in(r.LastCheckIn)
+	if r.Recurring != nil {
+		in(r.Recurring.EndDate)
+	}
+	for i := range r.History {
+		in(&r.History[i].DueTime)
+		in(&r.History[i].At)
+	}
+	for i := range r.TimeLog {
+		in(&r.TimeLog[i].Start)
+		in(r.TimeLog[i].End)
+	}
+}
+
+// utcCopy returns a copy of the reminder with every time in UTC, sharing
+// nothing with the original that setLocation changes
+func (r *Reminder) utcCopy() *Reminder {
+	c := r.Clone()
+	c.setLocation(time.UTC)
+	return c
+}
+
+// Clone returns a copy of the reminder sharing no times, history or
+// recurrence with the original, so completing or skipping one leaves the
+// other as it was
+func (r *Reminder) Clone() *Reminder {
+	c := *r
+	if r.CompletedAt != nil {
+		completedAt := *r.CompletedAt
+		c.CompletedAt = &completedAt
+	}
+	if r.LastCheckIn != nil {
+		lastCheckIn := *r.LastCheckIn
+		c.LastCheckIn = &lastCheckIn
+	}
+	if r.Recurring != nil {
+		recurring := *r.Recurring
+		if recurring.EndDate != nil {
+			endDate := *recurring.EndDate
+			recurring.EndDate = &endDate
+		}
+		c.Recurring = &recurring
+	}
+	c.History = append([]Occurrence(nil), r.History...)
+	c.TimeLog = make([]TimeEntry, len(r.TimeLog))
+	for i, entry := range r.TimeLog {
+		if entry.End != nil {
+			end := *entry.End
+			entry.End = &end
+		}
+		c.TimeLog[i] = entry
+	}
+	return &c
 }
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRemote is returned for operations that only work on a local reminders
+// file
+var ErrRemote = errors.New("not possible on a remote store")
+
+// remotePollInterval is the shortest interval Watch asks a remote store for
+// changes at
+const remotePollInterval = 15 * time.Second
+
+// Remote keeps a store's reminders somewhere other than a local file, such
+// as a 'nancy serve' instance
+type Remote interface {
+	// Fetch returns every reminder as the JSON array of the reminders file
+	Fetch() ([]byte, error)
+	// Put adds or replaces a reminder and returns it as stored
+	Put(reminder *Reminder) (*Reminder, error)
+	// Delete removes a reminder
+	Delete(id string) error
+}
+
+// NewRemoteStore creates a store whose reminders are kept by remote. Every
+// change is sent to it straight away.
+func NewRemoteStore(remote Remote) (*Store, error) {
+	store := &Store{
+		remote:    remote,
+		reminders: make(map[string]*Reminder),
+		cold:      make(map[string]coldRecord),
+	}
+
+	if err := store.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load reminders: %w", err)
+	}
+
+	return store, nil
+}
+
+// IsRemote reports whether the store's reminders are kept by a remote
+func (s *Store) IsRemote() bool {
+	return s.remote != nil
+}
+
+// loadRemote replaces the reminders with the ones the remote has
+func (s *Store) loadRemote() error {
+	data, err := s.remote.Fetch()
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.recordRemoteStamp(data)
+	if len(bytes.TrimSpace(data)) == 0 {
+		s.reminders = make(map[string]*Reminder)
+		s.cold = make(map[string]coldRecord)
+		return nil
+	}
+	return s.parse(data)
+}
+
+// pushRemote sends the reminders with the given IDs to the remote, or every
+// reminder for nil, and deletes the ones that are gone
+func (s *Store) pushRemote(ids []string) error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	s.mutex.Lock()
+	if ids == nil {
+		s.decodeCold()
+		for id := range s.reminders {
+			ids = append(ids, id)
+		}
+	}
+	changed := make(map[string]*Reminder, len(ids))
+	for _, id := range ids {
+		if reminder, exists := s.lookup(id); exists {
+			reminderCopy := *reminder
+			changed[id] = &reminderCopy
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, id := range ids {
+		reminder, exists := changed[id]
+		if !exists {
+			if err := s.remote.Delete(id); err != nil {
+				return err
+			}
+			continue
+		}
+
+		stored, err := s.remote.Put(reminder)
+		if err != nil {
+			return err
+		}
+		// The remote numbers new reminders itself, which differs from ours
+		// when another client added one in the meantime
+		if stored.ShortID != reminder.ShortID {
+			s.mutex.Lock()
+			if current, exists := s.reminders[id]; exists {
+				current.ShortID = stored.ShortID
+			}
+			s.mutex.Unlock()
+		}
+	}
+	return nil
+}
+
+// watchRemote is Watch for a remote store: it asks the remote for its
+// reminders every interval, but no more often than remotePollInterval
+func (s *Store) watchRemote(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(max(interval, remotePollInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		data, err := s.remote.Fetch()
+		if err != nil || remoteStamp(data) == s.lastStamp() {
+			// Unreachable for now, or unchanged
+			continue
+		}
+
+		s.mutex.Lock()
+		err = s.parse(data)
+		if err == nil {
+			s.recordRemoteStamp(data)
+		}
+		s.mutex.Unlock()
+		if err == nil {
+			s.publish(EventReloaded, "")
+		}
+	}
+}
+
+// remoteStamp identifies a version of a remote store's reminders
+func remoteStamp(data []byte) fileStamp {
+	return fileStamp{size: int64(len(data)), sum: sha256.Sum256(data)}
+}
+
+// recordRemoteStamp remembers the version of the remote's reminders last
+// loaded
+func (s *Store) recordRemoteStamp(data []byte) {
+	s.eventMutex.Lock()
+	s.stamp = remoteStamp(data)
+	s.eventMutex.Unlock()
+}
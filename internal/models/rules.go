@@ -0,0 +1,209 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule actions
+const (
+	RuleArchive = "archive"
+	RuleDelete  = "delete"
+)
+
+// Rule is a housekeeping rule like "tag=errand and overdue > 30d -> delete":
+// conditions joined with "and", then "->" (or "→") and an action
+type Rule struct {
+	Text       string
+	Action     string
+	conditions []ruleCondition
+}
+
+// ruleCondition is one condition of a rule. Age conditions ("completed",
+// "overdue", "untouched") compare how long ago that happened with age; the
+// others ("tag", "priority") compare a value.
+type ruleCondition struct {
+	field string
+	value string
+	age   time.Duration
+}
+
+// RuleMatch is a reminder a rule applies to
+type RuleMatch struct {
+	Reminder *Reminder
+	Rule     *Rule
+}
+
+// ParseRule parses a rule. Conditions are "completed", "overdue" or
+// "untouched" with an optional "> 7d" (d, w or h), "tag=NAME" and
+// "priority=LEVEL"; actions are "archive" and "delete".
+func ParseRule(text string) (*Rule, error) {
+	text = strings.TrimSpace(text)
+	lhs, action, ok := strings.Cut(strings.ReplaceAll(text, "→", "->"), "->")
+	if !ok {
+		return nil, fmt.Errorf("rule %q has no action (use: condition -> action)", text)
+	}
+
+	rule := &Rule{Text: text, Action: strings.ToLower(strings.TrimSpace(action))}
+	if rule.Action != RuleArchive && rule.Action != RuleDelete {
+		return nil, fmt.Errorf("rule %q: unknown action %q (use archive or delete)", text, rule.Action)
+	}
+
+	for _, part := range strings.Split(lhs, " and ") {
+		condition, err := parseRuleCondition(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", text, err)
+		}
+		rule.conditions = append(rule.conditions, condition)
+	}
+	return rule, nil
+}
+
+// ParseRules parses a list of rules, stopping at the first invalid one
+func ParseRules(texts []string) ([]*Rule, error) {
+	rules := make([]*Rule, 0, len(texts))
+	for _, text := range texts {
+		rule, err := ParseRule(text)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parseRuleCondition parses one condition like "overdue > 30d" or "tag=errand"
+func parseRuleCondition(text string) (ruleCondition, error) {
+	if field, value, ok := strings.Cut(text, "="); ok {
+		condition := ruleCondition{field: strings.ToLower(strings.TrimSpace(field)), value: strings.TrimSpace(value)}
+		switch condition.field {
+		case "tag":
+			condition.value = strings.TrimPrefix(condition.value, "#")
+		case "priority":
+			if ParsePriority(condition.value).String() != strings.ToLower(condition.value) {
+				return ruleCondition{}, fmt.Errorf("unknown priority %q", condition.value)
+			}
+			condition.value = strings.ToLower(condition.value)
+		default:
+			return ruleCondition{}, fmt.Errorf("unknown condition %q (use tag= or priority=)", field)
+		}
+		if condition.value == "" {
+			return ruleCondition{}, fmt.Errorf("condition %q has no value", text)
+		}
+		return condition, nil
+	}
+
+	field, age, hasAge := strings.Cut(text, ">")
+	condition := ruleCondition{field: strings.ToLower(strings.TrimSpace(field))}
+	switch condition.field {
+	case "completed", "overdue", "untouched":
+	default:
+		return ruleCondition{}, fmt.Errorf("unknown condition %q (use completed, overdue, untouched, tag= or priority=)", text)
+	}
+	if hasAge {
+		d, err := parseRuleAge(strings.TrimSpace(age))
+		if err != nil {
+			return ruleCondition{}, err
+		}
+		condition.age = d
+	}
+	return condition, nil
+}
+
+// parseRuleAge parses an age like "7d", "2w" or "12h"
+func parseRuleAge(text string) (time.Duration, error) {
+	units := map[byte]time.Duration{'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if len(text) >= 2 {
+		if unit, ok := units[text[len(text)-1]]; ok {
+			if n, err := strconv.Atoi(text[:len(text)-1]); err == nil && n >= 0 {
+				return time.Duration(n) * unit, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid age %q (use e.g. 12h, 7d or 2w)", text)
+}
+
+// Matches reports whether all of the rule's conditions hold for the reminder
+func (r *Rule) Matches(reminder *Reminder, now time.Time) bool {
+	for _, condition := range r.conditions {
+		if !condition.matches(reminder, now) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c ruleCondition) matches(reminder *Reminder, now time.Time) bool {
+	switch c.field {
+	case "tag":
+		return reminder.MatchesTag(c.value)
+	case "priority":
+		return reminder.Priority.String() == c.value
+	case "completed":
+		return reminder.Completed && reminder.CompletedAt != nil && now.Sub(*reminder.CompletedAt) > c.age
+	case "overdue":
+		return !reminder.Completed && now.Sub(reminder.DueTime) > c.age
+	case "untouched":
+		return now.Sub(reminder.UpdatedAt) > c.age
+	}
+	return false
+}
+
+// MatchRules returns the reminders a rule applies to, each with the first
+// rule that matches it. Archived reminders are only matched by delete rules.
+func MatchRules(reminders []*Reminder, rules []*Rule, now time.Time) []RuleMatch {
+	var matches []RuleMatch
+	for _, reminder := range reminders {
+		for _, rule := range rules {
+			if reminder.Archived && rule.Action == RuleArchive {
+				continue
+			}
+			if rule.Matches(reminder, now) {
+				matches = append(matches, RuleMatch{Reminder: reminder, Rule: rule})
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// ApplyRules archives or deletes the reminders the rules match, saves once
+// and returns what was done
+func (s *Store) ApplyRules(rules []*Rule, now time.Time) ([]RuleMatch, error) {
+	if s.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
+
+	matches := MatchRules(s.GetAll(&FilterOptions{ShowCompleted: true, ShowArchived: true}), rules, now)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	var archived, deleted []string
+	s.mutex.Lock()
+	for _, match := range matches {
+		switch match.Rule.Action {
+		case RuleArchive:
+			// GetAll returns copies; archive the stored reminder too
+			if reminder, ok := s.reminders[match.Reminder.ID]; ok {
+				reminder.Archive()
+			}
+			match.Reminder.Archive()
+			archived = append(archived, match.Reminder.ID)
+		case RuleDelete:
+			delete(s.reminders, match.Reminder.ID)
+			s.bury(match.Reminder.ID, time.Now())
+			deleted = append(deleted, match.Reminder.ID)
+		}
+	}
+	s.mutex.Unlock()
+
+	if err := s.SaveChanged(append(archived, deleted...)...); err != nil {
+		return matches, err
+	}
+	s.publish(EventUpdated, archived...)
+	s.publish(EventDeleted, deleted...)
+	return matches, nil
+}
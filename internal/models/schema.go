@@ -0,0 +1,305 @@
+package models
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReminderSchema is the published JSON Schema for the export/import format
+//
+//go:embed reminders.schema.json
+var ReminderSchema []byte
+
+// schemaNode is the subset of JSON Schema used by ReminderSchema
+type schemaNode struct {
+	Type                 schemaTypes            `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	Defs                 map[string]*schemaNode `json:"$defs"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinLength            *int                   `json:"minLength"`
+	Pattern              string                 `json:"pattern"`
+	Format               string                 `json:"format"`
+}
+
+// schemaTypes accepts both "type": "string" and "type": ["string", "null"]
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*t = multiple
+	return nil
+}
+
+// schemaProblem is a single validation failure inside one reminder
+type schemaProblem struct {
+	field   string // top-level property the problem belongs to, if any
+	message string
+}
+
+// ValidationError lists every problem found while validating import data
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid reminder data:\n  %s", strings.Join(e.Problems, "\n  "))
+}
+
+// ValidateReminders checks JSON data against ReminderSchema.
+// Problems are reported with the line number they occur on.
+func ValidateReminders(data []byte) error {
+	var schema schemaNode
+	if err := json.Unmarshal(ReminderSchema, &schema); err != nil {
+		return fmt.Errorf("failed to load reminder schema: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return syntaxProblem(data, err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return &ValidationError{Problems: []string{"line 1: expected an array of reminders"}}
+	}
+
+	var problems []string
+	for index := 0; dec.More(); index++ {
+		start := skipSeparators(data, int(dec.InputOffset()))
+
+		var item interface{}
+		if err := dec.Decode(&item); err != nil {
+			return syntaxProblem(data, err)
+		}
+		raw := data[start:dec.InputOffset()]
+
+		// Items are reminders or tombstones, told apart by deleted_at
+		// rather than by trying both, so problems are reported for one
+		node := schema.Defs["reminder"]
+		if object, ok := item.(map[string]interface{}); ok {
+			if _, ok := object["deleted_at"]; ok {
+				node = schema.Defs["tombstone"]
+			}
+		}
+		for _, p := range node.validate(item, "") {
+			line := lineAt(data, start)
+			if p.field != "" {
+				if i := bytes.Index(raw, []byte(`"`+p.field+`"`)); i >= 0 {
+					line = lineAt(data, start+i)
+				}
+			}
+			problems = append(problems, fmt.Sprintf("line %d: reminder %d: %s", line, index+1, p.message))
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return syntaxProblem(data, err)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validate checks a decoded JSON value against the schema node
+func (n *schemaNode) validate(value interface{}, path string) []schemaProblem {
+	if n == nil {
+		return nil
+	}
+
+	field := path
+	if i := strings.IndexAny(field, ".["); i >= 0 {
+		field = field[:i]
+	}
+	fail := func(format string, args ...interface{}) []schemaProblem {
+		message := fmt.Sprintf(format, args...)
+		if path != "" {
+			message = fmt.Sprintf("%s: %s", path, message)
+		}
+		return []schemaProblem{{field: field, message: message}}
+	}
+
+	if len(n.Type) > 0 && !n.Type.matches(value) {
+		return fail("expected %s, got %s", strings.Join(n.Type, " or "), jsonTypeName(value))
+	}
+
+	if len(n.Enum) > 0 {
+		found := false
+		for _, allowed := range n.Enum {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fail("must be one of %v", n.Enum)
+		}
+	}
+
+	var problems []schemaProblem
+	switch v := value.(type) {
+	case string:
+		if n.MinLength != nil && len(v) < *n.MinLength {
+			return fail("must not be empty")
+		}
+		if n.Pattern != "" {
+			if matched, err := regexp.MatchString(n.Pattern, v); err != nil || !matched {
+				return fail("%q doesn't match %s", v, n.Pattern)
+			}
+		}
+		if n.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				return fail("invalid date-time %q (expected RFC 3339, e.g. 2024-03-20T15:04:05Z)", v)
+			}
+		}
+
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return fail("invalid number %s", v)
+		}
+		if n.Minimum != nil && f < *n.Minimum {
+			return fail("must be at least %v", *n.Minimum)
+		}
+		if n.Maximum != nil && f > *n.Maximum {
+			return fail("must be at most %v", *n.Maximum)
+		}
+
+	case map[string]interface{}:
+		for _, key := range n.Required {
+			if _, ok := v[key]; !ok {
+				problems = append(problems, fail("missing required field %q", joinPath(path, key))...)
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			child, ok := n.Properties[key]
+			if !ok {
+				if n.AdditionalProperties != nil && !*n.AdditionalProperties {
+					problems = append(problems, schemaProblem{
+						field:   firstNonEmpty(field, key),
+						message: fmt.Sprintf("unknown field %q", joinPath(path, key)),
+					})
+				}
+				continue
+			}
+			problems = append(problems, child.validate(v[key], joinPath(path, key))...)
+		}
+
+	case []interface{}:
+		for i, item := range v {
+			problems = append(problems, n.Items.validate(item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+
+	return problems
+}
+
+// matches reports whether value has one of the allowed JSON types
+func (t schemaTypes) matches(value interface{}) bool {
+	actual := jsonTypeName(value)
+	for _, allowed := range t {
+		if allowed == actual {
+			return true
+		}
+		if allowed == "number" && actual == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonTypeName returns the JSON Schema type name of a decoded value
+func jsonTypeName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// syntaxProblem converts a JSON decoding error into a line-numbered error
+func syntaxProblem(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &ValidationError{Problems: []string{
+			fmt.Sprintf("line %d: %v", lineAt(data, int(syntaxErr.Offset)), err),
+		}}
+	}
+	return &ValidationError{Problems: []string{err.Error()}}
+}
+
+// lineAt returns the 1-based line number of a byte offset
+func lineAt(data []byte, offset int) int {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// skipSeparators advances past whitespace and commas between array elements
+func skipSeparators(data []byte, offset int) int {
+	for offset < len(data) && strings.ContainsRune(" \t\r\n,", rune(data[offset])) {
+		offset++
+	}
+	return offset
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -1,20 +1,101 @@
 package models
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
 )
 
+// ErrReadOnly is returned when modifying a store opened read-only
+var ErrReadOnly = errors.New("store is opened read-only")
+
 // Store handles data persistence for reminders
 type Store struct {
 	filePath  string
+	remote    Remote // Where the reminders are kept instead of filePath, if set
 	reminders map[string]*Reminder
+	cold      map[string]coldRecord // Old completed and archived reminders, not decoded yet
 	mutex     sync.RWMutex
+	saveMutex sync.Mutex // Held while writing the file and rotating backups, which Save does under a read lock
+	readOnly  bool
+	memory    bool // Reminders live only in memory, nothing is read or written
+	backups   int  // Copies of the file to keep, see rotateBackups
+
+	tombstones map[string]time.Time // When reminders were deleted, by ID; see Tombstone
+
+	// Event subscribers and the file version last loaded or saved, see
+	// events.go
+	eventMutex     sync.Mutex
+	subscribers    map[int]chan Event
+	nextSubscriber int
+	stamp          fileStamp
+
+	// Records with an ID seen before that Load dropped: exact copies, and
+	// conflicting copies that lost to a more recently updated one
+	duplicates int
+	conflicts  int
+}
+
+// coldRecord is a completed or archived reminder Load left undecoded. Years
+// of history can hold tens of thousands of them, and most commands never
+// look at them.
+type coldRecord struct {
+	raw       json.RawMessage
+	shortID   int
+	completed bool
+	archived  bool
+}
+
+// recordHeader is the part of a stored reminder Load decodes up front to
+// decide whether the rest can wait
+type recordHeader struct {
+	ID        string          `json:"id"`
+	ShortID   int             `json:"short_id"`
+	Priority  json.RawMessage `json:"priority"`
+	Completed bool            `json:"completed"`
+	Archived  bool            `json:"archived"`
+	DueTime   time.Time       `json:"due_time"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	DeletedAt *time.Time      `json:"deleted_at"` // Set for tombstones only
+	TimeLog   []struct {
+		End *time.Time `json:"end"`
+	} `json:"time_log"`
+}
+
+// changed returns when the record last changed: when the reminder was
+// updated, or deleted for a tombstone
+func (h *recordHeader) changed() time.Time {
+	if h.DeletedAt != nil {
+		return *h.DeletedAt
+	}
+	return h.UpdatedAt
+}
+
+// cold reports whether a reminder can stay undecoded: it is completed or
+// archived, has a short ID, and nothing about it (due time, changes, time
+// tracked) falls on or after since. Today's progress and tracked time then
+// never need it.
+func (h *recordHeader) cold(since time.Time) bool {
+	if !h.Completed && !h.Archived {
+		return false
+	}
+	if h.ShortID <= 0 || !h.DueTime.Before(since) || !h.UpdatedAt.Before(since) {
+		return false
+	}
+	for _, entry := range h.TimeLog {
+		if entry.End == nil || !entry.End.Before(since) {
+			return false
+		}
+	}
+	return true
 }
 
 // FilterOptions defines options for filtering reminders
@@ -24,11 +105,30 @@ type FilterOptions struct {
 	DueToday      bool
 	Overdue       bool
 	Tags          []string
+	AllTags       bool   // Reminders must have every one of Tags, not just one
+	Assignee      string // Only reminders for this user (plus unassigned ones)
+	ShowArchived  bool
+	StaleDays     int       // Only active reminders untouched for this many days
+	DueFrom       time.Time // Only reminders due at or after this time, if set
+	DueBefore     time.Time // Only reminders due before this time, if set
+	Offset        int       // Skip this many reminders, for paging with Limit
 	Limit         int
 }
 
 // NewStore creates a new store instance
 func NewStore(dataDir string) (*Store, error) {
+	return openStore(dataDir, false)
+}
+
+// NewReadOnlyStore creates a store that rejects writes. Unlike SetReadOnly on
+// a store already loaded, it also keeps Load from rewriting a file in an old
+// format, so the data directory is never touched.
+func NewReadOnlyStore(dataDir string) (*Store, error) {
+	return openStore(dataDir, true)
+}
+
+// openStore creates a store for dataDir and loads it
+func openStore(dataDir string, readOnly bool) (*Store, error) {
 	// Ensure data directory exists
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
@@ -37,7 +137,10 @@ func NewStore(dataDir string) (*Store, error) {
 	filePath := filepath.Join(dataDir, "reminders.json")
 	store := &Store{
 		filePath:  filePath,
+		backups:   DefaultBackups,
+		readOnly:  readOnly,
 		reminders: make(map[string]*Reminder),
+		cold:      make(map[string]coldRecord),
 	}
 
 	// Load existing data
@@ -48,8 +151,51 @@ func NewStore(dataDir string) (*Store, error) {
 	return store, nil
 }
 
+// NewMemoryStore creates a store holding reminders that is never read from
+// or written to disk, for trying Nancy out without touching real data
+func NewMemoryStore(reminders []*Reminder) *Store {
+	store := &Store{
+		memory:    true,
+		reminders: make(map[string]*Reminder),
+		cold:      make(map[string]coldRecord),
+	}
+	for _, reminder := range reminders {
+		store.reminders[reminder.ID] = reminder
+		if reminder.ShortID <= 0 {
+			reminder.ShortID = store.nextShortID()
+		}
+	}
+	return store
+}
+
+// IsMemory reports whether the store keeps its reminders only in memory
+func (s *Store) IsMemory() bool {
+	return s.memory
+}
+
+// SetReadOnly prevents any further writes to the store
+func (s *Store) SetReadOnly(readOnly bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.readOnly = readOnly
+}
+
+// IsReadOnly reports whether the store rejects writes
+func (s *Store) IsReadOnly() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.readOnly
+}
+
 // Load reads reminders from file
 func (s *Store) Load() error {
+	if s.remote != nil {
+		return s.loadRemote()
+	}
+	if s.memory {
+		return nil
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
@@ -65,39 +211,294 @@ func (s *Store) Load() error {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
+	s.recordStamp()
+
 	// Handle empty file
 	if len(data) == 0 {
 		return nil
 	}
 
-	// Parse JSON
-	var reminders []*Reminder
-	if err := json.Unmarshal(data, &reminders); err != nil {
+	return s.parse(data)
+}
+
+// parse replaces the reminders with the ones in data, the JSON array of the
+// reminders file; the caller must hold the mutex
+func (s *Store) parse(data []byte) error {
+	// Parse JSON, leaving each reminder undecoded until its header is read
+	var records []json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
 		return fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	// Convert slice to map for efficient lookups
+	// Read the headers, dropping records whose ID was seen before
+	headers := make([]recordHeader, 0, len(records))
+	kept := make([]json.RawMessage, 0, len(records))
+	seen := make(map[string]int, len(records))
+	s.duplicates, s.conflicts = 0, 0
+	for _, raw := range records {
+		if string(raw) == "null" {
+			continue
+		}
+
+		var header recordHeader
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		i, dup := seen[header.ID]
+		if !dup {
+			seen[header.ID] = len(kept)
+			headers = append(headers, header)
+			kept = append(kept, raw)
+			continue
+		}
+		if sameJSON(raw, kept[i]) {
+			s.duplicates++
+			continue
+		}
+		s.conflicts++
+		if header.changed().After(headers[i].changed()) {
+			headers[i], kept[i] = header, raw
+		}
+	}
+
+	// Decode what's current now and keep old completed and archived
+	// reminders for later, unless the file needs migrating
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	s.reminders = make(map[string]*Reminder)
-	for _, reminder := range reminders {
-		if reminder != nil {
-			s.reminders[reminder.ID] = reminder
+	s.cold = make(map[string]coldRecord)
+	s.tombstones = make(map[string]time.Time)
+	usedShortIDs := make(map[int]bool, len(kept))
+	legacy := false
+	for i, raw := range kept {
+		header := &headers[i]
+		if header.DeletedAt != nil {
+			s.tombstones[header.ID] = *header.DeletedAt
+			continue
+		}
+		if len(header.Priority) > 0 && header.Priority[0] != '"' {
+			legacy = true
+		}
+		if header.ShortID > 0 && usedShortIDs[header.ShortID] {
+			legacy = true
+		}
+		usedShortIDs[header.ShortID] = true
+
+		if header.cold(midnight) {
+			s.cold[header.ID] = coldRecord{raw: raw, shortID: header.ShortID, completed: header.Completed, archived: header.Archived}
+			continue
+		}
+		reminder, err := decodeReminder(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse JSON: %w", err)
 		}
+		s.reminders[reminder.ID] = reminder
+	}
+
+	// Rewrite files that still use integer priorities or lack short IDs.
+	// This is best effort: shared stores may not be writable, and the data
+	// is already loaded.
+	if legacy {
+		s.decodeCold()
+	}
+	assigned := s.assignShortIDs()
+	if !s.readOnly && s.remote == nil && (assigned || legacy) {
+		_ = s.write()
 	}
 
 	return nil
 }
 
-// Save writes reminders to file
+// loadCold decodes the reminders Load left undecoded, for methods that need
+// every reminder
+func (s *Store) loadCold() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.decodeCold()
+}
+
+// decodeCold decodes every cold record; the caller must hold the mutex.
+// Records that fail to decode stay cold, so they are saved unchanged.
+func (s *Store) decodeCold() {
+	for id := range s.cold {
+		s.thaw(id)
+	}
+}
+
+// thaw decodes the cold record with the given ID into the store and returns
+// it, or nil; the caller must hold the mutex
+func (s *Store) thaw(id string) *Reminder {
+	record, ok := s.cold[id]
+	if !ok {
+		return nil
+	}
+	reminder, err := decodeReminder(record.raw)
+	if err != nil {
+		return nil
+	}
+	delete(s.cold, id)
+	s.reminders[id] = reminder
+	return reminder
+}
+
+// lookup returns the reminder with the given ID, decoding it if it is
+// cold; the caller must hold the mutex
+func (s *Store) lookup(id string) (*Reminder, bool) {
+	if reminder, exists := s.reminders[id]; exists {
+		return reminder, true
+	}
+	reminder := s.thaw(id)
+	return reminder, reminder != nil
+}
+
+// decodeCopy decodes a cold record without keeping it, for readers that
+// only hold the read lock
+func (record coldRecord) decodeCopy() (*Reminder, error) {
+	reminder, err := decodeReminder(record.raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reminder: %w", err)
+	}
+	return reminder, nil
+}
+
+// decodeReminder decodes a stored reminder with its times in local time,
+// which the display and recurrence code expect
+func decodeReminder(raw json.RawMessage) (*Reminder, error) {
+	reminder := &Reminder{}
+	if err := json.Unmarshal(raw, reminder); err != nil {
+		return nil, err
+	}
+	reminder.setLocation(time.Local)
+	return reminder, nil
+}
+
+// sameJSON reports whether two JSON values are identical apart from
+// whitespace
+func sameJSON(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return false
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// assignShortIDs gives every reminder without a unique short ID the next free
+// number, oldest first. The reminders with the IDs in newcomers, like those
+// just imported, give way to the others when their short IDs clash. The
+// caller must hold the mutex.
+func (s *Store) assignShortIDs(newcomers ...string) bool {
+	used := make(map[int]bool)
+	var missing []*Reminder
+	next := 1
+
+	// Reminders not decoded yet keep theirs
+	for _, record := range s.cold {
+		used[record.shortID] = true
+		if record.shortID >= next {
+			next = record.shortID + 1
+		}
+	}
+
+	// Keep existing IDs stable; stable order makes collisions deterministic
+	isNew := make(map[string]bool, len(newcomers))
+	for _, id := range newcomers {
+		isNew[id] = true
+	}
+	ordered := make([]*Reminder, 0, len(s.reminders))
+	for _, reminder := range s.reminders {
+		ordered = append(ordered, reminder)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if isNew[ordered[i].ID] != isNew[ordered[j].ID] {
+			return !isNew[ordered[i].ID]
+		}
+		if !ordered[i].CreatedAt.Equal(ordered[j].CreatedAt) {
+			return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	for _, reminder := range ordered {
+		if reminder.ShortID <= 0 || used[reminder.ShortID] {
+			missing = append(missing, reminder)
+			continue
+		}
+		used[reminder.ShortID] = true
+		if reminder.ShortID >= next {
+			next = reminder.ShortID + 1
+		}
+	}
+
+	for _, reminder := range missing {
+		reminder.ShortID = next
+		next++
+	}
+
+	return len(missing) > 0
+}
+
+// Save writes reminders to file, or sends every one of them to a remote
+// store
 func (s *Store) Save() error {
+	if s.remote != nil {
+		return s.pushRemote(nil)
+	}
+
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	// Convert map to slice for JSON serialization
-	reminders := make([]*Reminder, 0, len(s.reminders))
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	return s.write()
+}
+
+// SaveChanged persists the reminders with the given IDs after they were
+// added, changed or deleted. The JSON file can only be rewritten whole, so
+// this saves everything; a remote store sends just these.
+func (s *Store) SaveChanged(ids ...string) error {
+	if s.remote != nil {
+		return s.pushRemote(ids)
+	}
+	return s.Save()
+}
+
+// write serializes all reminders to file; the caller must hold the mutex
+func (s *Store) write() error {
+	if s.memory {
+		return nil
+	}
+
+	// Store times in UTC and keep records in short ID order, so the file
+	// only changes where reminders did. Cold records are written back as
+	// they were read.
+	type entry struct {
+		shortID int
+		id      string
+		value   any
+	}
+	entries := make([]entry, 0, len(s.reminders)+len(s.cold))
 	for _, reminder := range s.reminders {
 		if reminder != nil {
-			reminders = append(reminders, reminder)
+			entries = append(entries, entry{reminder.ShortID, reminder.ID, reminder.utcCopy()})
+		}
+	}
+	for id, record := range s.cold {
+		entries = append(entries, entry{record.shortID, id, record.raw})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].shortID != entries[j].shortID {
+			return entries[i].shortID < entries[j].shortID
 		}
+		return entries[i].id < entries[j].id
+	})
+	reminders := make([]any, len(entries))
+	for i, e := range entries {
+		reminders[i] = e.value
+	}
+	for _, tombstone := range s.liveTombstones(time.Now()) {
+		reminders = append(reminders, tombstone)
 	}
 
 	// Marshal to JSON with indentation for readability
@@ -106,10 +507,18 @@ func (s *Store) Save() error {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
-	// Write to file with proper permissions
-	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+	// Keep the file as it was, then replace it whole so a crash mid-write
+	// can't leave half of it. Concurrent saves take turns, or they would
+	// lose backups renaming them at the same time.
+	s.saveMutex.Lock()
+	defer s.saveMutex.Unlock()
+	if err := s.rotateBackups(false); err != nil {
+		return err
+	}
+	if err := writeAtomic(s.filePath, data, s.filePath); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
+	s.recordStamp()
 
 	return nil
 }
@@ -119,12 +528,64 @@ func (s *Store) Add(reminder *Reminder) error {
 	if reminder == nil {
 		return fmt.Errorf("reminder cannot be nil")
 	}
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
 
 	s.mutex.Lock()
 	s.reminders[reminder.ID] = reminder
+	delete(s.tombstones, reminder.ID)
+	if reminder.ShortID <= 0 {
+		reminder.ShortID = s.nextShortID()
+	}
 	s.mutex.Unlock()
 
-	return s.Save()
+	return s.commit(EventAdded, reminder.ID)
+}
+
+// AddAll adds several reminders and saves once, so large imports don't
+// rewrite the store for every reminder
+func (s *Store) AddAll(reminders []*Reminder) error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	ids := make([]string, 0, len(reminders))
+	s.mutex.Lock()
+	for _, reminder := range reminders {
+		if reminder == nil {
+			continue
+		}
+		s.reminders[reminder.ID] = reminder
+		delete(s.tombstones, reminder.ID)
+		if reminder.ShortID <= 0 {
+			reminder.ShortID = s.nextShortID()
+		}
+		ids = append(ids, reminder.ID)
+	}
+	s.mutex.Unlock()
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.commit(EventAdded, ids...)
+}
+
+// nextShortID returns one more than the highest short ID in use; the caller
+// must hold the mutex
+func (s *Store) nextShortID() int {
+	highest := 0
+	for _, reminder := range s.reminders {
+		if reminder.ShortID > highest {
+			highest = reminder.ShortID
+		}
+	}
+	for _, record := range s.cold {
+		if record.shortID > highest {
+			highest = record.shortID
+		}
+	}
+	return highest + 1
 }
 
 // Get retrieves a reminder by ID
@@ -134,7 +595,11 @@ func (s *Store) Get(id string) (*Reminder, error) {
 
 	reminder, exists := s.reminders[id]
 	if !exists {
-		return nil, fmt.Errorf("reminder with ID %s not found", id)
+		record, cold := s.cold[id]
+		if !cold {
+			return nil, fmt.Errorf("reminder with ID %s not found", id)
+		}
+		return record.decodeCopy()
 	}
 
 	// Return a copy to prevent external modification
@@ -142,14 +607,37 @@ func (s *Store) Get(id string) (*Reminder, error) {
 	return &reminderCopy, nil
 }
 
+// GetByShortID retrieves a reminder by its short numeric ID
+func (s *Store) GetByShortID(shortID int) (*Reminder, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, reminder := range s.reminders {
+		if reminder.ShortID == shortID {
+			reminderCopy := *reminder
+			return &reminderCopy, nil
+		}
+	}
+	for _, record := range s.cold {
+		if record.shortID == shortID {
+			return record.decodeCopy()
+		}
+	}
+
+	return nil, fmt.Errorf("reminder #%d not found", shortID)
+}
+
 // Update updates an existing reminder
 func (s *Store) Update(reminder *Reminder) error {
 	if reminder == nil {
 		return fmt.Errorf("reminder cannot be nil")
 	}
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
 
 	s.mutex.Lock()
-	_, exists := s.reminders[reminder.ID]
+	_, exists := s.lookup(reminder.ID)
 	if !exists {
 		s.mutex.Unlock()
 		return fmt.Errorf("reminder with ID %s not found", reminder.ID)
@@ -159,26 +647,71 @@ func (s *Store) Update(reminder *Reminder) error {
 	s.reminders[reminder.ID] = reminder
 	s.mutex.Unlock()
 
-	return s.Save()
+	return s.commit(EventUpdated, reminder.ID)
+}
+
+// UpdateAll updates several existing reminders and saves once. Nothing is
+// changed if any of them doesn't exist.
+func (s *Store) UpdateAll(reminders []*Reminder) error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	s.mutex.Lock()
+	for _, reminder := range reminders {
+		if reminder == nil {
+			s.mutex.Unlock()
+			return fmt.Errorf("reminder cannot be nil")
+		}
+		if _, exists := s.lookup(reminder.ID); !exists {
+			s.mutex.Unlock()
+			return fmt.Errorf("reminder with ID %s not found", reminder.ID)
+		}
+	}
+
+	if len(reminders) == 0 {
+		s.mutex.Unlock()
+		return nil
+	}
+	ids := make([]string, len(reminders))
+	now := time.Now()
+	for i, reminder := range reminders {
+		reminder.UpdatedAt = now
+		s.reminders[reminder.ID] = reminder
+		ids[i] = reminder.ID
+	}
+	s.mutex.Unlock()
+
+	return s.commit(EventUpdated, ids...)
 }
 
 // Delete removes a reminder from the store
 func (s *Store) Delete(id string) error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+
 	s.mutex.Lock()
-	_, exists := s.reminders[id]
+	_, exists := s.lookup(id)
 	if !exists {
 		s.mutex.Unlock()
 		return fmt.Errorf("reminder with ID %s not found", id)
 	}
 
 	delete(s.reminders, id)
+	s.bury(id, time.Now())
 	s.mutex.Unlock()
 
-	return s.Save()
+	return s.commit(EventDeleted, id)
 }
 
 // GetAll returns all reminders with optional filtering
 func (s *Store) GetAll(filter *FilterOptions) []*Reminder {
+	// Old completed and archived reminders are only decoded when asked for
+	if filter == nil || filter.ShowCompleted || filter.ShowArchived {
+		s.loadCold()
+	}
+
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -207,28 +740,65 @@ func (s *Store) GetAll(filter *FilterOptions) []*Reminder {
 				continue
 			}
 
+			if filter.Assignee != "" && !reminder.IsAssignedTo(filter.Assignee) {
+				continue
+			}
+
+			if !filter.ShowArchived && reminder.Archived {
+				continue
+			}
+
+			if filter.StaleDays > 0 && !reminder.IsStale(filter.StaleDays) {
+				continue
+			}
+
+			if !filter.DueFrom.IsZero() && reminder.DueTime.Before(filter.DueFrom) {
+				continue
+			}
+
+			if !filter.DueBefore.IsZero() && !reminder.DueTime.Before(filter.DueBefore) {
+				continue
+			}
+
 			// Check tags filter
 			if len(filter.Tags) > 0 {
-				hasTag := false
+				matched := 0
 				for _, filterTag := range filter.Tags {
-					if reminder.HasTag(filterTag) {
-						hasTag = true
-						break
+					if reminder.MatchesTag(filterTag) {
+						matched++
 					}
 				}
-				if !hasTag {
+				if matched == 0 || filter.AllTags && matched < len(filter.Tags) {
 					continue
 				}
 			}
 		}
 
-		// Create a copy to prevent external modification
+		reminders = append(reminders, reminder)
+	}
+
+	SortReminders(reminders)
+
+	// Apply offset and limit if specified
+	if filter != nil && filter.Offset > 0 {
+		reminders = reminders[min(filter.Offset, len(reminders)):]
+	}
+	if filter != nil && filter.Limit > 0 && len(reminders) > filter.Limit {
+		reminders = reminders[:filter.Limit]
+	}
+
+	// Copy only the page to prevent external modification
+	page := make([]*Reminder, len(reminders))
+	for i, reminder := range reminders {
 		reminderCopy := *reminder
-		reminders = append(reminders, &reminderCopy)
+		page[i] = &reminderCopy
 	}
+	return page
+}
 
-	// Sort by due time (ascending)
-	sort.Slice(reminders, func(i, j int) bool {
+// SortReminders sorts by due time (ascending) with completed items at the bottom
+func SortReminders(reminders []*Reminder) {
+	sort.SliceStable(reminders, func(i, j int) bool {
 		// Completed items go to the bottom
 		if reminders[i].Completed && !reminders[j].Completed {
 			return false
@@ -240,13 +810,83 @@ func (s *Store) GetAll(filter *FilterOptions) []*Reminder {
 		// Sort by due time
 		return reminders[i].DueTime.Before(reminders[j].DueTime)
 	})
+}
+
+// SortByOverdueAge puts the longest overdue reminders first, followed by the
+// rest in due order, with completed items at the bottom
+func SortByOverdueAge(reminders []*Reminder) {
+	sort.SliceStable(reminders, func(i, j int) bool {
+		if reminders[i].Completed != reminders[j].Completed {
+			return !reminders[i].Completed
+		}
+
+		ageI, ageJ := reminders[i].OverdueAge(), reminders[j].OverdueAge()
+		if ageI != ageJ {
+			return ageI > ageJ
+		}
+		return reminders[i].DueTime.Before(reminders[j].DueTime)
+	})
+}
 
-	// Apply limit if specified
-	if filter != nil && filter.Limit > 0 && len(reminders) > filter.Limit {
-		reminders = reminders[:filter.Limit]
+// ReminderGroup is a named bucket of reminders
+type ReminderGroup struct {
+	Name      string
+	Reminders []*Reminder
+}
+
+// Group names used by GroupByDue, in display order
+const (
+	GroupOverdue   = "⚠ Overdue"
+	GroupToday     = "Today"
+	GroupTomorrow  = "Tomorrow"
+	GroupThisWeek  = "This week"
+	GroupLater     = "Later"
+	GroupCompleted = "Completed"
+)
+
+// GroupByDue splits reminders into relative-day buckets, overdue first.
+// Order within each group is preserved and empty groups are omitted.
+func GroupByDue(reminders []*Reminder, now time.Time) []ReminderGroup {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tomorrow := today.AddDate(0, 0, 1)
+	dayAfter := today.AddDate(0, 0, 2)
+	nextWeek := today.AddDate(0, 0, 7)
+
+	order := []string{GroupOverdue, GroupToday, GroupTomorrow, GroupThisWeek, GroupLater, GroupCompleted}
+	buckets := make(map[string][]*Reminder)
+
+	for _, reminder := range reminders {
+		var name string
+		due := reminder.DueTime
+		switch {
+		case reminder.Completed:
+			name = GroupCompleted
+		case due.Before(now):
+			name = GroupOverdue
+		case due.Before(tomorrow):
+			name = GroupToday
+		case due.Before(dayAfter):
+			name = GroupTomorrow
+		case due.Before(nextWeek):
+			name = GroupThisWeek
+		default:
+			name = GroupLater
+		}
+		buckets[name] = append(buckets[name], reminder)
 	}
 
-	return reminders
+	groups := make([]ReminderGroup, 0, len(order))
+	for _, name := range order {
+		if len(buckets[name]) > 0 {
+			groups = append(groups, ReminderGroup{Name: name, Reminders: buckets[name]})
+		}
+	}
+	return groups
+}
+
+// GetGrouped returns filtered reminders grouped by relative due day
+func (s *Store) GetGrouped(filter *FilterOptions) []ReminderGroup {
+	return GroupByDue(s.GetAll(filter), time.Now())
 }
 
 // GetByPriority returns reminders filtered by priority
@@ -275,6 +915,7 @@ func (s *Store) GetOverdue() []*Reminder {
 
 // GetCompleted returns completed reminders
 func (s *Store) GetCompleted() []*Reminder {
+	s.loadCold()
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -318,7 +959,7 @@ func (s *Store) Count() (total, active, completed, overdue int) {
 	defer s.mutex.RUnlock()
 
 	for _, reminder := range s.reminders {
-		if reminder == nil {
+		if reminder == nil || reminder.Archived {
 			continue
 		}
 
@@ -332,18 +973,189 @@ func (s *Store) Count() (total, active, completed, overdue int) {
 			}
 		}
 	}
+	for _, record := range s.cold {
+		if !record.archived && record.completed {
+			total++
+			completed++
+		}
+	}
 
 	return
 }
 
-// GetTags returns all unique tags used in reminders
-func (s *Store) GetTags() []string {
+// TodayProgress counts the reminders due today for the assignee (everyone
+// if empty) and how many of them are done. Completed occurrences of
+// recurring reminders count even though the reminder has moved on; skipped
+// ones don't count at all.
+func (s *Store) TodayProgress(assignee string) (done, total int) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	tagSet := make(map[string]bool)
+	now := time.Now()
+	isToday := func(t time.Time) bool {
+		return t.Year() == now.Year() && t.YearDay() == now.YearDay()
+	}
+
 	for _, reminder := range s.reminders {
-		if reminder == nil {
+		if reminder == nil || reminder.Archived {
+			continue
+		}
+		if assignee != "" && !reminder.IsAssignedTo(assignee) {
+			continue
+		}
+
+		if isToday(reminder.DueTime) {
+			total++
+			if reminder.Completed {
+				done++
+			}
+		}
+		for _, occurrence := range reminder.History {
+			// The last occurrence of a finished series is counted above
+			if reminder.Completed && occurrence.DueTime.Equal(reminder.DueTime) {
+				continue
+			}
+			if occurrence.Done() && isToday(occurrence.DueTime) {
+				total++
+				done++
+			}
+		}
+	}
+	return
+}
+
+// ConflictWindow is how close two due times may be before they conflict
+const ConflictWindow = 15 * time.Minute
+
+// Conflicts returns the active reminders due within window of due, soonest
+// first, and how many active reminders are due that day
+func (s *Store) Conflicts(due time.Time, window time.Duration) (nearby []*Reminder, sameDay int) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	day := due.Format("2006-01-02")
+	for _, reminder := range s.reminders {
+		if reminder == nil || reminder.Archived || reminder.Completed {
+			continue
+		}
+		if reminder.Recurring != nil && reminder.Recurring.Paused {
+			continue
+		}
+
+		if reminder.DueTime.In(due.Location()).Format("2006-01-02") == day {
+			sameDay++
+		}
+		if gap := reminder.DueTime.Sub(due); gap <= window && gap >= -window {
+			nearby = append(nearby, reminder)
+		}
+	}
+
+	sort.Slice(nearby, func(i, j int) bool {
+		return nearby[i].DueTime.Before(nearby[j].DueTime)
+	})
+	return nearby, sameDay
+}
+
+// DayLoads counts the active reminders due on each of days days, starting
+// with from's, counted the way Conflicts counts a day
+func (s *Store) DayLoads(from time.Time, days int) []int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	loads := make([]int, days)
+	index := make(map[string]int, days)
+	for i := range loads {
+		day := time.Date(from.Year(), from.Month(), from.Day()+i, 0, 0, 0, 0, from.Location())
+		index[day.Format("2006-01-02")] = i
+	}
+	for _, reminder := range s.reminders {
+		if reminder == nil || reminder.Archived || reminder.Completed {
+			continue
+		}
+		if reminder.Recurring != nil && reminder.Recurring.Paused {
+			continue
+		}
+		if i, ok := index[reminder.DueTime.In(from.Location()).Format("2006-01-02")]; ok {
+			loads[i]++
+		}
+	}
+	return loads
+}
+
+// FindDuplicates returns the active reminders, other than the one with ID
+// exclude, due the same day as due with a title like title
+func (s *Store) FindDuplicates(title string, due time.Time, exclude string) []*Reminder {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var duplicates []*Reminder
+	day := due.Format("2006-01-02")
+	for _, reminder := range s.reminders {
+		if reminder == nil || reminder.Archived || reminder.Completed || reminder.ID == exclude {
+			continue
+		}
+		if reminder.DueTime.In(due.Location()).Format("2006-01-02") == day && similarTitles(reminder.Title, title) {
+			duplicates = append(duplicates, reminder)
+		}
+	}
+
+	SortReminders(duplicates)
+	return duplicates
+}
+
+// similarTitles reports whether two titles differ only in case, punctuation,
+// spacing or a typo or two
+func similarTitles(a, b string) bool {
+	a, b = normalizeTitle(a), normalizeTitle(b)
+	if a == b {
+		return true
+	}
+
+	longer := max(len([]rune(a)), len([]rune(b)))
+	return longer >= 5 && editDistance(a, b) <= longer/5
+}
+
+// normalizeTitle lowercases a title and keeps only letters and digits,
+// separated by single spaces
+func normalizeTitle(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// GetTags returns all unique tags used in reminders
+func (s *Store) GetTags() []string {
+	s.loadCold()
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	tagSet := make(map[string]bool)
+	for _, reminder := range s.reminders {
+		if reminder == nil {
 			continue
 		}
 
@@ -361,10 +1173,99 @@ func (s *Store) GetTags() []string {
 	return tags
 }
 
+// ReplaceTags replaces the given tags with a single replacement tag on every
+// reminder in one write. Nested tags move along, so replacing "work" with
+// "job" turns "work/billing" into "job/billing". An empty replacement removes
+// the tags and their descendants. It returns the number of reminders changed.
+func (s *Store) ReplaceTags(tags []string, replacement string) (int, error) {
+	if s.IsReadOnly() {
+		return 0, ErrReadOnly
+	}
+
+	s.mutex.Lock()
+	s.decodeCold()
+	var changed []string
+	for _, reminder := range s.reminders {
+		if reminder == nil {
+			continue
+		}
+
+		newTags := make([]string, 0, len(reminder.Tags))
+		found := false
+		for _, existing := range reminder.Tags {
+			renamed, matched := replaceTag(existing, tags, replacement)
+			found = found || matched
+			// Merging into a tag the reminder already has leaves it once
+			if renamed != "" && !containsString(newTags, renamed) {
+				newTags = append(newTags, renamed)
+			}
+		}
+		if !found {
+			continue
+		}
+
+		reminder.Tags = newTags
+		reminder.UpdatedAt = time.Now()
+		changed = append(changed, reminder.ID)
+	}
+	s.mutex.Unlock()
+
+	if len(changed) == 0 {
+		return 0, nil
+	}
+	return len(changed), s.commit(EventUpdated, changed...)
+}
+
+// replaceTag renames tag if it equals or descends from one of the old tags
+func replaceTag(tag string, old []string, replacement string) (string, bool) {
+	for _, o := range old {
+		if tag == o {
+			return replacement, true
+		}
+		if strings.HasPrefix(tag, o+"/") {
+			if replacement == "" {
+				return "", true
+			}
+			return replacement + strings.TrimPrefix(tag, o), true
+		}
+	}
+	return tag, false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// TagCounts returns how many active reminders carry each tag
+func (s *Store) TagCounts() map[string]int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	counts := make(map[string]int)
+	for _, reminder := range s.reminders {
+		if reminder == nil || reminder.Completed || reminder.Archived {
+			continue
+		}
+		for _, tag := range reminder.Tags {
+			counts[tag]++
+		}
+	}
+	return counts
+}
+
 // CompleteReminder marks a reminder as completed by ID
 func (s *Store) CompleteReminder(id string) error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+
 	s.mutex.Lock()
-	reminder, exists := s.reminders[id]
+	reminder, exists := s.lookup(id)
 	if !exists {
 		s.mutex.Unlock()
 		return fmt.Errorf("reminder with ID %s not found", id)
@@ -373,86 +1274,395 @@ func (s *Store) CompleteReminder(id string) error {
 	reminder.Complete()
 	s.mutex.Unlock()
 
-	return s.Save()
+	return s.commit(EventCompleted, id)
 }
 
 // ToggleReminder toggles the completion status of a reminder by ID
 func (s *Store) ToggleReminder(id string) error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+
 	s.mutex.Lock()
-	reminder, exists := s.reminders[id]
+	reminder, exists := s.lookup(id)
 	if !exists {
 		s.mutex.Unlock()
 		return fmt.Errorf("reminder with ID %s not found", id)
 	}
 
 	reminder.Toggle()
+	kind := EventUpdated
+	if reminder.Completed {
+		kind = EventCompleted
+	}
 	s.mutex.Unlock()
 
-	return s.Save()
+	return s.commit(kind, id)
+}
+
+// SkipReminder moves a recurring reminder to its next occurrence by ID
+func (s *Store) SkipReminder(id string) error {
+	return s.updateRecurring(id, (*Reminder).Skip)
+}
+
+// PauseReminder pauses or resumes a recurring reminder by ID
+func (s *Store) PauseReminder(id string, paused bool) error {
+	return s.updateRecurring(id, func(r *Reminder) error {
+		return r.SetPaused(paused)
+	})
+}
+
+// SetExclusions adds and removes exception dates on a recurring reminder by ID
+func (s *Store) SetExclusions(id string, add, remove []string) error {
+	return s.updateRecurring(id, func(r *Reminder) error {
+		return r.SetExclusions(add, remove)
+	})
+}
+
+// SetDueTime moves a reminder's due time for an automatic adjustment, such as
+// following sunset. Unlike Update, it doesn't count as activity.
+func (s *Store) SetDueTime(id string, due time.Time) error {
+	return s.updateRecurring(id, func(r *Reminder) error {
+		r.DueTime = due
+		return nil
+	})
+}
+
+// CheckIn records that the user is still working on a reminder by ID. Like
+// SetDueTime, it doesn't count as an edit.
+func (s *Store) CheckIn(id string) error {
+	return s.updateRecurring(id, func(r *Reminder) error {
+		if r.Completed {
+			return fmt.Errorf("reminder '%s' is already completed", r.Title)
+		}
+		r.RecordCheckIn(time.Now())
+		return nil
+	})
+}
+
+// updateRecurring applies a recurrence change to a reminder and saves it
+func (s *Store) updateRecurring(id string, apply func(*Reminder) error) error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	s.mutex.Lock()
+	reminder, exists := s.lookup(id)
+	if !exists {
+		s.mutex.Unlock()
+		return fmt.Errorf("reminder with ID %s not found", id)
+	}
+
+	if err := apply(reminder); err != nil {
+		s.mutex.Unlock()
+		return err
+	}
+	s.mutex.Unlock()
+
+	return s.commit(EventUpdated, id)
+}
+
+// StartTimer starts tracking time on a reminder by ID. Only one timer runs
+// at a time, so a timer running on another reminder is stopped and that
+// reminder is returned.
+func (s *Store) StartTimer(id string) (stopped *Reminder, err error) {
+	if s.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
+
+	s.mutex.Lock()
+	reminder, exists := s.lookup(id)
+	if !exists {
+		s.mutex.Unlock()
+		return nil, fmt.Errorf("reminder with ID %s not found", id)
+	}
+	if reminder.TimerRunning() {
+		s.mutex.Unlock()
+		return nil, fmt.Errorf("the timer is already running")
+	}
+
+	now := time.Now()
+	for _, other := range s.reminders {
+		if other != nil && other.TimerRunning() {
+			other.StopTimer(now)
+			stopped = other
+		}
+	}
+	reminder.StartTimer(now)
+	s.mutex.Unlock()
+
+	if stopped != nil {
+		return stopped, s.commit(EventUpdated, id, stopped.ID)
+	}
+	return nil, s.commit(EventUpdated, id)
+}
+
+// StopTimer stops the running timer and returns its reminder and how long
+// the session lasted
+func (s *Store) StopTimer() (*Reminder, time.Duration, error) {
+	if s.IsReadOnly() {
+		return nil, 0, ErrReadOnly
+	}
+
+	s.mutex.Lock()
+	reminder := s.runningTimer()
+	if reminder == nil {
+		s.mutex.Unlock()
+		return nil, 0, fmt.Errorf("no timer is running")
+	}
+	session, _ := reminder.StopTimer(time.Now())
+	s.mutex.Unlock()
+
+	return reminder, session, s.commit(EventUpdated, reminder.ID)
+}
+
+// RunningTimer returns the reminder whose timer is running, or nil
+func (s *Store) RunningTimer() *Reminder {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.runningTimer()
+}
+
+func (s *Store) runningTimer() *Reminder {
+	for _, reminder := range s.reminders {
+		if reminder != nil && reminder.TimerRunning() {
+			return reminder
+		}
+	}
+	return nil
+}
+
+// TrackedToday sums the time tracked today across all reminders
+func (s *Store) TrackedToday() time.Duration {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var total time.Duration
+	for _, reminder := range s.reminders {
+		if reminder == nil {
+			continue
+		}
+		for _, entry := range reminder.TimeLog {
+			end := now
+			if entry.End != nil {
+				end = *entry.End
+			}
+			if end.After(midnight) {
+				total += end.Sub(maxTime(entry.Start, midnight))
+			}
+		}
+	}
+	return total
+}
+
+// maxTime returns the later of two times
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// DayActivity counts what happened to reminders on one day
+type DayActivity struct {
+	Created   int
+	Completed int
+}
+
+// Activity counts reminders created and completed per day, keyed by local
+// date ("2006-01-02"). Archived reminders count too, and every completed
+// occurrence of a recurring reminder counts once.
+func (s *Store) Activity() map[string]DayActivity {
+	s.loadCold()
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	activity := make(map[string]DayActivity)
+	count := func(t time.Time, created bool) {
+		key := t.In(time.Local).Format("2006-01-02")
+		day := activity[key]
+		if created {
+			day.Created++
+		} else {
+			day.Completed++
+		}
+		activity[key] = day
+	}
+
+	for _, reminder := range s.reminders {
+		if reminder == nil {
+			continue
+		}
+		count(reminder.CreatedAt, true)
+
+		// Recurring completions, including the last one, are in the history
+		for _, occurrence := range reminder.History {
+			if occurrence.Done() {
+				count(occurrence.At, false)
+			}
+		}
+		if reminder.Recurring == nil && reminder.Completed && reminder.CompletedAt != nil {
+			count(*reminder.CompletedAt, false)
+		}
+	}
+	return activity
+}
+
+// ArchiveReminder archives a reminder by ID
+func (s *Store) ArchiveReminder(id string) error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	s.mutex.Lock()
+	reminder, exists := s.lookup(id)
+	if !exists {
+		s.mutex.Unlock()
+		return fmt.Errorf("reminder with ID %s not found", id)
+	}
+
+	reminder.Archive()
+	s.mutex.Unlock()
+
+	return s.commit(EventUpdated, id)
 }
 
 // Cleanup removes old completed reminders (older than 30 days)
 func (s *Store) Cleanup() error {
+	if s.IsReadOnly() {
+		return ErrReadOnly
+	}
+
 	s.mutex.Lock()
+	s.decodeCold()
 	cutoff := time.Now().AddDate(0, 0, -30) // 30 days ago
-	deleted := 0
+	var deleted []string
 
 	for id, reminder := range s.reminders {
 		if reminder != nil && reminder.Completed {
 			completedAt := reminder.CompletedAt
 			if completedAt != nil && completedAt.Before(cutoff) {
 				delete(s.reminders, id)
-				deleted++
+				s.bury(id, time.Now())
+				deleted = append(deleted, id)
 			}
 		}
 	}
 	s.mutex.Unlock()
 
-	if deleted > 0 {
-		return s.Save()
+	if len(deleted) > 0 {
+		return s.commit(EventDeleted, deleted...)
 	}
 
 	return nil
 }
 
-// Export exports all reminders to a JSON string
+// Export exports all reminders to a JSON string, followed by the tombstones
+// of deleted ones
 func (s *Store) Export() ([]byte, error) {
+	s.loadCold()
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	reminders := make([]*Reminder, 0, len(s.reminders))
+	reminders := make([]any, 0, len(s.reminders)+len(s.tombstones))
 	for _, reminder := range s.reminders {
 		if reminder != nil {
 			reminders = append(reminders, reminder)
 		}
 	}
+	for _, tombstone := range s.liveTombstones(time.Now()) {
+		reminders = append(reminders, tombstone)
+	}
 
 	return json.MarshalIndent(reminders, "", "  ")
 }
 
-// Import imports reminders from JSON data (merges with existing)
-func (s *Store) Import(data []byte) error {
-	var importedReminders []*Reminder
-	if err := json.Unmarshal(data, &importedReminders); err != nil {
-		return fmt.Errorf("failed to parse import data: %w", err)
+// ImportReport says what Import did
+type ImportReport struct {
+	Added   int // Reminders that weren't in the store
+	Deleted int // Reminders the data has tombstones for, deleted since they last changed
+	Skipped int // Reminders deleted here after the data's copy last changed
+}
+
+// Import merges reminders from JSON data: new ones are added, existing ones
+// left alone. Tombstones in the data delete reminders that haven't changed
+// since, and reminders deleted here since the data's copy last changed stay
+// deleted.
+func (s *Store) Import(data []byte) (ImportReport, error) {
+	var report ImportReport
+	if s.IsReadOnly() {
+		return report, ErrReadOnly
+	}
+
+	if err := ValidateReminders(data); err != nil {
+		return report, err
+	}
+
+	var records []json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
+		return report, fmt.Errorf("failed to parse import data: %w", err)
 	}
 
 	s.mutex.Lock()
-	imported := 0
-	for _, reminder := range importedReminders {
-		if reminder != nil {
-			// Check if reminder with same ID already exists
-			if _, exists := s.reminders[reminder.ID]; !exists {
-				s.reminders[reminder.ID] = reminder
-				imported++
+	s.decodeCold()
+	var imported, deleted []string
+	for _, raw := range records {
+		if string(raw) == "null" {
+			continue
+		}
+		var header recordHeader
+		if err := json.Unmarshal(raw, &header); err != nil {
+			s.mutex.Unlock()
+			return report, fmt.Errorf("failed to parse import data: %w", err)
+		}
+
+		if header.DeletedAt != nil {
+			if existing, exists := s.reminders[header.ID]; exists {
+				if existing.UpdatedAt.After(*header.DeletedAt) {
+					// Changed here after it was deleted there
+					continue
+				}
+				delete(s.reminders, header.ID)
+				deleted = append(deleted, header.ID)
 			}
+			s.bury(header.ID, *header.DeletedAt)
+			continue
+		}
+
+		reminder, err := decodeReminder(raw)
+		if err != nil {
+			s.mutex.Unlock()
+			return report, fmt.Errorf("failed to parse import data: %w", err)
 		}
+		if _, exists := s.reminders[reminder.ID]; exists {
+			continue
+		}
+		if _, buried := s.buried(reminder); buried {
+			report.Skipped++
+			continue
+		}
+		reminder.setLocation(time.Local)
+		s.reminders[reminder.ID] = reminder
+		delete(s.tombstones, reminder.ID)
+		imported = append(imported, reminder.ID)
+	}
+	if len(imported) > 0 {
+		s.assignShortIDs(imported...)
 	}
 	s.mutex.Unlock()
+	report.Added, report.Deleted = len(imported), len(deleted)
 
-	if imported > 0 {
-		return s.Save()
+	if len(deleted) > 0 {
+		if err := s.commit(EventDeleted, deleted...); err != nil {
+			return report, err
+		}
+	}
+	if len(imported) > 0 {
+		return report, s.commit(EventAdded, imported...)
 	}
 
-	return nil
+	return report, nil
 }
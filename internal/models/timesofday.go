@@ -0,0 +1,41 @@
+package models
+
+import (
+	"maps"
+	"strings"
+	"time"
+)
+
+// TimesOfDay maps named times of day, as in "tomorrow morning" or
+// "tonight", to "HH:MM" clock times
+type TimesOfDay map[string]string
+
+// defaultTimesOfDay are the clock times of names that aren't configured
+var defaultTimesOfDay = TimesOfDay{
+	"morning":   "09:00",
+	"noon":      "12:00",
+	"afternoon": "15:00",
+	"evening":   "18:00",
+	"tonight":   "20:00",
+	"midnight":  "00:00",
+}
+
+// DefaultTimesOfDay returns the default clock time of every named time of day
+func DefaultTimesOfDay() TimesOfDay {
+	return maps.Clone(defaultTimesOfDay)
+}
+
+// Clock returns the clock time of a named time of day, or its default when
+// it isn't set to a valid HH:MM time. It reports false for unknown names.
+func (t TimesOfDay) Clock(name string) (hour, minute int, ok bool) {
+	name = strings.ToLower(name)
+	if _, known := defaultTimesOfDay[name]; !known {
+		return 0, 0, false
+	}
+	for _, times := range []TimesOfDay{t, defaultTimesOfDay} {
+		if clock, err := time.Parse("15:04", times[name]); err == nil {
+			return clock.Hour(), clock.Minute(), true
+		}
+	}
+	return 0, 0, false
+}
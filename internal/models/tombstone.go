@@ -0,0 +1,75 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrDeleted is returned for a copy of a reminder that was deleted after the
+// copy was last changed
+var ErrDeleted = errors.New("reminder was deleted")
+
+// tombstoneTTL is how long a deleted reminder's ID is remembered. A copy that
+// turns up later (from a device offline all that time, or a very old export)
+// comes back as if it were new.
+const tombstoneTTL = 90 * 24 * time.Hour
+
+// Tombstone records that a reminder was deleted, so an older copy of it from
+// an export, a synced file or another device doesn't bring it back. Whichever
+// is newer wins: a copy changed after the deletion is kept.
+type Tombstone struct {
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// bury remembers that the reminder with id was deleted at; the caller must
+// hold the mutex
+func (s *Store) bury(id string, at time.Time) {
+	if s.tombstones == nil {
+		s.tombstones = make(map[string]time.Time)
+	}
+	if at.After(s.tombstones[id]) {
+		s.tombstones[id] = at
+	}
+}
+
+// buried reports whether reminder is a copy from before its deletion; the
+// caller must hold the mutex
+func (s *Store) buried(reminder *Reminder) (time.Time, bool) {
+	deletedAt, ok := s.tombstones[reminder.ID]
+	return deletedAt, ok && !reminder.UpdatedAt.After(deletedAt)
+}
+
+// CheckDeleted returns ErrDeleted if reminder is a copy from before the
+// reminder was deleted, for servers deciding whether to accept it
+func (s *Store) CheckDeleted(reminder *Reminder) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if deletedAt, ok := s.buried(reminder); ok {
+		return fmt.Errorf("%w on %s, after this copy was last changed", ErrDeleted, deletedAt.Local().Format("Jan 2 15:04"))
+	}
+	return nil
+}
+
+// Tombstones returns the deleted reminders the store remembers, in ID order,
+// leaving out those older than tombstoneTTL
+func (s *Store) Tombstones() []Tombstone {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.liveTombstones(time.Now())
+}
+
+// liveTombstones is Tombstones for callers holding the mutex
+func (s *Store) liveTombstones(now time.Time) []Tombstone {
+	tombstones := make([]Tombstone, 0, len(s.tombstones))
+	for id, deletedAt := range s.tombstones {
+		if now.Sub(deletedAt) < tombstoneTTL {
+			tombstones = append(tombstones, Tombstone{ID: id, DeletedAt: deletedAt.UTC()})
+		}
+	}
+	sort.Slice(tombstones, func(i, j int) bool { return tombstones[i].ID < tombstones[j].ID })
+	return tombstones
+}
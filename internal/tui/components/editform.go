@@ -1,13 +1,13 @@
 package components
 
 import (
-	"fmt"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
 	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 )
@@ -23,6 +23,7 @@ type EditForm struct {
 	width       int
 	height      int
 	errorMsg    string
+	isNew       bool // Adding a reminder rather than editing one
 }
 
 const (
@@ -41,20 +42,20 @@ var (
 
 func NewEditForm(reminder *models.Reminder) *EditForm {
 	ti := textinput.New()
-	ti.Placeholder = "Title"
+	ti.Placeholder = i18n.T("Title")
 	ti.Focus()
 	ti.CharLimit = 200
 	ti.Width = 50
 	ti.SetValue(reminder.Title)
 
 	timeInput := textinput.New()
-	timeInput.Placeholder = "Time (e.g., 3pm, 14:30)"
+	timeInput.Placeholder = i18n.T("Time (e.g., 3pm, 14:30)")
 	timeInput.CharLimit = 20
 	timeInput.Width = 30
-	timeInput.SetValue(reminder.DueTime.Format("3:04 PM"))
+	timeInput.SetValue(i18n.FormatTime(reminder.DueTime, "3:04 PM"))
 
 	dateInput := textinput.New()
-	dateInput.Placeholder = "Date (e.g., tomorrow, 2024-03-20)"
+	dateInput.Placeholder = i18n.T("Date (e.g., tomorrow, 2024-03-20, %s)", time.Date(2024, 3, 20, 0, 0, 0, 0, time.Local).Format(i18n.NumericDateLayout()))
 	dateInput.CharLimit = 30
 	dateInput.Width = 30
 	dateInput.SetValue(reminder.DueTime.Format("2006-01-02"))
@@ -68,6 +69,13 @@ func NewEditForm(reminder *models.Reminder) *EditForm {
 	}
 }
 
+// NewAddForm opens the form on a reminder that isn't in the store yet
+func NewAddForm(reminder *models.Reminder) *EditForm {
+	f := NewEditForm(reminder)
+	f.isNew = true
+	return f
+}
+
 func (f *EditForm) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -121,10 +129,14 @@ func (f *EditForm) Update(msg tea.Msg) (*EditForm, tea.Cmd) {
 func (f *EditForm) View() string {
 	var s strings.Builder
 
-	s.WriteString(focusedStyle.Render("✏️  Edit Reminder\n\n"))
+	if f.isNew {
+		s.WriteString(focusedStyle.Render(utils.Symbol("➕ ", "") + i18n.T("New Reminder") + "\n\n"))
+	} else {
+		s.WriteString(focusedStyle.Render(utils.Symbol("✏️  ", "") + i18n.T("Edit Reminder") + "\n\n"))
+	}
 
 	// Title field
-	titleLabel := "Title:"
+	titleLabel := i18n.T("Title:")
 	if f.focused == titleField {
 		titleLabel = focusedStyle.Render("> " + titleLabel)
 	} else {
@@ -134,7 +146,7 @@ func (f *EditForm) View() string {
 	s.WriteString(f.titleInput.View() + "\n\n")
 
 	// Time field
-	timeLabel := "Time:"
+	timeLabel := i18n.T("Time:")
 	if f.focused == timeField {
 		timeLabel = focusedStyle.Render("> " + timeLabel)
 	} else {
@@ -144,7 +156,7 @@ func (f *EditForm) View() string {
 	s.WriteString(f.timeInput.View() + "\n\n")
 
 	// Date field
-	dateLabel := "Date:"
+	dateLabel := i18n.T("Date:")
 	if f.focused == dateField {
 		dateLabel = focusedStyle.Render("> " + dateLabel)
 	} else {
@@ -155,11 +167,11 @@ func (f *EditForm) View() string {
 
 	// Error message
 	if f.errorMsg != "" {
-		s.WriteString(errorStyle.Render("Error: " + f.errorMsg + "\n\n"))
+		s.WriteString(errorStyle.Render(i18n.T("Error: %s", f.errorMsg) + "\n\n"))
 	}
 
 	// Help text
-	help := helpStyle.Render("tab: next field • shift+tab: prev field • enter: save • esc: cancel")
+	help := helpStyle.Render(i18n.T("tab: next field • shift+tab: prev field • enter: save • esc: cancel"))
 	s.WriteString(help)
 
 	return s.String()
@@ -200,7 +212,7 @@ func (f *EditForm) submit() (*EditForm, tea.Cmd) {
 
 	// Validate title
 	if title == "" {
-		f.errorMsg = "Title cannot be empty"
+		f.errorMsg = i18n.T("Title cannot be empty")
 		return f, nil
 	}
 
@@ -212,7 +224,7 @@ func (f *EditForm) submit() (*EditForm, tea.Cmd) {
 	if timeStr != "" {
 		parsedTime, err := utils.ParseTimeString(timeStr)
 		if err != nil {
-			f.errorMsg = fmt.Sprintf("Invalid time format: %s", err.Error())
+			f.errorMsg = i18n.T("Invalid time format: %s", err.Error())
 			return f, nil
 		}
 		newTime = parsedTime
@@ -223,33 +235,10 @@ func (f *EditForm) submit() (*EditForm, tea.Cmd) {
 	// Parse date
 	var newDate time.Time
 	if dateStr != "" {
-		// Try different date formats
-		dateFormats := []string{
-			"2006-01-02",  // 2024-03-20
-			"01/02/2006",  // 03/20/2024
-			"01-02-2006",  // 03-20-2024
-			"Jan 2, 2006", // Mar 20, 2024
-			"Jan 2 2006",  // Mar 20 2024
-			"2 Jan 2006",  // 20 Mar 2006
-		}
-
-		// Handle relative dates
-		switch strings.ToLower(dateStr) {
-		case "today":
-			newDate = time.Now()
-		case "tomorrow":
-			newDate = time.Now().AddDate(0, 0, 1)
-		default:
-			// Try parsing as explicit date
-			for _, format := range dateFormats {
-				if newDate, err = time.Parse(format, dateStr); err == nil {
-					break
-				}
-			}
-			if err != nil {
-				f.errorMsg = fmt.Sprintf("Invalid date format: %s", dateStr)
-				return f, nil
-			}
+		newDate, err = utils.ParseDateString(dateStr)
+		if err != nil {
+			f.errorMsg = i18n.T("Invalid date format: %s", dateStr)
+			return f, nil
 		}
 	} else {
 		newDate = f.reminder.DueTime
@@ -262,7 +251,10 @@ func (f *EditForm) submit() (*EditForm, tea.Cmd) {
 		time.Local,
 	)
 
-	// Update the reminder
+	// Update the reminder; a new clock time replaces "at sunset"
+	if finalTime.Hour() != f.reminder.DueTime.Hour() || finalTime.Minute() != f.reminder.DueTime.Minute() {
+		f.reminder.SunEvent = ""
+	}
 	f.reminder.Title = title
 	f.reminder.DueTime = finalTime
 	f.reminder.UpdatedAt = time.Now()
@@ -279,6 +271,11 @@ func (f *EditForm) Cancelled() bool {
 	return f.cancelled
 }
 
+// IsNew reports whether the reminder still has to be added to the store
+func (f *EditForm) IsNew() bool {
+	return f.isNew
+}
+
 func (f *EditForm) GetReminder() *models.Reminder {
 	return f.reminder
 }
\ No newline at end of file
@@ -0,0 +1,159 @@
+package components
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// ExportForm prompts for where and how to export the visible reminders
+type ExportForm struct {
+	pathInput textinput.Model
+	format    int // Index into utils.ExportFormats
+	count     int // Number of reminders to export
+	focused   int
+	done      bool
+	cancelled bool
+	errorMsg  string
+}
+
+const (
+	exportPathField = iota
+	exportFormatField
+	numExportFields
+)
+
+// NewExportForm suggests a dated file in the current directory
+func NewExportForm(count int) *ExportForm {
+	pathInput := textinput.New()
+	pathInput.Placeholder = i18n.T("File")
+	pathInput.CharLimit = 500
+	pathInput.Width = 50
+	pathInput.SetValue("nancy-" + time.Now().Format("2006-01-02") + ".json")
+	pathInput.Focus()
+
+	return &ExportForm{
+		pathInput: pathInput,
+		count:     count,
+	}
+}
+
+func (f *ExportForm) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (f *ExportForm) Update(msg tea.Msg) (*ExportForm, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			f.cancelled = true
+			return f, nil
+
+		case "enter":
+			if strings.TrimSpace(f.pathInput.Value()) == "" {
+				f.errorMsg = i18n.T("File cannot be empty")
+				return f, nil
+			}
+			f.done = true
+			return f, nil
+
+		case "tab", "shift+tab", "up", "down":
+			f.focused = (f.focused + 1) % numExportFields
+			if f.focused == exportPathField {
+				f.pathInput.Focus()
+			} else {
+				f.pathInput.Blur()
+			}
+			return f, nil
+
+		case "left", "right", " ":
+			if f.focused == exportFormatField {
+				step := 1
+				if msg.String() == "left" {
+					step = len(utils.ExportFormats) - 1
+				}
+				f.setFormat((f.format + step) % len(utils.ExportFormats))
+				return f, nil
+			}
+		}
+	}
+
+	if f.focused != exportPathField {
+		return f, nil
+	}
+
+	var cmd tea.Cmd
+	f.pathInput, cmd = f.pathInput.Update(msg)
+	// Typing a known extension picks the format
+	if format := utils.ExportFormatFromPath(f.pathInput.Value()); format != "" {
+		for i, name := range utils.ExportFormats {
+			if name == format {
+				f.format = i
+			}
+		}
+	}
+	return f, cmd
+}
+
+func (f *ExportForm) View() string {
+	var s strings.Builder
+
+	s.WriteString(focusedStyle.Render(utils.Symbol("📤 ", "")+i18n.T("Export %d reminders", f.count)) + "\n\n")
+
+	label := func(field int, text string) string {
+		if f.focused == field {
+			return focusedStyle.Render("> " + text)
+		}
+		return blurredStyle.Render("  " + text)
+	}
+
+	s.WriteString(label(exportPathField, i18n.T("File:")) + "\n")
+	s.WriteString(f.pathInput.View() + "\n\n")
+
+	s.WriteString(label(exportFormatField, i18n.T("Format:")) + "\n")
+	s.WriteString("  ‹ " + utils.ExportFormats[f.format] + " ›\n\n")
+
+	if f.errorMsg != "" {
+		s.WriteString(errorStyle.Render(i18n.T("Error: %s", f.errorMsg)) + "\n\n")
+	}
+
+	s.WriteString(helpStyle.Render(i18n.T("tab: next field • ←/→: change format • enter: export • esc: cancel")))
+
+	return s.String()
+}
+
+// setFormat changes the format and the file extension along with it
+func (f *ExportForm) setFormat(format int) {
+	f.format = format
+	path := f.pathInput.Value()
+	if utils.ExportFormatFromPath(path) != "" {
+		path = strings.TrimSuffix(path, filepath.Ext(path))
+	}
+	f.pathInput.SetValue(path + "." + utils.ExportFormats[format])
+}
+
+// Path returns the file to export to
+func (f *ExportForm) Path() string {
+	return strings.TrimSpace(f.pathInput.Value())
+}
+
+// Format returns the chosen export format
+func (f *ExportForm) Format() string {
+	return utils.ExportFormats[f.format]
+}
+
+// Done reports whether the export was confirmed
+func (f *ExportForm) Done() bool {
+	return f.done
+}
+
+// Cancelled reports whether the prompt was closed without exporting
+func (f *ExportForm) Cancelled() bool {
+	return f.cancelled
+}
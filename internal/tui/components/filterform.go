@@ -0,0 +1,366 @@
+package components
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// FilterValues is what the filter builder composes. Dates are kept as typed,
+// so a saved "today" still means today in the next session.
+type FilterValues struct {
+	Priority      string   `json:"priority,omitempty"` // "low", "medium", "high" or "" for any
+	Tags          []string `json:"tags,omitempty"`
+	AllTags       bool     `json:"all_tags,omitempty"` // Match every tag instead of any
+	From          string   `json:"from,omitempty"`
+	To            string   `json:"to,omitempty"`
+	ShowCompleted bool     `json:"show_completed,omitempty"`
+}
+
+// Apply sets the priority, tag, date and completed options of filter
+func (v FilterValues) Apply(filter *models.FilterOptions) error {
+	filter.Priority = nil
+	if v.Priority != "" {
+		priority := models.ParsePriority(v.Priority)
+		filter.Priority = &priority
+	}
+	filter.Tags = v.Tags
+	filter.AllTags = v.AllTags
+	filter.ShowCompleted = v.ShowCompleted
+
+	filter.DueFrom = time.Time{}
+	if v.From != "" {
+		from, err := utils.ParseDateString(v.From)
+		if err != nil {
+			return err
+		}
+		filter.DueFrom = from
+	}
+
+	// The range includes the whole "to" day
+	filter.DueBefore = time.Time{}
+	if v.To != "" {
+		to, err := utils.ParseDateString(v.To)
+		if err != nil {
+			return err
+		}
+		filter.DueBefore = to.AddDate(0, 0, 1)
+	}
+	return nil
+}
+
+// Chips labels each active part of the filter for the header
+func (v FilterValues) Chips() []string {
+	var chips []string
+	if v.Priority != "" {
+		chips = append(chips, i18n.T(v.Priority))
+	}
+	for _, tag := range v.Tags {
+		chips = append(chips, "#"+tag)
+	}
+	if v.AllTags && len(v.Tags) > 1 {
+		chips = append(chips, i18n.T("all tags"))
+	}
+	if v.From != "" {
+		chips = append(chips, i18n.T("from %s", v.From))
+	}
+	if v.To != "" {
+		chips = append(chips, i18n.T("to %s", v.To))
+	}
+	if v.ShowCompleted {
+		chips = append(chips, i18n.T("+completed"))
+	}
+	return chips
+}
+
+// FilterForm is the overlay for building a filter
+type FilterForm struct {
+	priority      int // Index into filterPriorities
+	tagsInput     textinput.Model
+	fromInput     textinput.Model
+	toInput       textinput.Model
+	showCompleted bool
+	allTags       bool     // Kept from the tag browser
+	tags          []string // Known tags, for completion
+	focused       int
+	done          bool
+	cancelled     bool
+	errorMsg      string
+}
+
+const (
+	filterPriorityField = iota
+	filterTagsField
+	filterFromField
+	filterToField
+	filterCompletedField
+	numFilterFields
+)
+
+// filterPriorities are the choices of the priority field, "" meaning any
+var filterPriorities = []string{"", "low", "medium", "high"}
+
+var chipStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("15")).
+	Background(lipgloss.Color("62")).
+	Padding(0, 1)
+
+// NewFilterForm opens the filter builder on the current values, completing
+// tags from the given ones
+func NewFilterForm(values FilterValues, tags []string) *FilterForm {
+	tagsInput := textinput.New()
+	tagsInput.Placeholder = i18n.T("work, home")
+	tagsInput.CharLimit = 200
+	tagsInput.Width = 40
+	tagsInput.ShowSuggestions = true
+	// Up and down move between fields, so only ctrl+n/ctrl+p cycle suggestions
+	tagsInput.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	tagsInput.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+	tagsInput.SetValue(strings.Join(values.Tags, ", "))
+
+	fromInput := textinput.New()
+	fromInput.Placeholder = i18n.T("Date (e.g., today, 2024-03-20)")
+	fromInput.CharLimit = 30
+	fromInput.Width = 30
+	fromInput.SetValue(values.From)
+
+	toInput := textinput.New()
+	toInput.Placeholder = i18n.T("Date (e.g., tomorrow, 2024-03-20)")
+	toInput.CharLimit = 30
+	toInput.Width = 30
+	toInput.SetValue(values.To)
+
+	f := &FilterForm{
+		tagsInput:     tagsInput,
+		fromInput:     fromInput,
+		toInput:       toInput,
+		showCompleted: values.ShowCompleted,
+		allTags:       values.AllTags,
+		tags:          tags,
+	}
+	for i, priority := range filterPriorities {
+		if priority == values.Priority {
+			f.priority = i
+		}
+	}
+	f.updateSuggestions()
+	return f
+}
+
+func (f *FilterForm) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (f *FilterForm) Update(msg tea.Msg) (*FilterForm, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			f.cancelled = true
+			return f, nil
+
+		case "enter":
+			f.submit()
+			return f, nil
+
+		case "ctrl+r":
+			// Start over with no filter
+			f.priority = 0
+			f.tagsInput.SetValue("")
+			f.fromInput.SetValue("")
+			f.toInput.SetValue("")
+			f.showCompleted = false
+			f.allTags = false
+			f.updateSuggestions()
+			return f, nil
+
+		case "up", "shift+tab":
+			f.focus((f.focused - 1 + numFilterFields) % numFilterFields)
+			return f, nil
+
+		case "down":
+			f.focus((f.focused + 1) % numFilterFields)
+			return f, nil
+
+		case "tab":
+			// Complete a tag if there is something to complete, else move on
+			if f.focused != filterTagsField || !f.canComplete() {
+				f.focus((f.focused + 1) % numFilterFields)
+				return f, nil
+			}
+
+		case "left", "right", " ":
+			switch f.focused {
+			case filterPriorityField:
+				step := 1
+				if msg.String() == "left" {
+					step = len(filterPriorities) - 1
+				}
+				f.priority = (f.priority + step) % len(filterPriorities)
+				return f, nil
+			case filterCompletedField:
+				f.showCompleted = !f.showCompleted
+				return f, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	switch f.focused {
+	case filterTagsField:
+		f.tagsInput, cmd = f.tagsInput.Update(msg)
+		f.updateSuggestions()
+	case filterFromField:
+		f.fromInput, cmd = f.fromInput.Update(msg)
+	case filterToField:
+		f.toInput, cmd = f.toInput.Update(msg)
+	}
+	return f, cmd
+}
+
+func (f *FilterForm) View() string {
+	var s strings.Builder
+
+	s.WriteString(focusedStyle.Render(utils.Symbol("🔍 ", "")+i18n.T("Filter Reminders")) + "\n\n")
+
+	label := func(field int, text string) string {
+		if f.focused == field {
+			return focusedStyle.Render("> " + text)
+		}
+		return blurredStyle.Render("  " + text)
+	}
+
+	priority := i18n.T("any")
+	if p := filterPriorities[f.priority]; p != "" {
+		priority = utils.PriorityIcon(models.ParsePriority(p)) + " " + i18n.T(p)
+	}
+	s.WriteString(label(filterPriorityField, i18n.T("Priority:")) + "\n")
+	s.WriteString("  ‹ " + priority + " ›\n\n")
+
+	s.WriteString(label(filterTagsField, i18n.T("Tags:")) + "\n")
+	s.WriteString(f.tagsInput.View() + "\n\n")
+
+	s.WriteString(label(filterFromField, i18n.T("Due from:")) + "\n")
+	s.WriteString(f.fromInput.View() + "\n\n")
+
+	s.WriteString(label(filterToField, i18n.T("Due until:")) + "\n")
+	s.WriteString(f.toInput.View() + "\n\n")
+
+	completed := "[ ]"
+	if f.showCompleted {
+		completed = "[x]"
+	}
+	s.WriteString(label(filterCompletedField, i18n.T("Show completed:")) + "\n")
+	s.WriteString("  " + completed + "\n\n")
+
+	if chips := f.Values().Chips(); len(chips) > 0 {
+		s.WriteString(RenderChips(chips) + "\n\n")
+	}
+
+	if f.errorMsg != "" {
+		s.WriteString(errorStyle.Render(i18n.T("Error: %s", f.errorMsg)) + "\n\n")
+	}
+
+	s.WriteString(helpStyle.Render(i18n.T("↑/↓: field • ←/→/space: change • tab: complete tag • ctrl+r: reset • enter: apply • esc: cancel")))
+
+	return s.String()
+}
+
+// RenderChips renders filter labels as chips for the header
+func RenderChips(chips []string) string {
+	rendered := make([]string, len(chips))
+	for i, chip := range chips {
+		rendered[i] = chipStyle.Render(chip)
+	}
+	return strings.Join(rendered, " ")
+}
+
+// Values returns the filter as currently entered
+func (f *FilterForm) Values() FilterValues {
+	var tags []string
+	for _, tag := range strings.Split(f.tagsInput.Value(), ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return FilterValues{
+		Priority:      filterPriorities[f.priority],
+		Tags:          tags,
+		AllTags:       f.allTags,
+		From:          strings.TrimSpace(f.fromInput.Value()),
+		To:            strings.TrimSpace(f.toInput.Value()),
+		ShowCompleted: f.showCompleted,
+	}
+}
+
+// Done reports whether the filter was applied
+func (f *FilterForm) Done() bool {
+	return f.done
+}
+
+// Cancelled reports whether the overlay was closed without applying
+func (f *FilterForm) Cancelled() bool {
+	return f.cancelled
+}
+
+// submit checks the dates before applying
+func (f *FilterForm) submit() {
+	values := f.Values()
+	if err := values.Apply(&models.FilterOptions{}); err != nil {
+		f.errorMsg = err.Error()
+		return
+	}
+	f.errorMsg = ""
+	f.done = true
+}
+
+func (f *FilterForm) focus(field int) {
+	f.focused = field
+	f.tagsInput.Blur()
+	f.fromInput.Blur()
+	f.toInput.Blur()
+
+	switch field {
+	case filterTagsField:
+		f.tagsInput.Focus()
+	case filterFromField:
+		f.fromInput.Focus()
+	case filterToField:
+		f.toInput.Focus()
+	}
+}
+
+// updateSuggestions offers each known tag after whatever tags are typed
+// already, so completion works on the last one in a comma-separated list
+func (f *FilterForm) updateSuggestions() {
+	value := f.tagsInput.Value()
+	prefix := ""
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		prefix = value[:i+1]
+		if rest := value[i+1:]; strings.HasPrefix(rest, " ") {
+			prefix += " "
+		}
+	}
+
+	suggestions := make([]string, 0, len(f.tags))
+	for _, tag := range f.tags {
+		suggestions = append(suggestions, prefix+tag)
+	}
+	f.tagsInput.SetSuggestions(suggestions)
+}
+
+// canComplete reports whether tab would complete the tag being typed
+func (f *FilterForm) canComplete() bool {
+	suggestion := f.tagsInput.CurrentSuggestion()
+	return suggestion != "" && suggestion != f.tagsInput.Value() &&
+		len(f.tagsInput.MatchedSuggestions()) > 0
+}
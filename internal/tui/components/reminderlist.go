@@ -1 +1,303 @@
 package components
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/paginator"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// ReminderList is the main screen's list: it pages through the reminders
+// that fit the screen, narrows them with a fuzzy search and keeps the
+// selection on the same reminder when the list is reloaded.
+type ReminderList struct {
+	items     []*models.Reminder // As loaded from the store
+	visible   []*models.Reminder // Items matching the query, best first
+	cursor    int
+	height    int // Rows for reminders, 0 meaning all of them
+	pages     paginator.Model
+	query     textinput.Model
+	searching bool // The query is being typed
+}
+
+// NewReminderList creates an empty list
+func NewReminderList() *ReminderList {
+	query := textinput.New()
+	query.Prompt = "/ "
+	query.Placeholder = i18n.T("search")
+	query.CharLimit = 100
+	query.Width = 30
+
+	pages := paginator.New()
+	pages.Type = paginator.Dots
+	pages.ActiveDot = focusedStyle.Render("•")
+	pages.InactiveDot = blurredStyle.Render("•")
+
+	return &ReminderList{query: query, pages: pages}
+}
+
+// SetItems replaces the reminders. The selection stays on the reminder it
+// was on; if that one is gone, it stays at the same position.
+func (l *ReminderList) SetItems(items []*models.Reminder) {
+	selected := l.Selected()
+	l.items = items
+	l.match()
+
+	if selected != nil {
+		for i, reminder := range l.visible {
+			if reminder.ID == selected.ID {
+				l.cursor = i
+				return
+			}
+		}
+	}
+	l.clamp()
+}
+
+// SetHeight sets how many reminders fit on a page, 0 meaning all of them
+func (l *ReminderList) SetHeight(height int) {
+	l.height = max(height, 0)
+}
+
+// Items returns the reminders matching the query, in the order shown
+func (l *ReminderList) Items() []*models.Reminder {
+	return l.visible
+}
+
+// Selected returns the reminder under the cursor, or nil
+func (l *ReminderList) Selected() *models.Reminder {
+	if l.cursor < 0 || l.cursor >= len(l.visible) {
+		return nil
+	}
+	return l.visible[l.cursor]
+}
+
+// Index returns the position of the cursor in Items
+func (l *ReminderList) Index() int {
+	return l.cursor
+}
+
+// CursorDown moves down, wrapping around at the end
+func (l *ReminderList) CursorDown() {
+	if len(l.visible) > 0 {
+		l.cursor = (l.cursor + 1) % len(l.visible)
+	}
+}
+
+// CursorUp moves up, wrapping around at the start
+func (l *ReminderList) CursorUp() {
+	if len(l.visible) > 0 {
+		l.cursor = (l.cursor - 1 + len(l.visible)) % len(l.visible)
+	}
+}
+
+// NextPage moves the cursor a page down
+func (l *ReminderList) NextPage() {
+	l.cursor += l.perPage()
+	l.clamp()
+}
+
+// PrevPage moves the cursor a page up
+func (l *ReminderList) PrevPage() {
+	l.cursor = max(l.cursor-l.perPage(), 0)
+}
+
+// GoToStart moves the cursor to the first reminder
+func (l *ReminderList) GoToStart() {
+	l.cursor = 0
+}
+
+// GoToEnd moves the cursor to the last reminder
+func (l *ReminderList) GoToEnd() {
+	l.cursor = max(len(l.visible)-1, 0)
+}
+
+// Page returns the bounds in Items of the page with the cursor
+func (l *ReminderList) Page() (start, end int) {
+	perPage := l.perPage()
+	start = l.cursor / perPage * perPage
+	return start, min(start+perPage, len(l.visible))
+}
+
+// PageView shows which page is on screen, or "" when everything fits
+func (l *ReminderList) PageView() string {
+	l.pages.PerPage = l.perPage()
+	if l.pages.SetTotalPages(len(l.visible)) < 2 {
+		return ""
+	}
+	l.pages.Page = l.cursor / l.pages.PerPage
+	return l.pages.View()
+}
+
+// perPage is the number of reminders on a page
+func (l *ReminderList) perPage() int {
+	if l.height <= 0 {
+		return max(len(l.visible), 1)
+	}
+	return l.height
+}
+
+// clamp keeps the cursor on a reminder after the list shrinks
+func (l *ReminderList) clamp() {
+	l.cursor = min(l.cursor, len(l.visible)-1)
+	l.cursor = max(l.cursor, 0)
+}
+
+// Searching reports whether the query is being typed, so keys go to it
+func (l *ReminderList) Searching() bool {
+	return l.searching
+}
+
+// Query returns the search query
+func (l *ReminderList) Query() string {
+	return strings.TrimSpace(l.query.Value())
+}
+
+// StartSearch focuses the query
+func (l *ReminderList) StartSearch() tea.Cmd {
+	l.searching = true
+	return l.query.Focus()
+}
+
+// ClearSearch drops the query and shows every reminder again
+func (l *ReminderList) ClearSearch() {
+	l.searching = false
+	l.query.Blur()
+	l.query.SetValue("")
+	l.SetItems(l.items)
+}
+
+// Update handles keys while the query is typed: enter keeps the matches,
+// esc drops the query
+func (l *ReminderList) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			l.ClearSearch()
+			return nil
+		case "enter":
+			l.searching = false
+			l.query.Blur()
+			if l.Query() == "" {
+				l.ClearSearch()
+			}
+			return nil
+		case "up", "down":
+			// Move through the matches without leaving the query
+			if msg.String() == "up" {
+				l.CursorUp()
+			} else {
+				l.CursorDown()
+			}
+			return nil
+		}
+	}
+
+	before := l.query.Value()
+	var cmd tea.Cmd
+	l.query, cmd = l.query.Update(msg)
+	if l.query.Value() != before {
+		l.match()
+		l.cursor = 0
+	}
+	return cmd
+}
+
+// SearchView shows the query and how many reminders match it, or "" when
+// there is no search
+func (l *ReminderList) SearchView() string {
+	if !l.searching && l.Query() == "" {
+		return ""
+	}
+	return l.query.View() + "  " + helpStyle.Render(i18n.T("%d of %d", len(l.visible), len(l.items)))
+}
+
+// match narrows items to the ones matching the query, best match first
+func (l *ReminderList) match() {
+	query := l.Query()
+	if query == "" {
+		l.visible = l.items
+		return
+	}
+
+	type scored struct {
+		reminder *models.Reminder
+		score    int
+	}
+	var matches []scored
+	for _, reminder := range l.items {
+		if score, ok := matchReminder(query, reminder); ok {
+			matches = append(matches, scored{reminder, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	l.visible = make([]*models.Reminder, len(matches))
+	for i, match := range matches {
+		l.visible[i] = match.reminder
+	}
+}
+
+// matchReminder scores the best match of query against a reminder's title,
+// tags and ID
+func matchReminder(query string, reminder *models.Reminder) (int, bool) {
+	best, found := FuzzyMatch(query, reminder.Title)
+	candidates := []string{reminder.DisplayID()}
+	for _, tag := range reminder.Tags {
+		candidates = append(candidates, "#"+tag)
+	}
+	for _, candidate := range candidates {
+		if score, ok := FuzzyMatch(query, candidate); ok && (!found || score > best) {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+// FuzzyMatch reports whether the letters of pattern appear in text in order,
+// ignoring case, and scores the match: consecutive letters and letters
+// starting a word score higher, so "gro" ranks "Buy groceries" above
+// "Sign the car over".
+func FuzzyMatch(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return 0, true
+	}
+
+	score, pi, last := 0, 0, -1
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		// Spaces in the pattern match any gap
+		for pi < len(p) && unicode.IsSpace(p[pi]) {
+			pi++
+		}
+		if pi == len(p) {
+			break
+		}
+		if t[ti] != p[pi] {
+			continue
+		}
+		score++
+		if last >= 0 && ti == last+1 {
+			score += 5
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 8
+		}
+		if last < 0 {
+			// Matches starting late rank a little lower
+			score -= min(ti, 5)
+		}
+		last = ti
+		pi++
+	}
+	return score, pi == len(p)
+}
@@ -0,0 +1,197 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// tagBarWidth is the width of the bar showing the most used tag
+const tagBarWidth = 12
+
+// TagBrowser is the overlay listing every tag with how many active
+// reminders carry it. Picking tags filters the main list by them.
+type TagBrowser struct {
+	tags      []string
+	counts    map[string]int
+	selected  map[string]bool
+	allTags   bool // Reminders need every selected tag, not just one
+	cursor    int
+	height    int // Rows for tags, 0 meaning all of them
+	done      bool
+	cancelled bool
+}
+
+// NewTagBrowser lists the tags in counts, with the tags of the current
+// filter selected
+func NewTagBrowser(counts map[string]int, values FilterValues) *TagBrowser {
+	b := &TagBrowser{
+		counts:   counts,
+		selected: make(map[string]bool),
+		allTags:  values.AllTags,
+	}
+	for tag := range counts {
+		b.tags = append(b.tags, tag)
+	}
+	for _, tag := range values.Tags {
+		b.selected[tag] = true
+		if _, ok := counts[tag]; !ok {
+			// Keep filter tags no active reminder has, so they can be dropped
+			b.tags = append(b.tags, tag)
+		}
+	}
+	sort.Strings(b.tags)
+	return b
+}
+
+func (b *TagBrowser) Init() tea.Cmd {
+	return nil
+}
+
+// SetHeight sets how many tags fit on the screen
+func (b *TagBrowser) SetHeight(height int) {
+	b.height = max(height, 0)
+}
+
+func (b *TagBrowser) Update(msg tea.Msg) (*TagBrowser, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return b, nil
+	}
+	if key := keyMsg.String(); key == "ctrl+c" || key == "esc" || key == "q" {
+		b.cancelled = true
+		return b, nil
+	}
+	if len(b.tags) == 0 {
+		return b, nil
+	}
+
+	switch keyMsg.String() {
+	case "j", "down":
+		b.cursor = (b.cursor + 1) % len(b.tags)
+
+	case "k", "up":
+		b.cursor = (b.cursor - 1 + len(b.tags)) % len(b.tags)
+
+	case " ", "x":
+		tag := b.tags[b.cursor]
+		b.selected[tag] = !b.selected[tag]
+
+	case "tab", "m":
+		b.allTags = !b.allTags
+
+	case "c":
+		b.selected = make(map[string]bool)
+
+	case "enter":
+		// With nothing marked, enter picks the tag under the cursor
+		if len(b.Tags()) == 0 {
+			b.selected[b.tags[b.cursor]] = true
+		}
+		b.done = true
+	}
+	return b, nil
+}
+
+func (b *TagBrowser) View() string {
+	var s strings.Builder
+
+	s.WriteString(focusedStyle.Render(utils.Symbol("🏷 ", "")+i18n.T("Tags")) + "\n\n")
+
+	if len(b.tags) == 0 {
+		s.WriteString(i18n.T("No tags yet. Add some with #tag in a reminder.") + "\n\n")
+		s.WriteString(helpStyle.Render(i18n.T("esc: back")))
+		return s.String()
+	}
+
+	most := 1
+	width := 0
+	for _, tag := range b.tags {
+		most = max(most, b.counts[tag])
+		width = max(width, len(tag))
+	}
+
+	start, end := b.window()
+	for i := start; i < end; i++ {
+		tag := b.tags[i]
+		cursor, mark := "  ", "[ ]"
+		if i == b.cursor {
+			cursor = "> "
+		}
+		if b.selected[tag] {
+			mark = "[x]"
+		}
+
+		count := b.counts[tag]
+		bar := strings.Repeat("█", max(count*tagBarWidth/most, min(count, 1)))
+		line := fmt.Sprintf("%s%s #%-*s %3d %s", cursor, mark, width, tag, count, bar)
+		if i == b.cursor {
+			line = focusedStyle.Render(line)
+		} else if count == 0 {
+			line = blurredStyle.Render(line)
+		}
+		s.WriteString(line + "\n")
+	}
+	if start > 0 || end < len(b.tags) {
+		s.WriteString(helpStyle.Render(i18n.T("%d of %d", end-start, len(b.tags))) + "\n")
+	}
+
+	s.WriteString("\n")
+	if b.allTags {
+		s.WriteString(i18n.T("Match: reminders with all marked tags (AND)") + "\n")
+	} else {
+		s.WriteString(i18n.T("Match: reminders with any marked tag (OR)") + "\n")
+	}
+	if tags := b.Tags(); len(tags) > 0 {
+		chips := make([]string, len(tags))
+		for i, tag := range tags {
+			chips[i] = "#" + tag
+		}
+		s.WriteString(RenderChips(chips) + "\n")
+	}
+	s.WriteString("\n")
+
+	s.WriteString(helpStyle.Render(i18n.T("↑/↓: move • space: mark • tab: AND/OR • c: clear • enter: filter • esc: cancel")))
+
+	return s.String()
+}
+
+// window returns the tags on screen, scrolled to keep the cursor in view
+func (b *TagBrowser) window() (start, end int) {
+	if b.height <= 0 || len(b.tags) <= b.height {
+		return 0, len(b.tags)
+	}
+	start = min(max(b.cursor-b.height/2, 0), len(b.tags)-b.height)
+	return start, start + b.height
+}
+
+// Tags returns the marked tags, sorted
+func (b *TagBrowser) Tags() []string {
+	var tags []string
+	for _, tag := range b.tags {
+		if b.selected[tag] {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// AllTags reports whether reminders need every marked tag
+func (b *TagBrowser) AllTags() bool {
+	return b.allTags
+}
+
+// Done reports whether the tags were applied
+func (b *TagBrowser) Done() bool {
+	return b.done
+}
+
+// Cancelled reports whether the browser was closed without filtering
+func (b *TagBrowser) Cancelled() bool {
+	return b.cancelled
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+var (
+	detailStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("62")).
+			Padding(0, 1)
+
+	detailLabelStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("205"))
+)
+
+// detailHistory is how many past occurrences the detail pane lists
+const detailHistory = 5
+
+// minSplitWidth is the narrowest terminal that fits the list and the detail
+// pane side by side; below it the pane goes under the list
+const minSplitWidth = 80
+
+// detailView renders everything about the selected reminder in a pane of the
+// given width
+func (m Model) detailView(width int) string {
+	reminder := m.getCurrentReminder()
+	if reminder == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	field := func(label, value string) {
+		b.WriteString(detailLabelStyle.Render(label) + " " + value + "\n")
+	}
+
+	b.WriteString(titleStyle.UnsetMarginLeft().Render(reminder.Title) + "\n\n")
+	field(i18n.T("ID:"), reminder.DisplayID())
+	field(i18n.T("Due:"), reminder.FormattedDueTime())
+	field(i18n.T("Priority:"), utils.PriorityIcon(reminder.Priority)+" "+i18n.T(reminder.Priority.String()))
+	field(i18n.T("Status:"), detailStatus(reminder))
+
+	if reminder.IsCritical() {
+		field(i18n.T("Critical:"), i18n.T("notifies even in quiet hours and meetings"))
+	}
+	if reminder.Sticky {
+		field(i18n.T("Sticky:"), i18n.T("stays on screen until you act on it"))
+	}
+	if reminder.Grace != nil {
+		if grace := reminder.OverdueGrace(); grace > 0 {
+			field(i18n.T("Grace:"), i18n.T("nags once %s overdue", utils.FormatDuration(grace)))
+		} else {
+			field(i18n.T("Grace:"), i18n.T("nags as soon as it's overdue"))
+		}
+	}
+	if reminder.DoneWhen != "" {
+		field(i18n.T("Done when:"), i18n.T("%s succeeds", reminder.DoneWhen))
+	}
+	if reminder.Nag > 0 {
+		field(i18n.T("Nag:"), i18n.T("every %s once overdue", utils.FormatDuration(reminder.NagInterval())))
+	}
+	if color := reminder.LabelColor(); color != "" {
+		field(i18n.T("Color:"), utils.ColorLabel(color))
+	}
+	if len(reminder.Tags) > 0 {
+		field(i18n.T("Tags:"), strings.Join(reminder.Tags, ", "))
+	}
+	if reminder.Assignee != "" {
+		field(i18n.T("For:"), reminder.Assignee)
+	}
+	if reminder.Recurring != nil {
+		field(i18n.T("Repeats:"), reminder.Recurring.String())
+	}
+	if reminder.CheckIn != "" {
+		field(i18n.T("Check-in:"), reminder.CheckInText())
+	}
+	if reminder.SunEvent != "" {
+		field(i18n.T("Follows:"), i18n.T(reminder.SunEvent))
+	}
+	if reminder.Issue != "" {
+		field(i18n.T("Issue:"), reminder.Issue)
+	}
+	if reminder.URL != "" {
+		field(i18n.T("Link:"), reminder.URL)
+	}
+	if reminder.Input != "" && reminder.Input != reminder.Title {
+		field(i18n.T("Typed:"), reminder.Input)
+	}
+	if len(reminder.TimeLog) > 0 {
+		tracked := utils.TrackedText(reminder.TrackedTime(time.Now()))
+		if reminder.TimerRunning() {
+			tracked += " " + i18n.T("(timer running)")
+		}
+		field(i18n.T("Tracked:"), tracked)
+	}
+
+	if reminder.Description != "" {
+		b.WriteString("\n" + detailLabelStyle.Render(i18n.T("Description:")) + "\n")
+		// Border and padding take four columns
+		b.WriteString(utils.RenderMarkdown(reminder.Description, width-4) + "\n")
+	}
+
+	if len(reminder.History) > 0 {
+		b.WriteString("\n" + detailLabelStyle.Render(i18n.T("History:")) + "\n")
+		history := reminder.History
+		if len(history) > detailHistory {
+			history = history[len(history)-detailHistory:]
+		}
+		for _, occurrence := range history {
+			b.WriteString(fmt.Sprintf("%s  %s\n",
+				i18n.FormatTime(occurrence.DueTime, "Mon Jan 2 15:04"),
+				utils.OccurrenceLabel(occurrence.Status)))
+		}
+
+		if onTime, late, skipped, missed := reminder.Adherence(); onTime+late+skipped+missed > 0 {
+			b.WriteString(i18n.T("On time: %d  Late: %d  Skipped: %d  Missed: %d  (%d%% on time)",
+				onTime, late, skipped, missed, onTime*100/(onTime+late+skipped+missed)) + "\n")
+		}
+	}
+
+	// Width includes the border, Style.Width does not
+	return detailStyle.Width(width - 2).Render(strings.TrimRight(b.String(), "\n"))
+}
+
+// detailStatus describes where the reminder stands
+func detailStatus(reminder *models.Reminder) string {
+	switch {
+	case reminder.Completed:
+		return i18n.T("Completed")
+	case reminder.Recurring != nil && reminder.Recurring.Paused:
+		return i18n.T("Paused")
+	case reminder.IsOverdue():
+		return utils.OverdueText(reminder.OverdueAge())
+	case reminder.IsDueSoon():
+		return i18n.T("DUE SOON")
+	}
+	return i18n.T("Active")
+}
@@ -0,0 +1,24 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// exportVisible writes the reminders as currently filtered and sorted
+func (m Model) exportVisible(path, format string) error {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+
+	data, err := utils.ExportReminders(m.list.Items(), format)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
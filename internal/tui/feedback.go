@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// flashDuration is how long a flash message stays in the status bar
+const flashDuration = 1500 * time.Millisecond
+
+// clearFlashMsg removes the flash message once it has expired
+type clearFlashMsg struct {
+	id int
+}
+
+// feedback gives audible and/or visual confirmation of an action
+// according to the configured mode ("none", "bell", "flash" or "both")
+func (m *Model) feedback(mode, message string) tea.Cmd {
+	var cmds []tea.Cmd
+
+	if mode == "bell" || mode == "both" {
+		cmds = append(cmds, func() tea.Msg {
+			fmt.Fprint(os.Stderr, "\a")
+			return nil
+		})
+	}
+
+	if mode == "flash" || mode == "both" {
+		m.flashID++
+		m.flash = message
+		id := m.flashID
+		cmds = append(cmds, tea.Tick(flashDuration, func(time.Time) tea.Msg {
+			return clearFlashMsg{id: id}
+		}))
+	}
+
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/tui/components"
+)
+
+// filterFile keeps the last-used filter between sessions
+const filterFile = "filter.json"
+
+// loadFilter reads the saved filter, if any. A missing or broken file just
+// means no filter.
+func (m *Model) loadFilter() {
+	data, err := os.ReadFile(filepath.Join(m.config.GetConfigDir(), filterFile))
+	if err != nil {
+		return
+	}
+
+	var values components.FilterValues
+	if err := json.Unmarshal(data, &values); err != nil {
+		return
+	}
+	if err := values.Apply(m.filter); err != nil {
+		return
+	}
+	m.filterValues = values
+}
+
+// saveFilter remembers the current filter for the next session
+func (m *Model) saveFilter() error {
+	data, err := json.MarshalIndent(m.filterValues, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := m.config.GetConfigDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, filterFile), data, 0644)
+}
@@ -1,10 +1,13 @@
 package tui
 
 import (
+	"time"
+
 	"github.com/charmbracelet/bubbletea"
 	"github.com/ivyascorp-net/nagging-nancy/internal/app"
 	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 	"github.com/ivyascorp-net/nagging-nancy/internal/tui/components"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 )
 
 // Model represents the application state for the TUI
@@ -13,54 +16,85 @@ type Model struct {
 	config       *app.Config
 	width        int
 	height       int
-	reminders    []*models.Reminder
-	cursor       int
+	list         *components.ReminderList
 	showHelp     bool
+	showDetail   bool // Detail pane for the selected reminder
 	filter       *models.FilterOptions
 	quitting     bool
 	editing      bool
 	editForm     *components.EditForm
+	filtering    bool // Filter builder overlay is open
+	filterForm   *components.FilterForm
+	filterValues components.FilterValues
+	exporting    bool // Export prompt is open
+	exportForm   *components.ExportForm
+	browsingTags bool // Tag browser is open
+	tagBrowser   *components.TagBrowser
+	flash        string // Transient feedback shown in the status bar
+	flashID      int
+	focus        *utils.Focus     // Running focus session, if any
+	reopen       *models.Reminder // Recurring reminder as it was before space completed it
+	reopenAfter  time.Time        // Its UpdatedAt right after, so space again undoes only that
+	events       <-chan models.Event
 }
 
 // NewModel creates a new TUI model
 func NewModel(store *models.Store, config *app.Config) Model {
 	filter := &models.FilterOptions{
 		ShowCompleted: false,
+		Assignee:      config.CurrentUser(),
 	}
 
 	model := Model{
-		store:     store,
-		config:    config,
-		reminders: store.GetAll(filter),
-		cursor:    0,
-		showHelp:  false,
-		filter:    filter,
-		quitting:  false,
+		store:    store,
+		config:   config,
+		list:     components.NewReminderList(),
+		showHelp: false,
+		filter:   filter,
+		quitting: false,
 	}
+	model.loadFilter()
+	model.loadFocus()
+	model.list.SetItems(store.GetAll(filter))
+	model.events, _ = store.Subscribe()
 
 	return model
 }
 
 // Init implements tea.Model
 func (m Model) Init() tea.Cmd {
-	return nil
+	return tea.Batch(tick(), waitForEvent(m.events))
 }
 
-// refreshReminders loads reminders from store
+// refreshReminders loads reminders from store, keeping the selection on
+// the same reminder
 func (m *Model) refreshReminders() {
-	m.reminders = m.store.GetAll(m.filter)
-	if m.cursor >= len(m.reminders) && len(m.reminders) > 0 {
-		m.cursor = len(m.reminders) - 1
+	m.list.SetItems(m.store.GetAll(m.filter))
+	m.resizeList()
+}
+
+// resizeList fits a page of the list between the header and the status bar
+func (m *Model) resizeList() {
+	if m.height == 0 {
+		return
 	}
-	if len(m.reminders) == 0 {
-		m.cursor = 0
+
+	// Title, blank line, search or page line, blank line, flash, status bar
+	chrome := 6
+	for _, line := range []string{m.summaryView(), m.focusBannerView(), components.RenderChips(m.filterValues.Chips())} {
+		if line != "" {
+			chrome++
+		}
+	}
+	height := m.height - chrome
+	if m.showDetail && m.width < minSplitWidth {
+		// The detail pane goes under the list
+		height /= 2
 	}
+	m.list.SetHeight(max(height, 3))
 }
 
 // getCurrentReminder returns the currently selected reminder
 func (m Model) getCurrentReminder() *models.Reminder {
-	if len(m.reminders) == 0 || m.cursor < 0 || m.cursor >= len(m.reminders) {
-		return nil
-	}
-	return m.reminders[m.cursor]
+	return m.list.Selected()
 }
\ No newline at end of file
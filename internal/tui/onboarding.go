@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// demoTag marks the sample reminders so they are easy to find and remove
+const demoTag = "demo"
+
+var keyStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("212"))
+
+// emptyStateView welcomes a first-time user whose store has no reminders
+func (m Model) emptyStateView() string {
+	var s strings.Builder
+
+	s.WriteString(utils.Symbol("👋 ", "") + i18n.T("Welcome! You don't have any reminders yet.") + "\n\n")
+
+	hint := func(key, text string) {
+		s.WriteString("  " + keyStyle.Render(key) + strings.Repeat(" ", max(6-lipgloss.Width(key), 1)) + text + "\n")
+	}
+	hint("a", i18n.T("Add your first reminder"))
+	hint("D", i18n.T("Load a few sample reminders to try things out"))
+	hint("?", i18n.T("See all keyboard shortcuts"))
+	hint("q", i18n.T("Quit"))
+
+	s.WriteString("\n" + helpStyle.Render(i18n.T("Once you have reminders: space completes, e edits, d deletes, enter shows details.")) + "\n")
+	s.WriteString(helpStyle.Render(i18n.T("From the shell: nancy add \"Call mom tomorrow at 3pm\"")) + "\n")
+
+	return s.String()
+}
+
+// demoReminders returns sample reminders that show off what the list can do
+func demoReminders() []*models.Reminder {
+	now := time.Now()
+	at := func(days, hour int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, hour, 0, 0, 0, time.Local)
+	}
+
+	welcome := models.NewReminder(i18n.T("Press space to complete me"), now.Add(time.Hour).Truncate(time.Minute), models.Medium)
+
+	overdue := models.NewReminder(i18n.T("Overdue reminders stand out like this"), now.Add(-2*time.Hour).Truncate(time.Minute), models.High)
+
+	stretch := models.NewReminder(i18n.T("Stretch and drink some water"), at(1, 15), models.Low)
+	stretch.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 1}
+	stretch.SetDescription(i18n.T("Recurring reminders move to the next day when completed. Press s to skip one."))
+
+	report := models.NewReminder(i18n.T("Send the weekly report"), at(2, 10), models.High)
+	report.AddTag("work")
+
+	reminders := []*models.Reminder{welcome, overdue, stretch, report}
+	for _, reminder := range reminders {
+		reminder.AddTag(demoTag)
+	}
+	return reminders
+}
+
+// loadDemoReminders adds the sample reminders to the store
+func (m *Model) loadDemoReminders() error {
+	if err := m.store.AddAll(demoReminders()); err != nil {
+		return err
+	}
+	m.refreshReminders()
+	return nil
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// tickInterval is how often the TUI redraws so due-soon and overdue
+// statuses follow the clock without a keypress
+const tickInterval = 30 * time.Second
+
+// tickMsg is sent every tickInterval
+type tickMsg time.Time
+
+var overdueSummaryStyle = lipgloss.NewStyle().
+	Bold(true).
+	Blink(true).
+	Foreground(lipgloss.Color("196"))
+
+var focusBannerStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("0")).
+	Background(lipgloss.Color("141"))
+
+// tick schedules the next tickMsg
+func tick() tea.Cmd {
+	return tea.Tick(tickInterval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// storeEventMsg is sent when the store changes, in the TUI or elsewhere
+type storeEventMsg models.Event
+
+// waitForEvent waits for the next store change
+func waitForEvent(events <-chan models.Event) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return nil
+		}
+		return storeEventMsg(event)
+	}
+}
+
+// summaryView is the header line with what needs attention, e.g.
+// "⚠ 2 overdue • 3 today • next in 25 minutes". It covers all active
+// reminders, whatever the list is filtered to.
+func (m Model) summaryView() string {
+	overdue, today := 0, 0
+	var next *models.Reminder
+
+	for _, reminder := range m.store.GetAll(&models.FilterOptions{Assignee: m.config.CurrentUser()}) {
+		if reminder.Recurring != nil && reminder.Recurring.Paused {
+			continue
+		}
+		if reminder.IsOverdue() {
+			overdue++
+			continue
+		}
+		if reminder.IsDueToday() {
+			today++
+		}
+		if next == nil || reminder.DueTime.Before(next.DueTime) {
+			next = reminder
+		}
+	}
+
+	var parts []string
+	if overdue > 0 {
+		text := utils.Symbol("⚠ ", "") + i18n.T("%d overdue", overdue)
+		if !utils.AccessibleMode() {
+			text = overdueSummaryStyle.Render(text)
+		}
+		parts = append(parts, text)
+	}
+	if today > 0 {
+		parts = append(parts, i18n.T("%d today", today))
+	}
+	if next != nil {
+		if until := next.TimeUntilDue(); until < time.Minute {
+			parts = append(parts, i18n.T("next due now"))
+		} else {
+			parts = append(parts, i18n.T("next in %s", utils.FormatDuration(until)))
+		}
+	}
+	return strings.Join(parts, " • ")
+}
+
+// loadFocus picks up a focus session started or ended with 'nancy focus'.
+// An ended session reads as none, so the banner goes away on its own.
+func (m *Model) loadFocus() {
+	m.focus, _ = utils.LoadFocus(filepath.Join(m.config.GetDataDir(), utils.FocusFile), time.Now())
+}
+
+// focusBannerView is the banner shown while a focus session runs
+func (m Model) focusBannerView() string {
+	if m.focus == nil {
+		return ""
+	}
+	banner := utils.Symbol("🎯 ", "") + m.focus.Banner(time.Now())
+	if utils.AccessibleMode() {
+		return banner
+	}
+	return focusBannerStyle.Render(" " + banner + " ")
+}
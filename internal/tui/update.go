@@ -1,25 +1,53 @@
 package tui
 
 import (
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 	"github.com/ivyascorp-net/nagging-nancy/internal/tui/components"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 )
 
 // Update implements tea.Model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Keep the clock ticking whatever is on screen
+	if _, ok := msg.(tickMsg); ok {
+		m.refreshReminders()
+		m.loadFocus()
+		return m, tick()
+	}
+
+	// Show changes made elsewhere, like 'nancy add' in another terminal
+	if _, ok := msg.(storeEventMsg); ok {
+		m.refreshReminders()
+		return m, waitForEvent(m.events)
+	}
+
 	// Handle edit form updates when in edit mode
 	if m.editing && m.editForm != nil {
 		var cmd tea.Cmd
 		m.editForm, cmd = m.editForm.Update(msg)
 		
 		if m.editForm.Done() {
-			// Save the edited reminder
 			reminder := m.editForm.GetReminder()
+			isNew := m.editForm.IsNew()
+			m.editing = false
+			m.editForm = nil
+
+			if isNew {
+				if err := m.store.Add(reminder); err != nil {
+					return m, m.feedback("flash", utils.Symbol("✗ ", "[ERROR] ")+i18n.T("Cannot add reminder: %v", err))
+				}
+				m.refreshReminders()
+				return m, m.feedback("flash", utils.Symbol("✓ ", "")+i18n.T("Added reminder: %s", reminder.Title))
+			}
+
+			// Save the edited reminder
 			if err := m.store.Update(reminder); err == nil {
 				m.refreshReminders()
 			}
-			m.editing = false
-			m.editForm = nil
 		} else if m.editForm.Cancelled() {
 			// Cancel editing
 			m.editing = false
@@ -29,10 +57,93 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if m.filtering && m.filterForm != nil {
+		var cmd tea.Cmd
+		m.filterForm, cmd = m.filterForm.Update(msg)
+
+		if m.filterForm.Done() {
+			m.filterValues = m.filterForm.Values()
+			// Dates were checked by the form, so this can't fail
+			m.filterValues.Apply(m.filter)
+			m.list.GoToStart()
+			m.refreshReminders()
+			m.filtering = false
+			m.filterForm = nil
+			if err := m.saveFilter(); err != nil {
+				return m, m.feedback("flash", utils.Symbol("✗ ", "[ERROR] ")+i18n.T("Cannot save filter: %v", err))
+			}
+		} else if m.filterForm.Cancelled() {
+			m.filtering = false
+			m.filterForm = nil
+		}
+
+		return m, cmd
+	}
+
+	if m.exporting && m.exportForm != nil {
+		var cmd tea.Cmd
+		m.exportForm, cmd = m.exportForm.Update(msg)
+
+		if m.exportForm.Done() {
+			path, format := m.exportForm.Path(), m.exportForm.Format()
+			m.exporting = false
+			m.exportForm = nil
+			if err := m.exportVisible(path, format); err != nil {
+				return m, m.feedback("flash", utils.Symbol("✗ ", "[ERROR] ")+i18n.T("Cannot export: %v", err))
+			}
+			return m, m.feedback("flash", utils.Symbol("📤 ", "")+i18n.T("Exported %d reminders to %s", len(m.list.Items()), path))
+		} else if m.exportForm.Cancelled() {
+			m.exporting = false
+			m.exportForm = nil
+		}
+
+		return m, cmd
+	}
+
+	if m.browsingTags && m.tagBrowser != nil {
+		var cmd tea.Cmd
+		m.tagBrowser, cmd = m.tagBrowser.Update(msg)
+
+		if m.tagBrowser.Done() {
+			m.filterValues.Tags = m.tagBrowser.Tags()
+			m.filterValues.AllTags = m.tagBrowser.AllTags()
+			m.filterValues.Apply(m.filter)
+			m.list.GoToStart()
+			m.refreshReminders()
+			m.browsingTags = false
+			m.tagBrowser = nil
+			if err := m.saveFilter(); err != nil {
+				return m, m.feedback("flash", utils.Symbol("✗ ", "[ERROR] ")+i18n.T("Cannot save filter: %v", err))
+			}
+		} else if m.tagBrowser.Cancelled() {
+			m.browsingTags = false
+			m.tagBrowser = nil
+		}
+
+		return m, cmd
+	}
+
+	// Keys go to the search query while it is typed
+	if m.list.Searching() {
+		switch msg.(type) {
+		case tea.WindowSizeMsg, clearFlashMsg:
+		default:
+			return m, m.list.Update(msg)
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resizeList()
+		return m, nil
+
+	case clearFlashMsg:
+		// Ignore timers from older flashes
+		if msg.id == m.flashID {
+			m.flash = ""
+		}
 		return m, nil
 
 	case tea.KeyMsg:
@@ -52,39 +163,115 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "j", "down":
-			if len(m.reminders) > 0 {
-				m.cursor++
-				if m.cursor >= len(m.reminders) {
-					m.cursor = 0
-				}
-			}
+			m.list.CursorDown()
 			return m, nil
 
 		case "k", "up":
-			if len(m.reminders) > 0 {
-				m.cursor--
-				if m.cursor < 0 {
-					m.cursor = len(m.reminders) - 1
-				}
-			}
+			m.list.CursorUp()
+			return m, nil
+
+		case "right", "pgdown":
+			m.list.NextPage()
+			return m, nil
+
+		case "left", "pgup":
+			m.list.PrevPage()
+			return m, nil
+
+		case "g", "home":
+			m.list.GoToStart()
+			return m, nil
+
+		case "G", "end":
+			m.list.GoToEnd()
+			return m, nil
+
+		case "/":
+			// Search the list as you type
+			return m, m.list.StartSearch()
+
+		case "esc":
+			// Drop a search kept with enter
+			m.list.ClearSearch()
 			return m, nil
 
 		case " ":
 			// Toggle completion
 			if current := m.getCurrentReminder(); current != nil {
-				m.store.ToggleReminder(current.ID)
+				// Completing a recurring reminder moves it on to its next
+				// occurrence; space again puts it back as it was
+				if previous := m.reopen; previous != nil && previous.ID == current.ID && current.UpdatedAt.Equal(m.reopenAfter) {
+					m.reopen = nil
+					if err := m.store.Update(previous); err != nil {
+						return m, nil
+					}
+					m.refreshReminders()
+					return m, m.feedback(m.config.Appearance.Feedback.Uncomplete, utils.Symbol("↺ ", "")+i18n.T("Reopened: %s", current.Title))
+				}
+
+				m.reopen = nil
+				previous := current.Clone()
+				if err := m.store.ToggleReminder(current.ID); err != nil {
+					return m, nil
+				}
+				if toggled, err := m.store.Get(current.ID); err == nil && current.Recurring != nil && !toggled.Completed && !current.Completed {
+					m.reopen, m.reopenAfter = previous, toggled.UpdatedAt
+				}
 				m.refreshReminders()
+
+				feedback := m.config.Appearance.Feedback
+				if current.Completed {
+					return m, m.feedback(feedback.Uncomplete, utils.Symbol("↺ ", "")+i18n.T("Reopened: %s", current.Title))
+				}
+				return m, m.feedback(feedback.Complete, utils.Symbol("✓ ", "")+i18n.T("Completed: %s", current.Title))
+			}
+			return m, nil
+
+		case "s":
+			// Skip to the next occurrence of a recurring reminder
+			if current := m.getCurrentReminder(); current != nil && current.Recurring != nil {
+				if err := m.store.SkipReminder(current.ID); err != nil {
+					// Always explain why the skip was refused
+					return m, m.feedback("flash", utils.Symbol("✗ ", "[ERROR] ")+i18n.T("Cannot skip: %v", err))
+				}
+				m.refreshReminders()
+				return m, m.feedback(m.config.Appearance.Feedback.Complete, utils.Symbol("⏭ ", "")+i18n.T("Skipped: %s", current.Title))
 			}
 			return m, nil
 
 		case "d":
 			// Delete current reminder
 			if current := m.getCurrentReminder(); current != nil {
-				m.store.Delete(current.ID)
+				if err := m.store.Delete(current.ID); err != nil {
+					return m, nil
+				}
 				m.refreshReminders()
+				return m, m.feedback(m.config.Appearance.Feedback.Delete, utils.Symbol("🗑 ", "")+i18n.T("Deleted: %s", current.Title))
 			}
 			return m, nil
 
+		case "enter":
+			m.showDetail = !m.showDetail
+			m.resizeList()
+			return m, nil
+
+		case "a", "n":
+			reminder := models.NewReminder("", time.Now().Add(time.Hour).Truncate(time.Minute),
+				models.ParsePriority(m.config.Default.Priority))
+			m.editing = true
+			m.editForm = components.NewAddForm(reminder)
+			return m, m.editForm.Init()
+
+		case "D":
+			// Sample data is only offered while the store is empty
+			if total, _, _, _ := m.store.Count(); total > 0 {
+				return m, nil
+			}
+			if err := m.loadDemoReminders(); err != nil {
+				return m, m.feedback("flash", utils.Symbol("✗ ", "[ERROR] ")+i18n.T("Cannot add reminder: %v", err))
+			}
+			return m, m.feedback("flash", utils.Symbol("✓ ", "")+i18n.T("Added sample reminders tagged #%s", demoTag))
+
 		case "e":
 			if current := m.getCurrentReminder(); current != nil {
 				reminder, err := m.store.Get(current.ID)
@@ -105,8 +292,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "f":
 			// Toggle show completed filter
 			m.filter.ShowCompleted = !m.filter.ShowCompleted
+			m.filterValues.ShowCompleted = m.filter.ShowCompleted
 			m.refreshReminders()
+			m.saveFilter()
 			return m, nil
+
+		case "t":
+			// Start the timer on the selected reminder, or stop it if it runs
+			current := m.getCurrentReminder()
+			if current == nil {
+				return m, nil
+			}
+			if current.TimerRunning() {
+				_, session, err := m.store.StopTimer()
+				if err != nil {
+					return m, m.feedback("flash", utils.Symbol("✗ ", "[ERROR] ")+i18n.T("Cannot stop the timer: %v", err))
+				}
+				m.refreshReminders()
+				return m, m.feedback("flash", utils.Symbol("⏹ ", "")+i18n.T("Stopped: %s (%s)", current.Title, utils.TrackedText(session)))
+			}
+			if _, err := m.store.StartTimer(current.ID); err != nil {
+				return m, m.feedback("flash", utils.Symbol("✗ ", "[ERROR] ")+i18n.T("Cannot start the timer: %v", err))
+			}
+			m.refreshReminders()
+			return m, m.feedback("flash", utils.Symbol("⏱ ", "")+i18n.T("Started: %s", current.Title))
+
+		case "x":
+			if len(m.list.Items()) == 0 {
+				return m, nil
+			}
+			m.exporting = true
+			m.exportForm = components.NewExportForm(len(m.list.Items()))
+			return m, m.exportForm.Init()
+
+		case "T":
+			m.browsingTags = true
+			m.tagBrowser = components.NewTagBrowser(m.store.TagCounts(), m.filterValues)
+			// Leave room for the title, the AND/OR line and the help
+			m.tagBrowser.SetHeight(m.height - 9)
+			return m, m.tagBrowser.Init()
+
+		case "F":
+			m.filtering = true
+			m.filterForm = components.NewFilterForm(m.filterValues, m.store.GetTags())
+			return m, m.filterForm.Init()
 		}
 	}
 
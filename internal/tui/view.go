@@ -6,6 +6,10 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/tui/components"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
 )
 
 var (
@@ -28,18 +32,35 @@ var (
 	completedStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
 		Strikethrough(true)
+
+	flashStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("0")).
+		Background(lipgloss.Color("42"))
 )
 
 // View implements tea.Model
 func (m Model) View() string {
 	if m.quitting {
-		return "Thanks for using Nagging Nancy! 👋\n"
+		return i18n.T("Thanks for using Nagging Nancy!") + utils.Symbol(" 👋", "") + "\n"
 	}
 
 	if m.editing && m.editForm != nil {
 		return m.editForm.View()
 	}
 
+	if m.filtering && m.filterForm != nil {
+		return m.filterForm.View()
+	}
+
+	if m.exporting && m.exportForm != nil {
+		return m.exportForm.View()
+	}
+
+	if m.browsingTags && m.tagBrowser != nil {
+		return m.tagBrowser.View()
+	}
+
 	if m.showHelp {
 		return m.helpView()
 	}
@@ -47,80 +68,182 @@ func (m Model) View() string {
 	var s strings.Builder
 
 	// Title
-	s.WriteString(titleStyle.Render("📝 Nagging Nancy"))
-	s.WriteString(fmt.Sprintf(" - %s\n\n", time.Now().Format("Monday, January 2, 2006")))
+	s.WriteString(titleStyle.Render(utils.Symbol("📝 ", "") + "Nagging Nancy"))
+	s.WriteString(fmt.Sprintf(" - %s\n", i18n.FormatTime(time.Now(), "Monday, January 2, 2006")))
+	if summary := m.summaryView(); summary != "" {
+		s.WriteString("  " + summary + "\n")
+	}
+	if banner := m.focusBannerView(); banner != "" {
+		s.WriteString("  " + banner + "\n")
+	}
+	if chips := m.filterValues.Chips(); len(chips) > 0 {
+		s.WriteString("  " + components.RenderChips(chips) + "\n")
+	}
+	s.WriteString("\n")
+
+	if len(m.list.Items()) == 0 && m.list.SearchView() == "" {
+		if total, _, _, _ := m.store.Count(); total == 0 {
+			s.WriteString(m.emptyStateView())
+			if m.flash != "" {
+				s.WriteString("\n" + flashStyle.Render(" "+m.flash+" ") + "\n")
+			}
+			return s.String()
+		}
 
-	if len(m.reminders) == 0 {
-		s.WriteString("🎉 All caught up! No active reminders.\n\n")
-		s.WriteString("Press 'q' to quit, '?' for help\n")
+		s.WriteString(utils.Symbol("🎉 ", "") + i18n.T("All caught up! No active reminders.") + "\n\n")
+		if m.flash != "" {
+			s.WriteString(flashStyle.Render(" " + m.flash + " "))
+			s.WriteString("\n\n")
+		}
+		s.WriteString(i18n.T("Press 'q' to quit, '?' for help") + "\n")
 		return s.String()
 	}
 
+	// With the detail pane open beside it, the list gets what is left
+	listWidth := 0
+	split := m.showDetail && m.width >= minSplitWidth
+	if split {
+		listWidth = m.width - m.detailWidth()
+	}
+
+	// The label color column is as wide as its widest swatch, if any
+	start, end := m.list.Page()
+	page := m.list.Items()[start:end]
+	labelled, labelWidth := false, 0
+	for _, reminder := range page {
+		if color := reminder.LabelColor(); color != "" {
+			labelled = true
+			labelWidth = max(labelWidth, lipgloss.Width(utils.ColorSwatch(color)))
+		}
+	}
+
 	// List reminders
-	for i, reminder := range m.reminders {
+	var list strings.Builder
+	if len(page) == 0 {
+		list.WriteString(i18n.T("No reminders match '%s'.", m.list.Query()) + "\n")
+	}
+	for i, reminder := range page {
+		selected := start+i == m.list.Index()
 		cursor := " "
-		if m.cursor == i {
+		if selected {
 			cursor = ">"
 		}
 
-		status := "●"
-		if reminder.Completed {
-			status = "✓"
-		}
-
-		line := fmt.Sprintf("%s %s %s %s - %s",
+		line := fmt.Sprintf("%s %s %s #%s %s - %s",
 			cursor,
-			status,
-			reminder.Priority.Icon(),
+			utils.CompletionIcon(reminder.Completed),
+			utils.PriorityIcon(reminder.Priority),
+			reminder.DisplayID(),
 			reminder.Title,
 			reminder.FormattedDueTime(),
 		)
+		if reminder.TimerRunning() {
+			line += " " + utils.Symbol("⏱ ", i18n.T("timer")+" ") + utils.TrackedText(reminder.TrackedTime(time.Now()))
+		}
 
-		if reminder.Completed {
+		if utils.AccessibleMode() {
+			// Plain, label-first lines without color-only signaling
+			if !reminder.Completed && reminder.IsOverdue() {
+				line += " " + utils.OverdueText(reminder.OverdueAge())
+			} else if !reminder.Completed && reminder.IsDueSoon() {
+				line += " [" + i18n.T("DUE SOON") + "]"
+			}
+		} else if reminder.Completed {
 			// Apply strikethrough to entire line, then color the cursor separately
 			styledLine := completedStyle.Render(line)
 			// Replace the plain cursor with styled cursor after strikethrough
-			if m.cursor == i {
+			if selected {
 				styledLine = strings.Replace(styledLine, ">", cursorStyle.Render(">"), 1)
 			}
 			line = styledLine
 		} else {
 			// Apply cursor styling for non-completed items
-			if m.cursor == i {
+			if selected {
 				line = strings.Replace(line, ">", cursorStyle.Render(">"), 1)
 			}
 			
 			if reminder.IsOverdue() {
-				line = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(line + " ⚠️ OVERDUE")
+				// Grade the color so long-neglected reminders stand out
+				age := reminder.OverdueAge()
+				line = utils.OverdueStyle(age).Render(line + " " + utils.OverdueText(age))
 			} else if reminder.IsDueSoon() {
-				line = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(line + " ⏰ DUE SOON")
+				line = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(line + " " + utils.Symbol("⏰ ", "") + i18n.T("DUE SOON"))
 			}
 		}
 
-		s.WriteString(line)
+		// Label colors go in a column of their own, outside the line styles
+		if labelled {
+			swatch := utils.ColorSwatch(reminder.LabelColor())
+			line = swatch + strings.Repeat(" ", max(labelWidth-lipgloss.Width(swatch), 0)+1) + line
+		}
+
+		if listWidth > 0 {
+			line = lipgloss.NewStyle().MaxWidth(listWidth - 1).Render(line)
+		}
+		list.WriteString(line)
+		list.WriteString("\n")
+	}
+
+	// The search and which page is shown
+	if footer := strings.TrimSpace(m.list.SearchView() + "  " + m.list.PageView()); footer != "" {
+		list.WriteString(footer + "\n")
+	}
+
+	switch {
+	case split:
+		s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(listWidth).Render(strings.TrimSuffix(list.String(), "\n")),
+			m.detailView(m.detailWidth())))
+		s.WriteString("\n")
+	case m.showDetail:
+		// Too narrow to split, so the pane goes under the list
+		s.WriteString(list.String())
+		s.WriteString("\n")
+		s.WriteString(m.detailView(max(m.width, 30)))
 		s.WriteString("\n")
+	default:
+		s.WriteString(list.String())
 	}
 
 	// Status bar
 	s.WriteString("\n")
+	if m.flash != "" {
+		s.WriteString(flashStyle.Render(" " + m.flash + " "))
+		s.WriteString("\n")
+	}
 	s.WriteString(m.statusBarView())
 
 	return s.String()
 }
 
+// detailWidth is the width of the detail pane beside the list
+func (m Model) detailWidth() int {
+	return max(m.width*2/5, 36)
+}
+
 func (m Model) helpView() string {
-	help := `📝 Nagging Nancy - Help
+	help := `Nagging Nancy - Help
 
 Navigation:
   ↑/k      Move up
   ↓/j      Move down
+  ←/→      Previous/next page
+  g/G      First/last reminder
+  /        Search (esc clears it)
   
 Actions:
+  a/n      Add a reminder
   space    Toggle reminder completion
+  s        Skip to next occurrence (recurring)
+  enter    Show/hide details
   e        Edit selected reminder  
   d        Delete selected reminder
   r        Refresh list
   f        Toggle show completed
+  F        Build a filter
+  T        Browse tags
+  x        Export the visible reminders
+  t        Start/stop the timer
   
 Other:
   ?/h      Show/hide help
@@ -128,21 +251,24 @@ Other:
 
 Press any key to return...`
 
-	return help
+	return utils.Symbol("📝 ", "") + i18n.T(help)
 }
 
 func (m Model) statusBarView() string {
 	total, active, completed, overdue := m.store.Count()
 
-	status := fmt.Sprintf("Total: %d | Active: %d | Completed: %d | Overdue: %d",
+	status := i18n.T("Total: %d | Active: %d | Completed: %d | Overdue: %d",
 		total, active, completed, overdue)
+	if done, today := m.store.TodayProgress(m.config.CurrentUser()); today > 0 {
+		status = i18n.T("Today %s %d/%d", utils.ProgressBar(done, today, 8), done, today) + " | " + status
+	}
 
-	controls := "space=toggle e=edit d=delete f=filter ?=help q=quit"
+	controls := i18n.T("space=toggle enter=details s=skip e=edit d=delete /=search F=filter ?=help q=quit")
 
 	// Pad to full width
-	padding := m.width - len(status) - len(controls)
-	if padding < 0 {
-		padding = 0
+	padding := m.width - lipgloss.Width(status) - lipgloss.Width(controls)
+	if padding < 1 {
+		padding = 1
 	}
 
 	statusBar := status + strings.Repeat(" ", padding) + controls
@@ -0,0 +1,386 @@
+package utils
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// APIPrefix is where 'nancy serve' puts its REST API
+const APIPrefix = "/api/v1"
+
+// maxAPIBody caps the replies the client reads, which hold every reminder
+const maxAPIBody = 64 << 20
+
+// maxReminderBody caps a single reminder sent to the server
+const maxReminderBody = 1 << 20
+
+// apiError is the body of an error response
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// apiServer serves the REST API over a store
+type apiServer struct {
+	store  *models.Store
+	token  string
+	parser TextParser
+}
+
+// NewAPIHandler serves the REST API for reminders over store:
+//
+//	GET    /api/v1/reminders               every reminder
+//	POST   /api/v1/reminders               add a reminder; the server picks its ID
+//	                                       (with only "input", it's parsed like 'nancy add')
+//	GET    /api/v1/reminders/{id}          one reminder, by ID or short ID
+//	PUT    /api/v1/reminders/{id}          add or replace a reminder
+//	DELETE /api/v1/reminders/{id}          delete a reminder
+//	POST   /api/v1/reminders/{id}/complete complete a reminder
+//	POST   /api/v1/reminders/{id}/snooze   snooze a reminder, for ?for= (default 1h)
+//	GET    /api/v1/events                  server-sent events for every change,
+//	                                       and for reminders coming due or overdue
+//
+// Reminders are JSON in the export format; text is parsed by parser. With
+// token set, every request must carry it as a bearer token; /events also
+// takes it as ?token=, since browsers can't set headers on an EventSource.
+// Requests from another site's pages are refused, and POST, PUT and DELETE
+// must be sent as application/json, which a page can't do across sites
+// without the server's consent.
+func NewAPIHandler(store *models.Store, token string, parser TextParser) http.Handler {
+	api := &apiServer{store: store, token: token, parser: parser}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+APIPrefix+"/reminders", api.list)
+	mux.HandleFunc("POST "+APIPrefix+"/reminders", api.create)
+	mux.HandleFunc("GET "+APIPrefix+"/reminders/{id}", api.get)
+	mux.HandleFunc("PUT "+APIPrefix+"/reminders/{id}", api.put)
+	mux.HandleFunc("DELETE "+APIPrefix+"/reminders/{id}", api.delete)
+	mux.HandleFunc("POST "+APIPrefix+"/reminders/{id}/complete", api.complete)
+	mux.HandleFunc("POST "+APIPrefix+"/reminders/{id}/snooze", api.snooze)
+	mux.HandleFunc("GET "+APIPrefix+"/events", api.events)
+	return api.authorize(mux)
+}
+
+// authorize refuses requests without the token, from another origin, or
+// that change reminders without being JSON
+func (api *apiServer) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !sameOrigin(r) {
+			writeAPIError(w, http.StatusForbidden, errors.New("requests from other sites aren't allowed"))
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+				writeAPIError(w, http.StatusUnsupportedMediaType, errors.New("send the request as application/json"))
+				return
+			}
+		}
+		if api.token != "" {
+			bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if bearer == "" && r.URL.Path == APIPrefix+"/events" {
+				bearer = r.URL.Query().Get("token")
+			}
+			if subtle.ConstantTimeCompare([]byte(bearer), []byte(api.token)) != 1 {
+				writeAPIError(w, http.StatusUnauthorized, errors.New("invalid token"))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// sameOrigin reports whether a request comes from a page the server itself
+// served, or from something other than a browser, which sends no Origin
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// HostCheck refuses requests whose Host doesn't name the machine listening
+// on addr: its listen host, localhost, its hostname or an IP address. A
+// server without a token needs it, since a page on another site can
+// otherwise point its own hostname at 127.0.0.1 and reach the API as the
+// same origin.
+func HostCheck(addr string, next http.Handler) http.Handler {
+	allowed := map[string]bool{"localhost": true}
+	if host, _, err := net.SplitHostPort(addr); err == nil && host != "" {
+		allowed[strings.ToLower(host)] = true
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		allowed[strings.ToLower(hostname)] = true
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.ToLower(strings.Trim(host, "[]"))
+		if !allowed[host] && net.ParseIP(host) == nil {
+			writeAPIError(w, http.StatusForbidden, fmt.Errorf("unknown host %q", r.Host))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// list returns every reminder, in short ID order so an unchanged store
+// gives the same reply
+func (api *apiServer) list(w http.ResponseWriter, r *http.Request) {
+	reminders := api.store.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})
+	sort.Slice(reminders, func(i, j int) bool {
+		if reminders[i].ShortID != reminders[j].ShortID {
+			return reminders[i].ShortID < reminders[j].ShortID
+		}
+		return reminders[i].ID < reminders[j].ID
+	})
+	writeAPIJSON(w, http.StatusOK, reminders)
+}
+
+func (api *apiServer) get(w http.ResponseWriter, r *http.Request) {
+	reminder, err := api.find(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, reminder)
+}
+
+func (api *apiServer) create(w http.ResponseWriter, r *http.Request) {
+	// Whatever the body leaves out keeps the defaults of a new reminder, but
+	// the ID is always fresh, so a create can't bring back a deleted reminder
+	reminder := models.NewReminder("", time.Time{}, models.Medium)
+	id := reminder.ID
+	if err := decodeAPIReminder(w, r, reminder); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	reminder.ID = id
+	if reminder.Title == "" && reminder.DueTime.IsZero() && strings.TrimSpace(reminder.Input) != "" {
+		api.createFromText(w, reminder.Input)
+		return
+	}
+	if err := checkAPIReminder(reminder); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	reminder.ShortID = 0
+	if err := api.store.Add(reminder); err != nil {
+		writeAPIError(w, storeErrorStatus(err), err)
+		return
+	}
+	api.reply(w, http.StatusCreated, reminder.ID)
+}
+
+// createFromText adds a reminder from text like "Call mum tomorrow at 5pm
+// #family", parsed like 'nancy add'
+func (api *apiServer) createFromText(w http.ResponseWriter, input string) {
+	input = strings.TrimSpace(input)
+	parsed, err := api.parser.Parse(input, time.Now())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := ValidateReminderInput(parsed.Title, parsed.DueTime, false); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	reminder := models.NewReminder(parsed.Title, parsed.DueTime, parsed.Priority)
+	for _, tag := range parsed.Tags {
+		reminder.AddTag(tag)
+	}
+	reminder.SunEvent = parsed.SunEvent
+	reminder.Input = input
+	if err := api.store.Add(reminder); err != nil {
+		writeAPIError(w, storeErrorStatus(err), err)
+		return
+	}
+	api.reply(w, http.StatusCreated, reminder.ID)
+}
+
+func (api *apiServer) put(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	reminder := &models.Reminder{}
+	if err := readAPIReminder(w, r, reminder); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	if reminder.ID != id {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("the reminder's ID %s doesn't match %s", reminder.ID, id))
+		return
+	}
+	// A client that missed a delete can't bring the reminder back
+	if err := api.store.CheckDeleted(reminder); err != nil {
+		writeAPIError(w, storeErrorStatus(err), err)
+		return
+	}
+
+	// The server numbers reminders, so clients can't make short IDs clash
+	status := http.StatusOK
+	if existing, err := api.store.Get(id); err == nil {
+		reminder.ShortID = existing.ShortID
+		err = api.store.Update(reminder)
+		if err != nil {
+			writeAPIError(w, storeErrorStatus(err), err)
+			return
+		}
+	} else {
+		reminder.ShortID = 0
+		if err := api.store.Add(reminder); err != nil {
+			writeAPIError(w, storeErrorStatus(err), err)
+			return
+		}
+		status = http.StatusCreated
+	}
+	api.reply(w, status, id)
+}
+
+func (api *apiServer) delete(w http.ResponseWriter, r *http.Request) {
+	reminder, err := api.find(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, err)
+		return
+	}
+	if err := api.store.Delete(reminder.ID); err != nil {
+		writeAPIError(w, storeErrorStatus(err), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *apiServer) complete(w http.ResponseWriter, r *http.Request) {
+	reminder, err := api.find(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, err)
+		return
+	}
+	if err := api.store.CompleteReminder(reminder.ID); err != nil {
+		writeAPIError(w, storeErrorStatus(err), err)
+		return
+	}
+	api.reply(w, http.StatusOK, reminder.ID)
+}
+
+func (api *apiServer) snooze(w http.ResponseWriter, r *http.Request) {
+	reminder, err := api.find(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, err)
+		return
+	}
+	duration := time.Hour
+	if value := r.URL.Query().Get("for"); value != "" {
+		duration, err = time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid snooze duration '%s' (e.g. 15m, 1h)", value))
+			return
+		}
+	}
+	if reminder.Completed {
+		writeAPIError(w, http.StatusConflict, fmt.Errorf("reminder %s is completed", reminder.DisplayID()))
+		return
+	}
+
+	reminder.Snooze(duration)
+	if err := api.store.Update(reminder); err != nil {
+		writeAPIError(w, storeErrorStatus(err), err)
+		return
+	}
+	api.reply(w, http.StatusOK, reminder.ID)
+}
+
+// find looks a reminder up by ID, or by short ID for numbers
+func (api *apiServer) find(id string) (*models.Reminder, error) {
+	if shortID, err := strconv.Atoi(id); err == nil {
+		return api.store.GetByShortID(shortID)
+	}
+	return api.store.Get(id)
+}
+
+// reply sends the reminder as stored
+func (api *apiServer) reply(w http.ResponseWriter, status int, id string) {
+	reminder, err := api.store.Get(id)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeAPIJSON(w, status, reminder)
+}
+
+// readAPIReminder decodes the reminder in a request body into reminder and
+// checks it against the export schema
+func readAPIReminder(w http.ResponseWriter, r *http.Request, reminder *models.Reminder) error {
+	if err := decodeAPIReminder(w, r, reminder); err != nil {
+		return err
+	}
+	return checkAPIReminder(reminder)
+}
+
+// decodeAPIReminder reads a reminder from the request body without checking it
+func decodeAPIReminder(w http.ResponseWriter, r *http.Request, reminder *models.Reminder) error {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxReminderBody))
+	if err != nil {
+		return fmt.Errorf("failed to read the reminder: %w", err)
+	}
+	if err := json.Unmarshal(data, reminder); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+// checkAPIReminder checks a reminder sent to the server against the export
+// schema
+func checkAPIReminder(reminder *models.Reminder) error {
+	normalized, err := json.Marshal([]*models.Reminder{reminder})
+	if err != nil {
+		return err
+	}
+	if err := models.ValidateReminders(normalized); err != nil {
+		return err
+	}
+	if strings.TrimSpace(reminder.Title) == "" {
+		return errors.New("the reminder needs a title")
+	}
+	if reminder.DueTime.IsZero() {
+		return errors.New("the reminder needs a due_time")
+	}
+	return nil
+}
+
+// storeErrorStatus picks the status for an error from the store
+func storeErrorStatus(err error) int {
+	if errors.Is(err, models.ErrReadOnly) {
+		return http.StatusForbidden
+	}
+	if errors.Is(err, models.ErrDeleted) {
+		return http.StatusGone
+	}
+	return http.StatusInternalServerError
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeAPIJSON(w, status, apiError{Error: err.Error()})
+}
@@ -0,0 +1,397 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CalendarEvent is a busy block from an ICS feed
+type CalendarEvent struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+	rule    *recurrenceRule
+}
+
+// recurrenceRule is the subset of RFC 5545 RRULE supported for busy checks
+type recurrenceRule struct {
+	freq     string // DAILY, WEEKLY, MONTHLY or YEARLY
+	interval int
+	count    int
+	until    time.Time
+	byDay    []time.Weekday
+}
+
+// Calendar holds the busy events of an ICS feed
+type Calendar struct {
+	Events []CalendarEvent
+}
+
+// LoadCalendar reads an ICS feed from an http(s)/webcal URL or a file
+func LoadCalendar(source string) (*Calendar, error) {
+	var data []byte
+	var err error
+
+	if strings.HasPrefix(source, "webcal://") {
+		source = "https://" + strings.TrimPrefix(source, "webcal://")
+	}
+
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		client := &http.Client{Timeout: 15 * time.Second}
+		resp, err := client.Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch calendar: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch calendar: %s", resp.Status)
+		}
+		data, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read calendar: %w", err)
+		}
+	} else if data, err = os.ReadFile(source); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return ParseICS(data)
+}
+
+// ParseICS parses the timed, opaque events of an iCalendar document.
+// All-day, free (TRANSP:TRANSPARENT) and cancelled events are ignored.
+func ParseICS(data []byte) (*Calendar, error) {
+	lines := unfoldICS(data)
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("not an iCalendar document")
+	}
+
+	calendar := &Calendar{}
+	var event *CalendarEvent
+	var skip bool
+	var duration time.Duration
+
+	for _, line := range lines {
+		name, params, value := parseICSLine(line)
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event, skip, duration = &CalendarEvent{}, false, 0
+		case event == nil:
+			continue
+		case name == "END" && value == "VEVENT":
+			if event.End.IsZero() && duration > 0 {
+				event.End = event.Start.Add(duration)
+			}
+			if !skip && !event.Start.IsZero() && event.End.After(event.Start) {
+				calendar.Events = append(calendar.Events, *event)
+			}
+			event = nil
+		case name == "SUMMARY":
+			event.Summary = value
+		case name == "DTSTART" || name == "DTEND":
+			if params["VALUE"] == "DATE" || len(value) == 8 {
+				skip = true // all-day events don't mean you're in a meeting
+				continue
+			}
+			t, err := parseICSTime(value, params["TZID"])
+			if err != nil {
+				return nil, err
+			}
+			if name == "DTSTART" {
+				event.Start = t
+			} else {
+				event.End = t
+			}
+		case name == "DURATION":
+			duration = parseICSDuration(value)
+		case name == "TRANSP" && value == "TRANSPARENT", name == "STATUS" && value == "CANCELLED":
+			skip = true
+		case name == "RRULE":
+			event.rule = parseRRule(value)
+		}
+	}
+
+	return calendar, nil
+}
+
+// Invite is a meeting invitation: the event of an iCalendar document as
+// mailed by calendar clients
+type Invite struct {
+	Summary     string
+	Start       time.Time
+	Location    string
+	Organizer   string
+	Description string
+	URL         string
+}
+
+// ParseInvite parses the first event of an iCalendar document. Cancelled
+// and all-day events are refused, as there's no meeting to be reminded of.
+func ParseInvite(data []byte) (*Invite, error) {
+	lines := unfoldICS(data)
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("not an iCalendar document")
+	}
+
+	var invite *Invite
+	nested := 0 // Inside a component of the event, such as an alarm
+	for _, line := range lines {
+		name, params, value := parseICSLine(line)
+
+		switch {
+		case name == "METHOD" && strings.EqualFold(value, "CANCEL"):
+			return nil, fmt.Errorf("the invite cancels the meeting")
+		case name == "BEGIN" && value == "VEVENT" && invite == nil:
+			invite = &Invite{}
+		case invite == nil:
+			continue
+		case name == "BEGIN":
+			nested++
+		case name == "END" && nested > 0:
+			nested--
+		case nested > 0:
+			continue
+		case name == "END" && value == "VEVENT":
+			if invite.Start.IsZero() {
+				return nil, fmt.Errorf("the invite has no start time")
+			}
+			return invite, nil
+		case name == "SUMMARY":
+			invite.Summary = unescapeICSText(value)
+		case name == "LOCATION":
+			invite.Location = unescapeICSText(value)
+		case name == "DESCRIPTION":
+			invite.Description = strings.TrimSpace(unescapeICSText(value))
+		case name == "URL":
+			invite.URL = value
+		case name == "ORGANIZER":
+			invite.Organizer = params["CN"]
+			if invite.Organizer == "" {
+				invite.Organizer = strings.TrimPrefix(strings.TrimPrefix(value, "mailto:"), "MAILTO:")
+			}
+		case name == "DTSTART":
+			if params["VALUE"] == "DATE" || len(value) == 8 {
+				return nil, fmt.Errorf("the invite is for an all-day event")
+			}
+			t, err := parseICSTime(value, params["TZID"])
+			if err != nil {
+				return nil, err
+			}
+			invite.Start = t
+		case name == "STATUS" && value == "CANCELLED":
+			return nil, fmt.Errorf("the invite cancels the meeting")
+		}
+	}
+	return nil, fmt.Errorf("no event in the invite")
+}
+
+// BusyAt returns the event taking place at t, if any
+func (c *Calendar) BusyAt(t time.Time) (CalendarEvent, bool) {
+	for _, event := range c.Events {
+		if start, ok := event.occurrenceAt(t); ok {
+			occurrence := event
+			occurrence.End = start.Add(event.End.Sub(event.Start))
+			occurrence.Start = start
+			return occurrence, true
+		}
+	}
+	return CalendarEvent{}, false
+}
+
+// occurrenceAt returns the start of the occurrence covering t
+func (e CalendarEvent) occurrenceAt(t time.Time) (time.Time, bool) {
+	length := e.End.Sub(e.Start)
+	covers := func(start time.Time) bool {
+		return !t.Before(start) && t.Before(start.Add(length))
+	}
+
+	if e.rule == nil {
+		return e.Start, covers(e.Start)
+	}
+
+	// Walk occurrences until one starts after t
+	rule := e.rule
+	count := 0
+	for period := 0; ; period += rule.interval {
+		periodStart := e.Start
+		switch rule.freq {
+		case "DAILY":
+			periodStart = e.Start.AddDate(0, 0, period)
+		case "WEEKLY":
+			periodStart = e.Start.AddDate(0, 0, 7*period)
+		case "MONTHLY":
+			periodStart = e.Start.AddDate(0, period, 0)
+		case "YEARLY":
+			periodStart = e.Start.AddDate(period, 0, 0)
+		default:
+			return e.Start, covers(e.Start)
+		}
+
+		starts := []time.Time{periodStart}
+		if rule.freq == "WEEKLY" && len(rule.byDay) > 0 {
+			// Each listed weekday of the week containing periodStart
+			weekStart := periodStart.AddDate(0, 0, -int(periodStart.Weekday()))
+			starts = starts[:0]
+			for _, day := range rule.byDay {
+				if start := weekStart.AddDate(0, 0, int(day)); !start.Before(e.Start) {
+					starts = append(starts, start)
+				}
+			}
+		}
+
+		for _, start := range starts {
+			if start.After(t) || (!rule.until.IsZero() && start.After(rule.until)) {
+				return time.Time{}, false
+			}
+			count++
+			if rule.count > 0 && count > rule.count {
+				return time.Time{}, false
+			}
+			if covers(start) {
+				return start, true
+			}
+		}
+	}
+}
+
+// unfoldICS splits an iCalendar document into logical lines
+func unfoldICS(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseICSLine splits "NAME;PARAM=x:value" into its parts
+func parseICSLine(line string) (name string, params map[string]string, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+	params = make(map[string]string)
+	for _, param := range parts[1:] {
+		if key, val, ok := strings.Cut(param, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, value
+}
+
+// parseICSTime parses UTC ("...Z"), zoned (TZID) and floating date-times
+func parseICSTime(value, tzid string) (time.Time, error) {
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse("20060102T150405Z", value)
+	}
+
+	location := time.Local
+	if tzid != "" {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid calendar time %q", value)
+	}
+	return t, nil
+}
+
+// parseICSDuration parses durations like PT30M, PT1H30M or P1D
+func parseICSDuration(value string) time.Duration {
+	var d time.Duration
+	number := ""
+	for _, r := range strings.TrimPrefix(strings.TrimPrefix(value, "+"), "P") {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+		case r == 'T':
+		default:
+			n, _ := strconv.Atoi(number)
+			number = ""
+			switch r {
+			case 'W':
+				d += time.Duration(n) * 7 * 24 * time.Hour
+			case 'D':
+				d += time.Duration(n) * 24 * time.Hour
+			case 'H':
+				d += time.Duration(n) * time.Hour
+			case 'M':
+				d += time.Duration(n) * time.Minute
+			case 'S':
+				d += time.Duration(n) * time.Second
+			}
+		}
+	}
+	return d
+}
+
+// unescapeICSText undoes escapeICSText
+func unescapeICSText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i]) // \\, \; and \,
+		}
+	}
+	return b.String()
+}
+
+// icsWeekdays maps RRULE BYDAY codes to weekdays
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// parseRRule parses FREQ, INTERVAL, COUNT, UNTIL and (weekly) BYDAY
+func parseRRule(value string) *recurrenceRule {
+	rule := &recurrenceRule{interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.freq = strings.ToUpper(val)
+		case "INTERVAL":
+			if n, err := strconv.Atoi(val); err == nil && n > 0 {
+				rule.interval = n
+			}
+		case "COUNT":
+			rule.count, _ = strconv.Atoi(val)
+		case "UNTIL":
+			if t, err := parseICSTime(val, ""); err == nil {
+				rule.until = t
+			} else if t, err := time.ParseInLocation("20060102", val, time.Local); err == nil {
+				rule.until = t.AddDate(0, 0, 1)
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				if weekday, ok := icsWeekdays[strings.TrimLeft(day, "+-0123456789")]; ok {
+					rule.byDay = append(rule.byDay, weekday)
+				}
+			}
+		}
+	}
+	return rule
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DaemonStateFile is the file in the state directory recording when the
+// daemon last sent its weekly digest, daily plan and wrap-up, so a restart
+// doesn't send them again
+const DaemonStateFile = "daemon-state.json"
+
+// DaemonState is when the daemon last sent each of its summaries
+type DaemonState struct {
+	Digest time.Time `json:"digest"`
+	Plan   time.Time `json:"plan"`
+	WrapUp time.Time `json:"wrap_up"`
+}
+
+// LoadDaemonState reads the daemon state from path, or returns an empty one
+// when there is none yet
+func LoadDaemonState(path string) (DaemonState, error) {
+	var state DaemonState
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read daemon state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return DaemonState{}, fmt.Errorf("failed to parse daemon state: %w", err)
+	}
+	return state, nil
+}
+
+// SaveDaemonState writes the daemon state to path
+func SaveDaemonState(path string, state DaemonState) error {
+	data, err := json.MarshalIndent(&state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal daemon state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create the state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write daemon state: %w", err)
+	}
+	return nil
+}
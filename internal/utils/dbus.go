@@ -0,0 +1,534 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// Just enough of the D-Bus wire protocol to talk to the desktop notification
+// server (org.freedesktop.Notifications) on the session bus, without cgo or
+// an external notify-send.
+
+const (
+	notificationsService   = "org.freedesktop.Notifications"
+	notificationsPath      = "/org/freedesktop/Notifications"
+	notificationsInterface = "org.freedesktop.Notifications"
+
+	dbusTimeout = 5 * time.Second
+)
+
+// D-Bus message types
+const (
+	dbusMethodCall   = 1
+	dbusMethodReturn = 2
+	dbusError        = 3
+	dbusSignal       = 4
+)
+
+// Why the notification server closed a notification
+const (
+	ClosedExpired   = 1 // It timed out
+	ClosedDismissed = 2 // The user dismissed it
+	ClosedByCall    = 3 // Nancy closed it, e.g. because the reminder was completed
+)
+
+// NotificationClosed reports a notification the server closed
+type NotificationClosed struct {
+	ID     uint32 // As returned when it was shown
+	Reason uint32 // ClosedExpired, ClosedDismissed, ClosedByCall or 4 (other)
+}
+
+// ErrNoSessionBus means there is no D-Bus session bus to connect to, e.g. over
+// SSH or in a cron job
+var ErrNoSessionBus = errors.New("no D-Bus session bus")
+
+// ErrNoNotificationServer means the session bus works but nothing on it shows
+// notifications (no dunst, mako, GNOME Shell, ...)
+var ErrNoNotificationServer = errors.New("no notification server is running on the session bus")
+
+// DBusError is an error reply from a D-Bus service
+type DBusError struct {
+	Name    string
+	Message string
+}
+
+func (e *DBusError) Error() string {
+	if e.Message == "" {
+		return e.Name
+	}
+	return fmt.Sprintf("%s: %s", e.Name, e.Message)
+}
+
+// sessionBusAddress returns the unix socket of the session bus from
+// DBUS_SESSION_BUS_ADDRESS, or $XDG_RUNTIME_DIR/bus
+func sessionBusAddress() (string, error) {
+	for _, address := range strings.Split(os.Getenv("DBUS_SESSION_BUS_ADDRESS"), ";") {
+		transport, params, found := strings.Cut(address, ":")
+		if !found || transport != "unix" {
+			continue
+		}
+		for _, param := range strings.Split(params, ",") {
+			key, value, _ := strings.Cut(param, "=")
+			value, err := url.PathUnescape(value)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "path":
+				return value, nil
+			case "abstract":
+				return "@" + value, nil
+			}
+		}
+	}
+
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		path := filepath.Join(dir, "bus")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", ErrNoSessionBus
+}
+
+// dbusConn is an authenticated connection to the session bus
+type dbusConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	serial uint32
+}
+
+// dialSessionBus connects and authenticates to the session bus
+func dialSessionBus() (*dbusConn, error) {
+	address, err := sessionBusAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.DialTimeout("unix", address, dbusTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoSessionBus, err)
+	}
+	conn.SetDeadline(time.Now().Add(dbusTimeout))
+
+	c := &dbusConn{conn: conn, reader: bufio.NewReader(conn)}
+	if err := c.auth(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%w: %v", ErrNoSessionBus, err)
+	}
+	if _, err := c.call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello", "", nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%w: %v", ErrNoSessionBus, err)
+	}
+	return c, nil
+}
+
+// auth authenticates as the current user (SASL EXTERNAL)
+func (c *dbusConn) auth() error {
+	uid := hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+	if _, err := fmt.Fprintf(c.conn, "\x00AUTH EXTERNAL %s\r\n", uid); err != nil {
+		return err
+	}
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return fmt.Errorf("authentication rejected: %s", strings.TrimSpace(line))
+	}
+	_, err = io.WriteString(c.conn, "BEGIN\r\n")
+	return err
+}
+
+func (c *dbusConn) Close() error {
+	return c.conn.Close()
+}
+
+// dbusMessage is a received message
+type dbusMessage struct {
+	msgType     byte
+	replySerial uint32
+	errorName   string
+	member      string // Signal or method name
+	signature   string
+	body        *dbusDecoder
+}
+
+// call sends a method call and waits for its reply
+func (c *dbusConn) call(destination, path, iface, member, signature string, body []byte) (*dbusMessage, error) {
+	c.serial++
+	serial := c.serial
+
+	e := &dbusEncoder{}
+	e.buf = append(e.buf, 'l', dbusMethodCall, 0, 1)
+	e.uint32(uint32(len(body)))
+	e.uint32(serial)
+	e.array(8, func() {
+		e.field(1, "o", func() { e.string(path) })
+		e.field(2, "s", func() { e.string(iface) })
+		e.field(3, "s", func() { e.string(member) })
+		e.field(6, "s", func() { e.string(destination) })
+		if signature != "" {
+			e.field(8, "g", func() { e.signature(signature) })
+		}
+	})
+	e.align(8)
+	e.buf = append(e.buf, body...)
+
+	if _, err := c.conn.Write(e.buf); err != nil {
+		return nil, err
+	}
+
+	// Skip signals (NameAcquired, ...) until our reply arrives
+	for {
+		msg, err := c.read()
+		if err != nil {
+			return nil, err
+		}
+		if msg.replySerial != serial {
+			continue
+		}
+		if msg.msgType == dbusError {
+			dbusErr := &DBusError{Name: msg.errorName}
+			if strings.HasPrefix(msg.signature, "s") {
+				dbusErr.Message, _ = msg.body.string()
+			}
+			return nil, dbusErr
+		}
+		return msg, nil
+	}
+}
+
+// read reads one message from the bus
+func (c *dbusConn) read() (*dbusMessage, error) {
+	fixed := make([]byte, 16)
+	if _, err := io.ReadFull(c.reader, fixed); err != nil {
+		return nil, err
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if fixed[0] == 'B' {
+		order = binary.BigEndian
+	}
+	bodyLen := order.Uint32(fixed[4:])
+	fieldsLen := order.Uint32(fixed[12:])
+	if bodyLen > 1<<24 || fieldsLen > 1<<24 {
+		return nil, fmt.Errorf("D-Bus message too large")
+	}
+
+	headerLen := (16 + int(fieldsLen) + 7) &^ 7
+	data := make([]byte, headerLen+int(bodyLen))
+	copy(data, fixed)
+	if _, err := io.ReadFull(c.reader, data[16:]); err != nil {
+		return nil, err
+	}
+
+	msg := &dbusMessage{
+		msgType: fixed[1],
+		body:    &dbusDecoder{buf: data[headerLen:], order: order},
+	}
+	fields := &dbusDecoder{buf: data[:16+fieldsLen], pos: 16, order: order}
+	for fields.pos < len(fields.buf) {
+		fields.align(8)
+		code, err := fields.byte()
+		if err != nil {
+			return nil, err
+		}
+		signature, err := fields.signature()
+		if err != nil {
+			return nil, err
+		}
+
+		switch signature {
+		case "s", "o":
+			value, err := fields.string()
+			if err != nil {
+				return nil, err
+			}
+			switch code {
+			case 3:
+				msg.member = value
+			case 4:
+				msg.errorName = value
+			}
+		case "u":
+			value, err := fields.uint32()
+			if err != nil {
+				return nil, err
+			}
+			if code == 5 {
+				msg.replySerial = value
+			}
+		case "g":
+			value, err := fields.signature()
+			if err != nil {
+				return nil, err
+			}
+			if code == 8 {
+				msg.signature = value
+			}
+		default:
+			return nil, fmt.Errorf("unexpected D-Bus header field type %q", signature)
+		}
+	}
+	return msg, nil
+}
+
+// dbusEncoder marshals values in little-endian D-Bus format. Alignment is
+// relative to the start of the buffer, which is always 8-byte aligned in the
+// message.
+type dbusEncoder struct {
+	buf []byte
+}
+
+func (e *dbusEncoder) align(n int) {
+	for len(e.buf)%n != 0 {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *dbusEncoder) uint32(v uint32) {
+	e.align(4)
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, v)
+}
+
+func (e *dbusEncoder) string(s string) {
+	e.uint32(uint32(len(s)))
+	e.buf = append(e.buf, s...)
+	e.buf = append(e.buf, 0)
+}
+
+func (e *dbusEncoder) signature(s string) {
+	e.buf = append(e.buf, byte(len(s)))
+	e.buf = append(e.buf, s...)
+	e.buf = append(e.buf, 0)
+}
+
+// array writes the length prefix around elements aligned to elemAlign
+func (e *dbusEncoder) array(elemAlign int, elements func()) {
+	e.uint32(0)
+	lengthAt := len(e.buf) - 4
+	e.align(elemAlign)
+	start := len(e.buf)
+	elements()
+	binary.LittleEndian.PutUint32(e.buf[lengthAt:], uint32(len(e.buf)-start))
+}
+
+// field writes a header field or dict entry: a struct of a code or key and a
+// variant
+func (e *dbusEncoder) field(code byte, signature string, value func()) {
+	e.align(8)
+	e.buf = append(e.buf, code)
+	e.signature(signature)
+	value()
+}
+
+// dbusDecoder unmarshals the few types Nancy reads
+type dbusDecoder struct {
+	buf   []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+var errDBusShort = errors.New("truncated D-Bus message")
+
+func (d *dbusDecoder) align(n int) {
+	d.pos = (d.pos + n - 1) / n * n
+}
+
+func (d *dbusDecoder) byte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, errDBusShort
+	}
+	d.pos++
+	return d.buf[d.pos-1], nil
+}
+
+func (d *dbusDecoder) uint32() (uint32, error) {
+	d.align(4)
+	if d.pos+4 > len(d.buf) {
+		return 0, errDBusShort
+	}
+	d.pos += 4
+	return d.order.Uint32(d.buf[d.pos-4:]), nil
+}
+
+func (d *dbusDecoder) string() (string, error) {
+	n, err := d.uint32()
+	if err != nil {
+		return "", err
+	}
+	if d.pos+int(n)+1 > len(d.buf) {
+		return "", errDBusShort
+	}
+	s := string(d.buf[d.pos : d.pos+int(n)])
+	d.pos += int(n) + 1
+	return s, nil
+}
+
+func (d *dbusDecoder) signature() (string, error) {
+	n, err := d.byte()
+	if err != nil {
+		return "", err
+	}
+	if d.pos+int(n)+1 > len(d.buf) {
+		return "", errDBusShort
+	}
+	s := string(d.buf[d.pos : d.pos+int(n)])
+	d.pos += int(n) + 1
+	return s, nil
+}
+
+// callNotifications calls a method on the notification server, telling a
+// missing server apart from other failures
+func callNotifications(member, signature string, body []byte) (*dbusMessage, error) {
+	conn, err := dialSessionBus()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	msg, err := conn.call(notificationsService, notificationsPath, notificationsInterface, member, signature, body)
+	var dbusErr *DBusError
+	if errors.As(err, &dbusErr) && (dbusErr.Name == "org.freedesktop.DBus.Error.ServiceUnknown" ||
+		dbusErr.Name == "org.freedesktop.DBus.Error.NameHasNoOwner") {
+		return nil, ErrNoNotificationServer
+	}
+	return msg, err
+}
+
+// notifyDBus shows a notification and returns its ID. A non-zero replaces ID
+// updates that notification in place instead of adding another one. Sticky
+// notifications are critical and resident, so servers keep them on screen.
+func notifyDBus(replaces uint32, title, message string, priority models.Priority, sticky bool) (uint32, error) {
+	// Low priority notifications go away on their own, high priority ones
+	// stay until dismissed; the server decides for the rest
+	urgency, expire := byte(1), int32(-1)
+	switch {
+	case sticky || priority == models.High:
+		urgency, expire = 2, 0
+	case priority == models.Low:
+		urgency, expire = 0, 10000
+	}
+
+	e := &dbusEncoder{}
+	e.string("Nancy")
+	e.uint32(replaces)
+	e.string("appointment-soon")
+	e.string(title)
+	e.string(message)
+	e.array(4, func() {}) // No actions
+	e.array(8, func() {
+		e.align(8)
+		e.string("urgency")
+		e.signature("y")
+		e.buf = append(e.buf, urgency)
+		if sticky {
+			e.align(8)
+			e.string("resident")
+			e.signature("b")
+			e.uint32(1)
+		}
+	})
+	e.uint32(uint32(expire))
+
+	msg, err := callNotifications("Notify", "susssasa{sv}i", e.buf)
+	if err != nil {
+		return 0, err
+	}
+	return msg.body.uint32()
+}
+
+// closeDBusNotification removes a notification shown by notifyDBus
+func closeDBusNotification(id uint32) error {
+	e := &dbusEncoder{}
+	e.uint32(id)
+	_, err := callNotifications("CloseNotification", "u", e.buf)
+	return err
+}
+
+// WatchNotificationsClosed sends each notification the server closes to
+// closed until ctx is done, over a connection of its own. It returns
+// ErrNoSessionBus where there is no D-Bus, e.g. on macOS and Windows.
+func WatchNotificationsClosed(ctx context.Context, closed chan<- NotificationClosed) error {
+	conn, err := dialSessionBus()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	rule := fmt.Sprintf("type='signal',interface='%s',member='NotificationClosed'", notificationsInterface)
+	e := &dbusEncoder{}
+	e.string(rule)
+	if _, err := conn.call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "AddMatch", "s", e.buf); err != nil {
+		return fmt.Errorf("failed to subscribe to closed notifications: %w", err)
+	}
+
+	// Signals come whenever they come; closing the connection ends the read
+	conn.conn.SetDeadline(time.Time{})
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	for {
+		msg, err := conn.read()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if msg.msgType != dbusSignal || msg.member != "NotificationClosed" {
+			continue
+		}
+
+		id, err := msg.body.uint32()
+		if err != nil {
+			continue
+		}
+		reason, err := msg.body.uint32()
+		if err != nil {
+			continue
+		}
+		select {
+		case closed <- NotificationClosed{ID: id, Reason: reason}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// NotificationServer returns the name and version of the desktop notification
+// server, e.g. "dunst 1.9.0"
+func NotificationServer() (string, error) {
+	msg, err := callNotifications("GetServerInformation", "", nil)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := msg.body.string()
+	if err != nil {
+		return "", err
+	}
+	if _, err := msg.body.string(); err != nil { // Vendor
+		return name, nil
+	}
+	version, err := msg.body.string()
+	if err != nil || version == "" {
+		return name, nil
+	}
+	return name + " " + version, nil
+}
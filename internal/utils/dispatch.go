@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// ErrDuplicate is returned when the same notification was already delivered
+// within the deduplication window
+var ErrDuplicate = errors.New("same notification already delivered")
+
+// ErrRateLimited is returned when every notification channel has reached its
+// rate limit
+var ErrRateLimited = errors.New("all notification channels reached their rate limit")
+
+// rateLimitWindow is the period rate limits count notifications over
+const rateLimitWindow = time.Hour
+
+// ChannelName returns the short name of a notification method used in the
+// configuration, e.g. "desktop"
+func ChannelName(method NotificationMethod) string {
+	switch method {
+	case DesktopNotification:
+		return "desktop"
+	case TerminalBell:
+		return "bell"
+	case LogOnly:
+		return "log"
+	}
+	return "unknown"
+}
+
+// ParseChannel returns the notification method with the given short name
+func ParseChannel(name string) (NotificationMethod, error) {
+	for _, method := range []NotificationMethod{DesktopNotification, TerminalBell, LogOnly} {
+		if ChannelName(method) == name {
+			return method, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown notification channel '%s' (use desktop, bell or log)", name)
+}
+
+// SetDedupWindow drops notifications identical to one delivered less than
+// window ago, whichever channel delivered it; 0 turns deduplication off
+func (n *Notifier) SetDedupWindow(window time.Duration) {
+	n.dedupWindow = window
+}
+
+// SetRateLimits caps how many notifications each channel delivers per hour.
+// A channel at its limit is skipped in favor of the next one; channels
+// without a limit (or with 0) are unlimited.
+func (n *Notifier) SetRateLimits(limits map[NotificationMethod]int) {
+	n.rateLimits = limits
+}
+
+// LastChannel returns the channel that delivered the last notification, or
+// false if it wasn't delivered
+func (n *Notifier) LastChannel() (NotificationMethod, bool) {
+	return n.lastChannel, n.delivered
+}
+
+// dispatch delivers a notification through the first channel that is under
+// its rate limit and succeeds: the main method, then the fallbacks. primary,
+// if set, replaces the plain send for the main method. key identifies the
+// notification for deduplication.
+func (n *Notifier) dispatch(key, title, message string, priority models.Priority, primary func() error) error {
+	now := time.Now()
+	n.delivered = false
+
+	if last, ok := n.sentKeys[key]; ok && n.dedupWindow > 0 && now.Sub(last) < n.dedupWindow {
+		return ErrDuplicate
+	}
+
+	channels := append([]NotificationMethod{n.method}, n.fallbackMethods...)
+	var mainErr error
+	limited := 0
+	for i, channel := range channels {
+		if n.overLimit(channel, now) {
+			limited++
+			continue
+		}
+
+		var err error
+		if i == 0 && primary != nil {
+			err = primary()
+		} else {
+			err = n.sendWithMethod(channel, title, message, priority)
+		}
+		if err != nil {
+			if i == 0 {
+				mainErr = err
+			}
+			continue
+		}
+
+		n.record(key, channel, now)
+		n.lastErr = mainErr
+		return nil
+	}
+
+	if limited == len(channels) {
+		return ErrRateLimited
+	}
+	if mainErr == nil {
+		mainErr = ErrRateLimited
+	}
+	n.lastErr = mainErr
+	return fmt.Errorf("all notification methods failed, last error: %w", mainErr)
+}
+
+// RetryReminder sends a reminder notification again with the main method
+// only, for one that earlier went to a fallback or nowhere. Deduplication
+// doesn't apply, since the earlier copy didn't reach the desktop.
+func (n *Notifier) RetryReminder(reminder *models.Reminder, title, message string) error {
+	now := time.Now()
+	n.lastErr = nil
+	n.delivered = false
+	if n.overLimit(n.method, now) {
+		return ErrRateLimited
+	}
+
+	priority, send := n.reminderSender(reminder, title, message)
+	var err error
+	if send != nil {
+		err = send()
+	} else {
+		err = n.sendWithMethod(n.method, title, message, priority)
+	}
+	if err != nil {
+		n.lastErr = err
+		return err
+	}
+	n.record(reminder.ID+"\x00"+title, n.method, now)
+	return nil
+}
+
+// ChannelTest is the outcome of a test notification on one channel
+type ChannelTest struct {
+	Method  NotificationMethod
+	Latency time.Duration
+	Err     error
+}
+
+// TestChannels sends a test notification on each method on its own, with no
+// fallback, deduplication or rate limits, and times each send
+func (n *Notifier) TestChannels(methods []NotificationMethod, priority models.Priority) []ChannelTest {
+	results := make([]ChannelTest, 0, len(methods))
+	for _, method := range methods {
+		title := fmt.Sprintf("Nancy Test Notification (%s)", ChannelName(method))
+		start := time.Now()
+		err := n.sendWithMethod(method, title, "If you see this, this channel is working!"+Symbol(" 🎉", ""), priority)
+		results = append(results, ChannelTest{Method: method, Latency: time.Since(start), Err: err})
+	}
+	return results
+}
+
+// overLimit reports whether channel has delivered its hourly limit, and
+// forgets deliveries older than the rate limit window
+func (n *Notifier) overLimit(channel NotificationMethod, now time.Time) bool {
+	limit := n.rateLimits[channel]
+	if limit <= 0 {
+		return false
+	}
+
+	recent := n.sentBy[channel][:0]
+	for _, at := range n.sentBy[channel] {
+		if now.Sub(at) < rateLimitWindow {
+			recent = append(recent, at)
+		}
+	}
+	if n.sentBy != nil {
+		n.sentBy[channel] = recent
+	}
+	return len(recent) >= limit
+}
+
+// record notes a delivery for deduplication and rate limiting
+func (n *Notifier) record(key string, channel NotificationMethod, now time.Time) {
+	if n.sentKeys == nil {
+		n.sentKeys = make(map[string]time.Time)
+		n.sentBy = make(map[NotificationMethod][]time.Time)
+	}
+	for k, at := range n.sentKeys {
+		if now.Sub(at) >= n.dedupWindow {
+			delete(n.sentKeys, k)
+		}
+	}
+	n.sentKeys[key] = now
+	n.sentBy[channel] = append(n.sentBy[channel], now)
+	n.lastChannel = channel
+	n.delivered = true
+}
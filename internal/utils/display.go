@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// accessibleMode replaces emoji and symbols with plain text labels
+var accessibleMode bool
+
+// SetAccessibleMode enables or disables text labels instead of emoji
+func SetAccessibleMode(enabled bool) {
+	accessibleMode = enabled
+}
+
+// AccessibleMode reports whether text labels are used instead of emoji
+func AccessibleMode() bool {
+	return accessibleMode
+}
+
+// Symbol returns the emoji, or the text label in accessible mode
+func Symbol(emoji, label string) string {
+	if accessibleMode {
+		return label
+	}
+	return emoji
+}
+
+// PriorityIcon returns the priority icon, or a label like "[HIGH]"
+func PriorityIcon(p models.Priority) string {
+	return Symbol(p.Icon(), "["+strings.ToUpper(i18n.T(p.String()))+"]")
+}
+
+// CompletionIcon returns the completion marker, or "[DONE]"/"[TODO]"
+func CompletionIcon(completed bool) string {
+	if completed {
+		return Symbol("✓", i18n.T("[DONE]"))
+	}
+	return Symbol("●", i18n.T("[TODO]"))
+}
+
+// OccurrenceLabel returns a readable label for an occurrence status
+func OccurrenceLabel(status string) string {
+	switch status {
+	case models.OccurrenceOnTime:
+		return i18n.T("on time")
+	case models.OccurrenceLate:
+		return i18n.T("late")
+	case models.OccurrenceSkipped:
+		return i18n.T("skipped")
+	case models.OccurrenceMissed:
+		return i18n.T("missed")
+	}
+	return status
+}
+
+// overdueColors grade overdue reminders from recently missed to neglected
+var overdueColors = []struct {
+	minAge time.Duration
+	color  lipgloss.Color
+}{
+	{7 * 24 * time.Hour, lipgloss.Color("160")},
+	{24 * time.Hour, lipgloss.Color("196")},
+	{0, lipgloss.Color("209")},
+}
+
+// OverdueStyle returns the color for an overdue reminder, darker and bolder
+// the longer it has been neglected
+func OverdueStyle(age time.Duration) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	for _, grade := range overdueColors {
+		if age >= grade.minAge {
+			style = style.Foreground(grade.color)
+			break
+		}
+	}
+	if age >= 7*24*time.Hour {
+		style = style.Bold(true)
+	}
+	return style
+}
+
+// OverdueText labels an overdue reminder with how long it has been overdue,
+// e.g. "⚠️ OVERDUE by 3 days" or "[OVERDUE by 3 days]"
+func OverdueText(age time.Duration) string {
+	label := i18n.T("OVERDUE")
+	if age >= time.Minute {
+		label = i18n.T("OVERDUE by %s", FormatDuration(age))
+	}
+	return Symbol("⚠️ "+label, "["+label+"]")
+}
+
+// ProgressBar draws done out of total as a bar of the given width, with
+// "#" and "-" in accessible mode
+func ProgressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(done*width/total, width)
+	}
+	return Symbol(
+		strings.Repeat("▓", filled)+strings.Repeat("░", width-filled),
+		"["+strings.Repeat("#", filled)+strings.Repeat("-", width-filled)+"]")
+}
+
+// TodayProgress describes today's progress, e.g. "▓▓▓▓░░░░░░ 4/9 done today (44%)"
+func TodayProgress(done, total int) string {
+	return ProgressBar(done, total, 10) + " " +
+		i18n.T("%d/%d done today (%d%%)", done, total, done*100/max(total, 1))
+}
+
+// labelColors are the terminal colors of the reminder label colors
+var labelColors = map[string]lipgloss.Color{
+	"red":    lipgloss.Color("196"),
+	"orange": lipgloss.Color("208"),
+	"yellow": lipgloss.Color("226"),
+	"green":  lipgloss.Color("34"),
+	"blue":   lipgloss.Color("33"),
+	"purple": lipgloss.Color("129"),
+	"pink":   lipgloss.Color("205"),
+	"gray":   lipgloss.Color("245"),
+}
+
+// ColorSwatch returns a colored block for a label color, "[blue]" in
+// accessible mode or "" for no color
+func ColorSwatch(color string) string {
+	if color == "" {
+		return ""
+	}
+	if accessibleMode {
+		return "[" + i18n.T(color) + "]"
+	}
+	return lipgloss.NewStyle().Foreground(labelColors[color]).Render("■")
+}
+
+// ColorLabel returns the swatch followed by the color's name
+func ColorLabel(color string) string {
+	if accessibleMode {
+		return i18n.T(color)
+	}
+	return ColorSwatch(color) + " " + i18n.T(color)
+}
+
+// TrackedText formats tracked work time, e.g. "25m" or "1h 20m"
+func TrackedText(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes < 60 {
+		return i18n.T("%dm", minutes)
+	}
+	return i18n.T("%dh %dm", minutes/60, minutes%60)
+}
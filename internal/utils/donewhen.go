@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TrustedCommandsFile lists, one per line, the --done-when commands entered
+// on this machine. It lives in the config directory because the data
+// directory may be shared or remote, and the daemon mustn't run commands
+// that arrived with someone else's reminders.
+const TrustedCommandsFile = "trusted-commands"
+
+// doneWhenTimeout is how long a --done-when command may take
+const doneWhenTimeout = 30 * time.Second
+
+// TrustCommand records command as entered on this machine
+func TrustCommand(configDir, command string) error {
+	if IsTrustedCommand(configDir, command) {
+		return nil
+	}
+	if strings.ContainsAny(command, "\r\n") {
+		return fmt.Errorf("command must be a single line")
+	}
+	f, err := os.OpenFile(filepath.Join(configDir, TrustedCommandsFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to record trusted command: %w", err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, command); err != nil {
+		return fmt.Errorf("failed to record trusted command: %w", err)
+	}
+	return nil
+}
+
+// IsTrustedCommand reports whether command was entered on this machine
+func IsTrustedCommand(configDir, command string) bool {
+	data, err := os.ReadFile(filepath.Join(configDir, TrustedCommandsFile))
+	if err != nil {
+		return false
+	}
+	return slices.Contains(strings.Split(string(data), "\n"), command)
+}
+
+// RunDoneWhen runs a --done-when command through the shell and reports
+// whether it exited 0. An error means it couldn't tell: the command didn't
+// start or ran out of time.
+func RunDoneWhen(command string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), doneWhenTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return false, fmt.Errorf("%s took longer than %s", command, doneWhenTimeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to run %s: %w", command, err)
+	}
+	return true, nil
+}
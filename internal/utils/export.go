@@ -0,0 +1,207 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// ExportFormats are the formats reminders can be exported to
+var ExportFormats = []string{"json", "csv", "md", "ics", "html"}
+
+// ExportFormatFromPath guesses the export format from a file extension,
+// or returns "" if it isn't one of ExportFormats
+func ExportFormatFromPath(path string) string {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	switch ext {
+	case "markdown":
+		ext = "md"
+	case "htm":
+		ext = "html"
+	}
+	for _, format := range ExportFormats {
+		if ext == format {
+			return ext
+		}
+	}
+	return ""
+}
+
+// ExportReminders writes the reminders in the given format. JSON is the
+// format 'nancy import' reads; the others are for spreadsheets, notes,
+// calendar apps and browsers.
+func ExportReminders(reminders []*models.Reminder, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(reminders, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case "csv":
+		return exportCSV(reminders)
+	case "md":
+		return exportMarkdown(reminders), nil
+	case "ics":
+		return exportICS(reminders), nil
+	case "html":
+		return ExportHTML(reminders, time.Now(), 0)
+	}
+	return nil, fmt.Errorf("unknown export format '%s' (use %s)", format, strings.Join(ExportFormats, ", "))
+}
+
+// exportCSV writes one row per reminder with a header row. Tracked time is
+// in decimal hours, the way invoices bill it.
+func exportCSV(reminders []*models.Reminder) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	w.Write([]string{"id", "title", "due", "priority", "completed", "tags", "assignee", "repeats", "url", "description", "color", "tracked_hours"})
+	now := time.Now()
+	for _, r := range reminders {
+		repeats := ""
+		if r.Recurring != nil {
+			repeats = r.Recurring.String()
+		}
+		w.Write([]string{
+			r.DisplayID(),
+			r.Title,
+			r.DueTime.Format(time.RFC3339),
+			r.Priority.String(),
+			strconv.FormatBool(r.Completed),
+			strings.Join(r.Tags, ","),
+			r.Assignee,
+			repeats,
+			r.URL,
+			r.Description,
+			r.LabelColor(),
+			strconv.FormatFloat(r.TrackedTime(now).Hours(), 'f', 2, 64),
+		})
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// exportMarkdown writes a task list
+func exportMarkdown(reminders []*models.Reminder) []byte {
+	var b strings.Builder
+	b.WriteString("# Reminders\n\n")
+
+	for _, r := range reminders {
+		check := " "
+		if r.Completed {
+			check = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] %s — %s (%s)", check, r.Title, r.DueTime.Format("2006-01-02 15:04"), r.Priority)
+		for _, tag := range r.Tags {
+			b.WriteString(" #" + tag)
+		}
+		b.WriteString("\n")
+
+		if r.URL != "" {
+			b.WriteString("  " + r.URL + "\n")
+		}
+		for _, line := range strings.Split(r.Description, "\n") {
+			if strings.TrimSpace(line) != "" {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+	}
+	return []byte(b.String())
+}
+
+// icsPriority maps priorities onto the RFC 5545 scale (1 highest, 9 lowest)
+var icsPriority = map[models.Priority]int{
+	models.High:   1,
+	models.Medium: 5,
+	models.Low:    9,
+}
+
+// exportICS writes an iCalendar document with a VTODO per reminder
+func exportICS(reminders []*models.Reminder) []byte {
+	var b strings.Builder
+	line := func(content string) {
+		b.WriteString(foldICSLine(content) + "\r\n")
+	}
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Nagging Nancy//EN")
+	for _, r := range reminders {
+		line("BEGIN:VTODO")
+		line("UID:" + r.ID + "@nagging-nancy")
+		line("DTSTAMP:" + stamp)
+		line("SUMMARY:" + escapeICSText(r.Title))
+		line("DUE:" + r.DueTime.UTC().Format("20060102T150405Z"))
+		line("PRIORITY:" + strconv.Itoa(icsPriority[r.Priority]))
+		if r.Completed {
+			line("STATUS:COMPLETED")
+			if r.CompletedAt != nil {
+				line("COMPLETED:" + r.CompletedAt.UTC().Format("20060102T150405Z"))
+			}
+		} else {
+			line("STATUS:NEEDS-ACTION")
+		}
+		// Calendar apps color by category, so the label color becomes one
+		var categories []string
+		if color := r.LabelColor(); color != "" {
+			categories = append(categories, icsColorCategory(color))
+		}
+		for _, tag := range r.Tags {
+			categories = append(categories, escapeICSText(tag))
+		}
+		if len(categories) > 0 {
+			line("CATEGORIES:" + strings.Join(categories, ","))
+		}
+		if color := r.LabelColor(); color != "" {
+			line("COLOR:" + color)
+		}
+		if r.Description != "" {
+			line("DESCRIPTION:" + escapeICSText(r.Description))
+		}
+		if r.URL != "" {
+			line("URL:" + r.URL)
+		}
+		line("END:VTODO")
+	}
+	line("END:VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// icsColorCategory names the category for a label color the way Outlook's
+// default categories are named, e.g. "Blue Category"
+func icsColorCategory(color string) string {
+	return strings.ToUpper(color[:1]) + color[1:] + " Category"
+}
+
+// escapeICSText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICSLine splits content lines longer than 75 octets, never inside a
+// UTF-8 sequence, as unfoldICS expects
+func foldICSLine(s string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
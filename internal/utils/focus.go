@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// FocusFile is the name of the focus session file in the data directory
+const FocusFile = "focus.json"
+
+// Focus is a focus session: until it ends, only reminders with one of its
+// tags send notifications
+type Focus struct {
+	Tags  []string  `json:"tags"`
+	Until time.Time `json:"until"`
+}
+
+// LoadFocus reads the focus session from path. It returns nil when there is
+// none or it ended before now, so focus reverts on its own.
+func LoadFocus(path string, now time.Time) (*Focus, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read focus session: %w", err)
+	}
+
+	var focus Focus
+	if err := json.Unmarshal(data, &focus); err != nil {
+		return nil, fmt.Errorf("failed to parse focus session: %w", err)
+	}
+	if !now.Before(focus.Until) {
+		return nil, nil
+	}
+	return &focus, nil
+}
+
+// SaveFocus starts a focus session, replacing any running one
+func SaveFocus(path string, focus *Focus) error {
+	data, err := json.MarshalIndent(focus, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal focus session: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write focus session: %w", err)
+	}
+	return nil
+}
+
+// EndFocus ends the focus session at path, if any
+func EndFocus(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to end focus session: %w", err)
+	}
+	return nil
+}
+
+// Allows reports whether the reminder may notify during the focus session:
+// it has one of the focus tags or a tag nested below one
+func (f *Focus) Allows(reminder *models.Reminder) bool {
+	for _, tag := range f.Tags {
+		if reminder.MatchesTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Banner describes the focus session, e.g.
+// "Focus: #deepwork until 3:30 PM (1h 20m left)"
+func (f *Focus) Banner(now time.Time) string {
+	return i18n.T("Focus: %s until %s (%s left)", hashTags(f.Tags), i18n.FormatTime(f.Until, "3:04 PM"), FormatDuration(f.Until.Sub(now)))
+}
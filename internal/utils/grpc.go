@@ -0,0 +1,413 @@
+package utils
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	nancyv1 "github.com/ivyascorp-net/nagging-nancy/pkg/nancy/v1"
+)
+
+// grpcServer serves the nancy.v1.Reminders service over a store
+type grpcServer struct {
+	nancyv1.UnimplementedRemindersServer
+	api *apiServer
+}
+
+// NewGRPCServer serves the nancy.v1.Reminders service of
+// proto/nancy/v1/reminders.proto over store: the operations of the REST API
+// plus a Watch stream of store changes. With token set, every call must
+// carry it as a bearer token in its metadata; with cert and key set, it
+// serves over TLS.
+func NewGRPCServer(store *models.Store, token, cert, key string) (*grpc.Server, error) {
+	api := &apiServer{store: store, token: token}
+	options := []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := api.authorizeRPC(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := api.authorizeRPC(stream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	}
+	if cert != "" {
+		creds, err := credentials.NewServerTLSFromFile(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the TLS certificate: %w", err)
+		}
+		options = append(options, grpc.Creds(creds))
+	}
+
+	server := grpc.NewServer(options...)
+	nancyv1.RegisterRemindersServer(server, &grpcServer{api: api})
+	return server, nil
+}
+
+// authorizeRPC refuses calls without the token
+func (api *apiServer) authorizeRPC(ctx context.Context) error {
+	if api.token == "" {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		bearer, _ := strings.CutPrefix(value, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(bearer), []byte(api.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid token")
+}
+
+func (s *grpcServer) ListReminders(ctx context.Context, req *nancyv1.ListRemindersRequest) (*nancyv1.ListRemindersResponse, error) {
+	reminders := s.api.store.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})
+	sort.Slice(reminders, func(i, j int) bool {
+		if reminders[i].ShortID != reminders[j].ShortID {
+			return reminders[i].ShortID < reminders[j].ShortID
+		}
+		return reminders[i].ID < reminders[j].ID
+	})
+
+	resp := &nancyv1.ListRemindersResponse{Reminders: make([]*nancyv1.Reminder, len(reminders))}
+	for i, reminder := range reminders {
+		resp.Reminders[i] = reminderToProto(reminder)
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) GetReminder(ctx context.Context, req *nancyv1.GetReminderRequest) (*nancyv1.Reminder, error) {
+	reminder, err := s.api.find(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return reminderToProto(reminder), nil
+}
+
+func (s *grpcServer) CreateReminder(ctx context.Context, req *nancyv1.CreateReminderRequest) (*nancyv1.Reminder, error) {
+	reminder, err := reminderFromProto(req.GetReminder())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// A fresh ID, so a create can't bring back a deleted reminder; whatever
+	// else the request leaves out keeps the defaults of a new reminder
+	reminder.ID = uuid.New().String()
+	if reminder.CreatedAt.IsZero() {
+		reminder.CreatedAt = time.Now()
+	}
+	if reminder.UpdatedAt.IsZero() {
+		reminder.UpdatedAt = reminder.CreatedAt
+	}
+	if err := checkAPIReminder(reminder); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reminder.ShortID = 0
+	if err := s.api.store.Add(reminder); err != nil {
+		return nil, storeErrorCode(err)
+	}
+	return s.reply(reminder.ID)
+}
+
+func (s *grpcServer) PutReminder(ctx context.Context, req *nancyv1.PutReminderRequest) (*nancyv1.Reminder, error) {
+	reminder, err := reminderFromProto(req.GetReminder())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if reminder.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "the reminder needs an id")
+	}
+	if err := checkAPIReminder(reminder); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// A client that missed a delete can't bring the reminder back
+	if err := s.api.store.CheckDeleted(reminder); err != nil {
+		return nil, storeErrorCode(err)
+	}
+
+	// The server numbers reminders, so clients can't make short IDs clash
+	if existing, err := s.api.store.Get(reminder.ID); err == nil {
+		reminder.ShortID = existing.ShortID
+		err = s.api.store.Update(reminder)
+		if err != nil {
+			return nil, storeErrorCode(err)
+		}
+	} else {
+		reminder.ShortID = 0
+		if err := s.api.store.Add(reminder); err != nil {
+			return nil, storeErrorCode(err)
+		}
+	}
+	return s.reply(reminder.ID)
+}
+
+func (s *grpcServer) DeleteReminder(ctx context.Context, req *nancyv1.DeleteReminderRequest) (*nancyv1.DeleteReminderResponse, error) {
+	reminder, err := s.api.find(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err := s.api.store.Delete(reminder.ID); err != nil {
+		return nil, storeErrorCode(err)
+	}
+	return &nancyv1.DeleteReminderResponse{}, nil
+}
+
+func (s *grpcServer) CompleteReminder(ctx context.Context, req *nancyv1.CompleteReminderRequest) (*nancyv1.Reminder, error) {
+	reminder, err := s.api.find(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err := s.api.store.CompleteReminder(reminder.ID); err != nil {
+		return nil, storeErrorCode(err)
+	}
+	return s.reply(reminder.ID)
+}
+
+// Watch sends the store's events until the client hangs up
+func (s *grpcServer) Watch(req *nancyv1.WatchRequest, stream grpc.ServerStreamingServer[nancyv1.Event]) error {
+	events, unsubscribe := s.api.store.Subscribe()
+	defer unsubscribe()
+
+	// Tell the client the stream is up, so it knows it won't miss changes
+	// from here on
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(eventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// reply returns the reminder as stored
+func (s *grpcServer) reply(id string) (*nancyv1.Reminder, error) {
+	reminder, err := s.api.store.Get(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return reminderToProto(reminder), nil
+}
+
+// storeErrorCode turns an error from the store into a gRPC status
+func storeErrorCode(err error) error {
+	if errors.Is(err, models.ErrReadOnly) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, models.ErrDeleted) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+var eventKinds = map[string]nancyv1.EventKind{
+	models.EventAdded:     nancyv1.EventKind_EVENT_KIND_ADDED,
+	models.EventUpdated:   nancyv1.EventKind_EVENT_KIND_UPDATED,
+	models.EventCompleted: nancyv1.EventKind_EVENT_KIND_COMPLETED,
+	models.EventDeleted:   nancyv1.EventKind_EVENT_KIND_DELETED,
+	models.EventReloaded:  nancyv1.EventKind_EVENT_KIND_RELOADED,
+}
+
+func eventToProto(event models.Event) *nancyv1.Event {
+	return &nancyv1.Event{
+		Kind:     eventKinds[event.Kind],
+		Id:       event.ID,
+		Reminder: reminderToProto(event.Reminder),
+	}
+}
+
+var priorities = map[models.Priority]nancyv1.Priority{
+	models.Low:    nancyv1.Priority_PRIORITY_LOW,
+	models.Medium: nancyv1.Priority_PRIORITY_MEDIUM,
+	models.High:   nancyv1.Priority_PRIORITY_HIGH,
+}
+
+// reminderToProto converts a reminder to its nancy.v1 message
+func reminderToProto(r *models.Reminder) *nancyv1.Reminder {
+	if r == nil {
+		return nil
+	}
+	msg := &nancyv1.Reminder{
+		Id:           r.ID,
+		ShortId:      int32(r.ShortID),
+		Title:        r.Title,
+		Description:  r.Description,
+		DueTime:      timestampToProto(&r.DueTime),
+		Priority:     priorities[r.Priority],
+		Completed:    r.Completed,
+		CompletedAt:  timestampToProto(r.CompletedAt),
+		CreatedAt:    timestampToProto(&r.CreatedAt),
+		UpdatedAt:    timestampToProto(&r.UpdatedAt),
+		Tags:         r.Tags,
+		Assignee:     r.Assignee,
+		Archived:     r.Archived,
+		NotifyBefore: int32(r.NotifyBefore),
+		Url:          r.URL,
+		Issue:        r.Issue,
+		SunEvent:     r.SunEvent,
+		Critical:     r.Critical,
+		Color:        r.Color,
+		Input:        r.Input,
+		CheckIn:      r.CheckIn,
+		LastCheckIn:  timestampToProto(r.LastCheckIn),
+		Nag:          int32(r.Nag),
+		Sticky:       r.Sticky,
+		DoneWhen:     r.DoneWhen,
+	}
+	if r.Grace != nil {
+		msg.Grace = proto.Int32(int32(*r.Grace))
+	}
+	if rule := r.Recurring; rule != nil {
+		msg.Recurring = &nancyv1.RecurringRule{
+			Frequency:  rule.Frequency,
+			Interval:   int32(rule.Interval),
+			EndDate:    timestampToProto(rule.EndDate),
+			Count:      int32(rule.Count),
+			Occurrence: int32(rule.Occurrence),
+			Final:      rule.Final,
+			Paused:     rule.Paused,
+			Exclude:    rule.Exclude,
+		}
+	}
+	for _, occurrence := range r.History {
+		msg.History = append(msg.History, &nancyv1.Occurrence{
+			DueTime: timestampToProto(&occurrence.DueTime),
+			At:      timestampToProto(&occurrence.At),
+			Status:  occurrence.Status,
+		})
+	}
+	for _, entry := range r.TimeLog {
+		msg.TimeLog = append(msg.TimeLog, &nancyv1.TimeEntry{
+			Start: timestampToProto(&entry.Start),
+			End:   timestampToProto(entry.End),
+		})
+	}
+	return msg
+}
+
+// reminderFromProto converts a nancy.v1 message to a reminder. An
+// unspecified priority is medium.
+func reminderFromProto(msg *nancyv1.Reminder) (*models.Reminder, error) {
+	if msg == nil {
+		return nil, errors.New("the request needs a reminder")
+	}
+	r := &models.Reminder{
+		ID:           msg.GetId(),
+		ShortID:      int(msg.GetShortId()),
+		Title:        msg.GetTitle(),
+		Description:  msg.GetDescription(),
+		DueTime:      timestampFromProto(msg.GetDueTime()),
+		Priority:     models.Medium,
+		Completed:    msg.GetCompleted(),
+		CompletedAt:  optionalTimestampFromProto(msg.GetCompletedAt()),
+		CreatedAt:    timestampFromProto(msg.GetCreatedAt()),
+		UpdatedAt:    timestampFromProto(msg.GetUpdatedAt()),
+		Tags:         msg.GetTags(),
+		Assignee:     msg.GetAssignee(),
+		Archived:     msg.GetArchived(),
+		NotifyBefore: int(msg.GetNotifyBefore()),
+		URL:          msg.GetUrl(),
+		Issue:        msg.GetIssue(),
+		SunEvent:     msg.GetSunEvent(),
+		Critical:     msg.GetCritical(),
+		Color:        msg.GetColor(),
+		Input:        msg.GetInput(),
+		CheckIn:      msg.GetCheckIn(),
+		LastCheckIn:  optionalTimestampFromProto(msg.GetLastCheckIn()),
+		Nag:          int(msg.GetNag()),
+		Sticky:       msg.GetSticky(),
+		DoneWhen:     msg.GetDoneWhen(),
+	}
+	if msg.Grace != nil {
+		grace := int(msg.GetGrace())
+		r.Grace = &grace
+	}
+	switch msg.GetPriority() {
+	case nancyv1.Priority_PRIORITY_UNSPECIFIED, nancyv1.Priority_PRIORITY_MEDIUM:
+	case nancyv1.Priority_PRIORITY_LOW:
+		r.Priority = models.Low
+	case nancyv1.Priority_PRIORITY_HIGH:
+		r.Priority = models.High
+	default:
+		return nil, fmt.Errorf("invalid priority %d", msg.GetPriority())
+	}
+	if r.Tags == nil {
+		r.Tags = make([]string, 0)
+	}
+	if rule := msg.GetRecurring(); rule != nil {
+		r.Recurring = &models.RecurringRule{
+			Frequency:  rule.GetFrequency(),
+			Interval:   int(rule.GetInterval()),
+			EndDate:    optionalTimestampFromProto(rule.GetEndDate()),
+			Count:      int(rule.GetCount()),
+			Occurrence: int(rule.GetOccurrence()),
+			Final:      rule.GetFinal(),
+			Paused:     rule.GetPaused(),
+			Exclude:    rule.GetExclude(),
+		}
+	}
+	for _, occurrence := range msg.GetHistory() {
+		r.History = append(r.History, models.Occurrence{
+			DueTime: timestampFromProto(occurrence.GetDueTime()),
+			At:      timestampFromProto(occurrence.GetAt()),
+			Status:  occurrence.GetStatus(),
+		})
+	}
+	for _, entry := range msg.GetTimeLog() {
+		r.TimeLog = append(r.TimeLog, models.TimeEntry{
+			Start: timestampFromProto(entry.GetStart()),
+			End:   optionalTimestampFromProto(entry.GetEnd()),
+		})
+	}
+	return r, nil
+}
+
+// timestampToProto leaves zero and missing times unset
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// timestampFromProto gives the zero time for unset timestamps
+func timestampFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime().Local()
+}
+
+func optionalTimestampFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime().Local()
+	return &t
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// heatmapLevels are the cells from no activity up to the busiest days, and
+// their accessible-mode counterparts
+var (
+	heatmapLevels      = []string{"·", "░", "▒", "▓", "█"}
+	heatmapLevelLabels = []string{"0", "1", "2", "3", "4"}
+)
+
+// heatmapLabelWidth is the width of the weekday column
+const heatmapLabelWidth = 4
+
+// Heatmap renders one row per weekday and one column per week, ending with
+// the week of end, like a GitHub contributions graph. cell returns the
+// one-character cell for a day; days after end are left blank.
+func Heatmap(weeks int, end time.Time, cell func(day time.Time) string) string {
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	start := HeatmapStart(weeks, end)
+
+	var b strings.Builder
+
+	// Month names over the week they start in, where there is room
+	header := []rune(strings.Repeat(" ", heatmapLabelWidth+2*weeks))
+	free := 0
+	for w := 0; w < weeks; w++ {
+		weekStart := start.AddDate(0, 0, 7*w)
+		if w > 0 && weekStart.Month() == weekStart.AddDate(0, 0, -7).Month() {
+			continue
+		}
+		// A month with only its last week shown would crowd out the next
+		if w == 0 && weeks > 1 && weekStart.AddDate(0, 0, 7).Month() != weekStart.Month() {
+			continue
+		}
+		pos := heatmapLabelWidth + 2*w
+		name := []rune(i18n.FormatTime(weekStart, "Jan"))
+		if pos < free || pos+len(name) > len(header) {
+			continue
+		}
+		copy(header[pos:], name)
+		free = pos + len(name) + 1
+	}
+	b.WriteString(strings.TrimRight(string(header), " ") + "\n")
+
+	for d := 0; d < 7; d++ {
+		label := ""
+		if d%2 == 0 && d < 6 {
+			label = i18n.FormatTime(start.AddDate(0, 0, d), "Mon")
+		}
+		row := fmt.Sprintf("%-*s", heatmapLabelWidth, label)
+		for w := 0; w < weeks; w++ {
+			day := start.AddDate(0, 0, 7*w+d)
+			if day.After(end) {
+				break
+			}
+			row += cell(day) + " "
+		}
+		b.WriteString(strings.TrimRight(row, " ") + "\n")
+	}
+	return b.String()
+}
+
+// HeatmapStart returns the first day of a heatmap of the given number of
+// weeks ending with the week of end. Weeks start on the configured first day.
+func HeatmapStart(weeks int, end time.Time) time.Time {
+	return i18n.WeekStart(end).AddDate(0, 0, -7*(weeks-1))
+}
+
+// heatmapLevel returns the cell for a count, scaled to the highest count
+func heatmapLevel(count, highest int) string {
+	level := 0
+	if count > 0 && highest > 0 {
+		level = min((count*4+highest-1)/highest, 4)
+	}
+	return Symbol(heatmapLevels[level], heatmapLevelLabels[level])
+}
+
+// CompletionHeatmap renders how many reminders were completed each day
+func CompletionHeatmap(activity map[string]models.DayActivity, weeks int, end time.Time) string {
+	highest := 0
+	for _, day := range activity {
+		highest = max(highest, day.Completed)
+	}
+
+	heatmap := Heatmap(weeks, end, func(day time.Time) string {
+		return heatmapLevel(activity[day.Format("2006-01-02")].Completed, highest)
+	})
+
+	legend := make([]string, len(heatmapLevels))
+	for i := range heatmapLevels {
+		legend[i] = Symbol(heatmapLevels[i], heatmapLevelLabels[i])
+	}
+	return heatmap + strings.Repeat(" ", heatmapLabelWidth) +
+		i18n.T("Less %s More", strings.Join(legend, " ")) + "\n"
+}
+
+// LoadHeatmap renders whether more reminders were created or completed each
+// day, showing when the backlog grew and when it shrank
+func LoadHeatmap(activity map[string]models.DayActivity, weeks int, end time.Time) string {
+	heatmap := Heatmap(weeks, end, func(day time.Time) string {
+		activity := activity[day.Format("2006-01-02")]
+		switch {
+		case activity.Created > activity.Completed:
+			return "+"
+		case activity.Created < activity.Completed:
+			return "-"
+		case activity.Created > 0:
+			return "="
+		}
+		return Symbol(heatmapLevels[0], heatmapLevelLabels[0])
+	})
+
+	return heatmap + strings.Repeat(" ", heatmapLabelWidth) +
+		i18n.T("+ more added than done  - more done than added  = even") + "\n"
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// htmlPage is a self-contained page for a tablet or wall display: no
+// scripts, no external styles, and a plain black-on-white layout in print
+var htmlPage = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+{{- if .Refresh}}
+<meta http-equiv="refresh" content="{{.Refresh}}">
+{{- end}}
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 48rem; padding: 0 1rem; color: #1f2937; background: #f9fafb; }
+h1 { font-size: 1.6rem; margin-bottom: 0; }
+.generated { color: #6b7280; margin-top: .25rem; }
+h2 { font-size: 1.2rem; border-bottom: 2px solid #e5e7eb; padding-bottom: .25rem; margin-top: 2rem; }
+h2.overdue { color: #b91c1c; }
+ul { list-style: none; padding: 0; }
+li { background: #fff; border-left: 4px solid #6b7280; border-radius: 4px; margin: .5rem 0; padding: .6rem .8rem; }
+li.high { border-color: #ef4444; }
+li.medium { border-color: #f59e0b; }
+li.low { border-color: #10b981; }
+li.done { opacity: .6; }
+li.done .title { text-decoration: line-through; }
+.title { font-weight: 600; }
+.due, .tags, .note { color: #4b5563; font-size: .9rem; }
+.note { white-space: pre-wrap; margin-top: .3rem; }
+.empty { color: #6b7280; font-style: italic; }
+@media print {
+  body { background: #fff; color: #000; margin: 0; max-width: none; }
+  li { border: 1px solid #000; border-left-width: 4px; break-inside: avoid; }
+  .due, .tags, .note, .generated { color: #000; }
+}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="generated">{{.Generated}}</p>
+{{- range .Groups}}
+<h2{{if .Overdue}} class="overdue"{{end}}>{{.Name}}</h2>
+<ul>
+{{- range .Items}}
+<li class="{{.Priority}}{{if .Done}} done{{end}}">
+<div class="title">{{if .Done}}✓ {{end}}{{.Title}}</div>
+<div class="due">{{.Due}}{{if .Repeats}} · {{.Repeats}}{{end}}</div>
+{{- if .Tags}}
+<div class="tags">{{.Tags}}</div>
+{{- end}}
+{{- if .Note}}
+<div class="note">{{.Note}}</div>
+{{- end}}
+</li>
+{{- end}}
+</ul>
+{{- else}}
+<p class="empty">{{.Empty}}</p>
+{{- end}}
+</body>
+</html>
+`))
+
+type htmlItem struct {
+	Title, Due, Repeats, Tags, Note, Priority string
+	Done                                      bool
+}
+
+type htmlGroup struct {
+	Name    string
+	Overdue bool
+	Items   []htmlItem
+}
+
+// ExportHTML renders the reminders as a standalone HTML page grouped by due
+// day (overdue, today, tomorrow, this week, later, completed). A positive
+// refresh makes browsers reload the page every refresh seconds.
+func ExportHTML(reminders []*models.Reminder, now time.Time, refresh int) ([]byte, error) {
+	var groups []htmlGroup
+	for _, group := range models.GroupByDue(reminders, now) {
+		g := htmlGroup{Name: i18n.T(group.Name), Overdue: group.Name == models.GroupOverdue}
+		for _, r := range group.Reminders {
+			item := htmlItem{
+				Title:    r.Title,
+				Due:      r.FormattedDueTime(),
+				Tags:     hashTags(r.Tags),
+				Note:     r.Description,
+				Priority: r.Priority.String(),
+				Done:     r.Completed,
+			}
+			if r.Recurring != nil {
+				item.Repeats = r.Recurring.String()
+			}
+			g.Items = append(g.Items, item)
+		}
+		groups = append(groups, g)
+	}
+
+	var buf bytes.Buffer
+	err := htmlPage.Execute(&buf, map[string]any{
+		"Lang":      i18n.Language(),
+		"Title":     i18n.T("Reminders"),
+		"Generated": i18n.T("Updated %s", i18n.FormatTime(now, "Monday, January 2, 2006")+" "+i18n.FormatTime(now, "3:04 PM")),
+		"Refresh":   refresh,
+		"Groups":    groups,
+		"Empty":     i18n.T("Nothing to do."),
+	})
+	return buf.Bytes(), err
+}
+
+// hashTags formats tags like "#work #calls @home"
+func hashTags(tags []string) string {
+	labels := make([]string, len(tags))
+	for i, tag := range tags {
+		labels[i] = tag
+		if !strings.HasPrefix(tag, "@") {
+			labels[i] = "#" + tag
+		}
+	}
+	return strings.Join(labels, " ")
+}
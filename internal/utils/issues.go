@@ -0,0 +1,207 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// GitHubAPI is the GitHub REST API base URL
+var GitHubAPI = "https://api.github.com"
+
+var (
+	githubIssuePattern = regexp.MustCompile(`^([\w.-]+)/([\w.-]+)#(\d+)$`)
+	jiraIssuePattern   = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-\d+$`)
+)
+
+// IssueRef identifies a GitHub issue (owner/repo#123) or a Jira issue (PROJ-123)
+type IssueRef struct {
+	Owner  string
+	Repo   string
+	Number string
+	Key    string // Jira key, empty for GitHub issues
+}
+
+// ParseIssueRef parses "owner/repo#123" or a Jira key like "PROJ-123"
+func ParseIssueRef(s string) (IssueRef, error) {
+	s = strings.TrimSpace(s)
+	if m := githubIssuePattern.FindStringSubmatch(s); m != nil {
+		return IssueRef{Owner: m[1], Repo: m[2], Number: m[3]}, nil
+	}
+	if jiraIssuePattern.MatchString(s) {
+		return IssueRef{Key: s}, nil
+	}
+	return IssueRef{}, fmt.Errorf("invalid issue '%s' (use OWNER/REPO#123 or a Jira key like PROJ-123)", s)
+}
+
+// IsJira reports whether the reference is a Jira issue
+func (r IssueRef) IsJira() bool {
+	return r.Key != ""
+}
+
+func (r IssueRef) String() string {
+	if r.IsJira() {
+		return r.Key
+	}
+	return fmt.Sprintf("%s/%s#%s", r.Owner, r.Repo, r.Number)
+}
+
+// IssueCredentials holds the API settings used to look up issues
+type IssueCredentials struct {
+	GitHubToken string
+	JiraURL     string
+	JiraEmail   string
+	JiraToken   string
+}
+
+// IssueInfo is what Nancy needs to know about a linked issue
+type IssueInfo struct {
+	Title  string
+	URL    string
+	Closed bool
+}
+
+// FetchIssue looks up an issue's title, web link and state
+func FetchIssue(ref IssueRef, creds IssueCredentials) (*IssueInfo, error) {
+	if ref.IsJira() {
+		return fetchJiraIssue(ref, creds)
+	}
+	return fetchGitHubIssue(ref, creds)
+}
+
+func fetchGitHubIssue(ref IssueRef, creds IssueCredentials) (*IssueInfo, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/repos/%s/%s/issues/%s",
+		strings.TrimSuffix(GitHubAPI, "/"), ref.Owner, ref.Repo, ref.Number), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if creds.GitHubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+creds.GitHubToken)
+	}
+
+	var issue struct {
+		Title   string `json:"title"`
+		HTMLURL string `json:"html_url"`
+		State   string `json:"state"`
+	}
+	if err := getJSON(req, ref.String(), &issue); err != nil {
+		return nil, err
+	}
+	return &IssueInfo{Title: issue.Title, URL: issue.HTMLURL, Closed: issue.State == "closed"}, nil
+}
+
+func fetchJiraIssue(ref IssueRef, creds IssueCredentials) (*IssueInfo, error) {
+	if creds.JiraURL == "" {
+		return nil, fmt.Errorf("set integrations.jira_url to look up Jira issues")
+	}
+	base := strings.TrimSuffix(creds.JiraURL, "/")
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary,status", base, ref.Key), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if creds.JiraToken != "" {
+		if creds.JiraEmail != "" {
+			req.SetBasicAuth(creds.JiraEmail, creds.JiraToken)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+creds.JiraToken)
+		}
+	}
+
+	var issue struct {
+		Fields struct {
+			Summary string `json:"summary"`
+			Status  struct {
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := getJSON(req, ref.String(), &issue); err != nil {
+		return nil, err
+	}
+	return &IssueInfo{
+		Title:  issue.Fields.Summary,
+		URL:    fmt.Sprintf("%s/browse/%s", base, ref.Key),
+		Closed: issue.Fields.Status.StatusCategory.Key == "done",
+	}, nil
+}
+
+// getJSON performs an API request and decodes the JSON response; what
+// names the requested resource in errors
+func getJSON(req *http.Request, what string, v interface{}) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", what, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s: %s", what, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to read %s: %w", what, err)
+	}
+	return nil
+}
+
+// PullRequest is a pull request waiting for the user's review
+type PullRequest struct {
+	Title string
+	URL   string
+	Ref   IssueRef
+}
+
+// FetchReviewRequests lists open pull requests where the token's user has a
+// pending review request
+func FetchReviewRequests(token string) ([]PullRequest, error) {
+	if token == "" {
+		return nil, fmt.Errorf("a GitHub token is required (set GITHUB_TOKEN or integrations.github_token)")
+	}
+
+	req, err := http.NewRequest("GET", strings.TrimSuffix(GitHubAPI, "/")+
+		"/search/issues?per_page=100&q=is:open+is:pr+review-requested:@me", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var result struct {
+		Items []struct {
+			Title         string `json:"title"`
+			HTMLURL       string `json:"html_url"`
+			Number        int    `json:"number"`
+			RepositoryURL string `json:"repository_url"`
+		} `json:"items"`
+	}
+	if err := getJSON(req, "review requests", &result); err != nil {
+		return nil, err
+	}
+
+	prs := make([]PullRequest, 0, len(result.Items))
+	for _, item := range result.Items {
+		// repository_url ends in /repos/OWNER/REPO
+		parts := strings.Split(strings.TrimSuffix(item.RepositoryURL, "/"), "/")
+		if len(parts) < 2 {
+			continue
+		}
+		prs = append(prs, PullRequest{
+			Title: item.Title,
+			URL:   item.HTMLURL,
+			Ref: IssueRef{
+				Owner:  parts[len(parts)-2],
+				Repo:   parts[len(parts)-1],
+				Number: fmt.Sprint(item.Number),
+			},
+		})
+	}
+	return prs, nil
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// untaggedGroup is the journal heading for reminders without tags
+const untaggedGroup = "Other"
+
+// JournalHeading returns the heading line used for a day's journal entry
+func JournalHeading(day time.Time) string {
+	return "## Done " + day.Format("Monday, January 2, 2006")
+}
+
+// journalEntry is one completion in a done list; a recurring reminder can
+// have several on the same day
+type journalEntry struct {
+	reminder *models.Reminder
+	at       time.Time
+}
+
+// BuildJournal renders the reminders completed on the given day, including
+// the completed occurrences of recurring ones, as a "done list" grouped by
+// tag. format is "md" or "text".
+func BuildJournal(reminders []*models.Reminder, day time.Time, format string) (string, error) {
+	if format != "md" && format != "text" {
+		return "", fmt.Errorf("unsupported journal format: %s (use md or text)", format)
+	}
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	end := start.AddDate(0, 0, 1)
+
+	// Group completions by their first tag
+	groups := make(map[string][]journalEntry)
+	add := func(reminder *models.Reminder, at time.Time) {
+		if at.Before(start) || !at.Before(end) {
+			return
+		}
+		group := untaggedGroup
+		if len(reminder.Tags) > 0 {
+			group = reminder.Tags[0]
+		}
+		groups[group] = append(groups[group], journalEntry{reminder, at})
+	}
+	for _, reminder := range reminders {
+		// Recurring completions, including the last one, are in the history
+		for _, occurrence := range reminder.History {
+			if occurrence.Done() {
+				add(reminder, occurrence.At)
+			}
+		}
+		if reminder.Recurring == nil && reminder.CompletedAt != nil {
+			add(reminder, *reminder.CompletedAt)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		if name != untaggedGroup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := groups[untaggedGroup]; ok {
+		names = append(names, untaggedGroup)
+	}
+
+	var s strings.Builder
+	if format == "md" {
+		s.WriteString(JournalHeading(start) + "\n\n")
+	} else {
+		s.WriteString("Done " + start.Format("Monday, January 2, 2006") + "\n\n")
+	}
+
+	if len(names) == 0 {
+		s.WriteString("Nothing completed.\n")
+		return s.String(), nil
+	}
+
+	for _, name := range names {
+		items := groups[name]
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].at.Before(items[j].at)
+		})
+
+		if format == "md" {
+			s.WriteString("### " + name + "\n\n")
+		} else {
+			s.WriteString(name + ":\n")
+		}
+		for _, item := range items {
+			at := i18n.FormatTime(item.at, "3:04 PM")
+			if format == "md" {
+				s.WriteString(fmt.Sprintf("- [x] %s _(%s)_\n", item.reminder.Title, at))
+			} else {
+				s.WriteString(fmt.Sprintf("  %s %s (%s)\n", Symbol("✓", "[x]"), item.reminder.Title, at))
+			}
+		}
+		s.WriteString("\n")
+	}
+
+	return s.String(), nil
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ArrivalFile is the name of the file in the state directory recording the
+// place the phone last reported arriving at
+const ArrivalFile = "arrival.json"
+
+// ArrivalWindow is how long after an arrival its reminders may still go out,
+// so a daemon started later doesn't nag about an arrival hours ago
+const ArrivalWindow = 15 * time.Minute
+
+// Arrival is the place a phone automation app last reported arriving at
+type Arrival struct {
+	Place string    `json:"place"`
+	At    time.Time `json:"at"`
+}
+
+// PlaceTag returns the tag of the reminders for a place, e.g. "@home"
+func PlaceTag(place string) string {
+	return "@" + strings.TrimPrefix(strings.ToLower(strings.TrimSpace(place)), "@")
+}
+
+// LoadArrival reads the last arrival from path. It returns nil when there is
+// none or it is older than ArrivalWindow.
+func LoadArrival(path string, now time.Time) (*Arrival, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read arrival: %w", err)
+	}
+
+	var arrival Arrival
+	if err := json.Unmarshal(data, &arrival); err != nil {
+		return nil, fmt.Errorf("failed to parse arrival: %w", err)
+	}
+	if now.Sub(arrival.At) > ArrivalWindow {
+		return nil, nil
+	}
+	return &arrival, nil
+}
+
+// SaveArrival records arriving at place
+func SaveArrival(path, place string, now time.Time) error {
+	if PlaceTag(place) == "@" {
+		return fmt.Errorf("no place given")
+	}
+	data, err := json.MarshalIndent(&Arrival{Place: strings.TrimPrefix(PlaceTag(place), "@"), At: now}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal arrival: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create the state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write arrival: %w", err)
+	}
+	return nil
+}
+
+// ClearArrival forgets the last arrival after leaving place, unless it was
+// for another place: leaving the shop after getting home doesn't cancel home
+func ClearArrival(path, place string) error {
+	// However old it is
+	arrival, err := LoadArrival(path, time.Time{})
+	if err != nil || arrival == nil || PlaceTag(arrival.Place) != PlaceTag(place) {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear arrival: %w", err)
+	}
+	return nil
+}
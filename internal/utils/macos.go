@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// MacNotifierApp is the helper app that posts notifications through
+// UNUserNotificationCenter, so they show up as Nancy's own in Notification
+// Center. macOS only grants that to app bundles, hence the separate helper.
+const MacNotifierApp = "Nancy Notifier.app"
+
+// macNotifierBinary is the executable inside the helper bundle
+const macNotifierBinary = "Contents/MacOS/nancy-notifier"
+
+// Notification permission as reported by the helper
+const (
+	PermissionAuthorized    = "authorized"
+	PermissionDenied        = "denied"
+	PermissionNotDetermined = "not-determined"
+)
+
+// ErrNotificationsDenied is returned when the user turned off notifications
+// for Nancy Notifier in System Settings
+var ErrNotificationsDenied = errors.New("notifications are turned off for Nancy Notifier in System Settings")
+
+// MacNotifierPath finds the helper: $NANCY_NOTIFIER, next to the nancy
+// binary, then ~/Applications and /Applications.
+func MacNotifierPath() (string, bool) {
+	if path := os.Getenv("NANCY_NOTIFIER"); path != "" {
+		_, err := os.Stat(path)
+		return path, err == nil
+	}
+
+	var dirs []string
+	if exe, err := os.Executable(); err == nil {
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		dirs = append(dirs, filepath.Dir(exe))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "Applications"))
+	}
+	dirs = append(dirs, "/Applications")
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, MacNotifierApp, macNotifierBinary)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// MacNotificationPermission asks the helper whether Nancy may post
+// notifications
+func MacNotificationPermission() (string, error) {
+	path, ok := MacNotifierPath()
+	if !ok {
+		return "", fmt.Errorf("%s is not installed", MacNotifierApp)
+	}
+
+	out, err := exec.Command(path, "status").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to query notification permission: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// sendMacNotifier posts a notification through the helper. With a reminder ID
+// it gets Done and Snooze actions, which call back into "nancy toast-action";
+// repeats for the same reminder replace each other. Critical notifications are
+// time sensitive, which lets them through Focus.
+func sendMacNotifier(path, title, message string, priority models.Priority, id string, critical bool) error {
+	args := []string{"send", "--title", title, "--message", message}
+	if priority == models.High || critical {
+		args = append(args, "--sound")
+	}
+	if critical {
+		args = append(args, "--critical")
+	}
+	if id != "" {
+		nancy, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to locate nancy: %w", err)
+		}
+		args = append(args,
+			"--id", id,
+			"--nancy", nancy,
+			"--done-label", i18n.T("Done"),
+			"--snooze-label", i18n.T("Snooze"),
+		)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(path, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// The helper exits with 3 when permission is denied
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 3 {
+			return ErrNotificationsDenied
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("nancy-notifier: %s", msg)
+		}
+		return fmt.Errorf("nancy-notifier failed: %w", err)
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
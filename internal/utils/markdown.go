@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Just enough markdown for reminder notes: headings, lists, checklists,
+// quotes, code blocks, emphasis, inline code and links.
+
+var (
+	mdHeadingStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	mdQuoteStyle   = lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("245"))
+	mdCodeStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("179"))
+	mdBoldStyle    = lipgloss.NewStyle().Bold(true)
+	mdItalicStyle  = lipgloss.NewStyle().Italic(true)
+	mdStrikeStyle  = lipgloss.NewStyle().Strikethrough(true)
+	mdLinkStyle    = lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color("33"))
+	mdDoneStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+)
+
+var (
+	mdHeading   = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdCheckItem = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	mdListItem  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdNumbered  = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	mdRule      = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+
+	// Inline spans, tried in this order
+	mdInline = regexp.MustCompile("`([^`]+)`" +
+		`|\[([^\]]+)\]\(([^)\s]+)\)` +
+		`|\*\*([^*]+)\*\*|__([^_]+)__` +
+		`|~~([^~]+)~~` +
+		`|\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+)
+
+// RenderMarkdown renders markdown text for the terminal, wrapping
+// paragraphs at width when it is positive. Unknown syntax is left as is.
+func RenderMarkdown(text string, width int) string {
+	var out []string
+	inCode := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, "  "+mdCodeStyle.Render(line))
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			out = append(out, "")
+		case mdRule.MatchString(line):
+			out = append(out, mdQuoteStyle.Render(strings.Repeat("─", max(min(width, 40), 10))))
+		case mdHeading.MatchString(trimmed):
+			heading := mdHeading.FindStringSubmatch(trimmed)[2]
+			out = append(out, mdHeadingStyle.Render(renderInline(heading)))
+		case mdCheckItem.MatchString(line):
+			item := mdCheckItem.FindStringSubmatch(line)
+			box, body := Symbol("☐", "[ ]"), renderInline(item[3])
+			if item[2] != " " {
+				box, body = Symbol("☑", "[x]"), mdDoneStyle.Render(item[3])
+			}
+			out = append(out, wrapItem(listIndent(item[1])+box+" ", body, width))
+		case mdListItem.MatchString(line):
+			item := mdListItem.FindStringSubmatch(line)
+			out = append(out, wrapItem(listIndent(item[1])+Symbol("•", "-")+" ", renderInline(item[2]), width))
+		case mdNumbered.MatchString(line):
+			item := mdNumbered.FindStringSubmatch(line)
+			out = append(out, wrapItem(listIndent(item[1])+item[2]+" ", renderInline(item[3]), width))
+		case strings.HasPrefix(trimmed, ">"):
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			out = append(out, wrapItem("│ ", mdQuoteStyle.Render(renderInline(quote)), width))
+		default:
+			out = append(out, wrapItem("", renderInline(trimmed), width))
+		}
+	}
+
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// renderInline styles the inline spans of one line
+func renderInline(text string) string {
+	return mdInline.ReplaceAllStringFunc(text, func(span string) string {
+		m := mdInline.FindStringSubmatch(span)
+		switch {
+		case m[1] != "":
+			return mdCodeStyle.Render(m[1])
+		case m[2] != "":
+			return mdLinkStyle.Render(m[2]) + " (" + m[3] + ")"
+		case m[4] != "":
+			return mdBoldStyle.Render(m[4])
+		case m[5] != "":
+			return mdBoldStyle.Render(m[5])
+		case m[6] != "":
+			return mdStrikeStyle.Render(m[6])
+		case m[7] != "":
+			return mdItalicStyle.Render(m[7])
+		case m[8] != "":
+			return mdItalicStyle.Render(m[8])
+		}
+		return span
+	})
+}
+
+// listIndent turns a list item's leading whitespace into two spaces per level
+func listIndent(lead string) string {
+	level := len(strings.ReplaceAll(lead, "\t", "  ")) / 2
+	return strings.Repeat("  ", level)
+}
+
+// wrapItem wraps body at width, indenting continuation lines under the
+// first character after prefix
+func wrapItem(prefix, body string, width int) string {
+	if width <= 0 {
+		return prefix + body
+	}
+	indent := lipgloss.Width(prefix)
+	wrapped := lipgloss.NewStyle().Width(max(width-indent, 10)).Render(body)
+	lines := strings.Split(wrapped, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " ")
+		if i == 0 {
+			lines[i] = prefix + line
+		} else {
+			lines[i] = strings.Repeat(" ", indent) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
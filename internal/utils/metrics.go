@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricsFile is the name of the daily metrics file in the data directory
+const MetricsFile = "metrics.json"
+
+// maxMetricSamples is how many days of samples the metrics file keeps
+const maxMetricSamples = 366
+
+// MetricSample is one day's snapshot of the reminder backlog
+type MetricSample struct {
+	Date    string `json:"date"` // YYYY-MM-DD
+	Overdue int    `json:"overdue"`
+	Active  int    `json:"active"`
+}
+
+// LoadMetrics reads the samples from path, oldest first. A missing file has
+// no samples.
+func LoadMetrics(path string) ([]MetricSample, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+
+	var samples []MetricSample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	return samples, nil
+}
+
+// RecordMetric adds a sample to the metrics file at path, replacing any
+// sample from the same day and dropping the oldest beyond a year
+func RecordMetric(path string, sample MetricSample) error {
+	samples, err := LoadMetrics(path)
+	if err != nil {
+		return err
+	}
+
+	kept := samples[:0]
+	for _, existing := range samples {
+		if existing.Date != sample.Date {
+			kept = append(kept, existing)
+		}
+	}
+	samples = append(kept, sample)
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Date < samples[j].Date })
+	if len(samples) > maxMetricSamples {
+		samples = samples[len(samples)-maxMetricSamples:]
+	}
+
+	data, err := json.MarshalIndent(samples, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+	return nil
+}
+
+// RecentMetrics returns the samples from the last days days before now
+func RecentMetrics(samples []MetricSample, days int, now time.Time) []MetricSample {
+	first := now.AddDate(0, 0, -days+1).Format("2006-01-02")
+	for i, sample := range samples {
+		if sample.Date >= first {
+			return samples[i:]
+		}
+	}
+	return nil
+}
+
+// sparkBlocks are the bar heights of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a row of bars scaled to the largest one, or as
+// the plain numbers in accessible mode
+func Sparkline(values []int) string {
+	if accessibleMode {
+		numbers := make([]string, len(values))
+		for i, v := range values {
+			numbers[i] = strconv.Itoa(v)
+		}
+		return strings.Join(numbers, " ")
+	}
+
+	highest := 0
+	for _, v := range values {
+		highest = max(highest, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if highest > 0 {
+			level = v * (len(sparkBlocks) - 1) / highest
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
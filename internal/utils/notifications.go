@@ -1,10 +1,12 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
+	"time"
 
 	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 )
@@ -26,6 +28,32 @@ type Notifier struct {
 	method           NotificationMethod
 	fallbackMethods  []NotificationMethod
 	logFile          string
+
+	// Notification server IDs of the reminders shown over D-Bus, so the
+	// next nag replaces the previous one
+	shown map[string]uint32
+
+	// Why the main method failed the last time a fallback was used
+	lastErr error
+
+	// Deduplication and rate limits; see dispatch
+	dedupWindow time.Duration
+	rateLimits  map[NotificationMethod]int
+	sentKeys    map[string]time.Time
+	sentBy      map[NotificationMethod][]time.Time
+	lastChannel NotificationMethod
+	delivered   bool
+
+	// Takes every notification instead of the channels, when set
+	sink func(Notification) error
+}
+
+// Notification is a notification as the Notifier delivers it
+type Notification struct {
+	ReminderID string // Empty for ones not about a single reminder
+	Title      string
+	Message    string
+	Priority   models.Priority
 }
 
 // NewNotifier creates a new notifier instance with auto-detected best method
@@ -38,6 +66,16 @@ func NewNotifier() (*Notifier, error) {
 	return notifier, nil
 }
 
+// NewNotifierWithSink creates a notifier that hands every notification to
+// sink instead of showing it. Deduplication and rate limits still apply;
+// tests use it to see what would have been shown.
+func NewNotifierWithSink(sink func(Notification) error) *Notifier {
+	return &Notifier{
+		method: DesktopNotification,
+		sink:   sink,
+	}
+}
+
 // NewNotifierWithMethod creates a notifier with a specific method
 func NewNotifierWithMethod(method NotificationMethod) *Notifier {
 	return &Notifier{
@@ -50,6 +88,10 @@ func NewNotifierWithMethod(method NotificationMethod) *Notifier {
 func detectBestMethod() NotificationMethod {
 	switch runtime.GOOS {
 	case "linux":
+		// Check for a D-Bus session bus
+		if _, err := sessionBusAddress(); err == nil {
+			return DesktopNotification
+		}
 		// Check for notify-send (libnotify)
 		if _, err := exec.LookPath("notify-send"); err == nil {
 			return DesktopNotification
@@ -80,21 +122,112 @@ func detectBestMethod() NotificationMethod {
 
 // Send sends a notification with the given title, message, and priority
 func (n *Notifier) Send(title, message string, priority models.Priority) error {
-	err := n.sendWithMethod(n.method, title, message, priority)
-	if err != nil {
-		// Try fallback methods
-		for _, fallback := range n.fallbackMethods {
-			if fallbackErr := n.sendWithMethod(fallback, title, message, priority); fallbackErr == nil {
+	n.lastErr = nil
+	return n.dispatch(title+"\x00"+message, title, message, priority, nil)
+}
+
+// FallbackError returns why the main notification method failed, if the last
+// notification had to use a fallback
+func (n *Notifier) FallbackError() error {
+	return n.lastErr
+}
+
+// Dismiss closes the notification shown for a reminder, e.g. once it has been
+// completed. Only notifications shown over D-Bus can be closed.
+func (n *Notifier) Dismiss(reminderID string) error {
+	id, ok := n.shown[reminderID]
+	if !ok {
+		return nil
+	}
+	delete(n.shown, reminderID)
+	return closeDBusNotification(id)
+}
+
+// ShownReminder returns the reminder a D-Bus notification was shown for
+func (n *Notifier) ShownReminder(id uint32) (string, bool) {
+	for reminderID, shown := range n.shown {
+		if shown == id {
+			return reminderID, true
+		}
+	}
+	return "", false
+}
+
+// SendReminder notifies about a reminder. Where supported (Windows toasts and
+// the macOS helper) the notification has Done and Snooze buttons, and on Linux
+// it replaces the previous notification for the same reminder; elsewhere it
+// is the same as Send. Critical reminders use the platform's most urgent level,
+// and sticky ones a level that stays on screen until acted on.
+func (n *Notifier) SendReminder(reminder *models.Reminder, title, message string) error {
+	n.lastErr = nil
+	priority, desktop := n.reminderSender(reminder, title, message)
+	return n.dispatch(reminder.ID+"\x00"+title, title, message, priority, desktop)
+}
+
+// reminderSender returns the priority to notify about a reminder with and,
+// for the desktop method, the send that stands in for a plain notification
+func (n *Notifier) reminderSender(reminder *models.Reminder, title, message string) (models.Priority, func() error) {
+	critical := reminder.IsCritical()
+
+	// Where there is no separate critical level, the most urgent one will do;
+	// it is also the one that stays on screen
+	priority := reminder.Priority
+	if critical || reminder.Sticky {
+		priority = models.High
+	}
+
+	if n.sink != nil {
+		return priority, func() error {
+			return n.sink(Notification{ReminderID: reminder.ID, Title: title, Message: message, Priority: priority})
+		}
+	}
+
+	// The platform's richer notification stands in for the desktop method
+	if n.method != DesktopNotification {
+		return priority, nil
+	}
+	return priority, func() error {
+		return n.sendReminderDesktop(reminder, title, message, priority, critical)
+	}
+}
+
+// sendReminderDesktop shows a reminder with the platform's own notification
+// (toast with buttons, replaceable D-Bus notification, macOS helper), falling
+// back to a plain desktop notification
+func (n *Notifier) sendReminderDesktop(reminder *models.Reminder, title, message string, priority models.Priority, critical bool) error {
+	switch runtime.GOOS {
+	case "windows":
+		if err := sendWindowsToast(WindowsToastXML(title, message, priority, reminder.ID, critical), reminder.ID); err == nil {
+			return nil
+		}
+	case "linux":
+		id, err := notifyDBus(n.shown[reminder.ID], title, message, priority, reminder.Sticky)
+		if err == nil {
+			if n.shown == nil {
+				n.shown = make(map[string]uint32)
+			}
+			n.shown[reminder.ID] = id
+			return nil
+		}
+		if !errors.Is(err, ErrNoSessionBus) {
+			return err
+		}
+	case "darwin":
+		if path, ok := MacNotifierPath(); ok {
+			if err := sendMacNotifier(path, title, message, reminder.Priority, reminder.ID, critical); err == nil {
 				return nil
 			}
 		}
-		return fmt.Errorf("all notification methods failed, last error: %w", err)
 	}
-	return nil
+	return n.sendDesktopNotification(title, message, priority)
 }
 
 // sendWithMethod sends a notification using a specific method
 func (n *Notifier) sendWithMethod(method NotificationMethod, title, message string, priority models.Priority) error {
+	if n.sink != nil {
+		return n.sink(Notification{Title: title, Message: message, Priority: priority})
+	}
+
 	switch method {
 	case DesktopNotification:
 		return n.sendDesktopNotification(title, message, priority)
@@ -123,7 +256,13 @@ func (n *Notifier) sendDesktopNotification(title, message string, priority model
 
 // sendLinuxDesktopNotification sends a desktop notification on Linux
 func (n *Notifier) sendLinuxDesktopNotification(title, message string, priority models.Priority) error {
-	// Try notify-send first (most common)
+	// Talk to the notification server directly; the commands below are only
+	// for when there is no session bus to reach it through
+	if _, err := notifyDBus(0, title, message, priority, false); !errors.Is(err, ErrNoSessionBus) {
+		return err
+	}
+
+	// Try notify-send next (most common)
 	if _, err := exec.LookPath("notify-send"); err == nil {
 		urgency := "normal"
 		switch priority {
@@ -167,7 +306,15 @@ func (n *Notifier) sendLinuxDesktopNotification(title, message string, priority
 
 // sendMacOSDesktopNotification sends a desktop notification on macOS
 func (n *Notifier) sendMacOSDesktopNotification(title, message string, priority models.Priority) error {
-	// Try terminal-notifier first (if installed)
+	// Prefer Nancy's own helper app (if installed)
+	if path, ok := MacNotifierPath(); ok {
+		err := sendMacNotifier(path, title, message, priority, "", false)
+		if err == nil || err == ErrNotificationsDenied {
+			return err
+		}
+	}
+
+	// Try terminal-notifier next (if installed)
 	if _, err := exec.LookPath("terminal-notifier"); err == nil {
 		args := []string{
 			"-title", title,
@@ -186,9 +333,9 @@ func (n *Notifier) sendMacOSDesktopNotification(title, message string, priority
 
 	// Use built-in osascript as fallback
 	if _, err := exec.LookPath("osascript"); err == nil {
-		script := fmt.Sprintf(`display notification "%s" with title "%s"`, message, title)
+		script := fmt.Sprintf(`display notification %s with title %s`, appleScriptString(message), appleScriptString(title))
 		if priority == models.High {
-			script = fmt.Sprintf(`display notification "%s" with title "%s" sound name "default"`, message, title)
+			script += ` sound name "default"`
 		}
 
 		cmd := exec.Command("osascript", "-e", script)
@@ -200,34 +347,13 @@ func (n *Notifier) sendMacOSDesktopNotification(title, message string, priority
 
 // sendWindowsDesktopNotification sends a desktop notification on Windows
 func (n *Notifier) sendWindowsDesktopNotification(title, message string, priority models.Priority) error {
-	// Use PowerShell to show Windows Toast notification
-	script := fmt.Sprintf(`
-		[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null;
-		[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null;
-		$template = @"
-<toast>
-	<visual>
-		<binding template="ToastGeneric">
-			<text>%s</text>
-			<text>%s</text>
-		</binding>
-	</visual>
-</toast>
-"@;
-		$xml = New-Object Windows.Data.Xml.Dom.XmlDocument;
-		$xml.LoadXml($template);
-		$toast = New-Object Windows.UI.Notifications.ToastNotification $xml;
-		[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier("Nancy").Show($toast);
-	`, title, message)
-
-	cmd := exec.Command("powershell", "-Command", script)
-	return cmd.Run()
+	return sendWindowsToast(WindowsToastXML(title, message, priority, "", false), "")
 }
 
 // sendTerminalBell sends a terminal bell notification
 func (n *Notifier) sendTerminalBell(title, message string) error {
 	// Print notification to stderr with bell character
-	fmt.Fprintf(os.Stderr, "\a🔔 %s: %s\n", title, message)
+	fmt.Fprintf(os.Stderr, "\a%s%s: %s\n", Symbol("🔔 ", ""), title, message)
 	return nil
 }
 
@@ -252,11 +378,11 @@ func (n *Notifier) SetLogFile(path string) {
 }
 
 // TestNotification sends a test notification to verify the system works
-func (n *Notifier) TestNotification() error {
+func (n *Notifier) TestNotification(priority models.Priority) error {
 	return n.Send(
 		"Nancy Test Notification",
-		"If you see this, notifications are working correctly! 🎉",
-		models.Medium,
+		"If you see this, notifications are working correctly!"+Symbol(" 🎉", ""),
+		priority,
 	)
 }
 
@@ -291,7 +417,9 @@ func GetAvailableMethods() []NotificationMethod {
 	// Check if desktop notifications are available
 	switch runtime.GOOS {
 	case "linux":
-		if _, err := exec.LookPath("notify-send"); err == nil {
+		if _, err := sessionBusAddress(); err == nil {
+			methods = append(methods, DesktopNotification)
+		} else if _, err := exec.LookPath("notify-send"); err == nil {
 			methods = append(methods, DesktopNotification)
 		} else if _, err := exec.LookPath("dunstify"); err == nil {
 			methods = append(methods, DesktopNotification)
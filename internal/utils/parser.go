@@ -3,20 +3,25 @@ package utils
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
 	"github.com/ivyascorp-net/nagging-nancy/internal/models"
 )
 
 // ParsedReminder represents the result of parsing reminder text
 type ParsedReminder struct {
-	Title    string
-	DueTime  time.Time
-	Priority models.Priority
-	Tags     []string
-	HasTime  bool
+	Title       string
+	DueTime     time.Time
+	Priority    models.Priority
+	Tags        []string
+	HasTime     bool
+	HasPriority bool   // The text named a priority, e.g. "urgent"
+	SunEvent    string // Sunrise or Sunset when the time is anchored to the sun
 }
 
 // TimePattern represents a regex pattern for parsing time expressions
@@ -57,8 +62,20 @@ var timePatterns = []TimePattern{
 		regexp.MustCompile(`(?i)(monday|tuesday|wednesday|thursday|friday|saturday|sunday)\s+(?:at\s+)?(\d{1,2}):?(\d{0,2})\s*(am|pm)?`),
 		parseTimeWeekday,
 	},
+	// "at sunset", "tomorrow at sunrise"
+	{
+		sunPattern,
+		parseSunEvent,
+	},
 }
 
+// timeOfDayPattern matches named times of day such as "tomorrow morning",
+// "friday evening", "tonight" or "at noon". It is tried after timePatterns.
+var timeOfDayPattern = regexp.MustCompile(`(?i)\b(?:(today|tomorrow|monday|tuesday|wednesday|thursday|friday|saturday|sunday)\s+)?(?:at\s+|this\s+|in\s+the\s+)?(morning|noon|afternoon|evening|tonight|midnight)\b`)
+
+// sunPattern matches sunrise and sunset, optionally prefixed with a day
+var sunPattern = regexp.MustCompile(`(?i)\b(?:(today|tomorrow|monday|tuesday|wednesday|thursday|friday|saturday|sunday)\s+)?(?:at\s+)?(sunrise|sunset)\b`)
+
 // Priority patterns for detecting priority in text
 var priorityPatterns = []struct {
 	pattern  *regexp.Regexp
@@ -70,29 +87,51 @@ var priorityPatterns = []struct {
 
 // ParseReminder parses a reminder string and extracts structured information
 func ParseReminder(text string, defaultPriority models.Priority) (*ParsedReminder, error) {
+	return ParseReminderAt(text, defaultPriority, time.Now())
+}
+
+// ParseReminderAt parses a reminder string as if it was typed at now, so
+// "in 2 hours" counts from then. Named times of day have their default
+// clock times; TextParser takes configured ones.
+func ParseReminderAt(text string, defaultPriority models.Priority, now time.Time) (*ParsedReminder, error) {
+	return parseReminder(text, defaultPriority, now, nil)
+}
+
+// parseReminder is ParseReminderAt with the clock times of named times of day
+func parseReminder(text string, defaultPriority models.Priority, now time.Time, times models.TimesOfDay) (*ParsedReminder, error) {
 	if strings.TrimSpace(text) == "" {
 		return nil, fmt.Errorf("reminder text cannot be empty")
 	}
+	if matches := sunPattern.FindStringSubmatch(text); matches != nil && !locationSet &&
+		strings.Contains(strings.ToLower(matches[0]), "at ") {
+		return nil, fmt.Errorf("'%s' needs a location (set location.latitude and location.longitude)", matches[0])
+	}
 
 	result := &ParsedReminder{
 		Title:    text,
-		DueTime:  time.Now().Add(time.Hour), // Default to 1 hour from now
+		DueTime:  now.Add(time.Hour), // Default to 1 hour from now
 		Priority: defaultPriority,
 		Tags:     make([]string, 0),
 		HasTime:  false,
 	}
 
 	// Extract time information
-	if dueTime, cleanText, hasTime := extractTime(text); hasTime {
+	if dueTime, cleanText, hasTime := extractTime(text, now, times); hasTime {
 		result.DueTime = dueTime
 		result.Title = strings.TrimSpace(cleanText)
 		result.HasTime = true
+
+		// Remember sun-anchored times so they can follow the seasons
+		if matches := sunPattern.FindStringSubmatch(text); matches != nil && !sunPattern.MatchString(cleanText) {
+			result.SunEvent = strings.ToLower(matches[2])
+		}
 	}
 
 	// Extract priority information
-	if priority, cleanText := extractPriority(result.Title); priority != defaultPriority {
+	if priority, cleanText, found := extractPriority(result.Title); found {
 		result.Priority = priority
 		result.Title = strings.TrimSpace(cleanText)
+		result.HasPriority = true
 	}
 
 	// Extract tags (#hashtag format)
@@ -110,10 +149,9 @@ func ParseReminder(text string, defaultPriority models.Priority) (*ParsedReminde
 	return result, nil
 }
 
-// extractTime tries to extract time information from text
-func extractTime(text string) (time.Time, string, bool) {
-	baseTime := time.Now()
-
+// extractTime tries to extract time information from text, taking named
+// times of day to be at the clock times in times
+func extractTime(text string, baseTime time.Time, times models.TimesOfDay) (time.Time, string, bool) {
 	for _, pattern := range timePatterns {
 		if matches := pattern.Pattern.FindStringSubmatch(text); matches != nil {
 			if parsedTime, err := pattern.Handler(matches, baseTime); err == nil {
@@ -125,6 +163,13 @@ func extractTime(text string) (time.Time, string, bool) {
 		}
 	}
 
+	if matches := timeOfDayPattern.FindStringSubmatch(text); matches != nil {
+		if parsedTime, err := parseTimeOfDay(matches, baseTime, times); err == nil {
+			cleanText := timeOfDayPattern.ReplaceAllString(text, "")
+			return parsedTime, strings.TrimSpace(cleanText), true
+		}
+	}
+
 	return baseTime.Add(time.Hour), text, false
 }
 
@@ -264,39 +309,261 @@ func parseTimeWeekday(matches []string, baseTime time.Time) (time.Time, error) {
 	return targetTime, nil
 }
 
+// parseTimeOfDay parses named times of day, optionally prefixed with a day,
+// at their clock times in times
+func parseTimeOfDay(matches []string, baseTime time.Time, times models.TimesOfDay) (time.Time, error) {
+	day := strings.ToLower(matches[1])
+	name := strings.ToLower(matches[2])
+
+	hour, minute, ok := times.Clock(name)
+	if !ok {
+		return baseTime, fmt.Errorf("no time configured for %s", name)
+	}
+
+	now := baseTime
+	targetTime := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+
+	switch day {
+	case "":
+		// "midnight" means the coming one, otherwise roll over once passed
+		if !targetTime.After(now) {
+			targetTime = targetTime.AddDate(0, 0, 1)
+		}
+	case "today":
+		if name == "midnight" {
+			targetTime = targetTime.AddDate(0, 0, 1)
+		}
+	case "tomorrow":
+		targetTime = targetTime.AddDate(0, 0, 1)
+	default:
+		weekday, err := parseTimeWeekday([]string{"", day, strconv.Itoa(hour), strconv.Itoa(minute)}, baseTime)
+		if err != nil {
+			return baseTime, err
+		}
+		targetTime = weekday
+	}
+
+	return targetTime, nil
+}
+
+// parseSunEvent parses sunrise and sunset at the configured location
+func parseSunEvent(matches []string, baseTime time.Time) (time.Time, error) {
+	day := strings.ToLower(matches[1])
+	event := strings.ToLower(matches[2])
+
+	date := baseTime
+	switch day {
+	case "", "today":
+	case "tomorrow":
+		date = baseTime.AddDate(0, 0, 1)
+	default:
+		weekday, err := parseTimeWeekday([]string{"", day, "12", "00"}, baseTime)
+		if err != nil {
+			return baseTime, err
+		}
+		date = weekday
+	}
+
+	targetTime, err := SunEventTime(event, date)
+	if err != nil {
+		return baseTime, err
+	}
+
+	// Without a day, roll over to tomorrow once today's has passed
+	if day == "" && !targetTime.After(baseTime) {
+		return SunEventTime(event, date.AddDate(0, 0, 1))
+	}
+
+	return targetTime, nil
+}
+
 // extractPriority extracts priority keywords from text
-func extractPriority(text string) (models.Priority, string) {
+func extractPriority(text string) (models.Priority, string, bool) {
 	for _, pattern := range priorityPatterns {
 		if pattern.pattern.MatchString(text) {
 			cleanText := pattern.pattern.ReplaceAllString(text, "")
 			cleanText = strings.TrimSpace(cleanText)
-			return pattern.priority, cleanText
+			return pattern.priority, cleanText, true
 		}
 	}
-	return models.Medium, text
+	return models.Medium, text, false
+}
+
+// Deadlines closer than inferHighWithin are high priority, and further away
+// than inferLowBeyond low priority, when inferring priority
+const (
+	inferHighWithin = 2 * time.Hour
+	inferLowBeyond  = 14 * 24 * time.Hour
+)
+
+// InferPriority guesses a priority from how close the due time is: high
+// within 2 hours, low beyond 2 weeks. In between it returns false, leaving
+// the default priority.
+func InferPriority(due, now time.Time) (models.Priority, bool) {
+	switch until := due.Sub(now); {
+	case until <= inferHighWithin:
+		return models.High, true
+	case until > inferLowBeyond:
+		return models.Low, true
+	}
+	return models.Medium, false
 }
 
-// extractTags extracts hashtags from text
+// contextPattern matches words like @home that may be place contexts. Only
+// at the start of a word and starting with a letter, so email addresses and
+// times like @19:00 aren't.
+var contextPattern = regexp.MustCompile(`(^|\s)@(\pL\w*(?:/\w+)*)`)
+
+// KnownPlaces are the places an @word in a reminder is taken as, e.g. "Buy
+// milk @store". Others, like "email @bob", stay part of the title; a
+// reminder gets any other place with an explicit --tags @cabin.
+var KnownPlaces = []string{
+	"home", "work", "office", "school", "store", "shop", "supermarket",
+	"market", "mall", "pharmacy", "bank", "post", "library", "gym", "doctor",
+	"car", "garage", "garden", "downtown", "errands", "station", "airport",
+}
+
+// knownPlace reports whether a context like "home/garden" is at a known place
+func knownPlace(context string) bool {
+	place, _, _ := strings.Cut(strings.ToLower(context), "/")
+	return slices.Contains(KnownPlaces, place)
+}
+
+// extractTags extracts hashtags and @contexts at known places from text
 func extractTags(text string) ([]string, string) {
-	tagPattern := regexp.MustCompile(`#(\w+)`)
+	// Tags may be nested with slashes, e.g. #work/clientA/billing
+	tagPattern := regexp.MustCompile(`#(\w+(?:/\w+)*)`)
 	matches := tagPattern.FindAllStringSubmatch(text, -1)
+	var contexts []string
+	for _, match := range contextPattern.FindAllStringSubmatch(text, -1) {
+		if knownPlace(match[2]) {
+			contexts = append(contexts, "@"+match[2])
+		}
+	}
 
-	if len(matches) == 0 {
+	if len(matches) == 0 && len(contexts) == 0 {
 		return nil, text
 	}
 
-	tags := make([]string, 0, len(matches))
+	tags := make([]string, 0, len(matches)+len(contexts))
 	for _, match := range matches {
 		tags = append(tags, match[1])
 	}
+	tags = append(tags, contexts...)
 
-	// Remove hashtags from text
+	// Remove hashtags and contexts from text
 	cleanText := tagPattern.ReplaceAllString(text, "")
-	cleanText = strings.TrimSpace(cleanText)
+	cleanText = contextPattern.ReplaceAllStringFunc(cleanText, func(match string) string {
+		if context := strings.TrimLeftFunc(match, unicode.IsSpace); knownPlace(strings.TrimPrefix(context, "@")) {
+			return strings.TrimSuffix(match, context)
+		}
+		return match
+	})
+	cleanText = strings.Join(strings.Fields(cleanText), " ")
 
 	return tags, cleanText
 }
 
+// ParseDueTime parses a natural language due time such as "tomorrow at 3pm",
+// "in 2 hours" or "friday evening", falling back to the formats accepted by
+// ParseTimeString. Named times of day are at the clock times in times.
+func ParseDueTime(text string, times models.TimesOfDay) (time.Time, error) {
+	if dueTime, _, hasTime := extractTime(strings.TrimSpace(text), time.Now(), times); hasTime {
+		return dueTime, nil
+	}
+	return ParseTimeString(text)
+}
+
+// ParseDateString parses a calendar date such as "today", "tomorrow",
+// "2024-03-20" or "Mar 20, 2024", returning midnight local time
+func ParseDateString(dateStr string) (time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch strings.ToLower(strings.TrimSpace(dateStr)) {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	// Numeric dates follow the configured order: 03/20/2024 or 20/03/2024
+	numeric := i18n.NumericDateLayout()
+	for _, format := range []string{
+		"2006-01-02",                          // 2024-03-20
+		numeric,                               // 03/20/2024
+		strings.ReplaceAll(numeric, "/", "-"), // 03-20-2024
+		strings.ReplaceAll(numeric, "/", "."), // 20.03.2024
+		"Jan 2, 2006",                         // Mar 20, 2024
+		"Jan 2 2006",                          // Mar 20 2024
+		"2 Jan 2006",                          // 20 Mar 2024
+	} {
+		if date, err := time.ParseInLocation(format, strings.TrimSpace(dateStr), time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date format '%s'", dateStr)
+}
+
+// ParseDueRange reads a range of due dates in words, like "due before
+// today", "yesterday", "after 2024-03-20", "since 2024-03-01" or "between
+// 2024-03-01 and 2024-03-10". Whole days are included. It returns the start
+// of the range and the end, which isn't included; either is zero when the
+// range is open on that side.
+func ParseDueRange(text string) (from, before time.Time, err error) {
+	words := strings.Fields(strings.ToLower(text))
+	// Filler like "everything due" doesn't change the range
+	for len(words) > 0 && (words[0] == "everything" || words[0] == "all" || words[0] == "due") {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return from, before, fmt.Errorf("no dates in '%s'", text)
+	}
+
+	day := func(words []string) (time.Time, error) {
+		date, err := ParseDateString(strings.Join(words, " "))
+		if err != nil {
+			return date, fmt.Errorf("invalid range '%s': %w", text, err)
+		}
+		return date, nil
+	}
+
+	rest := words[1:]
+	switch words[0] {
+	case "before":
+		before, err = day(rest)
+	case "until":
+		before, err = day(rest)
+		before = before.AddDate(0, 0, 1)
+	case "after":
+		from, err = day(rest)
+		from = from.AddDate(0, 0, 1)
+	case "since":
+		from, err = day(rest)
+	case "between":
+		i := slices.Index(rest, "and")
+		if i < 0 {
+			return from, before, fmt.Errorf("invalid range '%s': use 'between <date> and <date>'", text)
+		}
+		if from, err = day(rest[:i]); err == nil {
+			before, err = day(rest[i+1:])
+			before = before.AddDate(0, 0, 1)
+		}
+	case "on", "from":
+		from, err = day(rest)
+		before = from.AddDate(0, 0, 1)
+	default:
+		from, err = day(words)
+		before = from.AddDate(0, 0, 1)
+	}
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, before, nil
+}
+
 // ParseTimeString parses various time string formats
 func ParseTimeString(timeStr string) (time.Time, error) {
 	timeStr = strings.TrimSpace(timeStr)
@@ -331,38 +598,38 @@ func ParseTimeString(timeStr string) (time.Time, error) {
 // FormatDuration returns a human-readable duration string
 func FormatDuration(d time.Duration) string {
 	if d < 0 {
-		return "overdue"
+		return i18n.T("overdue")
 	}
 
 	if d < time.Minute {
-		return "now"
+		return i18n.T("now")
 	}
 
 	if d < time.Hour {
 		minutes := int(d.Minutes())
 		if minutes == 1 {
-			return "1 minute"
+			return i18n.T("1 minute")
 		}
-		return fmt.Sprintf("%d minutes", minutes)
+		return i18n.T("%d minutes", minutes)
 	}
 
 	if d < 24*time.Hour {
 		hours := int(d.Hours())
 		minutes := int(d.Minutes()) % 60
 		if hours == 1 && minutes == 0 {
-			return "1 hour"
+			return i18n.T("1 hour")
 		}
 		if minutes == 0 {
-			return fmt.Sprintf("%d hours", hours)
+			return i18n.T("%d hours", hours)
 		}
 		return fmt.Sprintf("%dh %dm", hours, minutes)
 	}
 
 	days := int(d.Hours() / 24)
 	if days == 1 {
-		return "1 day"
+		return i18n.T("1 day")
 	}
-	return fmt.Sprintf("%d days", days)
+	return i18n.T("%d days", days)
 }
 
 // ParsePriorityString converts a string to Priority
@@ -370,21 +637,260 @@ func ParsePriorityString(priorityStr string) models.Priority {
 	return models.ParsePriority(strings.ToLower(strings.TrimSpace(priorityStr)))
 }
 
-// ValidateReminderInput validates reminder input
-func ValidateReminderInput(title string, dueTime time.Time) error {
+// Limits on due times accepted for new and edited reminders
+var (
+	pastGrace     = time.Hour
+	maxYearsAhead = 10
+)
+
+// SetDueLimits sets how far in the past (grace) and how many years ahead due
+// times may be. Negative values are ignored; zero years means no limit.
+func SetDueLimits(grace time.Duration, years int) {
+	if grace >= 0 {
+		pastGrace = grace
+	}
+	if years >= 0 {
+		maxYearsAhead = years
+	}
+}
+
+// ValidateReminderInput validates reminder input. pastOK allows due times
+// further in the past than the grace period, for logging overdue items.
+func ValidateReminderInput(title string, dueTime time.Time, pastOK bool) error {
 	if strings.TrimSpace(title) == "" {
 		return fmt.Errorf("reminder title cannot be empty")
 	}
 
-	// Don't allow reminders too far in the past (more than 1 hour)
-	if time.Since(dueTime) > time.Hour {
-		return fmt.Errorf("due time cannot be more than 1 hour in the past")
+	if !pastOK && time.Since(dueTime) > pastGrace {
+		return fmt.Errorf("due time cannot be more than %s in the past (use --past-ok to allow it)", describeLimit(pastGrace))
 	}
 
-	// Don't allow reminders too far in the future (more than 10 years)
-	if time.Until(dueTime) > 10*365*24*time.Hour {
-		return fmt.Errorf("due time cannot be more than 10 years in the future")
+	if maxYearsAhead > 0 && dueTime.After(time.Now().AddDate(maxYearsAhead, 0, 0)) {
+		return fmt.Errorf("due time cannot be more than %d years in the future", maxYearsAhead)
 	}
 
 	return nil
 }
+
+// describeLimit spells out a grace period for error messages
+func describeLimit(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	switch {
+	case minutes == 60:
+		return "1 hour"
+	case minutes > 0 && minutes%60 == 0:
+		return fmt.Sprintf("%d hours", minutes/60)
+	case minutes == 1:
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+// ParsedEdit holds the changes described by a natural language edit such as
+// "push to friday 3pm and make it high priority". Nil and empty fields are
+// left unchanged.
+type ParsedEdit struct {
+	Title      string
+	DueTime    *time.Time
+	Priority   *models.Priority
+	AddTags    []string
+	RemoveTags []string
+}
+
+// Patterns for natural language edits
+var (
+	// "rename to Call mom", "call it 'Call mom'" (the rest of the text)
+	editTitlePattern = regexp.MustCompile(`(?i)\b(?:rename(?:\s+it)?|retitle(?:\s+it)?|call\s+it)\s+(?:to\s+)?["']?(.+?)["']?\s*$`)
+	// "untag #home", "remove #home and #errands"
+	editRemoveTagPattern = regexp.MustCompile(`(?i)\b(?:untag|remove|drop|without)\s+((?:#[\w/]+[\s,]*(?:and\s+)?)+)`)
+	// "high priority", "make it low", "urgent"
+	editPriorityPattern = regexp.MustCompile(`(?i)\b(high|medium|normal|low)\b(?:\s+priority)?|\b(urgent|important)\b`)
+	// "push back 2 days", "postpone by an hour", "move it 30 minutes earlier"
+	editShiftPattern = regexp.MustCompile(`(?i)\b(?:push|postpone|delay|move|shift|bump|snooze)(?:\s+it)?(?:\s+back)?(?:\s+by)?\s+(\d+|an?|one)\s+(minute|min|hour|hr|day|week)s?(?:\s+(earlier|later))?\b`)
+	// "in 2 hours", counted from now
+	editInPattern = regexp.MustCompile(`(?i)\bin\s+(\d+|an?|one)\s+(minute|min|hour|hr|day|week)s?\b`)
+	// "to friday", "tomorrow", "next week"
+	editDayPattern = regexp.MustCompile(`(?i)\b(today|tomorrow|next week|monday|tuesday|wednesday|thursday|friday|saturday|sunday)\b`)
+	// "3pm", "3:30 pm", "15:30"
+	editClockPattern = regexp.MustCompile(`(?i)\b(\d{1,2})(?::(\d{2}))?\s*(am|pm)\b|\b(\d{1,2}):(\d{2})\b`)
+	// "morning", "tonight"
+	editTimeOfDayPattern = regexp.MustCompile(`(?i)\b(morning|noon|afternoon|evening|tonight|midnight)\b`)
+)
+
+// ParseEdit parses a natural language edit of a reminder due at due. A day
+// keeps the clock time and a clock time keeps the day, so "to friday" and
+// "at 3pm" each change only what they name. Named times of day are at the
+// clock times in times.
+func ParseEdit(text string, due time.Time, times models.TimesOfDay) (*ParsedEdit, error) {
+	edit := &ParsedEdit{}
+	rest := text
+
+	// The new title runs to the end, so take it before anything else
+	if m := editTitlePattern.FindStringSubmatchIndex(rest); m != nil {
+		edit.Title = strings.TrimSpace(rest[m[2]:m[3]])
+		rest = rest[:m[0]]
+	}
+
+	// Tags to remove first, so they aren't taken as tags to add
+	for _, m := range editRemoveTagPattern.FindAllStringSubmatch(rest, -1) {
+		tags, _ := extractTags(m[1])
+		edit.RemoveTags = append(edit.RemoveTags, tags...)
+	}
+	rest = editRemoveTagPattern.ReplaceAllString(rest, "")
+	if tags, clean := extractTags(rest); len(tags) > 0 {
+		edit.AddTags = tags
+		rest = clean
+	}
+
+	if m := editPriorityPattern.FindStringSubmatch(rest); m != nil {
+		level := strings.ToLower(m[1])
+		switch level {
+		case "":
+			level = "high"
+		case "normal":
+			level = "medium"
+		}
+		priority := models.ParsePriority(level)
+		edit.Priority = &priority
+		rest = strings.Replace(rest, m[0], "", 1)
+	}
+
+	newDue, changed := due, false
+	now := time.Now()
+
+	if m := editShiftPattern.FindStringSubmatch(rest); m != nil {
+		shift := 1
+		if strings.ToLower(m[3]) == "earlier" {
+			shift = -1
+		}
+		newDue = shiftTime(newDue, shift*editAmount(m[1]), m[2])
+		changed = true
+		rest = strings.Replace(rest, m[0], "", 1)
+	} else if m := editInPattern.FindStringSubmatch(rest); m != nil {
+		newDue = shiftTime(now, editAmount(m[1]), m[2]).Truncate(time.Minute)
+		changed = true
+		rest = strings.Replace(rest, m[0], "", 1)
+	}
+
+	if m := editDayPattern.FindStringSubmatch(rest); m != nil {
+		var date time.Time
+		switch day := strings.ToLower(m[1]); day {
+		case "today":
+			date = now
+		case "tomorrow":
+			date = now.AddDate(0, 0, 1)
+		case "next week":
+			date = newDue.AddDate(0, 0, 7)
+		default:
+			next, err := parseTimeWeekday([]string{"", day, "0", "0"}, now)
+			if err != nil {
+				return nil, err
+			}
+			date = next
+		}
+		newDue = time.Date(date.Year(), date.Month(), date.Day(),
+			newDue.Hour(), newDue.Minute(), 0, 0, newDue.Location())
+		changed = true
+	}
+
+	hour, minute, hasClock := 0, 0, false
+	if m := editClockPattern.FindStringSubmatch(rest); m != nil {
+		matches := []string{m[0], m[1], m[2], m[3]}
+		if m[4] != "" {
+			matches = []string{m[0], m[4], m[5], ""}
+		}
+		t, err := parseTimeToday(matches, now)
+		if err != nil {
+			return nil, err
+		}
+		hour, minute, hasClock = t.Hour(), t.Minute(), true
+	} else if m := editTimeOfDayPattern.FindStringSubmatch(rest); m != nil {
+		hour, minute, hasClock = times.Clock(m[1])
+	}
+	if hasClock {
+		newDue = time.Date(newDue.Year(), newDue.Month(), newDue.Day(),
+			hour, minute, 0, 0, newDue.Location())
+		changed = true
+	}
+
+	if changed {
+		edit.DueTime = &newDue
+	}
+	if !changed && edit.Title == "" && edit.Priority == nil && len(edit.AddTags) == 0 && len(edit.RemoveTags) == 0 {
+		return nil, fmt.Errorf("could not find any changes in '%s'", text)
+	}
+	return edit, nil
+}
+
+// editAmount reads the amount of a time shift: a number, "a", "an" or "one"
+func editAmount(s string) int {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return 1
+}
+
+// shiftTime moves t by amount minutes, hours, days or weeks
+func shiftTime(t time.Time, amount int, unit string) time.Time {
+	switch strings.ToLower(unit) {
+	case "minute", "min":
+		return t.Add(time.Duration(amount) * time.Minute)
+	case "hour", "hr":
+		return t.Add(time.Duration(amount) * time.Hour)
+	case "day":
+		return t.AddDate(0, 0, amount)
+	default:
+		return t.AddDate(0, 0, 7*amount)
+	}
+}
+
+// Shift moves a due time relative to its current value. Whole days keep the
+// clock time across daylight saving changes.
+type Shift struct {
+	Days     int
+	Duration time.Duration
+}
+
+// Apply returns t moved by the shift
+func (s Shift) Apply(t time.Time) time.Time {
+	return t.AddDate(0, 0, s.Days).Add(s.Duration)
+}
+
+var (
+	shiftPattern     = regexp.MustCompile(`^([+-])?((?:\d+[mhdw])+)$`)
+	shiftPartPattern = regexp.MustCompile(`(\d+)([mhdw])`)
+)
+
+// ParseShift parses a relative offset such as "+2d", "-3h", "+1w" or
+// "+1d12h" (m, h, d and w units)
+func ParseShift(text string) (Shift, error) {
+	m := shiftPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(text)))
+	if m == nil {
+		return Shift{}, fmt.Errorf("invalid shift '%s' (use e.g. +2d, -3h, +1w or +1d12h)", text)
+	}
+
+	sign := 1
+	if m[1] == "-" {
+		sign = -1
+	}
+
+	var shift Shift
+	for _, part := range shiftPartPattern.FindAllStringSubmatch(m[2], -1) {
+		n, err := strconv.Atoi(part[1])
+		if err != nil {
+			return Shift{}, fmt.Errorf("invalid shift '%s': %w", text, err)
+		}
+		n *= sign
+		switch part[2] {
+		case "m":
+			shift.Duration += time.Duration(n) * time.Minute
+		case "h":
+			shift.Duration += time.Duration(n) * time.Hour
+		case "d":
+			shift.Days += n
+		case "w":
+			shift.Days += 7 * n
+		}
+	}
+	return shift, nil
+}
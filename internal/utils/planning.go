@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// wrapUpTitles is how many rolled over reminders the wrap-up names
+const wrapUpTitles = 3
+
+// PlanDay picks the active reminders that need attention today: the ones
+// already overdue and the rest due before midnight, each by due time
+func PlanDay(reminders []*models.Reminder, now time.Time) (overdue, today []*models.Reminder) {
+	endOfDay := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	for _, reminder := range reminders {
+		if reminder.Completed || reminder.Archived || (reminder.Recurring != nil && reminder.Recurring.Paused) {
+			continue
+		}
+		switch {
+		case reminder.DueTime.Before(now):
+			overdue = append(overdue, reminder)
+		case reminder.DueTime.Before(endOfDay):
+			today = append(today, reminder)
+		}
+	}
+
+	byDue := func(list []*models.Reminder) {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].DueTime.Before(list[j].DueTime) })
+	}
+	byDue(overdue)
+	byDue(today)
+	return overdue, today
+}
+
+// DoneToday counts what was completed today, including occurrences of
+// recurring reminders, which move on rather than stay completed
+func DoneToday(reminders []*models.Reminder, now time.Time) int {
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	done := 0
+	for _, reminder := range reminders {
+		if reminder.Completed && reminder.CompletedAt != nil && !reminder.CompletedAt.Before(startOfDay) {
+			done++
+			continue
+		}
+		for _, occurrence := range reminder.History {
+			if occurrence.Done() && !occurrence.At.Before(startOfDay) {
+				done++
+			}
+		}
+	}
+	return done
+}
+
+// PlanMessage is the morning "plan your day" notification text. loads
+// counts the reminders due each day from today on; days with more than
+// maxPerDay are flagged so they can be spread out in time.
+func PlanMessage(reminders []*models.Reminder, now time.Time, loads []int, maxPerDay int) string {
+	overdue, today := PlanDay(reminders, now)
+	message := i18n.T("%s%d due today | %s%d overdue", Symbol("📆 ", ""), len(today), Symbol("⚠️ ", ""), len(overdue))
+	if len(today) > 0 {
+		message += "\n" + i18n.T("First up: %s at %s", today[0].Title, i18n.FormatTime(today[0].DueTime, "3:04 PM"))
+	}
+	if overloaded := OverloadedDays(loads, now, maxPerDay); len(overloaded) > 0 {
+		message += "\n" + i18n.T("%sOver %d a day: %s", Symbol("⚖️ ", ""), maxPerDay, strings.Join(overloaded, ", "))
+	}
+	return message + "\n" + i18n.T("Run 'nancy review --today' to plan your day.")
+}
+
+// OverloadedDays labels the days with more than maxPerDay reminders, e.g.
+// "Thu Oct 22 (11)", given loads counted from from's day on. A maxPerDay of
+// 0 means no limit.
+func OverloadedDays(loads []int, from time.Time, maxPerDay int) []string {
+	if maxPerDay <= 0 {
+		return nil
+	}
+	var days []string
+	for i, load := range loads {
+		if load > maxPerDay {
+			day := time.Date(from.Year(), from.Month(), from.Day()+i, 0, 0, 0, 0, from.Location())
+			days = append(days, fmt.Sprintf("%s (%d)", i18n.FormatTime(day, "Mon Jan 2"), load))
+		}
+	}
+	return days
+}
+
+// WrapUpMessage is the evening notification text: what got done today and
+// what is rolling over to tomorrow. reminders includes completed ones.
+func WrapUpMessage(reminders []*models.Reminder, now time.Time) string {
+	// Everything due by midnight that is still open rolls over
+	overdue, today := PlanDay(reminders, now)
+	rolled := append(overdue, today...)
+
+	message := i18n.T("%s%d done today | %s%d rolling over", Symbol("✅ ", ""), DoneToday(reminders, now), Symbol("↪️ ", ""), len(rolled))
+	if len(rolled) == 0 {
+		return message + "\n" + i18n.T("Nothing left over. Enjoy your evening!")
+	}
+
+	titles := make([]string, 0, wrapUpTitles)
+	for _, reminder := range rolled {
+		if len(titles) == wrapUpTitles {
+			break
+		}
+		titles = append(titles, reminder.Title)
+	}
+	message += "\n" + strings.Join(titles, ", ")
+	if more := len(rolled) - len(titles); more > 0 {
+		message += fmt.Sprintf(" (+%d)", more)
+	}
+	return message + "\n" + i18n.T("Run 'nancy review --today' to reschedule them.")
+}
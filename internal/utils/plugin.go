@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// ParserPluginFile is the name of the parser extension in the config
+// directory
+const ParserPluginFile = "parser-plugin"
+
+// parserPluginTimeout is how long a parser plugin may take
+const parserPluginTimeout = 5 * time.Second
+
+// PluginResult is what a parser plugin prints as JSON. Every field is
+// optional: the title is parsed by the built-in parser in place of the
+// original text, and the others win over what it finds.
+type PluginResult struct {
+	Title    string     `json:"title"`
+	Due      *time.Time `json:"due"`      // RFC 3339, e.g. "2025-03-20T15:00:00+01:00"
+	Priority string     `json:"priority"` // low, medium or high
+	Tags     []string   `json:"tags"`
+}
+
+// HasParserPlugin reports whether path is an executable parser plugin
+func HasParserPlugin(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0
+}
+
+// RunParserPlugin runs the plugin at path with the reminder text on stdin
+// and decodes the JSON it prints. Printing nothing leaves the text alone.
+func RunParserPlugin(path, text string) (*PluginResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), parserPluginTimeout)
+	defer cancel()
+
+	var stderr strings.Builder
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("parser plugin %s failed: %w: %s", path, err, msg)
+		}
+		return nil, fmt.Errorf("parser plugin %s failed: %w", path, err)
+	}
+
+	result := &PluginResult{}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(out, result); err != nil {
+		return nil, fmt.Errorf("parser plugin %s printed invalid JSON: %w", path, err)
+	}
+	if p := strings.ToLower(result.Priority); p != "" && p != "low" && p != "medium" && p != "high" {
+		return nil, fmt.Errorf("parser plugin %s returned an invalid priority '%s'", path, result.Priority)
+	}
+	return result, nil
+}
+
+// Apply merges the plugin's due time, priority and tags into what the
+// built-in parser found
+func (r *PluginResult) Apply(parsed *ParsedReminder) {
+	if r.Due != nil {
+		parsed.DueTime = r.Due.Local()
+		parsed.HasTime = true
+		parsed.SunEvent = ""
+	}
+	if r.Priority != "" {
+		parsed.Priority = models.ParsePriority(strings.ToLower(r.Priority))
+		parsed.HasPriority = true
+	}
+	for _, tag := range r.Tags {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag == "" || containsString(parsed.Tags, tag) {
+			continue
+		}
+		parsed.Tags = append(parsed.Tags, tag)
+	}
+}
+
+// TextParser parses reminder text the way 'nancy add' does: through the
+// parser plugin, if one is installed, then the built-in parser
+type TextParser struct {
+	Plugin          string            // Path of the parser plugin, used if it is executable
+	DefaultPriority models.Priority   // For text that names no priority
+	TimesOfDay      models.TimesOfDay // Clock times of "morning", "tonight", ...; defaults if unset
+	Warn            func(error)       // Told why a failing plugin was ignored, if set
+}
+
+// Parse parses text as if it was typed at now, so "in 2 hours" counts from
+// then. A plugin that fails is ignored.
+func (p TextParser) Parse(text string, now time.Time) (*ParsedReminder, error) {
+	var plugin *PluginResult
+	if p.Plugin != "" && HasParserPlugin(p.Plugin) {
+		result, err := RunParserPlugin(p.Plugin, text)
+		if err != nil && p.Warn != nil {
+			p.Warn(err)
+		}
+		plugin = result
+	}
+
+	if plugin != nil && strings.TrimSpace(plugin.Title) != "" {
+		text = plugin.Title
+	}
+	parsed, err := parseReminder(text, p.DefaultPriority, now, p.TimesOfDay)
+	if err != nil {
+		return nil, err
+	}
+	if plugin != nil {
+		plugin.Apply(parsed)
+	}
+	return parsed, nil
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
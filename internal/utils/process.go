@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DaemonLogFile is the daemon's log in the state directory
+const DaemonLogFile = "daemon.log"
+
+// maxDaemonLog is how large the daemon log may grow before the next start
+// moves it aside to daemon.log.1
+const maxDaemonLog = 5 << 20
+
+// ErrForeignProcess is returned for a process that belongs to another user,
+// which Nancy never signals
+var ErrForeignProcess = errors.New("process belongs to another user")
+
+// RuntimeDir returns where the daemon keeps its PID file: a directory in
+// XDG_RUNTIME_DIR, which is private to the user's session and emptied on
+// reboot, or fallback when there is none
+func RuntimeDir(fallback string) string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "nancy")
+	}
+	return fallback
+}
+
+// StateDir returns where the daemon keeps its log, out of the data directory
+// that may be synced or shared: $XDG_STATE_HOME/nancy, by default
+// ~/.local/state/nancy, on Linux and other Unix systems, or fallback on macOS
+// and Windows
+func StateDir(fallback string) string {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		return fallback
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "nancy")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".local", "state", "nancy")
+	}
+	return fallback
+}
+
+// IsNancyDaemon reports whether pid is a Nancy daemon, judging by its
+// command line, so a PID file left behind never points Nancy at an
+// unrelated program that got the same PID
+func IsNancyDaemon(pid int) (bool, error) {
+	args, err := ProcessArgs(pid)
+	if err != nil {
+		return false, err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return false, fmt.Errorf("failed to locate nancy: %w", err)
+	}
+	return IsDaemonCommand(args, executable), nil
+}
+
+// IsDaemonCommand reports whether args run 'daemon start' of the program
+// at executable, or of another program called nancy
+func IsDaemonCommand(args []string, executable string) bool {
+	if len(args) < 3 {
+		return false
+	}
+
+	name := strings.TrimSuffix(filepath.Base(args[0]), ".exe")
+	if name != strings.TrimSuffix(filepath.Base(executable), ".exe") && !strings.Contains(name, "nancy") {
+		return false
+	}
+	for i := 1; i+1 < len(args); i++ {
+		if args[i] == "daemon" && args[i+1] == "start" {
+			return true
+		}
+	}
+	return false
+}
+
+// StartDetached starts a program in the background, detached from the
+// terminal: in a session of its own, in the root directory and with its
+// output appended to the log at logPath. Closing the terminal or exiting
+// the caller leaves it running.
+func StartDetached(executable string, args []string, logPath string) (*os.Process, error) {
+	if err := os.MkdirAll(filepath.Dir(logPath), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if info, err := os.Stat(logPath); err == nil && info.Size() > maxDaemonLog {
+		os.Rename(logPath, logPath+".1")
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log: %w", err)
+	}
+	// The child gets its own copy
+	defer logFile.Close()
+
+	cmd := exec.Command(executable, args...)
+	// Don't keep the directory it was started from busy
+	cmd.Dir = filepath.VolumeName(executable) + string(filepath.Separator)
+	cmd.Stdin = nil
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = detachedAttr()
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", executable, err)
+	}
+	return cmd.Process, nil
+}
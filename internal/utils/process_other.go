@@ -0,0 +1,71 @@
+//go:build !windows
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// CheckProcess reports whether pid is running. A running process of another
+// user gives ErrForeignProcess.
+func CheckProcess(pid int) (bool, error) {
+	if pid <= 0 {
+		return false, nil
+	}
+
+	// Signal 0 only checks; EPERM means the process exists but isn't ours
+	switch err := syscall.Kill(pid, 0); {
+	case errors.Is(err, syscall.ESRCH):
+		return false, nil
+	case errors.Is(err, syscall.EPERM):
+		return true, ErrForeignProcess
+	case err != nil:
+		return false, fmt.Errorf("failed to check process %d: %w", pid, err)
+	}
+
+	// Root may signal anyone, so also check who owns it where /proc says
+	if info, err := os.Stat(fmt.Sprintf("/proc/%d", pid)); err == nil {
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+			return true, ErrForeignProcess
+		}
+	}
+	return true, nil
+}
+
+// SignalProcess sends sig to pid, refusing processes of other users
+func SignalProcess(pid int, sig syscall.Signal) error {
+	running, err := CheckProcess(pid)
+	if err != nil {
+		return err
+	}
+	if !running {
+		return fmt.Errorf("process %d is not running", pid)
+	}
+	return syscall.Kill(pid, sig)
+}
+
+// ProcessArgs returns the command line of pid, from /proc where there is
+// one and from ps elsewhere
+func ProcessArgs(pid int) ([]string, error) {
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid)); err == nil {
+		return strings.Split(strings.TrimRight(string(data), "\x00"), "\x00"), nil
+	}
+
+	out, err := exec.Command("ps", "-o", "command=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the command line of process %d: %w", pid, err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// detachedAttr starts a process in a new session, without a controlling
+// terminal
+func detachedAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// CheckProcess reports whether pid is running. Windows only lets a user open
+// their own processes, so finding it is enough.
+func CheckProcess(pid int) (bool, error) {
+	if pid <= 0 {
+		return false, nil
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false, nil
+	}
+	process.Release()
+	return true, nil
+}
+
+// SignalProcess stops pid. Windows has no signals, so any signal kills it.
+func SignalProcess(pid int, sig syscall.Signal) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Kill()
+}
+
+// ProcessArgs returns the command line of pid. Reading another process's
+// command line isn't supported on Windows.
+func ProcessArgs(pid int) ([]string, error) {
+	return nil, fmt.Errorf("reading the command line of process %d isn't supported on Windows", pid)
+}
+
+// detachedAttr starts a process without a console, in a process group of
+// its own so Ctrl+C in the terminal doesn't reach it
+func detachedAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: windows.DETACHED_PROCESS | windows.CREATE_NEW_PROCESS_GROUP}
+}
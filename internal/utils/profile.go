@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"time"
+)
+
+// ProfileDir is the directory in the data directory profiles are written to
+const ProfileDir = "profiles"
+
+// Profiler records a CPU profile, a heap profile and how long each phase of
+// a command took
+type Profiler struct {
+	base    string // Path prefix of the profile files
+	label   string
+	started time.Time
+	cpu     *os.File
+	phases  []profilePhase
+}
+
+type profilePhase struct {
+	name     string
+	duration time.Duration
+}
+
+// StartProfile starts a CPU profile in dir for the command called label.
+// The files share a timestamp prefix, so runs can be compared.
+func StartProfile(dir, label string, now time.Time) (*Profiler, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
+	name := now.Format("20060102-150405") + "-" + strings.ReplaceAll(label, " ", "-")
+	p := &Profiler{base: filepath.Join(dir, name), label: label, started: now}
+
+	cpu, err := os.Create(p.base + "-cpu.pprof")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(cpu); err != nil {
+		cpu.Close()
+		return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+	}
+	p.cpu = cpu
+	return p, nil
+}
+
+// Phase records how long a phase took
+func (p *Profiler) Phase(name string, d time.Duration) {
+	p.phases = append(p.phases, profilePhase{name: name, duration: d})
+}
+
+// Stop ends the CPU profile, writes the heap profile and the timings, and
+// returns the path of the timings file
+func (p *Profiler) Stop() (string, error) {
+	pprof.StopCPUProfile()
+	if err := p.cpu.Close(); err != nil {
+		return "", fmt.Errorf("failed to write CPU profile: %w", err)
+	}
+
+	heap, err := os.Create(p.base + "-heap.pprof")
+	if err != nil {
+		return "", fmt.Errorf("failed to create heap profile: %w", err)
+	}
+	runtime.GC()
+	err = pprof.WriteHeapProfile(heap)
+	if closeErr := heap.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write heap profile: %w", err)
+	}
+
+	path := p.base + "-timings.txt"
+	if err := os.WriteFile(path, []byte(p.Timings()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write timings: %w", err)
+	}
+	return path, nil
+}
+
+// Timings renders the phases and their total, one per line
+func (p *Profiler) Timings() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "nancy %s  %s\n\n", p.label, p.started.Format(time.RFC3339))
+
+	width := len("total")
+	var total time.Duration
+	for _, phase := range p.phases {
+		width = max(width, len(phase.name))
+		total += phase.duration
+	}
+	for _, phase := range p.phases {
+		fmt.Fprintf(&b, "%-*s  %10s\n", width, phase.name, phase.duration.Round(time.Microsecond))
+	}
+	fmt.Fprintf(&b, "%-*s  %10s\n", width, "total", total.Round(time.Microsecond))
+	return b.String()
+}
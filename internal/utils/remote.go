@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// RemoteClient talks to the REST API of a 'nancy serve' instance. It is the
+// models.Remote of a store opened with --remote.
+type RemoteClient struct {
+	base   string
+	token  string
+	client *http.Client
+}
+
+// NewRemoteClient creates a client for the server at base, e.g.
+// https://home.example.com:8080, sending token as a bearer token if set
+func NewRemoteClient(base, token string) (*RemoteClient, error) {
+	u, err := url.Parse(strings.TrimSpace(base))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid remote '%s' (use http://host:port or https://host:port)", base)
+	}
+	return &RemoteClient{
+		base:   strings.TrimSuffix(u.String(), "/"),
+		token:  token,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+// Fetch returns every reminder on the server
+func (c *RemoteClient) Fetch() ([]byte, error) {
+	return c.do(http.MethodGet, APIPrefix+"/reminders", nil)
+}
+
+// Put adds or replaces a reminder and returns it as the server stored it
+func (c *RemoteClient) Put(reminder *models.Reminder) (*models.Reminder, error) {
+	body, err := json.Marshal(reminder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode reminder: %w", err)
+	}
+	data, err := c.do(http.MethodPut, APIPrefix+"/reminders/"+url.PathEscape(reminder.ID), body)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &models.Reminder{}
+	if err := json.Unmarshal(data, stored); err != nil {
+		return nil, fmt.Errorf("failed to read the server's reply: %w", err)
+	}
+	return stored, nil
+}
+
+// Delete removes a reminder from the server
+func (c *RemoteClient) Delete(id string) error {
+	_, err := c.do(http.MethodDelete, APIPrefix+"/reminders/"+url.PathEscape(id), nil)
+	return err
+}
+
+// do sends a request to the API and returns the response body, turning
+// error responses into errors with the server's message
+func (c *RemoteClient) do(method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, c.base+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", c.base, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAPIBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the reply from %s: %w", c.base, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, fmt.Errorf("%s refused the token (set remote.token to the server's token)", c.base)
+	case resp.StatusCode >= 300:
+		var apiErr apiError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return nil, fmt.Errorf("%s: %s", c.base, apiErr.Error)
+		}
+		return nil, fmt.Errorf("%s: %s", c.base, resp.Status)
+	}
+	return data, nil
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"sort"
+	"time"
+)
+
+// First and longest wait between retries of a failed notification
+const (
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = 15 * time.Minute
+)
+
+// PendingNotification is a reminder notification waiting to be retried
+type PendingNotification struct {
+	ReminderID string
+	Title      string
+	Message    string
+	Attempts   int       // Retries so far
+	Since      time.Time // When the first send failed
+	Next       time.Time // When to retry
+}
+
+// RetryQueue holds notifications that didn't reach the main channel, for
+// example because the D-Bus session isn't up yet right after login. Each is
+// retried with exponential backoff until it is delivered or older than the
+// queue's maximum age.
+type RetryQueue struct {
+	maxAge  time.Duration
+	pending map[string]*PendingNotification
+}
+
+// NewRetryQueue creates a queue that gives up on notifications after maxAge
+func NewRetryQueue(maxAge time.Duration) *RetryQueue {
+	return &RetryQueue{maxAge: maxAge, pending: make(map[string]*PendingNotification)}
+}
+
+// RetryDelay returns how long to wait before retry number attempts+1:
+// 30s, 1m, 2m and so on, up to 15m
+func RetryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 0; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, retryMaxDelay)
+}
+
+// Add queues a notification for a reminder. A reminder already queued keeps
+// its place and backoff but gets the newer text.
+func (q *RetryQueue) Add(reminderID, title, message string, now time.Time) {
+	if q.maxAge <= 0 {
+		return
+	}
+	if pending, ok := q.pending[reminderID]; ok {
+		pending.Title, pending.Message = title, message
+		return
+	}
+	q.pending[reminderID] = &PendingNotification{
+		ReminderID: reminderID,
+		Title:      title,
+		Message:    message,
+		Since:      now,
+		Next:       now.Add(RetryDelay(0)),
+	}
+}
+
+// Due returns the notifications to retry now, oldest first, and removes and
+// returns the ones that are too old to retry
+func (q *RetryQueue) Due(now time.Time) (due, expired []PendingNotification) {
+	for id, pending := range q.pending {
+		switch {
+		case now.Sub(pending.Since) >= q.maxAge:
+			expired = append(expired, *pending)
+			delete(q.pending, id)
+		case !now.Before(pending.Next):
+			due = append(due, *pending)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].Since.Before(due[j].Since) })
+	return due, expired
+}
+
+// Failed schedules the next retry of a reminder's notification
+func (q *RetryQueue) Failed(reminderID string, now time.Time) {
+	if pending, ok := q.pending[reminderID]; ok {
+		pending.Attempts++
+		pending.Next = now.Add(RetryDelay(pending.Attempts))
+	}
+}
+
+// Remove takes a reminder's notification off the queue, once it was
+// delivered or the reminder no longer needs it
+func (q *RetryQueue) Remove(reminderID string) {
+	delete(q.pending, reminderID)
+}
+
+// Len returns how many notifications are waiting
+func (q *RetryQueue) Len() int {
+	return len(q.pending)
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSessionStateUnknown means the lock or idle state can't be read on this
+// system, e.g. without a desktop session
+var ErrSessionStateUnknown = errors.New("session lock state is unknown")
+
+// ScreenLocked reports whether the desktop session is locked: through the
+// screen saver service on the session bus or logind on Linux, and the
+// console session on macOS
+func ScreenLocked() (bool, error) {
+	switch runtime.GOOS {
+	case "linux":
+		for _, service := range []string{"org.freedesktop.ScreenSaver", "org.gnome.ScreenSaver"} {
+			if active, err := screenSaverActive(service); err == nil {
+				return active, nil
+			}
+		}
+		if value, err := logindSession("LockedHint"); err == nil {
+			return value == "yes", nil
+		}
+	case "darwin":
+		out, err := exec.Command("ioreg", "-n", "Root", "-d1").Output()
+		if err == nil {
+			return strings.Contains(string(out), `"CGSSessionScreenIsLocked"=Yes`), nil
+		}
+	}
+	return false, ErrSessionStateUnknown
+}
+
+// IdleTime returns how long there has been no keyboard or mouse input
+func IdleTime() (time.Duration, error) {
+	switch runtime.GOOS {
+	case "linux":
+		if out, err := exec.Command("xprintidle").Output(); err == nil {
+			if ms, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64); err == nil {
+				return time.Duration(ms) * time.Millisecond, nil
+			}
+		}
+		idle, err := logindSession("IdleHint")
+		if err != nil {
+			break
+		}
+		if idle != "yes" {
+			return 0, nil
+		}
+		since, err := logindSession("IdleSinceHint")
+		if usec, parseErr := strconv.ParseInt(since, 10, 64); err == nil && parseErr == nil && usec > 0 {
+			return time.Since(time.UnixMicro(usec)), nil
+		}
+	case "darwin":
+		out, err := exec.Command("ioreg", "-c", "IOHIDSystem", "-d", "4").Output()
+		if err == nil {
+			if ns, ok := parseHIDIdleTime(string(out)); ok {
+				return time.Duration(ns), nil
+			}
+		}
+	}
+	return 0, ErrSessionStateUnknown
+}
+
+// Away reports whether the user is away from the desktop: the screen is
+// locked, or with a positive idleAfter, there has been no input for that long
+func Away(idleAfter time.Duration) (bool, error) {
+	locked, err := ScreenLocked()
+	if err == nil && locked {
+		return true, nil
+	}
+	if idleAfter > 0 {
+		if idle, idleErr := IdleTime(); idleErr == nil {
+			return idle >= idleAfter, nil
+		}
+	}
+	return false, err
+}
+
+// screenSaverActive asks a screen saver service on the session bus whether
+// the screen is locked (GetActive)
+func screenSaverActive(service string) (bool, error) {
+	conn, err := dialSessionBus()
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	path := "/" + strings.ReplaceAll(service, ".", "/")
+	msg, err := conn.call(service, path, service, "GetActive", "", nil)
+	if err != nil {
+		return false, err
+	}
+	if msg.signature != "b" {
+		return false, fmt.Errorf("unexpected GetActive reply %q", msg.signature)
+	}
+	active, err := msg.body.uint32()
+	return active != 0, err
+}
+
+// logindSession reads a property of the current logind session
+func logindSession(property string) (string, error) {
+	session := os.Getenv("XDG_SESSION_ID")
+	if session == "" {
+		session = "auto"
+	}
+	out, err := exec.Command("loginctl", "show-session", session, "-p", property, "--value").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// hidIdlePattern finds the idle time in nanoseconds in ioreg output
+var hidIdlePattern = regexp.MustCompile(`"HIDIdleTime" = (\d+)`)
+
+// parseHIDIdleTime extracts HIDIdleTime (nanoseconds) from ioreg output
+func parseHIDIdleTime(out string) (int64, bool) {
+	match := hidIdlePattern.FindStringSubmatch(out)
+	if match == nil {
+		return 0, false
+	}
+	ns, err := strconv.ParseInt(match[1], 10, 64)
+	return ns, err == nil
+}
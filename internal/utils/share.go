@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// SharePrefix starts the link 'nancy share' prints. The blob after it is
+// what matters; 'nancy add --from-share' takes either.
+const SharePrefix = "nancy://share/"
+
+// shareVersion is the format EncodeShare writes
+const shareVersion = 1
+
+// maxShareSize caps how much a blob may inflate to, so a crafted one can't
+// exhaust memory
+const maxShareSize = 64 << 10
+
+// SharedReminder is what a share link carries: the parts of a reminder that
+// mean something to someone else, not its history or local settings
+type SharedReminder struct {
+	Version  int             `json:"v"`
+	Title    string          `json:"title"`
+	Due      time.Time       `json:"due"`
+	Notes    string          `json:"notes,omitempty"`
+	Tags     []string        `json:"tags,omitempty"`
+	Priority models.Priority `json:"priority"`
+}
+
+// EncodeShare returns a share link for a reminder: its shared parts as
+// compressed JSON in URL-safe base64
+func EncodeShare(r *models.Reminder) (string, error) {
+	data, err := json.Marshal(SharedReminder{
+		Version:  shareVersion,
+		Title:    r.Title,
+		Due:      r.DueTime,
+		Notes:    r.Description,
+		Tags:     r.Tags,
+		Priority: r.Priority,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode reminder: %w", err)
+	}
+
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", fmt.Errorf("failed to encode reminder: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode reminder: %w", err)
+	}
+	return SharePrefix + base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeShare reads a share link or the blob from one
+func DecodeShare(s string) (*SharedReminder, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), SharePrefix)
+	// Tolerate padding and line breaks picked up when copying
+	s = strings.Join(strings.Fields(s), "")
+	compressed, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, fmt.Errorf("not a shared reminder (invalid encoding)")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(compressed)), maxShareSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("not a shared reminder (corrupt data)")
+	}
+	if len(data) > maxShareSize {
+		return nil, fmt.Errorf("shared reminder is larger than %d KB", maxShareSize>>10)
+	}
+
+	var shared SharedReminder
+	if err := json.Unmarshal(data, &shared); err != nil {
+		return nil, fmt.Errorf("not a shared reminder: %w", err)
+	}
+	if shared.Version < 1 || shared.Version > shareVersion {
+		return nil, fmt.Errorf("shared reminder format %d is not supported (update Nancy)", shared.Version)
+	}
+	shared.Title = strings.TrimSpace(shared.Title)
+	if shared.Title == "" {
+		return nil, fmt.Errorf("shared reminder has no title")
+	}
+	if shared.Due.IsZero() {
+		return nil, fmt.Errorf("shared reminder has no due time")
+	}
+	return &shared, nil
+}
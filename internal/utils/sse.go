@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// Events on the /events stream beyond the store's own
+const (
+	EventDue     = "due"     // A reminder entered its due-soon window
+	EventOverdue = "overdue" // A reminder's due time passed
+)
+
+// dueCheckInterval is how often the /events stream looks for reminders that
+// came due or went overdue
+const dueCheckInterval = 15 * time.Second
+
+// keepAliveInterval is how often an idle /events stream sends a comment, so
+// proxies don't close it
+const keepAliveInterval = 30 * time.Second
+
+// apiEvent is an event on the /events stream
+type apiEvent struct {
+	Kind     string           `json:"kind"`
+	ID       string           `json:"id,omitempty"`
+	Reminder *models.Reminder `json:"reminder,omitempty"`
+}
+
+// dueTracker notices reminders that come due or go overdue. It remembers
+// the due time it reported each reminder for, so a snoozed reminder is
+// reported again.
+type dueTracker struct {
+	due     map[string]time.Time
+	overdue map[string]time.Time
+}
+
+func newDueTracker() *dueTracker {
+	return &dueTracker{
+		due:     make(map[string]time.Time),
+		overdue: make(map[string]time.Time),
+	}
+}
+
+// scan returns the events for the reminders that came due or went overdue
+// since the last scan
+func (d *dueTracker) scan(reminders []*models.Reminder) []apiEvent {
+	var events []apiEvent
+	current := make(map[string]bool)
+	for _, reminder := range reminders {
+		if reminder.Completed || (reminder.Recurring != nil && reminder.Recurring.Paused) {
+			continue
+		}
+		current[reminder.ID] = true
+
+		switch {
+		case reminder.IsOverdue():
+			if !d.overdue[reminder.ID].Equal(reminder.DueTime) {
+				d.overdue[reminder.ID] = reminder.DueTime
+				events = append(events, apiEvent{Kind: EventOverdue, ID: reminder.ID, Reminder: reminder})
+			}
+		case reminder.IsDueSoon():
+			if !d.due[reminder.ID].Equal(reminder.DueTime) {
+				d.due[reminder.ID] = reminder.DueTime
+				events = append(events, apiEvent{Kind: EventDue, ID: reminder.ID, Reminder: reminder})
+			}
+		}
+	}
+
+	for id := range d.due {
+		if !current[id] {
+			delete(d.due, id)
+		}
+	}
+	for id := range d.overdue {
+		if !current[id] {
+			delete(d.overdue, id)
+		}
+	}
+	return events
+}
+
+// events streams the store's events as server-sent events, along with due
+// and overdue events, until the client hangs up
+func (api *apiServer) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	events, unsubscribe := api.store.Subscribe()
+	defer unsubscribe()
+
+	// Reminders already due when the client connects are in its list
+	tracker := newDueTracker()
+	tracker.scan(api.store.GetAll(&models.FilterOptions{}))
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	dueTicker := time.NewTicker(dueCheckInterval)
+	defer dueTicker.Stop()
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	send := func(events ...apiEvent) bool {
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Kind, data); err != nil {
+				return false
+			}
+		}
+		flusher.Flush()
+		return true
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			// A new or rescheduled reminder may be due already
+			if !send(apiEvent{Kind: event.Kind, ID: event.ID, Reminder: event.Reminder}) ||
+				!send(tracker.scan(api.store.GetAll(&models.FilterOptions{}))...) {
+				return
+			}
+		case <-dueTicker.C:
+			if !send(tracker.scan(api.store.GetAll(&models.FilterOptions{}))...) {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// suggestDays is how far ahead SuggestDueTime looks for a free slot
+const suggestDays = 7
+
+// SuggestDueTime picks the least crowded upcoming weekday slot with room for
+// estimate within working hours. Each existing reminder is treated as
+// occupying the hour it is due in; busy days are penalized so load spreads
+// out, and sooner slots win ties. An estimate of 0 counts as an hour, and
+// one longer than the working day as the whole day.
+func SuggestDueTime(existing []*models.Reminder, workStart, workEnd string, estimate time.Duration, from time.Time) time.Time {
+	start, end := 9*time.Hour, 17*time.Hour
+	if t, err := time.Parse("15:04", workStart); err == nil {
+		start = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if t, err := time.Parse("15:04", workEnd); err == nil {
+		end = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if end <= start {
+		start, end = 9*time.Hour, 17*time.Hour
+	}
+	if estimate <= 0 {
+		estimate = time.Hour
+	}
+	estimate = min(estimate, end-start)
+
+	// Count active reminders per day and per hour slot
+	perDay := make(map[string]int)
+	perHour := make(map[string]int)
+	for _, reminder := range existing {
+		if reminder == nil || reminder.Completed {
+			continue
+		}
+		due := reminder.DueTime.In(from.Location())
+		perDay[due.Format("2006-01-02")]++
+		perHour[due.Format("2006-01-02 15")]++
+	}
+
+	// Start at the next full hour
+	first := from.Truncate(time.Hour).Add(time.Hour)
+
+	best := time.Time{}
+	bestScore := -1
+	for day := 0; day < suggestDays; day++ {
+		date := first.AddDate(0, 0, day)
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+		// The slots are the start of working hours and every full hour after
+		for offset := start; offset+estimate <= end; offset = offset.Truncate(time.Hour) + time.Hour {
+			slot := time.Date(date.Year(), date.Month(), date.Day(), 0, int(offset/time.Minute), 0, 0, from.Location())
+			if slot.Before(first) {
+				continue
+			}
+
+			// Every hour the work would take counts. An occupied hour weighs
+			// more than a busy day, which weighs more than waiting another day.
+			score := perDay[slot.Format("2006-01-02")]*3 + day
+			for busy := slot; busy.Before(slot.Add(estimate)); {
+				score += perHour[busy.Format("2006-01-02 15")] * 10
+				busy = time.Date(busy.Year(), busy.Month(), busy.Day(), busy.Hour()+1, 0, 0, 0, busy.Location())
+			}
+			if bestScore < 0 || score < bestScore {
+				best = slot
+				bestScore = score
+			}
+		}
+	}
+
+	if best.IsZero() {
+		return first
+	}
+	return best
+}
+
+// minHourSamples is how many completions of similar reminders it takes
+// for PreferredHour to trust the pattern
+const minHourSamples = 3
+
+// PreferredHour returns the hour of day, in loc, at which reminders sharing
+// a tag with tags were most often completed, counting each occurrence of
+// recurring ones, and how many of the completions fell in it. Earlier hours
+// win ties.
+func PreferredHour(reminders []*models.Reminder, tags []string, loc *time.Location) (hour, count int, ok bool) {
+	if len(tags) == 0 {
+		return 0, 0, false
+	}
+	similar := func(reminder *models.Reminder) bool {
+		for _, tag := range tags {
+			if reminder.HasTag(tag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var perHour [24]int
+	total := 0
+	for _, reminder := range reminders {
+		if reminder == nil || !similar(reminder) {
+			continue
+		}
+		recorded := false
+		for _, occurrence := range reminder.History {
+			if occurrence.Done() {
+				perHour[occurrence.At.In(loc).Hour()]++
+				total++
+			}
+			if reminder.CompletedAt != nil && occurrence.At.Equal(*reminder.CompletedAt) {
+				recorded = true
+			}
+		}
+		// Completing the last occurrence of a recurring reminder records it
+		// in the history too
+		if reminder.Completed && reminder.CompletedAt != nil && !recorded {
+			perHour[reminder.CompletedAt.In(loc).Hour()]++
+			total++
+		}
+	}
+	if total < minHourSamples {
+		return 0, 0, false
+	}
+
+	for h, n := range perHour {
+		if n > count {
+			hour, count = h, n
+		}
+	}
+	return hour, count, true
+}
+
+// alternateStep is the spacing of the alternate slots tried around a
+// conflicting due time
+const alternateStep = 30 * time.Minute
+
+// AlternateSlots offers up to three due times near due that don't conflict:
+// nearby times on the same day first, unless that day already has maxPerDay
+// reminders (0 means no limit), then the same time on the following days
+func AlternateSlots(existing []*models.Reminder, due time.Time, window time.Duration, maxPerDay int, now time.Time) []time.Time {
+	perDay := make(map[string]int)
+	var active []*models.Reminder
+	for _, reminder := range existing {
+		if reminder == nil || reminder.Completed || (reminder.Recurring != nil && reminder.Recurring.Paused) {
+			continue
+		}
+		active = append(active, reminder)
+		perDay[reminder.DueTime.In(due.Location()).Format("2006-01-02")]++
+	}
+
+	free := func(slot time.Time) bool {
+		if !slot.After(now) {
+			return false
+		}
+		if maxPerDay > 0 && perDay[slot.Format("2006-01-02")] >= maxPerDay {
+			return false
+		}
+		for _, reminder := range active {
+			if gap := reminder.DueTime.Sub(slot); gap <= window && gap >= -window {
+				return false
+			}
+		}
+		return true
+	}
+
+	var slots []time.Time
+	for step := 1; step <= 6 && len(slots) < 2; step++ {
+		for _, slot := range []time.Time{due.Add(time.Duration(step) * alternateStep), due.Add(-time.Duration(step) * alternateStep)} {
+			if len(slots) < 2 && slot.YearDay() == due.YearDay() && free(slot) {
+				slots = append(slots, slot)
+			}
+		}
+	}
+	for day := 1; day <= 14 && len(slots) < 3; day++ {
+		if slot := due.AddDate(0, 0, day); free(slot) {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
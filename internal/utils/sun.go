@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Sun events usable as due times, e.g. "close the blinds at sunset"
+const (
+	Sunrise = "sunrise"
+	Sunset  = "sunset"
+)
+
+// latitude and longitude used for sunrise and sunset, set from config
+var (
+	latitude, longitude float64
+	locationSet         bool
+)
+
+// SetLocation sets the coordinates used for sunrise and sunset. Both zero
+// means no location is configured.
+func SetLocation(lat, lon float64) {
+	latitude, longitude = lat, lon
+	locationSet = lat != 0 || lon != 0
+}
+
+// SunEventTime returns the time of sunrise or sunset on day's date, in day's
+// time zone, at the configured location
+func SunEventTime(event string, day time.Time) (time.Time, error) {
+	if !locationSet {
+		return time.Time{}, fmt.Errorf("no location configured (set location.latitude and location.longitude)")
+	}
+	if event != Sunrise && event != Sunset {
+		return time.Time{}, fmt.Errorf("unknown sun event: %s", event)
+	}
+	return sunEventAt(event, day, latitude, longitude)
+}
+
+// sunEventAt implements the sunrise equation
+// (https://en.wikipedia.org/wiki/Sunrise_equation), accurate to a minute or two
+func sunEventAt(event string, day time.Time, lat, lon float64) (time.Time, error) {
+	const j2000 = 2451545.0
+	rad := math.Pi / 180
+
+	// Days since J2000 at noon UTC of day's calendar date
+	noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, time.UTC)
+	n := math.Round(float64(noon.Unix())/86400 + 2440587.5 - j2000)
+
+	solarNoon := n - lon/360
+	anomaly := math.Mod(357.5291+0.98560028*solarNoon, 360)
+	center := 1.9148*math.Sin(anomaly*rad) + 0.02*math.Sin(2*anomaly*rad) + 0.0003*math.Sin(3*anomaly*rad)
+	eclipticLon := math.Mod(anomaly+center+180+102.9372, 360)
+	transit := j2000 + solarNoon + 0.0053*math.Sin(anomaly*rad) - 0.0069*math.Sin(2*eclipticLon*rad)
+
+	declination := math.Asin(math.Sin(eclipticLon*rad) * math.Sin(23.4397*rad))
+	cosHourAngle := (math.Sin(-0.833*rad) - math.Sin(lat*rad)*math.Sin(declination)) /
+		(math.Cos(lat*rad) * math.Cos(declination))
+	if cosHourAngle < -1 || cosHourAngle > 1 {
+		return time.Time{}, fmt.Errorf("the sun doesn't rise or set here on %s", day.Format("Jan 2"))
+	}
+
+	hourAngle := math.Acos(cosHourAngle) / rad
+	julian := transit + hourAngle/360
+	if event == Sunrise {
+		julian = transit - hourAngle/360
+	}
+
+	seconds := (julian - 2440587.5) * 86400
+	return time.Unix(int64(math.Round(seconds)), 0).In(day.Location()).Truncate(time.Minute), nil
+}
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// OpenURL opens a link with the system's default handler. Only http(s)
+// links are opened, so a stored link can't start a local program or pass
+// options to the handler.
+func OpenURL(url string) error {
+	if !models.IsWebLink(url) {
+		return fmt.Errorf("not opening %q: only http and https links can be opened", url)
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+	return nil
+}
+
+// clipboardCommands lists the clipboard readers to try on each platform
+func clipboardCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbpaste"}}
+	case "windows":
+		return [][]string{{"powershell", "-NoProfile", "-Command", "Get-Clipboard -Raw"}}
+	}
+
+	commands := [][]string{
+		{"xclip", "-selection", "clipboard", "-o"},
+		{"xsel", "--clipboard", "--output"},
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append([][]string{{"wl-paste", "--no-newline"}}, commands...)
+	}
+	return commands
+}
+
+// ReadClipboard returns the text on the system clipboard
+func ReadClipboard() (string, error) {
+	for _, argv := range clipboardCommands() {
+		if _, err := exec.LookPath(argv[0]); err != nil {
+			continue
+		}
+		out, err := exec.Command(argv[0], argv[1:]...).Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to read clipboard with %s: %w", argv[0], err)
+		}
+		return strings.ReplaceAll(string(out), "\r\n", "\n"), nil
+	}
+	return "", fmt.Errorf("no clipboard tool found (install wl-clipboard, xclip or xsel)")
+}
+
+// SplitClipboard splits copied text into a title (the first non-empty line)
+// and a description (the remaining lines)
+func SplitClipboard(text string) (title, description string) {
+	text = strings.TrimSpace(text)
+	title, description, _ = strings.Cut(text, "\n")
+	return strings.TrimSpace(title), strings.TrimSpace(description)
+}
+
+// transcriptTimestamps matches segment timestamps such as whisper's
+// "[00:00:00.000 --> 00:00:02.500]"
+var transcriptTimestamps = regexp.MustCompile(`\[\d[\d:.,]* --> [\d:.,]+\]`)
+
+// Transcribe runs a speech-to-text command on an audio file and returns the
+// cleaned-up transcript. A "{file}" argument is replaced with the file path;
+// without one the path is appended.
+func Transcribe(command, file string) (string, error) {
+	argv := strings.Fields(command)
+	if len(argv) == 0 {
+		return "", fmt.Errorf("no speech-to-text command configured (use --stt or set default.stt_command)")
+	}
+
+	substituted := false
+	for i, arg := range argv {
+		if strings.Contains(arg, "{file}") {
+			argv[i] = strings.ReplaceAll(arg, "{file}", file)
+			substituted = true
+		}
+	}
+	if !substituted {
+		argv = append(argv, file)
+	}
+
+	var stderr strings.Builder
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("transcription with %s failed: %w: %s", argv[0], err, msg)
+		}
+		return "", fmt.Errorf("transcription with %s failed: %w", argv[0], err)
+	}
+
+	transcript := CleanTranscript(string(out))
+	if transcript == "" {
+		return "", fmt.Errorf("transcription of %s is empty", file)
+	}
+	return transcript, nil
+}
+
+// CleanTranscript turns dictated text into a single line, dropping segment
+// timestamps
+func CleanTranscript(text string) string {
+	text = transcriptTimestamps.ReplaceAllString(text, " ")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// titlePattern extracts the contents of an HTML <title> element
+var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// FetchPageTitle downloads a web page and returns its <title>
+func FetchPageTitle(url string) (string, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+
+	// The title is in the head, so the start of the page is enough
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", url, err)
+	}
+
+	match := titlePattern.FindSubmatch(body)
+	if match == nil {
+		return "", fmt.Errorf("%s has no title", url)
+	}
+	title := strings.Join(strings.Fields(html.UnescapeString(string(match[1]))), " ")
+	if title == "" {
+		return "", fmt.Errorf("%s has no title", url)
+	}
+	return title, nil
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// WindowsAppID is the AppUserModelID Nancy's toasts are shown under. It is
+// registered on first use so toasts carry Nancy's name and the app can be
+// added to Focus Assist's priority list.
+const WindowsAppID = "IvyasCorp.NaggingNancy"
+
+// ToastScheme is the URL scheme toast buttons use to call back into Nancy,
+// e.g. "nancy://complete/<id>"
+const ToastScheme = "nancy"
+
+// registerOnce registers the app ID and URL scheme once per process
+var registerOnce sync.Once
+
+// WindowsToastXML builds the toast for a notification. With a reminder ID it
+// gets Done and Snooze buttons; high priority reminders use the reminder
+// scenario, which stays on screen until dismissed. Critical ones use the
+// urgent scenario, which Windows 11 lets through Do Not Disturb.
+func WindowsToastXML(title, message string, priority models.Priority, id string, critical bool) string {
+	var b strings.Builder
+
+	b.WriteString("<toast")
+	if id != "" && critical {
+		b.WriteString(` scenario="urgent"`)
+	} else if id != "" && priority == models.High {
+		b.WriteString(` scenario="reminder"`)
+	}
+	b.WriteString(`><visual><binding template="ToastGeneric">`)
+	fmt.Fprintf(&b, "<text>%s</text>", escapeXML(title))
+	for _, line := range strings.Split(message, "\n") {
+		fmt.Fprintf(&b, "<text>%s</text>", escapeXML(line))
+	}
+	b.WriteString("</binding></visual>")
+
+	if id != "" {
+		b.WriteString("<actions>")
+		for _, action := range []struct{ label, verb string }{
+			{i18n.T("Done"), "complete"},
+			{i18n.T("Snooze"), "snooze"},
+		} {
+			fmt.Fprintf(&b, `<action content="%s" activationType="protocol" arguments="%s://%s/%s"/>`,
+				escapeXML(action.label), ToastScheme, action.verb, escapeXML(id))
+		}
+		b.WriteString("</actions>")
+	}
+
+	b.WriteString("</toast>")
+	return b.String()
+}
+
+// ParseToastAction splits a toast button URL like "nancy://snooze/<id>"
+func ParseToastAction(url string) (verb, id string, err error) {
+	rest, ok := strings.CutPrefix(url, ToastScheme+"://")
+	if ok {
+		verb, id, ok = strings.Cut(strings.TrimSuffix(rest, "/"), "/")
+	}
+	if !ok || verb == "" || id == "" {
+		return "", "", fmt.Errorf("invalid toast action: %s", url)
+	}
+	return verb, id, nil
+}
+
+// toastScript shows the toast XML read from stdin. Tagging the toast with
+// the reminder ID makes repeat notifications replace the previous one, so
+// while Focus Assist holds them back the Action Center keeps one per reminder.
+const toastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+$xml = New-Object Windows.Data.Xml.Dom.XmlDocument
+$xml.LoadXml([Console]::In.ReadToEnd())
+$toast = New-Object Windows.UI.Notifications.ToastNotification $xml
+if ($env:NANCY_TOAST_TAG) { $toast.Tag = $env:NANCY_TOAST_TAG; $toast.Group = "reminders" }
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($env:NANCY_APP_ID).Show($toast)
+`
+
+// sendWindowsToast shows a toast through the WinRT notification API
+func sendWindowsToast(toastXML, tag string) error {
+	registerOnce.Do(func() {
+		// Without registration toasts still show, just under a generic name
+		_ = RegisterWindowsApp()
+	})
+
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", toastScript)
+	cmd.Stdin = strings.NewReader(toastXML)
+	cmd.Env = append(cmd.Environ(), "NANCY_APP_ID="+WindowsAppID, "NANCY_TOAST_TAG="+tag)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to show toast: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// escapeXML escapes text for use in XML content and attributes
+func escapeXML(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
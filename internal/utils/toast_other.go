@@ -0,0 +1,10 @@
+//go:build !windows
+
+package utils
+
+import "fmt"
+
+// RegisterWindowsApp registers Nancy's toast app ID; only Windows has one
+func RegisterWindowsApp() error {
+	return fmt.Errorf("toast registration is only available on Windows")
+}
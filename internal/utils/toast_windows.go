@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// RegisterWindowsApp registers Nancy's AppUserModelID, so toasts show as
+// "Nagging Nancy", and the nancy:// URL scheme used by the toast buttons.
+// Everything goes under HKEY_CURRENT_USER; no admin rights are needed.
+func RegisterWindowsApp() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate nancy: %w", err)
+	}
+
+	values := []struct {
+		path, name, value string
+	}{
+		{`Software\Classes\AppUserModelId\` + WindowsAppID, "DisplayName", "Nagging Nancy"},
+		{`Software\Classes\` + ToastScheme, "", "URL:Nagging Nancy"},
+		{`Software\Classes\` + ToastScheme, "URL Protocol", ""},
+		{`Software\Classes\` + ToastScheme + `\shell\open\command`, "", fmt.Sprintf(`"%s" toast-action "%%1"`, exe)},
+	}
+	for _, v := range values {
+		key, _, err := registry.CreateKey(registry.CURRENT_USER, v.path, registry.SET_VALUE)
+		if err != nil {
+			return fmt.Errorf("failed to register %s: %w", v.path, err)
+		}
+		err = key.SetStringValue(v.name, v.value)
+		key.Close()
+		if err != nil {
+			return fmt.Errorf("failed to register %s: %w", v.path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReleaseRepo is the GitHub repository Nancy's releases are published in
+const ReleaseRepo = "ivyascorp-net/nagging-nancy"
+
+// checksumsAsset is the release asset listing the SHA-256 of every archive
+const checksumsAsset = "checksums.txt"
+
+// maxDownloadSize caps release downloads
+const maxDownloadSize = 100 << 20
+
+// Release is a published version of Nancy
+type Release struct {
+	Version string            // e.g. "1.4.0", without the leading v
+	URL     string            // Release page
+	Assets  map[string]string // Asset name -> download URL
+}
+
+// LatestRelease looks up the newest release on GitHub
+func LatestRelease() (*Release, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/repos/%s/releases/latest", strings.TrimSuffix(GitHubAPI, "/"), ReleaseRepo), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	var result struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+		Assets  []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := getJSON(req, "the latest release", &result); err != nil {
+		return nil, err
+	}
+
+	release := &Release{
+		Version: strings.TrimPrefix(result.TagName, "v"),
+		URL:     result.HTMLURL,
+		Assets:  make(map[string]string, len(result.Assets)),
+	}
+	for _, asset := range result.Assets {
+		release.Assets[asset.Name] = asset.URL
+	}
+	return release, nil
+}
+
+// NewerVersion reports whether latest is a newer version than current. It
+// fails for versions that aren't MAJOR.MINOR.PATCH, like development builds.
+func NewerVersion(current, latest string) (bool, error) {
+	a, err := parseVersion(current)
+	if err != nil {
+		return false, err
+	}
+	b, err := parseVersion(latest)
+	if err != nil {
+		return false, err
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return b[i] > a[i], nil
+		}
+	}
+	return false, nil
+}
+
+// parseVersion splits "v1.4.0" or "1.4.0-rc1" into its numbers
+func parseVersion(version string) ([3]int, error) {
+	var numbers [3]int
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "-")
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return numbers, fmt.Errorf("'%s' is not a release version", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return numbers, fmt.Errorf("'%s' is not a release version", version)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
+
+// ReleaseAssetName returns the name of the release archive for a platform,
+// e.g. nancy_Linux_x86_64.tar.gz. goarm is the ARM version for 32-bit ARM.
+func ReleaseAssetName(goos, goarch, goarm string) (string, error) {
+	var system string
+	switch goos {
+	case "linux":
+		system = "Linux"
+	case "darwin":
+		system = "Darwin"
+	default:
+		return "", fmt.Errorf("no release builds for %s; build from source or use your package manager", goos)
+	}
+
+	arch := goarch
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "386":
+		arch = "i386"
+	case "arm":
+		if goarm == "" {
+			goarm = "7"
+		}
+		arch = "armv" + goarm
+	case "arm64":
+	default:
+		return "", fmt.Errorf("no release builds for %s/%s", goos, goarch)
+	}
+	return fmt.Sprintf("nancy_%s_%s.tar.gz", system, arch), nil
+}
+
+// DownloadBinary downloads the release archive for this platform, checks it
+// against the release checksums and returns the nancy binary inside
+func (r *Release) DownloadBinary() ([]byte, error) {
+	name, err := ReleaseAssetName(runtime.GOOS, runtime.GOARCH, buildSetting("GOARM"))
+	if err != nil {
+		return nil, err
+	}
+	archiveURL, ok := r.Assets[name]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s", r.Version, name)
+	}
+	checksumsURL, ok := r.Assets[checksumsAsset]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s to verify the download with", r.Version, checksumsAsset)
+	}
+
+	checksums, err := download(checksumsURL)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := download(archiveURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyChecksum(archive, name, checksums); err != nil {
+		return nil, err
+	}
+	return ExtractBinary(archive, "nancy")
+}
+
+// VerifyChecksum checks data against its entry in a checksums file with
+// "SHA256  NAME" lines. The checksums come unsigned from the same release,
+// so this catches damaged or truncated downloads, not a forged release.
+func VerifyChecksum(data []byte, name string, checksums []byte) error {
+	sum := sha256.Sum256(data)
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("checksum mismatch for %s: the download is damaged or incomplete; try again", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum for %s", name)
+}
+
+// ExtractBinary returns the file called name from a .tar.gz archive
+func ExtractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the release archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("the release archive has no %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the release archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == name {
+			return io.ReadAll(io.LimitReader(tr, maxDownloadSize))
+		}
+	}
+}
+
+// ReplaceExecutable swaps the executable at path for binary. The new file
+// is written next to it and renamed over it in one step, so a failed update
+// leaves the old one working.
+func ReplaceExecutable(path string, binary []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".nancy-update-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s (%w); run the update with the permissions you installed Nancy with", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write the new version: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the new version: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return fmt.Errorf("failed to make the new version executable: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
+
+// download fetches a release asset
+func download(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize))
+}
+
+// buildSetting returns a setting the binary was built with, e.g. GOARM
+func buildSetting(key string) string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == key {
+				return setting.Value
+			}
+		}
+	}
+	return ""
+}
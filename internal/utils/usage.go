@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// UsageFile is the name of the local usage log in the data directory
+const UsageFile = "usage.jsonl"
+
+// UsageEvent is one command run, without its arguments
+type UsageEvent struct {
+	At      time.Time `json:"at"`
+	Command string    `json:"command"` // e.g. "add" or "daemon start"
+}
+
+// CommandCount is how often a command was run
+type CommandCount struct {
+	Command string
+	Count   int
+}
+
+// RecordUsage appends a command run to the usage log at path
+func RecordUsage(path, command string, at time.Time) error {
+	data, err := json.Marshal(UsageEvent{At: at, Command: command})
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage event: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open usage log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write usage log: %w", err)
+	}
+	return nil
+}
+
+// LoadUsage reads the usage log at path, oldest first. A missing log has no
+// events, and lines that don't parse are skipped.
+func LoadUsage(path string) ([]UsageEvent, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage log: %w", err)
+	}
+	defer file.Close()
+
+	var events []UsageEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event UsageEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err == nil && event.Command != "" {
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage log: %w", err)
+	}
+	return events, nil
+}
+
+// CommandCounts counts the runs of each command, most used first
+func CommandCounts(events []UsageEvent) []CommandCount {
+	counts := make(map[string]int)
+	for _, event := range events {
+		counts[event.Command]++
+	}
+
+	result := make([]CommandCount, 0, len(counts))
+	for command, count := range counts {
+		result = append(result, CommandCount{Command: command, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Command < result[j].Command
+	})
+	return result
+}
+
+// BusiestSlot returns the weekday and hour command was run most often in,
+// and how often; count is 0 if it was never run
+func BusiestSlot(events []UsageEvent, command string) (day time.Weekday, hour, count int) {
+	var slots [7][24]int
+	for _, event := range events {
+		if event.Command != command {
+			continue
+		}
+		at := event.At.Local()
+		slots[at.Weekday()][at.Hour()]++
+		if n := slots[at.Weekday()][at.Hour()]; n > count {
+			day, hour, count = at.Weekday(), at.Hour(), n
+		}
+	}
+	return day, hour, count
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// webUIPage is the whole web UI, styles and script included
+//
+//go:embed webui/index.html
+var webUIPage []byte
+
+// NewWebUIHandler serves the web UI of 'nancy serve': a single page with
+// today's reminders, Done and Snooze buttons and an add box, working
+// through the REST API. It needs nothing beyond the binary, and asks for
+// the token itself, so it works from a phone browser.
+func NewWebUIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'unsafe-inline'; style-src 'unsafe-inline'")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Write(webUIPage)
+	})
+}
@@ -0,0 +1,145 @@
+// nancy-notifier posts Nagging Nancy's notifications through
+// UNUserNotificationCenter. It lives in its own app bundle because macOS only
+// lets bundled apps use the framework; nancy runs it as
+//
+//   nancy-notifier status
+//   nancy-notifier send --title T --message M [--sound] [--critical]
+//                       [--id ID --nancy PATH --done-label L --snooze-label L]
+//
+// "status" prints authorized, denied or not-determined. "send" exits with 3
+// when notifications are turned off. Clicking Done or Snooze relaunches the
+// app, which hands the action to "nancy toast-action nancy://<verb>/<id>".
+
+import AppKit
+import Foundation
+import UserNotifications
+
+let center = UNUserNotificationCenter.current()
+let reminderCategory = "reminder"
+
+func fail(_ message: String, code: Int32 = 1) -> Never {
+    FileHandle.standardError.write((message + "\n").data(using: .utf8)!)
+    exit(code)
+}
+
+func option(_ name: String) -> String? {
+    let args = CommandLine.arguments
+    guard let i = args.firstIndex(of: "--" + name), i + 1 < args.count else { return nil }
+    return args[i + 1]
+}
+
+func flag(_ name: String) -> Bool {
+    CommandLine.arguments.contains("--" + name)
+}
+
+func statusName(_ status: UNAuthorizationStatus) -> String {
+    switch status {
+    case .authorized, .provisional, .ephemeral:
+        return "authorized"
+    case .denied:
+        return "denied"
+    default:
+        return "not-determined"
+    }
+}
+
+final class Delegate: NSObject, UNUserNotificationCenterDelegate {
+    func userNotificationCenter(
+        _ center: UNUserNotificationCenter,
+        willPresent notification: UNNotification,
+        withCompletionHandler completionHandler: @escaping (UNNotificationPresentationOptions) -> Void
+    ) {
+        completionHandler([.banner, .list, .sound])
+    }
+
+    func userNotificationCenter(
+        _ center: UNUserNotificationCenter,
+        didReceive response: UNNotificationResponse,
+        withCompletionHandler completionHandler: @escaping () -> Void
+    ) {
+        let info = response.notification.request.content.userInfo
+        let verb = ["complete", "snooze"].first { $0 == response.actionIdentifier }
+        if let verb = verb, let nancy = info["nancy"] as? String, let id = info["id"] as? String {
+            let process = Process()
+            process.executableURL = URL(fileURLWithPath: nancy)
+            process.arguments = ["toast-action", "nancy://\(verb)/\(id)"]
+            try? process.run()
+            process.waitUntilExit()
+        }
+        completionHandler()
+        exit(0)
+    }
+}
+
+func send() {
+    guard let title = option("title"), let message = option("message") else {
+        fail("send needs --title and --message", code: 2)
+    }
+
+    center.requestAuthorization(options: [.alert, .sound]) { granted, _ in
+        guard granted else {
+            fail("notifications are turned off for Nancy Notifier", code: 3)
+        }
+
+        let content = UNMutableNotificationContent()
+        content.title = title
+        content.body = message
+        if flag("sound") {
+            content.sound = .default
+        }
+        // Time Sensitive notifications break through Focus, if the app is
+        // signed with that entitlement and the user allows it
+        if flag("critical"), #available(macOS 12.0, *) {
+            content.interruptionLevel = .timeSensitive
+        }
+
+        // Repeats for the same reminder replace the previous notification
+        var identifier = UUID().uuidString
+        if let id = option("id"), let nancy = option("nancy") {
+            identifier = id
+            content.categoryIdentifier = reminderCategory
+            content.userInfo = ["id": id, "nancy": nancy]
+            center.setNotificationCategories([
+                UNNotificationCategory(
+                    identifier: reminderCategory,
+                    actions: [
+                        UNNotificationAction(identifier: "complete", title: option("done-label") ?? "Done"),
+                        UNNotificationAction(identifier: "snooze", title: option("snooze-label") ?? "Snooze"),
+                    ],
+                    intentIdentifiers: []
+                ),
+            ])
+        }
+
+        let request = UNNotificationRequest(identifier: identifier, content: content, trigger: nil)
+        center.add(request) { error in
+            if let error = error {
+                fail(error.localizedDescription)
+            }
+            exit(0)
+        }
+    }
+}
+
+let delegate = Delegate()
+center.delegate = delegate
+
+let app = NSApplication.shared
+app.setActivationPolicy(.accessory)
+
+switch CommandLine.arguments.dropFirst().first {
+case "status":
+    center.getNotificationSettings { settings in
+        print(statusName(settings.authorizationStatus))
+        exit(0)
+    }
+case "send":
+    send()
+case nil:
+    // Launched by a click on a notification; the delegate takes it from here
+    DispatchQueue.main.asyncAfter(deadline: .now() + 30) { exit(0) }
+default:
+    fail("usage: nancy-notifier status | send --title T --message M", code: 2)
+}
+
+app.run()
@@ -0,0 +1,1253 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: nancy/v1/reminders.proto
+
+// gRPC interface of 'nancy serve', next to the REST API under /api/v1. It
+// offers the same operations, plus a stream of store changes for long-lived
+// integrations.
+//
+// Regenerate the Go code in pkg/nancy/v1 with 'make proto'.
+
+package nancyv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Priority int32
+
+const (
+	Priority_PRIORITY_UNSPECIFIED Priority = 0 // Medium when creating a reminder
+	Priority_PRIORITY_LOW         Priority = 1
+	Priority_PRIORITY_MEDIUM      Priority = 2
+	Priority_PRIORITY_HIGH        Priority = 3
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_MEDIUM",
+		3: "PRIORITY_HIGH",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_LOW":         1,
+		"PRIORITY_MEDIUM":      2,
+		"PRIORITY_HIGH":        3,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_nancy_v1_reminders_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_nancy_v1_reminders_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{0}
+}
+
+type EventKind int32
+
+const (
+	EventKind_EVENT_KIND_UNSPECIFIED EventKind = 0
+	EventKind_EVENT_KIND_ADDED       EventKind = 1
+	EventKind_EVENT_KIND_UPDATED     EventKind = 2
+	EventKind_EVENT_KIND_COMPLETED   EventKind = 3
+	EventKind_EVENT_KIND_DELETED     EventKind = 4
+	EventKind_EVENT_KIND_RELOADED    EventKind = 5 // Another process changed the store; anything may have changed
+)
+
+// Enum value maps for EventKind.
+var (
+	EventKind_name = map[int32]string{
+		0: "EVENT_KIND_UNSPECIFIED",
+		1: "EVENT_KIND_ADDED",
+		2: "EVENT_KIND_UPDATED",
+		3: "EVENT_KIND_COMPLETED",
+		4: "EVENT_KIND_DELETED",
+		5: "EVENT_KIND_RELOADED",
+	}
+	EventKind_value = map[string]int32{
+		"EVENT_KIND_UNSPECIFIED": 0,
+		"EVENT_KIND_ADDED":       1,
+		"EVENT_KIND_UPDATED":     2,
+		"EVENT_KIND_COMPLETED":   3,
+		"EVENT_KIND_DELETED":     4,
+		"EVENT_KIND_RELOADED":    5,
+	}
+)
+
+func (x EventKind) Enum() *EventKind {
+	p := new(EventKind)
+	*p = x
+	return p
+}
+
+func (x EventKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_nancy_v1_reminders_proto_enumTypes[1].Descriptor()
+}
+
+func (EventKind) Type() protoreflect.EnumType {
+	return &file_nancy_v1_reminders_proto_enumTypes[1]
+}
+
+func (x EventKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventKind.Descriptor instead.
+func (EventKind) EnumDescriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{1}
+}
+
+// Reminder mirrors a reminder in the export format
+type Reminder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShortId       int32                  `protobuf:"varint,2,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	DueTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=due_time,json=dueTime,proto3" json:"due_time,omitempty"`
+	Priority      Priority               `protobuf:"varint,6,opt,name=priority,proto3,enum=nancy.v1.Priority" json:"priority,omitempty"`
+	Completed     bool                   `protobuf:"varint,7,opt,name=completed,proto3" json:"completed,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Tags          []string               `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	Recurring     *RecurringRule         `protobuf:"bytes,12,opt,name=recurring,proto3" json:"recurring,omitempty"`
+	Assignee      string                 `protobuf:"bytes,13,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Archived      bool                   `protobuf:"varint,14,opt,name=archived,proto3" json:"archived,omitempty"`
+	History       []*Occurrence          `protobuf:"bytes,15,rep,name=history,proto3" json:"history,omitempty"`
+	NotifyBefore  int32                  `protobuf:"varint,16,opt,name=notify_before,json=notifyBefore,proto3" json:"notify_before,omitempty"` // Due-soon window in minutes, 0 = default
+	Url           string                 `protobuf:"bytes,17,opt,name=url,proto3" json:"url,omitempty"`
+	Issue         string                 `protobuf:"bytes,18,opt,name=issue,proto3" json:"issue,omitempty"`
+	SunEvent      string                 `protobuf:"bytes,19,opt,name=sun_event,json=sunEvent,proto3" json:"sun_event,omitempty"` // "sunrise" or "sunset"
+	Critical      bool                   `protobuf:"varint,20,opt,name=critical,proto3" json:"critical,omitempty"`
+	Color         string                 `protobuf:"bytes,21,opt,name=color,proto3" json:"color,omitempty"`
+	TimeLog       []*TimeEntry           `protobuf:"bytes,22,rep,name=time_log,json=timeLog,proto3" json:"time_log,omitempty"`
+	Input         string                 `protobuf:"bytes,23,opt,name=input,proto3" json:"input,omitempty"`
+	CheckIn       string                 `protobuf:"bytes,24,opt,name=check_in,json=checkIn,proto3" json:"check_in,omitempty"` // daily, weekly, biweekly or monthly
+	LastCheckIn   *timestamppb.Timestamp `protobuf:"bytes,25,opt,name=last_check_in,json=lastCheckIn,proto3" json:"last_check_in,omitempty"`
+	Nag           int32                  `protobuf:"varint,26,opt,name=nag,proto3" json:"nag,omitempty"`                          // Minutes between overdue notifications, 0 = hourly
+	Sticky        bool                   `protobuf:"varint,27,opt,name=sticky,proto3" json:"sticky,omitempty"`                    // Notifications stay until acted on
+	Grace         *int32                 `protobuf:"varint,28,opt,name=grace,proto3,oneof" json:"grace,omitempty"`                // Minutes overdue before the first nag, unset = the configured grace
+	DoneWhen      string                 `protobuf:"bytes,29,opt,name=done_when,json=doneWhen,proto3" json:"done_when,omitempty"` // Shell command that completes the reminder once it exits 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reminder) Reset() {
+	*x = Reminder{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reminder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reminder) ProtoMessage() {}
+
+func (x *Reminder) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reminder.ProtoReflect.Descriptor instead.
+func (*Reminder) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Reminder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reminder) GetShortId() int32 {
+	if x != nil {
+		return x.ShortId
+	}
+	return 0
+}
+
+func (x *Reminder) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Reminder) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Reminder) GetDueTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueTime
+	}
+	return nil
+}
+
+func (x *Reminder) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *Reminder) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *Reminder) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Reminder) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Reminder) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Reminder) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Reminder) GetRecurring() *RecurringRule {
+	if x != nil {
+		return x.Recurring
+	}
+	return nil
+}
+
+func (x *Reminder) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *Reminder) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Reminder) GetHistory() []*Occurrence {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Reminder) GetNotifyBefore() int32 {
+	if x != nil {
+		return x.NotifyBefore
+	}
+	return 0
+}
+
+func (x *Reminder) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Reminder) GetIssue() string {
+	if x != nil {
+		return x.Issue
+	}
+	return ""
+}
+
+func (x *Reminder) GetSunEvent() string {
+	if x != nil {
+		return x.SunEvent
+	}
+	return ""
+}
+
+func (x *Reminder) GetCritical() bool {
+	if x != nil {
+		return x.Critical
+	}
+	return false
+}
+
+func (x *Reminder) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Reminder) GetTimeLog() []*TimeEntry {
+	if x != nil {
+		return x.TimeLog
+	}
+	return nil
+}
+
+func (x *Reminder) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *Reminder) GetCheckIn() string {
+	if x != nil {
+		return x.CheckIn
+	}
+	return ""
+}
+
+func (x *Reminder) GetLastCheckIn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCheckIn
+	}
+	return nil
+}
+
+func (x *Reminder) GetNag() int32 {
+	if x != nil {
+		return x.Nag
+	}
+	return 0
+}
+
+func (x *Reminder) GetSticky() bool {
+	if x != nil {
+		return x.Sticky
+	}
+	return false
+}
+
+func (x *Reminder) GetGrace() int32 {
+	if x != nil && x.Grace != nil {
+		return *x.Grace
+	}
+	return 0
+}
+
+func (x *Reminder) GetDoneWhen() string {
+	if x != nil {
+		return x.DoneWhen
+	}
+	return ""
+}
+
+type RecurringRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frequency     string                 `protobuf:"bytes,1,opt,name=frequency,proto3" json:"frequency,omitempty"` // daily, weekdays, weekly, monthly
+	Interval      int32                  `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Occurrence    int32                  `protobuf:"varint,5,opt,name=occurrence,proto3" json:"occurrence,omitempty"`
+	Final         bool                   `protobuf:"varint,6,opt,name=final,proto3" json:"final,omitempty"`
+	Paused        bool                   `protobuf:"varint,7,opt,name=paused,proto3" json:"paused,omitempty"`
+	Exclude       []string               `protobuf:"bytes,8,rep,name=exclude,proto3" json:"exclude,omitempty"` // YYYY-MM-DD
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecurringRule) Reset() {
+	*x = RecurringRule{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecurringRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecurringRule) ProtoMessage() {}
+
+func (x *RecurringRule) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecurringRule.ProtoReflect.Descriptor instead.
+func (*RecurringRule) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RecurringRule) GetFrequency() string {
+	if x != nil {
+		return x.Frequency
+	}
+	return ""
+}
+
+func (x *RecurringRule) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *RecurringRule) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *RecurringRule) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RecurringRule) GetOccurrence() int32 {
+	if x != nil {
+		return x.Occurrence
+	}
+	return 0
+}
+
+func (x *RecurringRule) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+func (x *RecurringRule) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *RecurringRule) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+// Occurrence is a past occurrence of a recurring reminder
+type Occurrence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DueTime       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=due_time,json=dueTime,proto3" json:"due_time,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // on_time, late or skipped
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Occurrence) Reset() {
+	*x = Occurrence{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Occurrence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Occurrence) ProtoMessage() {}
+
+func (x *Occurrence) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Occurrence.ProtoReflect.Descriptor instead.
+func (*Occurrence) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Occurrence) GetDueTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueTime
+	}
+	return nil
+}
+
+func (x *Occurrence) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *Occurrence) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// TimeEntry is a work session from 'nancy start'/'nancy stop'
+type TimeEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeEntry) Reset() {
+	*x = TimeEntry{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeEntry) ProtoMessage() {}
+
+func (x *TimeEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeEntry.ProtoReflect.Descriptor instead.
+func (*TimeEntry) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TimeEntry) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *TimeEntry) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type ListRemindersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRemindersRequest) Reset() {
+	*x = ListRemindersRequest{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRemindersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRemindersRequest) ProtoMessage() {}
+
+func (x *ListRemindersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRemindersRequest.ProtoReflect.Descriptor instead.
+func (*ListRemindersRequest) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{4}
+}
+
+type ListRemindersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reminders     []*Reminder            `protobuf:"bytes,1,rep,name=reminders,proto3" json:"reminders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRemindersResponse) Reset() {
+	*x = ListRemindersResponse{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRemindersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRemindersResponse) ProtoMessage() {}
+
+func (x *ListRemindersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRemindersResponse.ProtoReflect.Descriptor instead.
+func (*ListRemindersResponse) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRemindersResponse) GetReminders() []*Reminder {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+type GetReminderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // ID or short ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReminderRequest) Reset() {
+	*x = GetReminderRequest{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReminderRequest) ProtoMessage() {}
+
+func (x *GetReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReminderRequest.ProtoReflect.Descriptor instead.
+func (*GetReminderRequest) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetReminderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateReminderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reminder      *Reminder              `protobuf:"bytes,1,opt,name=reminder,proto3" json:"reminder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReminderRequest) Reset() {
+	*x = CreateReminderRequest{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReminderRequest) ProtoMessage() {}
+
+func (x *CreateReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReminderRequest.ProtoReflect.Descriptor instead.
+func (*CreateReminderRequest) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateReminderRequest) GetReminder() *Reminder {
+	if x != nil {
+		return x.Reminder
+	}
+	return nil
+}
+
+type PutReminderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reminder      *Reminder              `protobuf:"bytes,1,opt,name=reminder,proto3" json:"reminder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutReminderRequest) Reset() {
+	*x = PutReminderRequest{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutReminderRequest) ProtoMessage() {}
+
+func (x *PutReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutReminderRequest.ProtoReflect.Descriptor instead.
+func (*PutReminderRequest) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PutReminderRequest) GetReminder() *Reminder {
+	if x != nil {
+		return x.Reminder
+	}
+	return nil
+}
+
+type DeleteReminderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // ID or short ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteReminderRequest) Reset() {
+	*x = DeleteReminderRequest{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReminderRequest) ProtoMessage() {}
+
+func (x *DeleteReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReminderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteReminderRequest) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteReminderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteReminderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteReminderResponse) Reset() {
+	*x = DeleteReminderResponse{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteReminderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReminderResponse) ProtoMessage() {}
+
+func (x *DeleteReminderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReminderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteReminderResponse) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{10}
+}
+
+type CompleteReminderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // ID or short ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteReminderRequest) Reset() {
+	*x = CompleteReminderRequest{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteReminderRequest) ProtoMessage() {}
+
+func (x *CompleteReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteReminderRequest.ProtoReflect.Descriptor instead.
+func (*CompleteReminderRequest) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CompleteReminderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{12}
+}
+
+// Event is a change to the store
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          EventKind              `protobuf:"varint,1,opt,name=kind,proto3,enum=nancy.v1.EventKind" json:"kind,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`             // Empty for EVENT_KIND_RELOADED
+	Reminder      *Reminder              `protobuf:"bytes,3,opt,name=reminder,proto3" json:"reminder,omitempty"` // The reminder after the change; unset if deleted or reloaded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_nancy_v1_reminders_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_nancy_v1_reminders_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_nancy_v1_reminders_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Event) GetKind() EventKind {
+	if x != nil {
+		return x.Kind
+	}
+	return EventKind_EVENT_KIND_UNSPECIFIED
+}
+
+func (x *Event) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event) GetReminder() *Reminder {
+	if x != nil {
+		return x.Reminder
+	}
+	return nil
+}
+
+var File_nancy_v1_reminders_proto protoreflect.FileDescriptor
+
+const file_nancy_v1_reminders_proto_rawDesc = "" +
+	"\n" +
+	"\x18nancy/v1/reminders.proto\x12\bnancy.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x83\b\n" +
+	"\bReminder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bshort_id\x18\x02 \x01(\x05R\ashortId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x125\n" +
+	"\bdue_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\adueTime\x12.\n" +
+	"\bpriority\x18\x06 \x01(\x0e2\x12.nancy.v1.PriorityR\bpriority\x12\x1c\n" +
+	"\tcompleted\x18\a \x01(\bR\tcompleted\x12=\n" +
+	"\fcompleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags\x125\n" +
+	"\trecurring\x18\f \x01(\v2\x17.nancy.v1.RecurringRuleR\trecurring\x12\x1a\n" +
+	"\bassignee\x18\r \x01(\tR\bassignee\x12\x1a\n" +
+	"\barchived\x18\x0e \x01(\bR\barchived\x12.\n" +
+	"\ahistory\x18\x0f \x03(\v2\x14.nancy.v1.OccurrenceR\ahistory\x12#\n" +
+	"\rnotify_before\x18\x10 \x01(\x05R\fnotifyBefore\x12\x10\n" +
+	"\x03url\x18\x11 \x01(\tR\x03url\x12\x14\n" +
+	"\x05issue\x18\x12 \x01(\tR\x05issue\x12\x1b\n" +
+	"\tsun_event\x18\x13 \x01(\tR\bsunEvent\x12\x1a\n" +
+	"\bcritical\x18\x14 \x01(\bR\bcritical\x12\x14\n" +
+	"\x05color\x18\x15 \x01(\tR\x05color\x12.\n" +
+	"\btime_log\x18\x16 \x03(\v2\x13.nancy.v1.TimeEntryR\atimeLog\x12\x14\n" +
+	"\x05input\x18\x17 \x01(\tR\x05input\x12\x19\n" +
+	"\bcheck_in\x18\x18 \x01(\tR\acheckIn\x12>\n" +
+	"\rlast_check_in\x18\x19 \x01(\v2\x1a.google.protobuf.TimestampR\vlastCheckIn\x12\x10\n" +
+	"\x03nag\x18\x1a \x01(\x05R\x03nag\x12\x16\n" +
+	"\x06sticky\x18\x1b \x01(\bR\x06sticky\x12\x19\n" +
+	"\x05grace\x18\x1c \x01(\x05H\x00R\x05grace\x88\x01\x01\x12\x1b\n" +
+	"\tdone_when\x18\x1d \x01(\tR\bdoneWhenB\b\n" +
+	"\x06_grace\"\xfe\x01\n" +
+	"\rRecurringRule\x12\x1c\n" +
+	"\tfrequency\x18\x01 \x01(\tR\tfrequency\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\x05R\binterval\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\x12\x1e\n" +
+	"\n" +
+	"occurrence\x18\x05 \x01(\x05R\n" +
+	"occurrence\x12\x14\n" +
+	"\x05final\x18\x06 \x01(\bR\x05final\x12\x16\n" +
+	"\x06paused\x18\a \x01(\bR\x06paused\x12\x18\n" +
+	"\aexclude\x18\b \x03(\tR\aexclude\"\x87\x01\n" +
+	"\n" +
+	"Occurrence\x125\n" +
+	"\bdue_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\adueTime\x12*\n" +
+	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"k\n" +
+	"\tTimeEntry\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"\x16\n" +
+	"\x14ListRemindersRequest\"I\n" +
+	"\x15ListRemindersResponse\x120\n" +
+	"\treminders\x18\x01 \x03(\v2\x12.nancy.v1.ReminderR\treminders\"$\n" +
+	"\x12GetReminderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x15CreateReminderRequest\x12.\n" +
+	"\breminder\x18\x01 \x01(\v2\x12.nancy.v1.ReminderR\breminder\"D\n" +
+	"\x12PutReminderRequest\x12.\n" +
+	"\breminder\x18\x01 \x01(\v2\x12.nancy.v1.ReminderR\breminder\"'\n" +
+	"\x15DeleteReminderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16DeleteReminderResponse\")\n" +
+	"\x17CompleteReminderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x0e\n" +
+	"\fWatchRequest\"p\n" +
+	"\x05Event\x12'\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x13.nancy.v1.EventKindR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12.\n" +
+	"\breminder\x18\x03 \x01(\v2\x12.nancy.v1.ReminderR\breminder*^\n" +
+	"\bPriority\x12\x18\n" +
+	"\x14PRIORITY_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x01\x12\x13\n" +
+	"\x0fPRIORITY_MEDIUM\x10\x02\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x03*\xa0\x01\n" +
+	"\tEventKind\x12\x1a\n" +
+	"\x16EVENT_KIND_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10EVENT_KIND_ADDED\x10\x01\x12\x16\n" +
+	"\x12EVENT_KIND_UPDATED\x10\x02\x12\x18\n" +
+	"\x14EVENT_KIND_COMPLETED\x10\x03\x12\x16\n" +
+	"\x12EVENT_KIND_DELETED\x10\x04\x12\x17\n" +
+	"\x13EVENT_KIND_RELOADED\x10\x052\xfa\x03\n" +
+	"\tReminders\x12P\n" +
+	"\rListReminders\x12\x1e.nancy.v1.ListRemindersRequest\x1a\x1f.nancy.v1.ListRemindersResponse\x12?\n" +
+	"\vGetReminder\x12\x1c.nancy.v1.GetReminderRequest\x1a\x12.nancy.v1.Reminder\x12E\n" +
+	"\x0eCreateReminder\x12\x1f.nancy.v1.CreateReminderRequest\x1a\x12.nancy.v1.Reminder\x12?\n" +
+	"\vPutReminder\x12\x1c.nancy.v1.PutReminderRequest\x1a\x12.nancy.v1.Reminder\x12S\n" +
+	"\x0eDeleteReminder\x12\x1f.nancy.v1.DeleteReminderRequest\x1a .nancy.v1.DeleteReminderResponse\x12I\n" +
+	"\x10CompleteReminder\x12!.nancy.v1.CompleteReminderRequest\x1a\x12.nancy.v1.Reminder\x122\n" +
+	"\x05Watch\x12\x16.nancy.v1.WatchRequest\x1a\x0f.nancy.v1.Event0\x01B=Z;github.com/ivyascorp-net/nagging-nancy/pkg/nancy/v1;nancyv1b\x06proto3"
+
+var (
+	file_nancy_v1_reminders_proto_rawDescOnce sync.Once
+	file_nancy_v1_reminders_proto_rawDescData []byte
+)
+
+func file_nancy_v1_reminders_proto_rawDescGZIP() []byte {
+	file_nancy_v1_reminders_proto_rawDescOnce.Do(func() {
+		file_nancy_v1_reminders_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_nancy_v1_reminders_proto_rawDesc), len(file_nancy_v1_reminders_proto_rawDesc)))
+	})
+	return file_nancy_v1_reminders_proto_rawDescData
+}
+
+var file_nancy_v1_reminders_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_nancy_v1_reminders_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_nancy_v1_reminders_proto_goTypes = []any{
+	(Priority)(0),                   // 0: nancy.v1.Priority
+	(EventKind)(0),                  // 1: nancy.v1.EventKind
+	(*Reminder)(nil),                // 2: nancy.v1.Reminder
+	(*RecurringRule)(nil),           // 3: nancy.v1.RecurringRule
+	(*Occurrence)(nil),              // 4: nancy.v1.Occurrence
+	(*TimeEntry)(nil),               // 5: nancy.v1.TimeEntry
+	(*ListRemindersRequest)(nil),    // 6: nancy.v1.ListRemindersRequest
+	(*ListRemindersResponse)(nil),   // 7: nancy.v1.ListRemindersResponse
+	(*GetReminderRequest)(nil),      // 8: nancy.v1.GetReminderRequest
+	(*CreateReminderRequest)(nil),   // 9: nancy.v1.CreateReminderRequest
+	(*PutReminderRequest)(nil),      // 10: nancy.v1.PutReminderRequest
+	(*DeleteReminderRequest)(nil),   // 11: nancy.v1.DeleteReminderRequest
+	(*DeleteReminderResponse)(nil),  // 12: nancy.v1.DeleteReminderResponse
+	(*CompleteReminderRequest)(nil), // 13: nancy.v1.CompleteReminderRequest
+	(*WatchRequest)(nil),            // 14: nancy.v1.WatchRequest
+	(*Event)(nil),                   // 15: nancy.v1.Event
+	(*timestamppb.Timestamp)(nil),   // 16: google.protobuf.Timestamp
+}
+var file_nancy_v1_reminders_proto_depIdxs = []int32{
+	16, // 0: nancy.v1.Reminder.due_time:type_name -> google.protobuf.Timestamp
+	0,  // 1: nancy.v1.Reminder.priority:type_name -> nancy.v1.Priority
+	16, // 2: nancy.v1.Reminder.completed_at:type_name -> google.protobuf.Timestamp
+	16, // 3: nancy.v1.Reminder.created_at:type_name -> google.protobuf.Timestamp
+	16, // 4: nancy.v1.Reminder.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 5: nancy.v1.Reminder.recurring:type_name -> nancy.v1.RecurringRule
+	4,  // 6: nancy.v1.Reminder.history:type_name -> nancy.v1.Occurrence
+	5,  // 7: nancy.v1.Reminder.time_log:type_name -> nancy.v1.TimeEntry
+	16, // 8: nancy.v1.Reminder.last_check_in:type_name -> google.protobuf.Timestamp
+	16, // 9: nancy.v1.RecurringRule.end_date:type_name -> google.protobuf.Timestamp
+	16, // 10: nancy.v1.Occurrence.due_time:type_name -> google.protobuf.Timestamp
+	16, // 11: nancy.v1.Occurrence.at:type_name -> google.protobuf.Timestamp
+	16, // 12: nancy.v1.TimeEntry.start:type_name -> google.protobuf.Timestamp
+	16, // 13: nancy.v1.TimeEntry.end:type_name -> google.protobuf.Timestamp
+	2,  // 14: nancy.v1.ListRemindersResponse.reminders:type_name -> nancy.v1.Reminder
+	2,  // 15: nancy.v1.CreateReminderRequest.reminder:type_name -> nancy.v1.Reminder
+	2,  // 16: nancy.v1.PutReminderRequest.reminder:type_name -> nancy.v1.Reminder
+	1,  // 17: nancy.v1.Event.kind:type_name -> nancy.v1.EventKind
+	2,  // 18: nancy.v1.Event.reminder:type_name -> nancy.v1.Reminder
+	6,  // 19: nancy.v1.Reminders.ListReminders:input_type -> nancy.v1.ListRemindersRequest
+	8,  // 20: nancy.v1.Reminders.GetReminder:input_type -> nancy.v1.GetReminderRequest
+	9,  // 21: nancy.v1.Reminders.CreateReminder:input_type -> nancy.v1.CreateReminderRequest
+	10, // 22: nancy.v1.Reminders.PutReminder:input_type -> nancy.v1.PutReminderRequest
+	11, // 23: nancy.v1.Reminders.DeleteReminder:input_type -> nancy.v1.DeleteReminderRequest
+	13, // 24: nancy.v1.Reminders.CompleteReminder:input_type -> nancy.v1.CompleteReminderRequest
+	14, // 25: nancy.v1.Reminders.Watch:input_type -> nancy.v1.WatchRequest
+	7,  // 26: nancy.v1.Reminders.ListReminders:output_type -> nancy.v1.ListRemindersResponse
+	2,  // 27: nancy.v1.Reminders.GetReminder:output_type -> nancy.v1.Reminder
+	2,  // 28: nancy.v1.Reminders.CreateReminder:output_type -> nancy.v1.Reminder
+	2,  // 29: nancy.v1.Reminders.PutReminder:output_type -> nancy.v1.Reminder
+	12, // 30: nancy.v1.Reminders.DeleteReminder:output_type -> nancy.v1.DeleteReminderResponse
+	2,  // 31: nancy.v1.Reminders.CompleteReminder:output_type -> nancy.v1.Reminder
+	15, // 32: nancy.v1.Reminders.Watch:output_type -> nancy.v1.Event
+	26, // [26:33] is the sub-list for method output_type
+	19, // [19:26] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_nancy_v1_reminders_proto_init() }
+func file_nancy_v1_reminders_proto_init() {
+	if File_nancy_v1_reminders_proto != nil {
+		return
+	}
+	file_nancy_v1_reminders_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_nancy_v1_reminders_proto_rawDesc), len(file_nancy_v1_reminders_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nancy_v1_reminders_proto_goTypes,
+		DependencyIndexes: file_nancy_v1_reminders_proto_depIdxs,
+		EnumInfos:         file_nancy_v1_reminders_proto_enumTypes,
+		MessageInfos:      file_nancy_v1_reminders_proto_msgTypes,
+	}.Build()
+	File_nancy_v1_reminders_proto = out.File
+	file_nancy_v1_reminders_proto_goTypes = nil
+	file_nancy_v1_reminders_proto_depIdxs = nil
+}
@@ -0,0 +1,391 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: nancy/v1/reminders.proto
+
+// gRPC interface of 'nancy serve', next to the REST API under /api/v1. It
+// offers the same operations, plus a stream of store changes for long-lived
+// integrations.
+//
+// Regenerate the Go code in pkg/nancy/v1 with 'make proto'.
+
+package nancyv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Reminders_ListReminders_FullMethodName    = "/nancy.v1.Reminders/ListReminders"
+	Reminders_GetReminder_FullMethodName      = "/nancy.v1.Reminders/GetReminder"
+	Reminders_CreateReminder_FullMethodName   = "/nancy.v1.Reminders/CreateReminder"
+	Reminders_PutReminder_FullMethodName      = "/nancy.v1.Reminders/PutReminder"
+	Reminders_DeleteReminder_FullMethodName   = "/nancy.v1.Reminders/DeleteReminder"
+	Reminders_CompleteReminder_FullMethodName = "/nancy.v1.Reminders/CompleteReminder"
+	Reminders_Watch_FullMethodName            = "/nancy.v1.Reminders/Watch"
+)
+
+// RemindersClient is the client API for Reminders service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Reminders serves the reminders of a 'nancy serve' instance. With a token
+// set on the server, every call must carry "authorization: Bearer <token>"
+// metadata.
+type RemindersClient interface {
+	// ListReminders returns every reminder, completed and archived included,
+	// in short ID order.
+	ListReminders(ctx context.Context, in *ListRemindersRequest, opts ...grpc.CallOption) (*ListRemindersResponse, error)
+	// GetReminder returns one reminder, by ID or short ID.
+	GetReminder(ctx context.Context, in *GetReminderRequest, opts ...grpc.CallOption) (*Reminder, error)
+	// CreateReminder adds a reminder. The server picks its ID and short ID;
+	// use PutReminder to keep an ID of your own.
+	CreateReminder(ctx context.Context, in *CreateReminderRequest, opts ...grpc.CallOption) (*Reminder, error)
+	// PutReminder adds or replaces the reminder with the given ID.
+	PutReminder(ctx context.Context, in *PutReminderRequest, opts ...grpc.CallOption) (*Reminder, error)
+	// DeleteReminder deletes a reminder, by ID or short ID.
+	DeleteReminder(ctx context.Context, in *DeleteReminderRequest, opts ...grpc.CallOption) (*DeleteReminderResponse, error)
+	// CompleteReminder completes a reminder, by ID or short ID. Recurring
+	// reminders move on to their next occurrence.
+	CompleteReminder(ctx context.Context, in *CompleteReminderRequest, opts ...grpc.CallOption) (*Reminder, error)
+	// Watch streams every change to the store until the client hangs up. A
+	// client that falls behind misses events, so refresh with ListReminders
+	// rather than replay them, and on EVENT_KIND_RELOADED.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type remindersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRemindersClient(cc grpc.ClientConnInterface) RemindersClient {
+	return &remindersClient{cc}
+}
+
+func (c *remindersClient) ListReminders(ctx context.Context, in *ListRemindersRequest, opts ...grpc.CallOption) (*ListRemindersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRemindersResponse)
+	err := c.cc.Invoke(ctx, Reminders_ListReminders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindersClient) GetReminder(ctx context.Context, in *GetReminderRequest, opts ...grpc.CallOption) (*Reminder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reminder)
+	err := c.cc.Invoke(ctx, Reminders_GetReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindersClient) CreateReminder(ctx context.Context, in *CreateReminderRequest, opts ...grpc.CallOption) (*Reminder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reminder)
+	err := c.cc.Invoke(ctx, Reminders_CreateReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindersClient) PutReminder(ctx context.Context, in *PutReminderRequest, opts ...grpc.CallOption) (*Reminder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reminder)
+	err := c.cc.Invoke(ctx, Reminders_PutReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindersClient) DeleteReminder(ctx context.Context, in *DeleteReminderRequest, opts ...grpc.CallOption) (*DeleteReminderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteReminderResponse)
+	err := c.cc.Invoke(ctx, Reminders_DeleteReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindersClient) CompleteReminder(ctx context.Context, in *CompleteReminderRequest, opts ...grpc.CallOption) (*Reminder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reminder)
+	err := c.cc.Invoke(ctx, Reminders_CompleteReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindersClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Reminders_ServiceDesc.Streams[0], Reminders_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Reminders_WatchClient = grpc.ServerStreamingClient[Event]
+
+// RemindersServer is the server API for Reminders service.
+// All implementations must embed UnimplementedRemindersServer
+// for forward compatibility.
+//
+// Reminders serves the reminders of a 'nancy serve' instance. With a token
+// set on the server, every call must carry "authorization: Bearer <token>"
+// metadata.
+type RemindersServer interface {
+	// ListReminders returns every reminder, completed and archived included,
+	// in short ID order.
+	ListReminders(context.Context, *ListRemindersRequest) (*ListRemindersResponse, error)
+	// GetReminder returns one reminder, by ID or short ID.
+	GetReminder(context.Context, *GetReminderRequest) (*Reminder, error)
+	// CreateReminder adds a reminder. The server picks its ID and short ID;
+	// use PutReminder to keep an ID of your own.
+	CreateReminder(context.Context, *CreateReminderRequest) (*Reminder, error)
+	// PutReminder adds or replaces the reminder with the given ID.
+	PutReminder(context.Context, *PutReminderRequest) (*Reminder, error)
+	// DeleteReminder deletes a reminder, by ID or short ID.
+	DeleteReminder(context.Context, *DeleteReminderRequest) (*DeleteReminderResponse, error)
+	// CompleteReminder completes a reminder, by ID or short ID. Recurring
+	// reminders move on to their next occurrence.
+	CompleteReminder(context.Context, *CompleteReminderRequest) (*Reminder, error)
+	// Watch streams every change to the store until the client hangs up. A
+	// client that falls behind misses events, so refresh with ListReminders
+	// rather than replay them, and on EVENT_KIND_RELOADED.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedRemindersServer()
+}
+
+// UnimplementedRemindersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRemindersServer struct{}
+
+func (UnimplementedRemindersServer) ListReminders(context.Context, *ListRemindersRequest) (*ListRemindersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReminders not implemented")
+}
+func (UnimplementedRemindersServer) GetReminder(context.Context, *GetReminderRequest) (*Reminder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReminder not implemented")
+}
+func (UnimplementedRemindersServer) CreateReminder(context.Context, *CreateReminderRequest) (*Reminder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReminder not implemented")
+}
+func (UnimplementedRemindersServer) PutReminder(context.Context, *PutReminderRequest) (*Reminder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutReminder not implemented")
+}
+func (UnimplementedRemindersServer) DeleteReminder(context.Context, *DeleteReminderRequest) (*DeleteReminderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteReminder not implemented")
+}
+func (UnimplementedRemindersServer) CompleteReminder(context.Context, *CompleteReminderRequest) (*Reminder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteReminder not implemented")
+}
+func (UnimplementedRemindersServer) Watch(*WatchRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedRemindersServer) mustEmbedUnimplementedRemindersServer() {}
+func (UnimplementedRemindersServer) testEmbeddedByValue()                   {}
+
+// UnsafeRemindersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RemindersServer will
+// result in compilation errors.
+type UnsafeRemindersServer interface {
+	mustEmbedUnimplementedRemindersServer()
+}
+
+func RegisterRemindersServer(s grpc.ServiceRegistrar, srv RemindersServer) {
+	// If the following call pancis, it indicates UnimplementedRemindersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Reminders_ServiceDesc, srv)
+}
+
+func _Reminders_ListReminders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRemindersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindersServer).ListReminders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reminders_ListReminders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindersServer).ListReminders(ctx, req.(*ListRemindersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reminders_GetReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindersServer).GetReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reminders_GetReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindersServer).GetReminder(ctx, req.(*GetReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reminders_CreateReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindersServer).CreateReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reminders_CreateReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindersServer).CreateReminder(ctx, req.(*CreateReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reminders_PutReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindersServer).PutReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reminders_PutReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindersServer).PutReminder(ctx, req.(*PutReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reminders_DeleteReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindersServer).DeleteReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reminders_DeleteReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindersServer).DeleteReminder(ctx, req.(*DeleteReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reminders_CompleteReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindersServer).CompleteReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reminders_CompleteReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindersServer).CompleteReminder(ctx, req.(*CompleteReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reminders_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemindersServer).Watch(m, &grpc.GenericServerStream[WatchRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Reminders_WatchServer = grpc.ServerStreamingServer[Event]
+
+// Reminders_ServiceDesc is the grpc.ServiceDesc for Reminders service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Reminders_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nancy.v1.Reminders",
+	HandlerType: (*RemindersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListReminders",
+			Handler:    _Reminders_ListReminders_Handler,
+		},
+		{
+			MethodName: "GetReminder",
+			Handler:    _Reminders_GetReminder_Handler,
+		},
+		{
+			MethodName: "CreateReminder",
+			Handler:    _Reminders_CreateReminder_Handler,
+		},
+		{
+			MethodName: "PutReminder",
+			Handler:    _Reminders_PutReminder_Handler,
+		},
+		{
+			MethodName: "DeleteReminder",
+			Handler:    _Reminders_DeleteReminder_Handler,
+		},
+		{
+			MethodName: "CompleteReminder",
+			Handler:    _Reminders_CompleteReminder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Reminders_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nancy/v1/reminders.proto",
+}
@@ -88,6 +88,20 @@ case "$(uname -s)" in
             echo "   To install Homebrew: /bin/bash -c \"\$(curl -fsSL https://raw.githubusercontent.com/Homebrew/install/HEAD/install.sh)\""
             echo "   Then run: brew install terminal-notifier"
         fi
+
+        # Nancy's own helper shows notifications under Nancy's name, with
+        # Done/Snooze buttons
+        if command -v swiftc &> /dev/null; then
+            echo "Building Nancy Notifier.app..."
+            make -C "$(dirname "$0")/.." macos-notifier
+            mkdir -p "$HOME/Applications"
+            rm -rf "$HOME/Applications/Nancy Notifier.app"
+            cp -R "$(dirname "$0")/../build/Nancy Notifier.app" "$HOME/Applications/"
+            echo "✅ Installed Nancy Notifier.app to ~/Applications"
+        else
+            echo "⚠️  swiftc not found; skipping Nancy Notifier.app."
+            echo "   Install the Xcode command line tools with: xcode-select --install"
+        fi
         ;;
         
     CYGWIN*|MINGW*|MSYS*)
@@ -0,0 +1,136 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/tui/components"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// benchSizes are the store sizes the suite runs at, from a typical store to
+// far beyond a decade of use
+var benchSizes = []int{1000, 10000, 100000}
+
+// checkBudget fails the benchmark when an operation took longer on average
+// than budget. Budgets are generous, around ten times what a laptop
+// manages, so they only catch regressions in how the work scales.
+func checkBudget(b *testing.B, budget time.Duration) {
+	b.Helper()
+	if b.N == 0 {
+		return
+	}
+	if perOp := b.Elapsed() / time.Duration(b.N); perOp > budget {
+		b.Errorf("%s per operation, over the budget of %s", perOp, budget)
+	}
+}
+
+// perThousand scales a budget for 1,000 reminders to n
+func perThousand(budget time.Duration, n int) time.Duration {
+	return budget * time.Duration(n) / 1000
+}
+
+// benchStore loads a synthetic store of n reminders
+func benchStore(b *testing.B, n int) *models.Store {
+	b.Helper()
+	store, err := models.NewStore(writeSyntheticStore(b, n))
+	if err != nil {
+		b.Fatalf("NewStore: %v", err)
+	}
+	return store
+}
+
+func BenchmarkStoreGetAll(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			store := benchStore(b, n)
+			b.ResetTimer()
+			for b.Loop() {
+				store.GetAll(&models.FilterOptions{})
+			}
+			checkBudget(b, perThousand(200*time.Microsecond, n))
+		})
+	}
+}
+
+func BenchmarkStoreGetAllCompleted(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			store := benchStore(b, n)
+			b.ResetTimer()
+			for b.Loop() {
+				store.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})
+			}
+			checkBudget(b, perThousand(50*time.Millisecond, n))
+		})
+	}
+}
+
+func BenchmarkStoreSave(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			store := benchStore(b, n)
+			b.ResetTimer()
+			for b.Loop() {
+				if err := store.Save(); err != nil {
+					b.Fatalf("Save: %v", err)
+				}
+			}
+			checkBudget(b, perThousand(50*time.Millisecond, n))
+		})
+	}
+}
+
+func BenchmarkParseReminder(b *testing.B) {
+	inputs := []string{
+		"Call mom tomorrow at 3pm",
+		"Team meeting every monday at 10am #work !high",
+		"Pay rent on the 1st of every month",
+		"Water plants in 2 hours",
+		"Dentist next friday 9:30am priority:low",
+	}
+	now := time.Now()
+	for b.Loop() {
+		for _, input := range inputs {
+			utils.ParseReminderAt(input, models.Medium, now)
+		}
+	}
+	checkBudget(b, time.Duration(len(inputs))*time.Millisecond)
+}
+
+// BenchmarkListCommand runs 'nancy list' end to end against a store that
+// is already loaded
+func BenchmarkListCommand(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			h := newHarness(b)
+			writeSyntheticStoreTo(b, h.app.GetConfig().GetDataDir(), n)
+			if err := h.app.GetStore().Load(); err != nil {
+				b.Fatalf("Load: %v", err)
+			}
+			b.ResetTimer()
+			for b.Loop() {
+				h.mustRun("list", "--flat", "--no-color")
+			}
+			checkBudget(b, perThousand(5*time.Millisecond, n))
+		})
+	}
+}
+
+func BenchmarkReminderListView(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			store := benchStore(b, n)
+			list := components.NewReminderList()
+			list.SetHeight(40)
+			b.ResetTimer()
+			for b.Loop() {
+				list.SetItems(store.GetAll(&models.FilterOptions{}))
+				list.PageView()
+			}
+			checkBudget(b, perThousand(200*time.Microsecond, n))
+		})
+	}
+}
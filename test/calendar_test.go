@@ -0,0 +1,133 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Planning\r\n" +
+	"DTSTART:20250106T140000Z\r\n" +
+	"DTEND:20250106T150000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Stand\r\n" +
+	" up\r\n" +
+	"DTSTART:20250106T090000Z\r\n" +
+	"DURATION:PT15M\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=6\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Focus time\r\n" +
+	"DTSTART:20250107T100000Z\r\n" +
+	"DTEND:20250107T120000Z\r\n" +
+	"TRANSP:TRANSPARENT\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Holiday\r\n" +
+	"DTSTART;VALUE=DATE:20250108\r\n" +
+	"DTEND;VALUE=DATE:20250109\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestCalendarBusyAt(t *testing.T) {
+	calendar, err := utils.ParseICS([]byte(testICS))
+	if err != nil {
+		t.Fatalf("ParseICS: %v", err)
+	}
+
+	at := func(s string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		when string
+		want string // Busy event summary, "" when free
+	}{
+		{"2025-01-06T14:30:00Z", "Planning"},
+		{"2025-01-06T15:00:00Z", ""},
+		{"2025-01-06T09:05:00Z", "Standup"},
+		{"2025-01-08T09:10:00Z", "Standup"},
+		{"2025-01-07T09:05:00Z", ""}, // Not a BYDAY weekday
+		{"2025-01-15T09:05:00Z", "Standup"},
+		{"2025-01-17T09:05:00Z", "Standup"},
+		{"2025-01-20T09:05:00Z", ""}, // Past COUNT
+		{"2025-01-07T11:00:00Z", ""}, // Transparent
+		{"2025-01-08T12:00:00Z", ""}, // All-day
+	}
+	for _, tt := range tests {
+		event, busy := calendar.BusyAt(at(tt.when))
+		if got := map[bool]string{true: event.Summary}[busy]; got != tt.want {
+			t.Errorf("BusyAt(%s) = %q, want %q", tt.when, got, tt.want)
+		}
+	}
+
+	if _, err := utils.ParseICS([]byte("not a calendar")); err == nil {
+		t.Error("ParseICS should reject non-iCalendar input")
+	}
+}
+
+const testInvite = "BEGIN:VCALENDAR\r\n" +
+	"METHOD:REQUEST\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Quarterly planning\\, Q4\r\n" +
+	"DTSTART:20250106T140000Z\r\n" +
+	"DTEND:20250106T150000Z\r\n" +
+	"LOCATION:https://meet.example.com/abc\r\n" +
+	"ORGANIZER;CN=\"Dana Smith\":mailto:dana@example.com\r\n" +
+	"DESCRIPTION:Agenda:\\n- budget\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"DESCRIPTION:Reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseInvite(t *testing.T) {
+	invite, err := utils.ParseInvite([]byte(testInvite))
+	if err != nil {
+		t.Fatalf("ParseInvite: %v", err)
+	}
+	if invite.Summary != "Quarterly planning, Q4" || !invite.Start.Equal(time.Date(2025, 1, 6, 14, 0, 0, 0, time.UTC)) ||
+		invite.Location != "https://meet.example.com/abc" || invite.Organizer != "Dana Smith" ||
+		invite.Description != "Agenda:\n- budget" {
+		t.Errorf("ParseInvite = %+v", invite)
+	}
+
+	for name, ics := range map[string]string{
+		"cancelled": strings.Replace(testInvite, "METHOD:REQUEST", "METHOD:CANCEL", 1),
+		"all-day":   strings.Replace(testInvite, "DTSTART:20250106T140000Z", "DTSTART;VALUE=DATE:20250106", 1),
+		"no event":  "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+	} {
+		if _, err := utils.ParseInvite([]byte(ics)); err == nil {
+			t.Errorf("%s invite was accepted", name)
+		}
+	}
+}
+
+func TestAddFromInvite(t *testing.T) {
+	h := newHarness(t)
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Minute)
+	ics := strings.Replace(testInvite, "20250106T140000Z", start.Format("20060102T150405Z"), 1)
+
+	h.app.GetConfig().Default.InviteLead = 30
+	if _, err := h.runWithInput(ics, "add", "--ics", "-"); err != nil {
+		t.Fatalf("add --ics: %v", err)
+	}
+	r := h.reminder("Quarterly planning, Q4")
+	if !r.DueTime.Equal(start.Add(-30 * time.Minute)) {
+		t.Errorf("due %s, want 30 minutes before %s", r.DueTime, start)
+	}
+	if r.URL != "https://meet.example.com/abc" || !strings.Contains(r.Description, "Organizer: Dana Smith") ||
+		!strings.HasSuffix(r.Description, "Agenda:\n- budget") {
+		t.Errorf("URL %q, description %q", r.URL, r.Description)
+	}
+}
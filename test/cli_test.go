@@ -0,0 +1,510 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/cli"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestCLIAddEditComplete(t *testing.T) {
+	h := newHarness(t)
+
+	out := h.mustRun("add", "Water plants", "--date", "tomorrow", "--time", "09:00", "--priority", "high", "--tags", "home")
+	if !strings.Contains(out, "Water plants") {
+		t.Errorf("add printed:\n%s", out)
+	}
+	reminder := h.reminder("Water plants")
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	if reminder.Priority != models.High || !reminder.HasTag("home") ||
+		reminder.DueTime.Day() != tomorrow.Day() || reminder.DueTime.Hour() != 9 {
+		t.Errorf("added %+v", reminder)
+	}
+
+	// Flags don't carry over from one run to the next
+	h.mustRun("add", "Call mom", "--date", "tomorrow", "--time", "10:00")
+	if priority := h.reminder("Call mom").Priority; priority != models.Medium {
+		t.Errorf("second add has priority %s, want the default", priority)
+	}
+
+	if out := h.mustRun("list", "--flat"); !strings.Contains(out, "Water plants") || !strings.Contains(out, "Call mom") {
+		t.Errorf("list printed:\n%s", out)
+	}
+
+	h.mustRun("edit", "1", "--title", "Water the plants", "--shift", "+1h")
+	edited := h.reminder("Water the plants")
+	if edited.DueTime.Hour() != 10 {
+		t.Errorf("edited due time = %v, want 10:00", edited.DueTime)
+	}
+	if out := h.mustRun("show", "1"); !strings.Contains(out, "Water the plants") {
+		t.Errorf("show printed:\n%s", out)
+	}
+
+	h.mustRun("complete", "1")
+	if !h.reminder("Water the plants").Completed {
+		t.Error("reminder 1 is not completed")
+	}
+	if _, err := h.run("complete", "42"); err == nil {
+		t.Error("completing a missing reminder should fail")
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	h := newHarness(t)
+	h.mustRun("add", "Water plants", "--date", "tomorrow", "--time", "09:00")
+	h.mustRun("add", "Renew passport", "--date", time.Now().AddDate(0, 1, 0).Format("2006-01-02"), "--time", "09:00")
+
+	out := h.mustRun("list")
+	tomorrow, later := strings.Index(out, "Tomorrow (1)"), strings.Index(out, "Later (1)")
+	if tomorrow < 0 || later < tomorrow || strings.Index(out, "Water plants") > later {
+		t.Errorf("list printed:\n%s", out)
+	}
+	if out := h.mustRun("list", "--flat"); strings.Contains(out, "Tomorrow (") || !strings.Contains(out, "Renew passport") {
+		t.Errorf("list --flat printed:\n%s", out)
+	}
+}
+
+func TestTagCommands(t *testing.T) {
+	h := newHarness(t)
+	h.mustRun("add", "Fix the roof", "--date", "tomorrow", "--time", "09:00", "--tags", "house,diy")
+	h.mustRun("add", "Water plants", "--date", "tomorrow", "--time", "10:00", "--tags", "home")
+
+	tests := []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{[]string{"tag", "merge", "house"}, "", true},
+		{[]string{"tag", "merge", "house", "home", "--into", "home"}, "Retagged 2 reminders", false},
+		{[]string{"tag", "rename", "diy", "projects/diy"}, "Retagged 1 reminders", false},
+		{[]string{"tag", "rm", "projects"}, "Removed projects from 1 reminders", false},
+		{[]string{"tag", "rm", "someday"}, "No reminders tagged someday.", false},
+	}
+	for _, tt := range tests {
+		out, err := h.run(tt.args...)
+		if (err != nil) != tt.wantErr || !strings.Contains(out, tt.want) {
+			t.Errorf("nancy %s: %v\n%s", strings.Join(tt.args, " "), err, out)
+		}
+	}
+
+	if tags := h.reminder("Fix the roof").Tags; len(tags) != 1 || tags[0] != "home" {
+		t.Errorf("tags = %v, want [home]", tags)
+	}
+}
+
+func TestTagTree(t *testing.T) {
+	h := newHarness(t)
+	h.mustRun("add", "Invoice", "--date", "tomorrow", "--time", "09:00", "--tags", "work,work/clientA")
+	h.mustRun("add", "Billing", "--date", "tomorrow", "--time", "10:00", "--tags", "work/clientA/billing")
+	h.mustRun("add", "Groceries", "--date", "tomorrow", "--time", "11:00", "--tags", "home")
+	h.mustRun("add", "Old report", "--date", "tomorrow", "--time", "12:00", "--tags", "work")
+	h.mustRun("complete", "4")
+
+	out := h.mustRun("tags")
+	for _, want := range []string{"work (2)", "clientA (2)", "billing (1)", "home (1)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("tags printed no %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExists(t *testing.T) {
+	h := newHarness(t)
+	h.mustRun("add", "Call the dentist", "--date", "tomorrow", "--time", "09:00")
+	h.mustRun("add", "Water plants", "--date", "tomorrow", "--time", "10:00")
+	h.mustRun("complete", "2")
+
+	tests := []struct {
+		args  []string
+		found bool
+	}{
+		{[]string{"dentist"}, true},
+		{[]string{"DENTIST"}, true},
+		{[]string{"1"}, true},
+		{[]string{"#1"}, true},
+		{[]string{"2"}, false},
+		{[]string{"--all", "2"}, true},
+		{[]string{"3"}, false},
+		{[]string{"plumber"}, false},
+	}
+	for _, tt := range tests {
+		out, err := h.run(append([]string{"exists"}, tt.args...)...)
+		if tt.found && err != nil {
+			t.Errorf("exists %v: %v", tt.args, err)
+		}
+		if !tt.found && !errors.Is(err, cli.ErrSilent) {
+			t.Errorf("exists %v = %v, want ErrSilent", tt.args, err)
+		}
+		if out != "" {
+			t.Errorf("exists %v printed %q", tt.args, out)
+		}
+	}
+}
+
+func TestStaleDays(t *testing.T) {
+	h := newHarness(t)
+	old := models.NewReminder("Sort the garage", time.Now().AddDate(0, 1, 0), models.Low)
+	old.CreatedAt = time.Now().AddDate(0, 0, -20)
+	old.UpdatedAt = old.CreatedAt
+	if err := h.app.GetStore().Add(old); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		staleDays int
+		args      []string
+		stale     bool
+	}{
+		{14, nil, true},
+		{30, nil, false},
+		{30, []string{"--days", "14"}, true},
+		{14, []string{"--days", "30"}, false},
+	}
+	for _, tt := range tests {
+		h.app.GetConfig().Daemon.StaleDays = tt.staleDays
+		out := h.mustRun(append([]string{"stale"}, tt.args...)...)
+		if strings.Contains(out, "Sort the garage") != tt.stale {
+			t.Errorf("stale %v with stale_days %d printed:\n%s", tt.args, tt.staleDays, out)
+		}
+	}
+
+	config := h.app.GetConfig()
+	if err := config.Set("daemon.stale_days", "21"); err != nil || config.Daemon.StaleDays != 21 {
+		t.Errorf("Set daemon.stale_days: %v, %d", err, config.Daemon.StaleDays)
+	}
+	if value, err := config.Get("daemon.stale_days"); value != "21" || err != nil {
+		t.Errorf("Get daemon.stale_days = %q, %v", value, err)
+	}
+	if err := config.Set("daemon.stale_days", "0"); err == nil {
+		t.Error("Set accepted 0 stale days")
+	}
+}
+
+func TestCLIReviewToday(t *testing.T) {
+	h := newHarness(t)
+
+	now := time.Now()
+	earlier := now.Add(-2 * time.Hour)
+	h.mustRun("add", "File taxes", "--date", earlier.Format("2006-01-02"), "--time", earlier.Format("15:04"), "--past-ok")
+	later := earlier.Add(time.Minute)
+	h.mustRun("add", "Book flights", "--date", later.Format("2006-01-02"), "--time", later.Format("15:04"), "--past-ok")
+
+	out, err := h.runWithInput("d\nt\n", "review", "--today")
+	if err != nil {
+		t.Fatalf("review: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "1 done, 1 moved, 0 kept") {
+		t.Errorf("review printed:\n%s", out)
+	}
+	if !h.reminder("File taxes").Completed {
+		t.Error("File taxes is not done")
+	}
+	moved := h.reminder("Book flights").DueTime
+	if want := now.AddDate(0, 0, 1); moved.Day() != want.Day() || moved.Format("15:04") != later.Format("15:04") {
+		t.Errorf("Book flights moved to %v, want tomorrow at %s", moved, later.Format("15:04"))
+	}
+}
+
+func TestDaemonNagsOverdue(t *testing.T) {
+	h := newHarness(t)
+
+	due := time.Now().Add(-2 * time.Hour)
+	h.mustRun("add", "Take pills", "--date", due.Format("2006-01-02"), "--time", due.Format("15:04"),
+		"--past-ok", "--nag", "every 30m", "--priority", "high")
+	h.mustRun("add", "Dentist", "--date", "tomorrow", "--time", "09:00")
+
+	sent := h.check()
+	if len(sent) != 1 || sent[0].Title != "Overdue Reminder" || !strings.Contains(sent[0].Message, "Take pills") {
+		t.Fatalf("first check sent %+v", sent)
+	}
+	if sent[0].ReminderID != h.reminder("Take pills").ID || sent[0].Priority != models.High {
+		t.Errorf("notification %+v", sent[0])
+	}
+
+	// Nothing new until the nag cadence comes round
+	h.advance(10 * time.Minute)
+	if sent := h.check(); len(sent) != 0 {
+		t.Errorf("check after 10 minutes sent %+v", sent)
+	}
+	h.advance(20 * time.Minute)
+	if sent := h.check(); len(sent) != 1 {
+		t.Errorf("check after 30 minutes sent %+v", sent)
+	}
+
+	// Completed reminders stop nagging
+	h.mustRun("complete", "1")
+	h.advance(time.Hour)
+	if sent := h.check(); len(sent) != 0 {
+		t.Errorf("check after completing sent %+v", sent)
+	}
+}
+
+func TestDaemonDailyPlan(t *testing.T) {
+	h := newHarness(t)
+	h.app.GetConfig().Daemon.PlanTime = "08:00"
+	h.app.GetConfig().Daemon.WrapUpTime = "18:00"
+
+	today := time.Now()
+	h.now = time.Date(today.Year(), today.Month(), today.Day(), 7, 0, 0, 0, time.Local)
+	titles := func(sent []utils.Notification) []string {
+		var titles []string
+		for _, n := range sent {
+			titles = append(titles, n.Title)
+		}
+		return titles
+	}
+
+	if sent := h.check(); len(sent) != 0 {
+		t.Errorf("check before plan time sent %v", titles(sent))
+	}
+	h.advance(90 * time.Minute)
+	if sent := h.check(); len(sent) != 1 || sent[0].Title != "Plan Your Day" {
+		t.Errorf("check after plan time sent %v", titles(sent))
+	}
+	h.advance(time.Hour)
+	if sent := h.check(); len(sent) != 0 {
+		t.Errorf("second check after plan time sent %v", titles(sent))
+	}
+	// A restarted daemon remembers the plan went out
+	h.daemon = nil
+	if sent := h.check(); len(sent) != 0 {
+		t.Errorf("check after a restart sent %v", titles(sent))
+	}
+	h.advance(9 * time.Hour)
+	if sent := h.check(); len(sent) != 1 || sent[0].Title != "Today's Wrap-Up" {
+		t.Errorf("check after wrap-up time sent %v", titles(sent))
+	}
+	h.daemon = nil
+	if sent := h.check(); len(sent) != 0 {
+		t.Errorf("check after a restart at night sent %v", titles(sent))
+	}
+}
+
+func TestDaemonOverdueGrace(t *testing.T) {
+	h := newHarness(t)
+	h.app.GetConfig().Notifications.OverdueGrace = 10
+
+	due := time.Now().Add(-2 * time.Minute)
+	at := []string{"--date", due.Format("2006-01-02"), "--time", due.Format("15:04"), "--past-ok"}
+	h.mustRun(append([]string{"add", "Leave for the station"}, at...)...)
+	h.mustRun(append([]string{"add", "Take pills", "--grace", "0"}, at...)...)
+	if out := h.mustRun("show", "2"); !strings.Contains(out, "nags as soon as it's overdue") {
+		t.Errorf("show printed:\n%s", out)
+	}
+
+	// Only the reminder without grace nags right away
+	sent := h.check()
+	if len(sent) != 1 || !strings.Contains(sent[0].Message, "Take pills") {
+		t.Fatalf("check within the grace period sent %+v", sent)
+	}
+	h.advance(10 * time.Minute)
+	sent = h.check()
+	if len(sent) != 1 || !strings.Contains(sent[0].Message, "Leave for the station") {
+		t.Errorf("check after the grace period sent %+v", sent)
+	}
+
+	h.mustRun("edit", "1", "--grace", "5m")
+	if grace := h.reminder("Leave for the station").Grace; grace == nil || *grace != 5 {
+		t.Errorf("--grace 5m set grace %v", grace)
+	}
+	h.mustRun("edit", "1", "--grace", "")
+	if grace := h.reminder("Leave for the station").Grace; grace != nil {
+		t.Errorf("--grace \"\" left grace %d", *grace)
+	}
+}
+
+func TestDaemonDoneWhen(t *testing.T) {
+	h := newHarness(t)
+	h.mustRun("add", "Deploy release", "--date", "tomorrow", "--time", "09:00", "--done-when", "exit 0")
+	h.mustRun("add", "Publish notes", "--date", "tomorrow", "--time", "10:00", "--done-when", "exit 1")
+
+	// One that came from elsewhere, e.g. an import, isn't run
+	imported := models.NewReminder("Imported", time.Now().Add(time.Hour), models.Medium)
+	marker := filepath.Join(t.TempDir(), "ran")
+	imported.DoneWhen = "touch " + marker
+	if err := h.app.GetStore().Add(imported); err != nil {
+		t.Fatal(err)
+	}
+
+	h.check()
+	if !h.reminder("Deploy release").Completed {
+		t.Error("a reminder whose command exits 0 should be completed")
+	}
+	if h.reminder("Publish notes").Completed {
+		t.Error("a reminder whose command fails should stay active")
+	}
+	if h.reminder("Imported").Completed {
+		t.Error("a command not entered on this machine should not run")
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("the imported command ran")
+	}
+}
+
+func TestAddOverloadedDay(t *testing.T) {
+	h := newHarness(t)
+	h.app.GetConfig().Default.MaxPerDay = 2
+	day := func(days int) string { return time.Now().AddDate(0, 0, days).Format("2006-01-02") }
+	for i, date := range []string{day(1), day(1), day(2), day(2)} {
+		h.mustRun("add", fmt.Sprintf("Task %d", i), "--date", date, "--time", fmt.Sprintf("%d:00", 9+i*2))
+	}
+
+	out := h.mustRun("add", "One too many", "--date", day(1), "--time", "17:00")
+	third := time.Now().AddDate(0, 0, 3)
+	if !strings.Contains(out, "now has 3 reminders (more than 2)") ||
+		!strings.Contains(out, "Next day with room: "+third.Format("Mon Jan 2")) {
+		t.Errorf("add to a full day printed:\n%s", out)
+	}
+
+	// The morning plan flags it
+	h.app.GetConfig().Daemon.PlanTime = "08:00"
+	today := time.Now()
+	h.now = time.Date(today.Year(), today.Month(), today.Day(), 8, 30, 0, 0, time.Local)
+	sent := h.check()
+	if len(sent) != 1 || !strings.Contains(sent[0].Message, "Over 2 a day: "+time.Now().AddDate(0, 0, 1).Format("Mon Jan 2")+" (3)") {
+		t.Errorf("plan = %v", sent)
+	}
+}
+
+func TestAddLearnsTimeOfDay(t *testing.T) {
+	h := newHarness(t)
+	done := func(title, tag string, hour int, daysAgo int) {
+		r := models.NewReminder(title, time.Now().AddDate(0, 0, -daysAgo), models.Medium)
+		r.AddTag(tag)
+		r.Complete()
+		at := time.Now().AddDate(0, 0, -daysAgo)
+		at = time.Date(at.Year(), at.Month(), at.Day(), hour, 20, 0, 0, time.Local)
+		r.CompletedAt = &at
+		if err := h.app.GetStore().Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	done("Expenses", "admin", 16, 1)
+	done("Invoices", "admin", 16, 2)
+	done("Timesheet", "admin", 9, 3)
+	done("Run", "health", 7, 1)
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	out := h.mustRun("add", "File receipts", "--date", tomorrow, "--tags", "admin")
+	if due := h.reminder("File receipts").DueTime; due.Hour() != 16 || due.Minute() != 0 {
+		t.Errorf("due %s, want 16:00 learned from #admin", due)
+	}
+	if !strings.Contains(out, "you completed 2 reminders tagged #admin around then") {
+		t.Errorf("add printed:\n%s", out)
+	}
+
+	// Too few similar completions: the configured default
+	h.mustRun("add", "Stretch", "--date", tomorrow, "--tags", "health")
+	if due := h.reminder("Stretch").DueTime; due.Hour() != 9 {
+		t.Errorf("due %s, want default.untimed_time 09:00", due)
+	}
+
+	// An explicit time wins
+	h.mustRun("add", "Pay rent", "--date", tomorrow, "--time", "11:30", "--tags", "admin")
+	if due := h.reminder("Pay rent").DueTime; due.Hour() != 11 || due.Minute() != 30 {
+		t.Errorf("due %s, want the 11:30 given", due)
+	}
+}
+
+func TestDemoLeavesDataAlone(t *testing.T) {
+	h := newHarness(t)
+	h.mustRun("add", "Real reminder", "--date", "tomorrow", "--time", "09:00")
+	file := filepath.Join(h.app.GetConfig().GetDataDir(), "reminders.json")
+	before, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := h.mustRun("--demo", "list")
+	if !strings.Contains(out, "Team standup") || strings.Contains(out, "Real reminder") {
+		t.Errorf("demo list printed:\n%s", out)
+	}
+	h.mustRun("--demo", "add", "Demo only", "--date", "tomorrow", "--time", "10:00")
+	h.mustRun("--demo", "complete", "1")
+
+	// Back on the real store, which nothing touched
+	out = h.mustRun("list")
+	if !strings.Contains(out, "Real reminder") || strings.Contains(out, "Team standup") || strings.Contains(out, "Demo only") {
+		t.Errorf("list after the demo printed:\n%s", out)
+	}
+	after, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Error("--demo changed reminders.json")
+	}
+
+	if _, err := h.run("--demo", "daemon", "status"); err == nil {
+		t.Error("daemon ran with --demo")
+	}
+	if _, err := h.run("--demo", "--remote", "http://127.0.0.1:1", "list"); err == nil {
+		t.Error("--demo accepted --remote")
+	}
+}
+
+func TestAccessibleModeHasNoEmoji(t *testing.T) {
+	h := newHarness(t)
+	t.Cleanup(func() { utils.SetAccessibleMode(false) })
+	h.app.GetConfig().Daemon.PlanTime = "08:00"
+	h.app.GetConfig().Daemon.WrapUpTime = "18:00"
+	exported := filepath.Join(t.TempDir(), "export.json")
+
+	due := time.Now().Add(-2 * time.Hour)
+	old := models.NewReminder("Sort the garage", time.Now().AddDate(0, 1, 0), models.Low)
+	old.CreatedAt = time.Now().AddDate(0, 0, -40)
+	old.UpdatedAt = old.CreatedAt
+	if err := h.app.GetStore().Add(old); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	for _, args := range [][]string{
+		{"add", "Take pills", "--date", due.Format("2006-01-02"), "--time", due.Format("15:04"), "--past-ok", "--priority", "high"},
+		{"add", "Dentist", "--date", "tomorrow", "--time", "09:00", "--tags", "health"},
+		{"list"},
+		{"list", "--all"},
+		{"show", "1"},
+		{"stale"},
+		{"complete", "2"},
+		{"journal", "--format", "text"},
+		{"export", "--output", exported},
+		{"import", exported},
+		{"delete", "1", "--force"},
+	} {
+		got, err := h.run(append([]string{"--accessible"}, args...)...)
+		if err != nil {
+			t.Fatalf("nancy %s: %v\n%s", strings.Join(args, " "), err, got)
+		}
+		out.WriteString(got)
+	}
+
+	today := time.Now()
+	h.now = time.Date(today.Year(), today.Month(), today.Day(), 8, 30, 0, 0, time.Local)
+	h.mustRun("--accessible", "add", "Call mum", "--date", "today", "--time", "08:00", "--past-ok")
+	h.check()
+	h.advance(10 * time.Hour)
+	h.check()
+	if len(h.sent) < 3 {
+		t.Errorf("the daemon sent %+v", h.sent)
+	}
+	for _, n := range h.sent {
+		out.WriteString(n.Title + "\n" + n.Message + "\n")
+	}
+
+	for _, line := range strings.Split(out.String(), "\n") {
+		for _, r := range line {
+			if r >= 0x2600 && r <= 0x27BF || r >= 0x1F000 && r <= 0x1FAFF || r >= 0x23E9 && r <= 0x23FA || r == '↺' || r == '↪' {
+				t.Errorf("accessible mode printed %q in %q", r, line)
+				break
+			}
+		}
+	}
+}
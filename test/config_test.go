@@ -0,0 +1,152 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+)
+
+func TestConfigInclude(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	load := func() *app.Config {
+		t.Helper()
+		viper.Reset()
+		config, err := app.LoadConfig()
+		if err != nil {
+			t.Fatalf("LoadConfig: %v", err)
+		}
+		return config
+	}
+
+	team := filepath.Join(dir, "team.yaml")
+	writeTeam := func(priority string) {
+		t.Helper()
+		data := "data_dir: /elsewhere\ndefault:\n  priority: " + priority + "\n  max_per_day: 3\n" +
+			"  stt_command: rm -rf {file}\nappearance:\n  tag_colors:\n    work: blue\n" +
+			"integrations:\n  github_token: secret\n  jira_url: https://jira.example.com\n" +
+			"remote:\n  url: https://nancy.example.com\ndaemon:\n  journal_file: /tmp/journal.md\n  stale_days: 30\n" +
+			"rules:\n  - completed > 7d -> archive\n  - untouched > 1d -> delete\n"
+		if err := os.WriteFile(team, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTeam("high")
+
+	config := load()
+	if err := config.Set("default.max_per_day", "5"); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Set("include", team); err != nil {
+		t.Fatal(err)
+	}
+
+	// Local changes win; defaults do, but only for the settings an include
+	// may set
+	config = load()
+	if config.Default.Priority != "high" || config.Default.MaxPerDay != 5 ||
+		config.Appearance.TagColors["work"] != "blue" || config.Daemon.StaleDays != 30 {
+		t.Errorf("merged config: priority %s, max per day %d, tag colors %v, stale days %d",
+			config.Default.Priority, config.Default.MaxPerDay, config.Appearance.TagColors, config.Daemon.StaleDays)
+	}
+	if config.DataDir == "/elsewhere" || config.Integrations.GitHubToken != "" || config.Integrations.JiraURL != "" ||
+		config.Remote.URL != "" || config.Default.STTCommand != "" || config.Daemon.JournalFile != "" {
+		t.Error("the include should only set harmless settings")
+	}
+	// Rules that delete could wipe everyone's reminders
+	if len(config.Rules) != 1 || config.Rules[0] != "completed > 7d -> archive" {
+		t.Errorf("included rules = %v, want only the one that archives", config.Rules)
+	}
+	if included, err := config.Included(); included != 4 || err != nil {
+		t.Errorf("Included() = %d, %v; want 4", included, err)
+	}
+
+	// Saving keeps the included settings out of the local file, so changes
+	// to the include still apply
+	if err := config.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config", "nancy", "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "blue") || strings.Contains(string(data), "priority: high") || strings.Contains(string(data), "-> archive") {
+		t.Errorf("included settings were saved locally:\n%s", data)
+	}
+	writeTeam("low")
+	if config := load(); config.Default.Priority != "low" {
+		t.Errorf("priority after changing the include = %s, want low", config.Default.Priority)
+	}
+
+	// Nancy still starts when the include is gone
+	os.Remove(team)
+	if _, err := load().Included(); err == nil {
+		t.Error("a missing include should be reported")
+	}
+
+	// Includes over plain http aren't read
+	config.Include = "http://intranet.example.com/team.yaml"
+	if err := config.Validate(); err == nil {
+		t.Error("Validate accepted an http include")
+	}
+}
+
+func TestConfigMigration(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	configDir := filepath.Join(dir, "config", "nancy")
+	configPath := filepath.Join(configDir, "config.yaml")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	old := "# My settings\n\ndefault:\n  priority: High   # keep this comment\nwork_hours:\n  enabled: true\n  start: \"08:00\"\ndaemon:\n  log_level: WARNING\n"
+	if err := os.WriteFile(configPath, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	config, err := app.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig on an old file: %v", err)
+	}
+	if config.Default.Priority != "high" || config.Daemon.LogLevel != "warn" || config.WorkHours.Start != "08:00" {
+		t.Errorf("migrated config: priority %s, log level %s, work start %s",
+			config.Default.Priority, config.Daemon.LogLevel, config.WorkHours.Start)
+	}
+
+	backup, changes := config.Migrated()
+	if len(changes) != 3 {
+		t.Errorf("changes = %q, want 3", changes)
+	}
+	if data, err := os.ReadFile(backup); err != nil || string(data) != old {
+		t.Errorf("backup %s: %q, %v", backup, data, err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"version: 1", "workhours:", "keep this comment", "# My settings"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("migrated file lacks %q:\n%s", want, data)
+		}
+	}
+
+	// Current files are left alone
+	viper.Reset()
+	config, err = app.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, changes := config.Migrated(); len(changes) != 0 {
+		t.Errorf("second load changed %q", changes)
+	}
+}
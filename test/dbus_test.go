@@ -0,0 +1,281 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// fakeBus is a session bus with a scripted notification server on it
+type fakeBus struct {
+	listener net.Listener
+	calls    chan string // Member names, with the ID argument for Notify/CloseNotification
+	noServer bool
+}
+
+func startFakeBus(t *testing.T, noServer bool) *fakeBus {
+	dir, err := os.MkdirTemp("", "nancy-dbus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "bus")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", "unix:path="+socket)
+
+	bus := &fakeBus{listener: listener, calls: make(chan string, 16), noServer: noServer}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go bus.serve(conn)
+		}
+	}()
+	return bus
+}
+
+func (b *fakeBus) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	// Authentication: "\0AUTH EXTERNAL <uid>", then "BEGIN"
+	if line, err := reader.ReadString('\n'); err != nil || !strings.Contains(line, "AUTH EXTERNAL") {
+		return
+	}
+	io.WriteString(conn, "OK 0123456789abcdef\r\n")
+	if _, err := reader.ReadString('\n'); err != nil {
+		return
+	}
+
+	for {
+		fixed := make([]byte, 16)
+		if _, err := io.ReadFull(reader, fixed); err != nil {
+			return
+		}
+		bodyLen := binary.LittleEndian.Uint32(fixed[4:])
+		serial := binary.LittleEndian.Uint32(fixed[8:])
+		fieldsLen := binary.LittleEndian.Uint32(fixed[12:])
+		headerLen := (16 + int(fieldsLen) + 7) &^ 7
+		rest := make([]byte, headerLen-16+int(bodyLen))
+		if _, err := io.ReadFull(reader, rest); err != nil {
+			return
+		}
+		header, body := string(rest[:fieldsLen]), rest[headerLen-16:]
+
+		switch {
+		case strings.Contains(header, "Hello"):
+			conn.Write(dbusReply(serial, "s", dbusStrings(":1.1")))
+		case strings.Contains(header, "GetActive"):
+			// The screen saver reports a locked screen
+			conn.Write(dbusReply(serial, "b", binary.LittleEndian.AppendUint32(nil, 1)))
+		case b.noServer:
+			conn.Write(dbusErrorReply(serial, "org.freedesktop.DBus.Error.ServiceUnknown"))
+		case strings.Contains(header, "CloseNotification"):
+			b.calls <- fmt.Sprintf("CloseNotification %d", binary.LittleEndian.Uint32(body))
+			conn.Write(dbusReply(serial, "", nil))
+		case strings.Contains(header, "AddMatch"):
+			// The user dismisses notification 7 right away
+			conn.Write(dbusReply(serial, "", nil))
+			conn.Write(dbusSignal("org.freedesktop.Notifications", "NotificationClosed",
+				binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, 7), utils.ClosedDismissed)))
+		case strings.Contains(header, "Notify"):
+			// replaces_id follows the app name "Nancy" (4+5+1 bytes, padded to 12)
+			call := fmt.Sprintf("Notify %d", binary.LittleEndian.Uint32(body[12:]))
+			if strings.Contains(string(body), "resident") {
+				call += " resident"
+			}
+			b.calls <- call
+			conn.Write(dbusReply(serial, "u", binary.LittleEndian.AppendUint32(nil, 7)))
+		case strings.Contains(header, "GetServerInformation"):
+			conn.Write(dbusReply(serial, "ssss", dbusStrings("dunst", "knopwob", "1.9.0", "1.2")))
+		}
+	}
+}
+
+// dbusStrings marshals consecutive D-Bus strings
+func dbusStrings(values ...string) []byte {
+	var buf []byte
+	for _, v := range values {
+		for len(buf)%4 != 0 {
+			buf = append(buf, 0)
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(v)))
+		buf = append(append(buf, v...), 0)
+	}
+	return buf
+}
+
+// dbusMessage builds a reply message from already marshaled header fields
+func dbusMessage(msgType byte, fields, body []byte) []byte {
+	msg := []byte{'l', msgType, 0, 1}
+	msg = binary.LittleEndian.AppendUint32(msg, uint32(len(body)))
+	msg = binary.LittleEndian.AppendUint32(msg, 1000)
+	msg = binary.LittleEndian.AppendUint32(msg, uint32(len(fields)))
+	msg = append(msg, fields...)
+	for len(msg)%8 != 0 {
+		msg = append(msg, 0)
+	}
+	return append(msg, body...)
+}
+
+// dbusSignal builds a signal message with a "uu" body
+func dbusSignal(iface, member string, body []byte) []byte {
+	var fields []byte
+	field := func(code, kind byte, value []byte) {
+		for len(fields)%8 != 0 {
+			fields = append(fields, 0)
+		}
+		fields = append(append(fields, code, 1, kind, 0), value...)
+	}
+	field(1, 'o', dbusStrings("/org/freedesktop/Notifications"))
+	field(2, 's', dbusStrings(iface))
+	field(3, 's', dbusStrings(member))
+	field(8, 'g', []byte{2, 'u', 'u', 0})
+	return dbusMessage(4, fields, body)
+}
+
+func dbusReplySerial(serial uint32) []byte {
+	return binary.LittleEndian.AppendUint32([]byte{5, 1, 'u', 0}, serial)
+}
+
+func dbusReply(serial uint32, signature string, body []byte) []byte {
+	fields := dbusReplySerial(serial)
+	if signature != "" {
+		fields = append(fields, 8, 1, 'g', 0, byte(len(signature)))
+		fields = append(append(fields, signature...), 0)
+	}
+	return dbusMessage(2, fields, body)
+}
+
+func dbusErrorReply(serial uint32, name string) []byte {
+	fields := append([]byte{4, 1, 's', 0}, dbusStrings(name)...)
+	for len(fields)%8 != 0 {
+		fields = append(fields, 0)
+	}
+	return dbusMessage(3, append(fields, dbusReplySerial(serial)...), nil)
+}
+
+func TestDBusNotificationsReplaceAndClose(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("D-Bus notifications are only used on Linux")
+	}
+	bus := startFakeBus(t, false)
+
+	notifier := utils.NewNotifierWithMethod(utils.DesktopNotification)
+	reminder := &models.Reminder{ID: "abc", Title: "Water plants", Priority: models.High}
+
+	for i := 0; i < 2; i++ {
+		if err := notifier.SendReminder(reminder, "Overdue Reminder", reminder.Title); err != nil {
+			t.Fatalf("SendReminder: %v", err)
+		}
+		if err := notifier.FallbackError(); err != nil {
+			t.Fatalf("SendReminder fell back: %v", err)
+		}
+	}
+	if err := notifier.Dismiss(reminder.ID); err != nil {
+		t.Fatalf("Dismiss: %v", err)
+	}
+
+	// The second nag replaces the first, and completing it closes it
+	for _, want := range []string{"Notify 0", "Notify 7", "CloseNotification 7"} {
+		if got := <-bus.calls; got != want {
+			t.Errorf("call = %q, want %q", got, want)
+		}
+	}
+
+	server, err := utils.NotificationServer()
+	if err != nil || server != "dunst 1.9.0" {
+		t.Errorf("NotificationServer() = %q, %v", server, err)
+	}
+}
+
+func TestDBusStickyNotifications(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("D-Bus notifications are only used on Linux")
+	}
+	bus := startFakeBus(t, false)
+
+	notifier := utils.NewNotifierWithMethod(utils.DesktopNotification)
+	reminder := &models.Reminder{ID: "abc", Title: "Take pills", Priority: models.Low, Sticky: true}
+	if err := notifier.SendReminder(reminder, "Reminder Due Soon", reminder.Title); err != nil {
+		t.Fatalf("SendReminder: %v", err)
+	}
+	if got := <-bus.calls; got != "Notify 0 resident" {
+		t.Errorf("call = %q, want a resident notification", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	closed := make(chan utils.NotificationClosed)
+	done := make(chan error, 1)
+	go func() { done <- utils.WatchNotificationsClosed(ctx, closed) }()
+
+	select {
+	case notification := <-closed:
+		want := utils.NotificationClosed{ID: 7, Reason: utils.ClosedDismissed}
+		if notification != want {
+			t.Errorf("closed = %+v, want %+v", notification, want)
+		}
+		if id, ok := notifier.ShownReminder(notification.ID); !ok || id != reminder.ID {
+			t.Errorf("ShownReminder(%d) = %q, %v", notification.ID, id, ok)
+		}
+	case err := <-done:
+		t.Fatalf("WatchNotificationsClosed: %v", err)
+	case <-ctx.Done():
+		t.Fatal("no NotificationClosed signal")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("WatchNotificationsClosed after cancel = %v", err)
+	}
+}
+
+func TestDBusNoNotificationServer(t *testing.T) {
+	startFakeBus(t, true)
+
+	if _, err := utils.NotificationServer(); !errors.Is(err, utils.ErrNoNotificationServer) {
+		t.Errorf("NotificationServer() error = %v, want ErrNoNotificationServer", err)
+	}
+
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", "unix:path="+filepath.Join(t.TempDir(), "missing"))
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if _, err := utils.NotificationServer(); !errors.Is(err, utils.ErrNoSessionBus) {
+		t.Errorf("NotificationServer() error = %v, want ErrNoSessionBus", err)
+	}
+}
+
+func TestScreenLockedOverDBus(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the screen saver is only asked over D-Bus on Linux")
+	}
+	startFakeBus(t, false)
+
+	locked, err := utils.ScreenLocked()
+	if err != nil || !locked {
+		t.Errorf("ScreenLocked() = %v, %v; want locked", locked, err)
+	}
+	if away, err := utils.Away(0); err != nil || !away {
+		t.Errorf("Away() = %v, %v; want away while locked", away, err)
+	}
+}
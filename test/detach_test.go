@@ -0,0 +1,75 @@
+//go:build !windows
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestStartDetached(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), utils.DaemonLogFile)
+
+	// A full log is moved aside when the next daemon starts
+	if err := os.WriteFile(logPath, make([]byte, 5<<20+1), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	process, err := utils.StartDetached("/bin/sh", []string{"-c", "echo started; echo oops >&2; exec sleep 30"}, logPath)
+	if err != nil {
+		t.Fatalf("StartDetached: %v", err)
+	}
+	defer process.Kill()
+
+	if _, err := os.Stat(logPath + ".1"); err != nil {
+		t.Errorf("the full log wasn't rotated: %v", err)
+	}
+
+	// Its output goes to the log rather than the terminal
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, _ := os.ReadFile(logPath)
+		if strings.Contains(string(data), "started\n") && strings.Contains(string(data), "oops\n") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("log = %q, want the output of both streams", data)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// It leads a session of its own, away from the terminal's
+	if sid, err := unix.Getsid(process.Pid); err != nil || sid != process.Pid {
+		t.Errorf("Getsid = %d, %v, want %d", sid, err, process.Pid)
+	}
+	if cwd, err := os.Readlink(filepath.Join("/proc", strconv.Itoa(process.Pid), "cwd")); err == nil && cwd != "/" {
+		t.Errorf("working directory = %q, want /", cwd)
+	}
+
+	if running, err := utils.CheckProcess(process.Pid); !running || err != nil {
+		t.Fatalf("CheckProcess = %v, %v", running, err)
+	}
+	if daemon, err := utils.IsNancyDaemon(process.Pid); daemon || err != nil {
+		t.Errorf("IsNancyDaemon(sleep) = %v, %v", daemon, err)
+	}
+
+	if err := utils.SignalProcess(process.Pid, syscall.SIGTERM); err != nil {
+		t.Fatalf("SignalProcess: %v", err)
+	}
+	process.Wait()
+	if running, _ := utils.CheckProcess(process.Pid); running {
+		t.Error("the process still runs after SIGTERM")
+	}
+	if err := utils.SignalProcess(process.Pid, syscall.SIGTERM); err == nil {
+		t.Error("signalling a stopped process should fail")
+	}
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestNotifierDeduplicates(t *testing.T) {
+	notifier := utils.NewNotifierWithMethod(utils.LogOnly)
+	notifier.SetDedupWindow(10 * time.Minute)
+
+	if err := notifier.Send("Reminder Due Soon", "Call Bob", models.Medium); err != nil {
+		t.Fatalf("first Send: %v", err)
+	}
+	if err := notifier.Send("Reminder Due Soon", "Call Bob", models.Medium); !errors.Is(err, utils.ErrDuplicate) {
+		t.Errorf("repeated Send = %v, want ErrDuplicate", err)
+	}
+	if _, delivered := notifier.LastChannel(); delivered {
+		t.Error("a dropped duplicate should not count as delivered")
+	}
+	if err := notifier.Send("Reminder Due Soon", "Call Alice", models.Medium); err != nil {
+		t.Errorf("different message: %v", err)
+	}
+}
+
+func TestNotifierRateLimits(t *testing.T) {
+	notifier := utils.NewNotifierWithMethod(utils.LogOnly)
+	notifier.SetRateLimits(map[utils.NotificationMethod]int{utils.LogOnly: 1, utils.TerminalBell: 1})
+
+	notifier.Send("one", "first", models.Low)
+	if channel, ok := notifier.LastChannel(); !ok || channel != utils.LogOnly {
+		t.Errorf("first notification went to %s", utils.ChannelName(channel))
+	}
+
+	// The log is at its limit, so the bell takes over
+	notifier.Send("two", "second", models.Low)
+	if channel, ok := notifier.LastChannel(); !ok || channel != utils.TerminalBell {
+		t.Errorf("second notification went to %s, want bell", utils.ChannelName(channel))
+	}
+
+	if err := notifier.Send("three", "third", models.Low); !errors.Is(err, utils.ErrRateLimited) {
+		t.Errorf("third Send = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	want := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 15 * time.Minute, 15 * time.Minute}
+	for attempts, delay := range want {
+		if got := utils.RetryDelay(attempts); got != delay {
+			t.Errorf("RetryDelay(%d) = %s, want %s", attempts, got, delay)
+		}
+	}
+}
+
+func TestRetryQueue(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	queue := utils.NewRetryQueue(10 * time.Minute)
+	queue.Add("a", "Nancy Reminder", "Call Bob", start)
+
+	if due, _ := queue.Due(start.Add(10 * time.Second)); len(due) != 0 {
+		t.Errorf("retried before the first backoff: %v", due)
+	}
+	due, _ := queue.Due(start.Add(30 * time.Second))
+	if len(due) != 1 || due[0].Message != "Call Bob" {
+		t.Fatalf("due after 30s = %v", due)
+	}
+
+	// A failed retry waits twice as long
+	queue.Failed("a", start.Add(30*time.Second))
+	if due, _ := queue.Due(start.Add(80 * time.Second)); len(due) != 0 {
+		t.Errorf("retried before the second backoff: %v", due)
+	}
+	if due, _ := queue.Due(start.Add(90 * time.Second)); len(due) != 1 || due[0].Attempts != 1 {
+		t.Errorf("due after 90s = %v", due)
+	}
+
+	// Too old to retry
+	due, expired := queue.Due(start.Add(10 * time.Minute))
+	if len(due) != 0 || len(expired) != 1 || queue.Len() != 0 {
+		t.Errorf("after the max age: due %v, expired %v, %d left", due, expired, queue.Len())
+	}
+
+	queue.Add("b", "Nancy Reminder", "Call Alice", start)
+	queue.Remove("b")
+	if queue.Len() != 0 {
+		t.Error("Remove left the notification queued")
+	}
+
+	disabled := utils.NewRetryQueue(0)
+	disabled.Add("a", "Nancy Reminder", "Call Bob", start)
+	if disabled.Len() != 0 {
+		t.Error("a queue with no max age should not retry")
+	}
+}
+
+func TestNotifierTestChannels(t *testing.T) {
+	notifier := utils.NewNotifierWithMethod(utils.LogOnly)
+	notifier.SetRateLimits(map[utils.NotificationMethod]int{utils.LogOnly: 1})
+	notifier.Send("one", "first", models.Low)
+
+	// Each channel is tried on its own, ignoring rate limits
+	results := notifier.TestChannels([]utils.NotificationMethod{utils.LogOnly, utils.TerminalBell}, models.High)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for i, method := range []utils.NotificationMethod{utils.LogOnly, utils.TerminalBell} {
+		if results[i].Method != method || results[i].Err != nil {
+			t.Errorf("result %d = %+v, want %s without error", i, results[i], utils.ChannelName(method))
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// nextEvent waits briefly for an event
+func nextEvent(t *testing.T, events <-chan models.Event) models.Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event")
+		return models.Event{}
+	}
+}
+
+func TestStoreEvents(t *testing.T) {
+	store := newTestStore(t)
+	events, unsubscribe := store.Subscribe()
+
+	reminder := models.NewReminder("Call mom", time.Now().Add(time.Hour), models.Medium)
+	if err := store.Add(reminder); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if event := nextEvent(t, events); event.Kind != models.EventAdded || event.ID != reminder.ID || event.Reminder.Title != "Call mom" {
+		t.Errorf("after Add got %+v", event)
+	}
+
+	if err := store.CompleteReminder(reminder.ID); err != nil {
+		t.Fatalf("CompleteReminder: %v", err)
+	}
+	if event := nextEvent(t, events); event.Kind != models.EventCompleted || !event.Reminder.Completed {
+		t.Errorf("after CompleteReminder got %+v", event)
+	}
+
+	if err := store.Delete(reminder.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if event := nextEvent(t, events); event.Kind != models.EventDeleted || event.Reminder != nil {
+		t.Errorf("after Delete got %+v", event)
+	}
+
+	unsubscribe()
+	if _, open := <-events; open {
+		t.Error("unsubscribing should close the channel")
+	}
+}
+
+func TestStoreWatch(t *testing.T) {
+	dir := t.TempDir()
+	watched, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	events, unsubscribe := watched.Subscribe()
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watched.Watch(ctx, 10*time.Millisecond)
+
+	// Its own saves aren't reloads
+	if err := watched.Add(models.NewReminder("Mine", time.Now().Add(time.Hour), models.Low)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if event := nextEvent(t, events); event.Kind != models.EventAdded {
+		t.Errorf("own Add got %+v", event)
+	}
+
+	// Another process adds a reminder
+	other, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	added := models.NewReminder("Theirs", time.Now().Add(time.Hour), models.Low)
+	if err := other.Add(added); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if event := nextEvent(t, events); event.Kind != models.EventReloaded {
+		t.Fatalf("expected a reload, got %+v", event)
+	}
+	if _, err := watched.Get(added.ID); err != nil {
+		t.Errorf("reloaded store misses the other process's reminder: %v", err)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
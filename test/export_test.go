@@ -0,0 +1,122 @@
+package test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func exportSample() []*models.Reminder {
+	due := time.Date(2025, 3, 20, 14, 30, 0, 0, time.UTC)
+	open := models.NewReminder("Call Bob, then Alice; quickly", due, models.High)
+	open.Tags = []string{"work", "calls"}
+	open.Description = "Agenda:\nbudget"
+	done := models.NewReminder("Water plants", due.AddDate(0, 0, 1), models.Low)
+	done.Complete()
+	return []*models.Reminder{open, done}
+}
+
+func TestExportCSV(t *testing.T) {
+	data, err := utils.ExportReminders(exportSample(), "csv")
+	if err != nil {
+		t.Fatalf("ExportReminders: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][1] != "title" {
+		t.Fatalf("got %d rows, want a header and 2 reminders", len(records))
+	}
+	if records[1][1] != "Call Bob, then Alice; quickly" || records[1][5] != "work,calls" || records[1][9] != "Agenda:\nbudget" {
+		t.Errorf("first row = %q", records[1])
+	}
+	if records[2][4] != "true" {
+		t.Errorf("completed = %q, want true", records[2][4])
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	data, err := utils.ExportReminders(exportSample(), "md")
+	if err != nil {
+		t.Fatalf("ExportReminders: %v", err)
+	}
+	md := string(data)
+	for _, want := range []string{"- [ ] Call Bob", "#work #calls", "  Agenda:\n  budget\n", "- [x] Water plants"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown lacks %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestExportICS(t *testing.T) {
+	reminders := exportSample()
+	reminders[0].Title = strings.Repeat("Long title ", 10)
+
+	data, err := utils.ExportReminders(reminders, "ics")
+	if err != nil {
+		t.Fatalf("ExportReminders: %v", err)
+	}
+	ics := string(data)
+
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	for _, want := range []string{"BEGIN:VTODO", "DUE:20250320T143000Z", "PRIORITY:1", "CATEGORIES:work,calls",
+		`DESCRIPTION:Agenda:\nbudget`, "STATUS:COMPLETED"} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("ics lacks %q", want)
+		}
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	reminders := exportSample()
+	reminders[0].Title = "<script>alert(1)</script>"
+	now := reminders[0].DueTime.Add(-time.Hour)
+
+	data, err := utils.ExportHTML(reminders, now, 60)
+	if err != nil {
+		t.Fatalf("ExportHTML: %v", err)
+	}
+	page := string(data)
+
+	for _, want := range []string{"<!DOCTYPE html>", `content="60"`, "<h2>Today</h2>", "<h2>Completed</h2>",
+		"&lt;script&gt;", "#work #calls", "@media print"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page lacks %q", want)
+		}
+	}
+	if strings.Contains(page, "<script>") {
+		t.Error("titles are not escaped")
+	}
+	if strings.Index(page, "Today") > strings.Index(page, "Water plants") {
+		t.Error("today's reminders should come before completed ones")
+	}
+}
+
+func TestExportFormatFromPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"out.CSV":          "csv",
+		"notes.markdown":   "md",
+		"reminders.json":   "json",
+		"todo.ics":         "ics",
+		"wall.htm":         "html",
+		"archive.tar":      "",
+		"no-extension-csv": "",
+	} {
+		if got := utils.ExportFormatFromPath(path); got != want {
+			t.Errorf("ExportFormatFromPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if _, err := utils.ExportReminders(nil, "xml"); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
@@ -0,0 +1,46 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestFocusSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), utils.FocusFile)
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)
+
+	if focus, err := utils.LoadFocus(path, now); err != nil || focus != nil {
+		t.Fatalf("LoadFocus without a session = %v, %v", focus, err)
+	}
+
+	if err := utils.SaveFocus(path, &utils.Focus{Tags: []string{"deepwork"}, Until: now.Add(2 * time.Hour)}); err != nil {
+		t.Fatalf("SaveFocus: %v", err)
+	}
+	focus, err := utils.LoadFocus(path, now.Add(time.Hour))
+	if err != nil || focus == nil {
+		t.Fatalf("LoadFocus during the session = %v, %v", focus, err)
+	}
+
+	if !focus.Allows(&models.Reminder{Tags: []string{"deepwork/thesis"}}) {
+		t.Error("a nested focus tag should notify")
+	}
+	if focus.Allows(&models.Reminder{Tags: []string{"errands"}}) || focus.Allows(&models.Reminder{}) {
+		t.Error("other reminders should be held back")
+	}
+
+	// The session reverts on its own once it's over
+	if focus, _ := utils.LoadFocus(path, now.Add(2*time.Hour)); focus != nil {
+		t.Errorf("LoadFocus after the session = %+v", focus)
+	}
+
+	if err := utils.EndFocus(path); err != nil {
+		t.Fatalf("EndFocus: %v", err)
+	}
+	if err := utils.EndFocus(path); err != nil {
+		t.Errorf("EndFocus without a session: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// fuzzTimeout is how long one input may take before it counts as a hang
+const fuzzTimeout = 5 * time.Second
+
+// failIfSlow turns a hang into a crash, which the fuzzer records along
+// with the input; call the returned func when done
+func failIfSlow(input any) func() bool {
+	return time.AfterFunc(fuzzTimeout, func() {
+		panic(fmt.Sprintf("no result after %s for %q", fuzzTimeout, input))
+	}).Stop
+}
+
+// fuzzNow is the "now" natural-language input is parsed relative to, so
+// runs are reproducible
+var fuzzNow = time.Date(2024, 3, 20, 10, 30, 0, 0, time.UTC)
+
+func FuzzParseReminder(f *testing.F) {
+	for _, seed := range []string{
+		"Call mom tomorrow at 3pm",
+		"Team meeting every monday at 10am #work !high",
+		"Pay rent on the 1st of every month",
+		"Water plants in 2 hours",
+		"Dentist next friday 9:30am priority:low",
+		"Take out trash on tuesday at sunset",
+		"Standup monday at 9",
+		"Submit report by end of month",
+		"Review PR in 90 minutes @bob",
+		"",
+		"at",
+		"every",
+		"in -5 minutes",
+		"on 2024-02-30 at 25:61",
+		"every 0 days",
+		"every 999999999999 years",
+		"#### !!!! @@@@",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		defer failIfSlow(text)()
+		parsed, err := utils.ParseReminderAt(text, models.Medium, fuzzNow)
+		if err != nil {
+			return
+		}
+		if parsed == nil {
+			t.Fatalf("ParseReminderAt(%q) returned neither a reminder nor an error", text)
+		}
+
+		// What the parser returns is what add builds a reminder from
+		reminder := models.NewReminder(parsed.Title, parsed.DueTime, parsed.Priority)
+		for _, tag := range parsed.Tags {
+			reminder.AddTag(tag)
+		}
+		reminder.FormattedDueTime()
+		reminder.IsOverdue()
+	})
+}
+
+func FuzzParseTimeString(f *testing.F) {
+	for _, seed := range []string{
+		"15:04", "3pm", "3:30 PM", "09:00", "noon", "midnight",
+		"tomorrow morning", "2024-03-20 15:04", "25:00", "12:60", "-1:00",
+		"", ":", "pm", "99999999999999999999:00",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		defer failIfSlow(text)()
+		utils.ParseTimeString(text)
+		utils.ParseDateString(text)
+		utils.ParseDueTime(text, nil)
+	})
+}
+
+func FuzzStoreImport(f *testing.F) {
+	due := time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)
+	sample := models.NewReminder("Water plants", due, models.High)
+	sample.AddTag("home")
+	sample.Recurring = &models.RecurringRule{Frequency: "weekly", Interval: 1}
+	if data, err := json.Marshal([]*models.Reminder{sample}); err == nil {
+		f.Add(data)
+	}
+	for _, seed := range []string{
+		`[]`,
+		`[null]`,
+		`{}`,
+		`[{"id":"a","title":"x","due_time":"2024-03-20T09:00:00Z","priority":"high"}]`,
+		`[{"id":"a","title":"x","due_time":"2024-03-20T09:00:00Z","recurring":{"frequency":"monthly","interval":0}}]`,
+		`[{"id":"a","title":"x","due_time":"0001-01-01T00:00:00Z","recurring":{"frequency":"daily","interval":-1}}]`,
+		`[{"id":"","title":"","due_time":"9999-12-31T23:59:59Z"}]`,
+		`[{"id":"a","title":"x","due_time":"2024-03-20T09:00:00Z","short_id":-1,"nag":-5}]`,
+		// Used to hang completing: the date arithmetic overflowed
+		`[{"id":"a","title":"x","due_time":"2024-03-20T09:00:00Z","recurring":{"frequency":"daily","interval":9223372036854775807}}]`,
+		`[{"id":"a","title":"x","due_time":"0001-01-01T00:00:00Z","recurring":{"frequency":"daily","interval":1000}}]`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		defer failIfSlow(data)()
+		store, err := models.NewStore(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.Import(data); err != nil {
+			return
+		}
+
+		// Whatever was accepted has to work with the rest of Nancy
+		for _, reminder := range store.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true}) {
+			reminder.FormattedDueTime()
+			reminder.IsOverdue()
+			reminder.IsDueSoon()
+			reminder.NagInterval()
+			reminder.Complete()
+		}
+		exported, err := store.Export()
+		if err != nil {
+			t.Fatalf("Export after Import: %v", err)
+		}
+		again, err := models.NewStore(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := again.Import(exported); err != nil {
+			t.Fatalf("exported reminders don't import again: %v\n%s", err, exported)
+		}
+	})
+}
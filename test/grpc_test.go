@@ -0,0 +1,125 @@
+package test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+	nancyv1 "github.com/ivyascorp-net/nagging-nancy/pkg/nancy/v1"
+)
+
+func TestGRPCServer(t *testing.T) {
+	store := newTestStore(t)
+	server, err := utils.NewGRPCServer(store, "s3cret", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := nancyv1.NewRemindersClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.ListReminders(ctx, &nancyv1.ListRemindersRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("ListReminders without the token = %v", err)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer s3cret")
+
+	watch, err := client.Watch(ctx, &nancyv1.WatchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := watch.Header(); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	due := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+	created, err := client.CreateReminder(ctx, &nancyv1.CreateReminderRequest{Reminder: &nancyv1.Reminder{
+		Title:    "Water plants",
+		DueTime:  timestamppb.New(due),
+		Priority: nancyv1.Priority_PRIORITY_HIGH,
+		Tags:     []string{"home"},
+	}})
+	if err != nil {
+		t.Fatalf("CreateReminder: %v", err)
+	}
+	if created.GetId() == "" || created.GetShortId() != 1 {
+		t.Errorf("created %v", created)
+	}
+	if r, err := store.Get(created.GetId()); err != nil || r.Priority != models.High || !r.DueTime.Equal(due) || len(r.Tags) != 1 {
+		t.Errorf("store has %v, %v", r, err)
+	}
+	if _, err := client.CreateReminder(ctx, &nancyv1.CreateReminderRequest{Reminder: &nancyv1.Reminder{Title: "No due time"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateReminder without a due time = %v", err)
+	}
+
+	event, err := watch.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if event.GetKind() != nancyv1.EventKind_EVENT_KIND_ADDED || event.GetReminder().GetTitle() != "Water plants" {
+		t.Errorf("event = %v", event)
+	}
+
+	created.Title = "Water the plants"
+	if updated, err := client.PutReminder(ctx, &nancyv1.PutReminderRequest{Reminder: created}); err != nil || updated.GetTitle() != "Water the plants" {
+		t.Errorf("PutReminder = %v, %v", updated, err)
+	}
+	if got, err := client.GetReminder(ctx, &nancyv1.GetReminderRequest{Id: "1"}); err != nil || got.GetTitle() != "Water the plants" {
+		t.Errorf("GetReminder(1) = %v, %v", got, err)
+	}
+	if done, err := client.CompleteReminder(ctx, &nancyv1.CompleteReminderRequest{Id: created.GetId()}); err != nil || !done.GetCompleted() {
+		t.Errorf("CompleteReminder = %v, %v", done, err)
+	}
+	if list, err := client.ListReminders(ctx, &nancyv1.ListRemindersRequest{}); err != nil || len(list.GetReminders()) != 1 {
+		t.Errorf("ListReminders = %v, %v", list, err)
+	}
+	if _, err := client.DeleteReminder(ctx, &nancyv1.DeleteReminderRequest{Id: "1"}); err != nil {
+		t.Errorf("DeleteReminder: %v", err)
+	}
+	if _, err := client.GetReminder(ctx, &nancyv1.GetReminderRequest{Id: "1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetReminder after delete = %v", err)
+	}
+
+	// Creating with the deleted reminder's ID makes a new one instead
+	recreated, err := client.CreateReminder(ctx, &nancyv1.CreateReminderRequest{Reminder: created})
+	if err != nil || recreated.GetId() == created.GetId() {
+		t.Errorf("CreateReminder with a deleted ID = %v, %v", recreated, err)
+	}
+
+	for _, want := range []nancyv1.EventKind{
+		nancyv1.EventKind_EVENT_KIND_UPDATED,
+		nancyv1.EventKind_EVENT_KIND_COMPLETED,
+		nancyv1.EventKind_EVENT_KIND_DELETED,
+	} {
+		event, err := watch.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if event.GetKind() != want || event.GetId() != created.GetId() {
+			t.Errorf("event = %v, want %v", event, want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/app"
+	"github.com/ivyascorp-net/nagging-nancy/internal/cli"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// harnessConfig keeps the results of a run from depending on the time of
+// day it runs at: no quiet hours, and no deduplication (its window follows
+// the real clock, not the harness's)
+const harnessConfig = `workhours:
+  enabled: false
+notifications:
+  dedup_minutes: 0
+`
+
+// harness runs nancy end to end in-process: commands through the real
+// cobra tree, and daemon checks at a clock the test controls, against
+// temporary config and data directories. Notifications are recorded in
+// sent instead of shown.
+type harness struct {
+	t    testing.TB
+	app  *app.App
+	now  time.Time
+	sent []utils.Notification
+
+	daemon *cli.Daemon
+}
+
+// newHarness sets up a fresh home directory with the harness config and
+// points the commands at it
+func newHarness(t testing.TB) *harness {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("LANG", "en_US.UTF-8")
+	t.Setenv("LC_ALL", "")
+	// No session bus: nothing reaches the real desktop
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", "unix:path="+filepath.Join(home, "no-bus"))
+
+	configDir := filepath.Join(home, "config", "nancy")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(harnessConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	a, err := app.New()
+	if err != nil {
+		t.Fatalf("app.New: %v", err)
+	}
+	cli.SetApp(a)
+	t.Cleanup(func() { cli.SetApp(nil) })
+
+	// The daemon logs every check
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	return &harness{t: t, app: a, now: time.Now()}
+}
+
+// run runs a nancy command and returns what it printed
+func (h *harness) run(args ...string) (string, error) {
+	return h.runWithInput("", args...)
+}
+
+// runWithInput runs a nancy command with input as its stdin
+func (h *harness) runWithInput(input string, args ...string) (string, error) {
+	h.t.Helper()
+
+	stdin, err := os.CreateTemp(h.t.TempDir(), "stdin")
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	defer stdin.Close()
+	if _, err := stdin.WriteString(input); err != nil {
+		h.t.Fatal(err)
+	}
+	stdin.Seek(0, io.SeekStart)
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	oldStdin, oldStdout, oldStderr := os.Stdin, os.Stdout, os.Stderr
+	os.Stdin, os.Stdout, os.Stderr = stdin, writer, writer
+
+	output := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, reader)
+		output <- buf.String()
+	}()
+
+	err = cli.Run(args...)
+	writer.Close()
+	os.Stdin, os.Stdout, os.Stderr = oldStdin, oldStdout, oldStderr
+	return <-output, err
+}
+
+// mustRun runs a nancy command that has to succeed
+func (h *harness) mustRun(args ...string) string {
+	h.t.Helper()
+	out, err := h.run(args...)
+	if err != nil {
+		h.t.Fatalf("nancy %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return out
+}
+
+// advance moves the harness clock on
+func (h *harness) advance(d time.Duration) {
+	h.now = h.now.Add(d)
+}
+
+// check runs one daemon check at the harness clock and returns the
+// notifications it sent
+func (h *harness) check() []utils.Notification {
+	if h.daemon == nil {
+		notifier := utils.NewNotifierWithSink(func(n utils.Notification) error {
+			h.sent = append(h.sent, n)
+			return nil
+		})
+		h.daemon = cli.NewDaemonWithNotifier(h.app, time.Minute, notifier)
+	}
+
+	before := len(h.sent)
+	h.daemon.Check(h.now)
+	return h.sent[before:]
+}
+
+// reminder returns the stored reminder with the given title
+func (h *harness) reminder(title string) *models.Reminder {
+	h.t.Helper()
+	for _, reminder := range h.app.GetStore().GetAll(&models.FilterOptions{ShowCompleted: true}) {
+		if reminder.Title == title {
+			return reminder
+		}
+	}
+	h.t.Fatalf("no reminder titled %q", title)
+	return nil
+}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+)
+
+func TestTranslate(t *testing.T) {
+	defer i18n.SetLanguage("en")
+
+	i18n.SetLanguage("de")
+	if got := i18n.T("Added reminder: %s", "Zahnarzt"); got != "Erinnerung hinzugefügt: Zahnarzt" {
+		t.Errorf("German T = %q", got)
+	}
+	if got := i18n.T("Not in any catalog"); got != "Not in any catalog" {
+		t.Errorf("untranslated messages should fall back to English, got %q", got)
+	}
+
+	due := time.Date(2025, time.March, 3, 15, 4, 0, 0, time.UTC)
+	if got := i18n.FormatTime(due, "Mon Jan 2 3:04 PM"); got != "Mo 3. Mär 15:04" {
+		t.Errorf("German FormatTime = %q", got)
+	}
+	if got := i18n.FormatTime(due, "Monday, January 2, 2006"); got != "Montag, 3. März 2025" {
+		t.Errorf("German FormatTime = %q", got)
+	}
+
+	i18n.SetLanguage("en")
+	if got := i18n.FormatTime(due, "Mon Jan 2 3:04 PM"); got != "Mon Mar 3 3:04 PM" {
+		t.Errorf("English FormatTime = %q", got)
+	}
+}
+
+func TestLanguageFromEnvironment(t *testing.T) {
+	defer i18n.SetLanguage("en")
+
+	tests := []struct {
+		lcAll, lang string
+		want        string
+	}{
+		{"", "de_DE.UTF-8", "de"},
+		{"en_US.UTF-8", "de_DE.UTF-8", "en"},
+		{"", "fr_FR.UTF-8", "en"}, // Unsupported falls back to English
+		{"", "C", "en"},
+	}
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", tt.lang)
+		i18n.SetLanguage("auto")
+		if got := i18n.Language(); got != tt.want {
+			t.Errorf("LC_ALL=%q LANG=%q: language %q, want %q", tt.lcAll, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestDateFormats(t *testing.T) {
+	defer i18n.SetFormats("auto", "auto", "monday")
+
+	due := time.Date(2025, time.March, 3, 15, 4, 0, 0, time.UTC)
+	if err := i18n.SetFormats("dmy", "24h", "sunday"); err != nil {
+		t.Fatalf("SetFormats: %v", err)
+	}
+	if got := i18n.FormatTime(due, "Mon Jan 2 3:04 PM"); got != "Mon 3 Mar 15:04" {
+		t.Errorf("dmy/24h FormatTime = %q", got)
+	}
+	if got := i18n.FormatTime(due, "Jan 2, 2006"); got != "3 Mar 2025" {
+		t.Errorf("dmy FormatTime = %q", got)
+	}
+	if got := i18n.NumericDateLayout(); got != "02/01/2006" {
+		t.Errorf("dmy NumericDateLayout = %q", got)
+	}
+	if got := i18n.WeekStart(due); got.Weekday() != time.Sunday || got.Day() != 2 {
+		t.Errorf("WeekStart with sunday = %v", got)
+	}
+
+	if err := i18n.SetFormats("auto", "12h", "monday"); err != nil {
+		t.Fatalf("SetFormats: %v", err)
+	}
+	if got := i18n.FormatTime(due, "Mon Jan 2 15:04"); got != "Mon Mar 3 3:04 PM" {
+		t.Errorf("12h FormatTime = %q", got)
+	}
+	if got := i18n.WeekStart(due); !got.Equal(time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("WeekStart with monday = %v", got)
+	}
+
+	if err := i18n.SetFormats("ymd", "auto", "monday"); err == nil {
+		t.Error("SetFormats should reject unknown date formats")
+	}
+}
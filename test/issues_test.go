@@ -0,0 +1,96 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestParseIssueRef(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		jira  bool
+	}{
+		{"golang/go#123", "golang/go#123", false},
+		{" my-org/my.repo#7 ", "my-org/my.repo#7", false},
+		{"PROJ-42", "PROJ-42", true},
+	}
+	for _, tt := range tests {
+		ref, err := utils.ParseIssueRef(tt.input)
+		if err != nil {
+			t.Errorf("ParseIssueRef(%q): %v", tt.input, err)
+			continue
+		}
+		if ref.String() != tt.want || ref.IsJira() != tt.jira {
+			t.Errorf("ParseIssueRef(%q) = %s (jira=%v), want %s (jira=%v)", tt.input, ref, ref.IsJira(), tt.want, tt.jira)
+		}
+	}
+
+	for _, bad := range []string{"", "golang/go", "#123", "proj-42", "golang/go#abc"} {
+		if _, err := utils.ParseIssueRef(bad); err == nil {
+			t.Errorf("ParseIssueRef(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFetchGitHubIssue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/app/issues/9" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("missing token, got %q", r.Header.Get("Authorization"))
+		}
+		fmt.Fprint(w, `{"title": "Crash on start", "html_url": "https://github.com/acme/app/issues/9", "state": "closed"}`)
+	}))
+	defer server.Close()
+
+	defer func(api string) { utils.GitHubAPI = api }(utils.GitHubAPI)
+	utils.GitHubAPI = server.URL
+
+	ref, _ := utils.ParseIssueRef("acme/app#9")
+	issue, err := utils.FetchIssue(ref, utils.IssueCredentials{GitHubToken: "secret"})
+	if err != nil {
+		t.Fatalf("FetchIssue: %v", err)
+	}
+	if issue.Title != "Crash on start" || !issue.Closed || issue.URL != "https://github.com/acme/app/issues/9" {
+		t.Errorf("unexpected issue %+v", issue)
+	}
+
+	missing, _ := utils.ParseIssueRef("acme/app#10")
+	if _, err := utils.FetchIssue(missing, utils.IssueCredentials{GitHubToken: "secret"}); err == nil {
+		t.Error("expected an error for a missing issue")
+	}
+}
+
+func TestFetchReviewRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/issues" || r.URL.Query().Get("q") != "is:open is:pr review-requested:@me" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		fmt.Fprint(w, `{"items": [{"title": "Add caching", "number": 12,
+			"html_url": "https://github.com/acme/app/pull/12",
+			"repository_url": "https://api.github.com/repos/acme/app"}]}`)
+	}))
+	defer server.Close()
+
+	defer func(api string) { utils.GitHubAPI = api }(utils.GitHubAPI)
+	utils.GitHubAPI = server.URL
+
+	if _, err := utils.FetchReviewRequests(""); err == nil {
+		t.Error("expected an error without a token")
+	}
+
+	prs, err := utils.FetchReviewRequests("secret")
+	if err != nil {
+		t.Fatalf("FetchReviewRequests: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Title != "Add caching" || prs[0].Ref.String() != "acme/app#12" {
+		t.Errorf("unexpected pull requests %+v", prs)
+	}
+}
@@ -0,0 +1,147 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/i18n"
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestBuildJournal(t *testing.T) {
+	day := time.Date(2024, 3, 20, 15, 0, 0, 0, time.Local)
+	done := func(title string, hour int, tags ...string) *models.Reminder {
+		completedAt := time.Date(2024, 3, 20, hour, 5, 0, 0, time.Local)
+		return &models.Reminder{Title: title, Tags: tags, Completed: true, CompletedAt: &completedAt}
+	}
+	dayBefore := time.Date(2024, 3, 19, 23, 59, 0, 0, time.Local)
+	dayAfter := time.Date(2024, 3, 21, 0, 0, 0, 0, time.Local)
+	reminders := []*models.Reminder{
+		done("Send invoice", 16, "work", "finance"),
+		done("Buy milk", 8),
+		done("Fix bike", 9, "home"),
+		done("Review PR", 10, "work"),
+		{Title: "Late night", Completed: true, CompletedAt: &dayBefore},
+		{Title: "Tomorrow's", Completed: true, CompletedAt: &dayAfter},
+		{Title: "Not done"},
+	}
+
+	// A daily reminder done that morning has moved on to tomorrow, and one
+	// whose last occurrence was that evening is completed
+	at := func(hour int) time.Time { return time.Date(2024, 3, 20, hour, 30, 0, 0, time.Local) }
+	daily := &models.RecurringRule{Frequency: "daily", Interval: 1}
+	lastDose := at(19)
+	recurring := []*models.Reminder{
+		{Title: "Take vitamins", Tags: []string{"health"}, Recurring: daily, History: []models.Occurrence{
+			{DueTime: at(7).AddDate(0, 0, -1), At: at(7).AddDate(0, 0, -1), Status: models.OccurrenceOnTime},
+			{DueTime: at(7), At: at(7), Status: models.OccurrenceOnTime},
+		}},
+		{Title: "Stretch", Tags: []string{"health"}, Recurring: daily, History: []models.Occurrence{
+			{DueTime: at(6), At: at(11), Status: models.OccurrenceMissed},
+		}},
+		{Title: "Last dose", Tags: []string{"health"}, Recurring: daily, Completed: true, CompletedAt: &lastDose, History: []models.Occurrence{
+			{DueTime: at(19), At: at(19), Status: models.OccurrenceLate},
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		reminders []*models.Reminder
+		format    string
+		want      string
+	}{
+		{"markdown", reminders, "md", "## Done Wednesday, March 20, 2024\n\n" +
+			"### home\n\n- [x] Fix bike _(9:05 AM)_\n\n" +
+			"### work\n\n- [x] Review PR _(10:05 AM)_\n- [x] Send invoice _(4:05 PM)_\n\n" +
+			"### Other\n\n- [x] Buy milk _(8:05 AM)_\n\n"},
+		{"text", reminders, "text", "Done Wednesday, March 20, 2024\n\n" +
+			"home:\n  ✓ Fix bike (9:05 AM)\n\n" +
+			"work:\n  ✓ Review PR (10:05 AM)\n  ✓ Send invoice (4:05 PM)\n\n" +
+			"Other:\n  ✓ Buy milk (8:05 AM)\n\n"},
+		{"nothing that day", reminders[4:], "md", "## Done Wednesday, March 20, 2024\n\nNothing completed.\n"},
+		{"recurring", recurring, "text", "Done Wednesday, March 20, 2024\n\n" +
+			"health:\n  ✓ Take vitamins (7:30 AM)\n  ✓ Last dose (7:30 PM)\n\n"},
+	}
+	for _, tt := range tests {
+		got, err := utils.BuildJournal(tt.reminders, day, tt.format)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: BuildJournal() = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := utils.BuildJournal(reminders, day, "html"); err == nil {
+		t.Error("an unknown format should fail")
+	}
+
+	// Times follow the configured clock
+	defer i18n.SetFormats("auto", "auto", "monday")
+	if err := i18n.SetFormats("auto", "24h", "monday"); err != nil {
+		t.Fatalf("SetFormats: %v", err)
+	}
+	if got, _ := utils.BuildJournal(reminders, day, "text"); !strings.Contains(got, "Send invoice (16:05)") {
+		t.Errorf("BuildJournal() with a 24-hour clock = %q", got)
+	}
+}
+
+func TestJournalCommand(t *testing.T) {
+	h := newHarness(t)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	reminder := models.NewReminder("Send invoice", yesterday, models.Medium)
+	reminder.Complete()
+	reminder.CompletedAt = &yesterday
+	if err := h.app.GetStore().Add(reminder); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		date    string
+		want    string
+		wantErr bool
+	}{
+		{"yesterday", "Send invoice", false},
+		{yesterday.Format("2006-01-02"), "Send invoice", false},
+		{"today", "Nothing completed.", false},
+		{"Yesterday", "Send invoice", false},
+		{"20/03/2024", "", true},
+	}
+	for _, tt := range tests {
+		out, err := h.run("journal", "--date", tt.date)
+		if (err != nil) != tt.wantErr || !strings.Contains(out, tt.want) {
+			t.Errorf("journal --date %s: %v\n%s", tt.date, err, out)
+		}
+	}
+}
+
+func TestDaemonAppendsJournal(t *testing.T) {
+	h := newHarness(t)
+	path := filepath.Join(t.TempDir(), "journal.md")
+	h.app.GetConfig().Daemon.JournalFile = path
+
+	yesterday := h.now.AddDate(0, 0, -1)
+	reminder := models.NewReminder("Send invoice", yesterday, models.Medium)
+	reminder.Complete()
+	reminder.CompletedAt = &yesterday
+	if err := h.app.GetStore().Add(reminder); err != nil {
+		t.Fatal(err)
+	}
+
+	h.check()
+	h.advance(time.Minute)
+	h.check()
+	// A restarted daemon doesn't append the same day again either
+	h.daemon = nil
+	h.check()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	heading := utils.JournalHeading(yesterday)
+	if strings.Count(string(data), heading) != 1 || !strings.Contains(string(data), "Send invoice") {
+		t.Errorf("journal file:\n%s", data)
+	}
+}
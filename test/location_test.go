@@ -0,0 +1,87 @@
+package test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestParseContexts(t *testing.T) {
+	tests := []struct {
+		text  string
+		title string
+		tags  []string
+	}{
+		{"Buy milk @store #errands, ask bob@example.com", "Buy milk , ask bob@example.com", []string{"errands", "@store"}},
+		{"Water @Home/garden plants", "Water plants", []string{"@Home/garden"}},
+		{"Email @bob about report", "Email @bob about report", nil},
+		{"Dinner @19:00", "Dinner @19:00", nil},
+		{"Pick up the keys @homework", "Pick up the keys @homework", nil},
+	}
+	for _, tt := range tests {
+		parsed, err := utils.ParseReminder(tt.text, models.Medium)
+		if err != nil {
+			t.Errorf("ParseReminder(%q): %v", tt.text, err)
+			continue
+		}
+		if parsed.Title != tt.title || len(parsed.Tags) != len(tt.tags) || (len(tt.tags) > 0 && !reflect.DeepEqual(parsed.Tags, tt.tags)) {
+			t.Errorf("ParseReminder(%q) = %q, tags %v; want %q, tags %v", tt.text, parsed.Title, parsed.Tags, tt.title, tt.tags)
+		}
+	}
+}
+
+func TestArrival(t *testing.T) {
+	path := filepath.Join(t.TempDir(), utils.ArrivalFile)
+	now := time.Date(2025, 3, 3, 18, 0, 0, 0, time.Local)
+
+	if arrival, err := utils.LoadArrival(path, now); err != nil || arrival != nil {
+		t.Fatalf("LoadArrival without an arrival = %v, %v", arrival, err)
+	}
+	if err := utils.SaveArrival(path, " ", now); err == nil {
+		t.Error("SaveArrival without a place should fail")
+	}
+
+	if err := utils.SaveArrival(path, "@Home", now); err != nil {
+		t.Fatalf("SaveArrival: %v", err)
+	}
+	arrival, err := utils.LoadArrival(path, now.Add(time.Minute))
+	if err != nil || arrival == nil || arrival.Place != "home" {
+		t.Fatalf("LoadArrival = %+v, %v", arrival, err)
+	}
+	if tag := utils.PlaceTag(arrival.Place); !(&models.Reminder{Tags: []string{"@home/garden"}}).MatchesTag(tag) {
+		t.Errorf("a reminder tagged @home/garden should match %s", tag)
+	}
+
+	// An old arrival doesn't nag any more
+	if arrival, _ := utils.LoadArrival(path, now.Add(utils.ArrivalWindow+time.Minute)); arrival != nil {
+		t.Errorf("LoadArrival after the window = %+v", arrival)
+	}
+
+	// Leaving somewhere else keeps the arrival
+	if err := utils.ClearArrival(path, "store"); err != nil {
+		t.Fatalf("ClearArrival(store): %v", err)
+	}
+	if arrival, _ := utils.LoadArrival(path, now); arrival == nil {
+		t.Error("leaving the store forgot arriving home")
+	}
+
+	if err := utils.ClearArrival(path, "Home"); err != nil {
+		t.Fatalf("ClearArrival: %v", err)
+	}
+	if arrival, _ := utils.LoadArrival(path, now); arrival != nil {
+		t.Errorf("LoadArrival after leaving = %+v", arrival)
+	}
+	if err := utils.ClearArrival(path, "home"); err != nil {
+		t.Errorf("ClearArrival without an arrival: %v", err)
+	}
+
+	// It goes in the state directory, which may not exist yet
+	nested := filepath.Join(t.TempDir(), "state", "nancy", utils.ArrivalFile)
+	if err := utils.SaveArrival(nested, "home", now); err != nil {
+		t.Errorf("SaveArrival into a new directory: %v", err)
+	}
+}
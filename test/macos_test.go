@@ -0,0 +1,36 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestMacNotificationPermission(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the helper")
+	}
+
+	helper := filepath.Join(t.TempDir(), "nancy-notifier")
+	script := "#!/bin/sh\n[ \"$1\" = status ] && echo denied\n"
+	if err := os.WriteFile(helper, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NANCY_NOTIFIER", helper)
+
+	if path, ok := utils.MacNotifierPath(); !ok || path != helper {
+		t.Fatalf("MacNotifierPath() = %q, %v", path, ok)
+	}
+	permission, err := utils.MacNotificationPermission()
+	if err != nil || permission != utils.PermissionDenied {
+		t.Errorf("MacNotificationPermission() = %q, %v", permission, err)
+	}
+
+	t.Setenv("NANCY_NOTIFIER", filepath.Join(t.TempDir(), "missing"))
+	if _, err := utils.MacNotificationPermission(); err == nil {
+		t.Error("a missing helper should be reported")
+	}
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	utils.SetAccessibleMode(true)
+	defer utils.SetAccessibleMode(false)
+
+	notes := "# Plan\n- [x] book venue\n- [ ] send **invites**\n  - see [the plan](https://example.com/plan)\n```\nraw *text*\n```"
+	got := utils.RenderMarkdown(notes, 0)
+
+	for _, want := range []string{
+		"Plan",
+		"[x] book venue",
+		"[ ] send invites",
+		"  - see the plan (https://example.com/plan)",
+		"raw *text*",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderMarkdown() = %q, missing %q", got, want)
+		}
+	}
+	if strings.Contains(got, "```") || strings.Contains(got, "# Plan") {
+		t.Errorf("RenderMarkdown() left markup in %q", got)
+	}
+}
+
+func TestRenderMarkdownWraps(t *testing.T) {
+	got := utils.RenderMarkdown("- one two three four five six seven eight nine ten", 20)
+	lines := strings.Split(got, "\n")
+	if len(lines) < 2 {
+		t.Fatalf("RenderMarkdown() = %q, want wrapped lines", got)
+	}
+	if !strings.HasPrefix(lines[1], "  ") {
+		t.Errorf("continuation line %q should be indented under the item", lines[1])
+	}
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestRecordMetric(t *testing.T) {
+	path := filepath.Join(t.TempDir(), utils.MetricsFile)
+
+	for _, sample := range []utils.MetricSample{
+		{Date: "2025-03-02", Overdue: 4, Active: 10},
+		{Date: "2025-03-01", Overdue: 6, Active: 12},
+		{Date: "2025-03-02", Overdue: 3, Active: 9}, // Replaces the first sample of the day
+	} {
+		if err := utils.RecordMetric(path, sample); err != nil {
+			t.Fatalf("RecordMetric: %v", err)
+		}
+	}
+
+	samples, err := utils.LoadMetrics(path)
+	if err != nil {
+		t.Fatalf("LoadMetrics: %v", err)
+	}
+	if len(samples) != 2 || samples[0].Date != "2025-03-01" || samples[1].Overdue != 3 {
+		t.Errorf("samples = %+v", samples)
+	}
+
+	now := time.Date(2025, 3, 2, 12, 0, 0, 0, time.Local)
+	if recent := utils.RecentMetrics(samples, 1, now); len(recent) != 1 || recent[0].Date != "2025-03-02" {
+		t.Errorf("RecentMetrics(1 day) = %+v", recent)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := utils.Sparkline([]int{0, 4, 8, 2}); got != "▁▄█▂" {
+		t.Errorf("Sparkline = %q", got)
+	}
+	if got := utils.Sparkline([]int{0, 0}); got != "▁▁" {
+		t.Errorf("Sparkline of zeros = %q", got)
+	}
+
+	utils.SetAccessibleMode(true)
+	defer utils.SetAccessibleMode(false)
+	if got := utils.Sparkline([]int{3, 1}); got != "3 1" {
+		t.Errorf("accessible Sparkline = %q", got)
+	}
+}
@@ -0,0 +1,255 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestParseEdit(t *testing.T) {
+	due := time.Date(2030, 3, 20, 10, 0, 0, 0, time.Local)
+
+	edit, err := utils.ParseEdit("push to 3pm and make it high priority", due, nil)
+	if err != nil {
+		t.Fatalf("ParseEdit: %v", err)
+	}
+	if want := time.Date(2030, 3, 20, 15, 0, 0, 0, time.Local); edit.DueTime == nil || !edit.DueTime.Equal(want) {
+		t.Errorf("DueTime = %v, want %v (a clock time keeps the day)", edit.DueTime, want)
+	}
+	if edit.Priority == nil || *edit.Priority != models.High {
+		t.Errorf("Priority = %v, want high", edit.Priority)
+	}
+
+	edit, err = utils.ParseEdit("push back 2 days, tag #work and remove #home", due, nil)
+	if err != nil {
+		t.Fatalf("ParseEdit: %v", err)
+	}
+	if want := due.AddDate(0, 0, 2); edit.DueTime == nil || !edit.DueTime.Equal(want) {
+		t.Errorf("DueTime = %v, want %v", edit.DueTime, want)
+	}
+	if len(edit.AddTags) != 1 || edit.AddTags[0] != "work" {
+		t.Errorf("AddTags = %v, want [work]", edit.AddTags)
+	}
+	if len(edit.RemoveTags) != 1 || edit.RemoveTags[0] != "home" {
+		t.Errorf("RemoveTags = %v, want [home]", edit.RemoveTags)
+	}
+
+	// A weekday keeps the clock time
+	edit, err = utils.ParseEdit("move to friday", due, nil)
+	if err != nil {
+		t.Fatalf("ParseEdit: %v", err)
+	}
+	if edit.DueTime == nil || edit.DueTime.Weekday() != time.Friday || edit.DueTime.Hour() != 10 {
+		t.Errorf("DueTime = %v, want a Friday at 10:00", edit.DueTime)
+	}
+
+	edit, err = utils.ParseEdit("rename to Call mom at 5pm", due, nil)
+	if err != nil {
+		t.Fatalf("ParseEdit: %v", err)
+	}
+	if edit.Title != "Call mom at 5pm" || edit.DueTime != nil {
+		t.Errorf("Title = %q, DueTime = %v; want the rest as title and no time change", edit.Title, edit.DueTime)
+	}
+
+	if _, err := utils.ParseEdit("hello there", due, nil); err == nil {
+		t.Error("ParseEdit should fail when nothing is recognized")
+	}
+}
+
+func TestParseTimesOfDay(t *testing.T) {
+	now := time.Date(2024, 3, 20, 10, 0, 0, 0, time.Local) // a Wednesday
+	at := func(day, hour, minute int) time.Time { return time.Date(2024, 3, day, hour, minute, 0, 0, time.Local) }
+	early := models.TimesOfDay{"morning": "07:30", "evening": "19:15"}
+
+	tests := []struct {
+		text  string
+		times models.TimesOfDay
+		title string
+		due   time.Time
+	}{
+		{"Run tomorrow morning", nil, "Run", at(21, 9, 0)},
+		{"Run tomorrow morning", early, "Run", at(21, 7, 30)},
+		{"Call mom tonight", early, "Call mom", at(20, 20, 0)},
+		{"Walk in the evening", early, "Walk", at(20, 19, 15)},
+		{"Pack at midnight", nil, "Pack", at(21, 0, 0)},
+		{"Stretch this morning", nil, "Stretch", at(21, 9, 0)},
+		{"Review friday afternoon", nil, "Review", at(22, 15, 0)},
+		{"Lunch at noon", models.TimesOfDay{"noon": "12:30"}, "Lunch", at(20, 12, 30)},
+		{"Run tomorrow morning", models.TimesOfDay{"noon": "12:30"}, "Run", at(21, 9, 0)},
+		{"Run tomorrow morning", models.TimesOfDay{"morning": "7am"}, "Run", at(21, 9, 0)},
+	}
+	for _, tt := range tests {
+		parsed, err := utils.TextParser{DefaultPriority: models.Medium, TimesOfDay: tt.times}.Parse(tt.text, now)
+		if err != nil {
+			t.Errorf("Parse(%q, %v): %v", tt.text, tt.times, err)
+			continue
+		}
+		if parsed.Title != tt.title || !parsed.DueTime.Equal(tt.due) || !parsed.HasTime {
+			t.Errorf("Parse(%q, %v) = %q at %v; want %q at %v", tt.text, tt.times, parsed.Title, parsed.DueTime, tt.title, tt.due)
+		}
+	}
+
+	edit, err := utils.ParseEdit("move to friday morning", at(21, 14, 0), early)
+	if err != nil || edit.DueTime == nil || edit.DueTime.Weekday() != time.Friday || edit.DueTime.Hour() != 7 || edit.DueTime.Minute() != 30 {
+		t.Errorf("ParseEdit with the morning at 07:30 = %+v, %v", edit, err)
+	}
+	due, err := utils.ParseDueTime("tomorrow evening", early)
+	if err != nil || due.Hour() != 19 || due.Minute() != 15 {
+		t.Errorf("ParseDueTime with the evening at 19:15 = %v, %v", due, err)
+	}
+
+	if _, _, ok := early.Clock("brunch"); ok {
+		t.Error("Clock should not know brunch")
+	}
+}
+
+func TestAddUsesConfiguredTimesOfDay(t *testing.T) {
+	h := newHarness(t)
+	if morning := h.app.GetConfig().TimesOfDay["morning"]; morning != "09:00" {
+		t.Fatalf("default morning = %q, want 09:00", morning)
+	}
+
+	h.app.GetConfig().TimesOfDay["morning"] = "07:30"
+	h.mustRun("add", "Run tomorrow morning")
+	if due := h.reminder("Run").DueTime; due.Hour() != 7 || due.Minute() != 30 {
+		t.Errorf("due = %v, want 07:30", due)
+	}
+}
+
+func TestParseShift(t *testing.T) {
+	due := time.Date(2030, 3, 20, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		text string
+		want time.Time
+	}{
+		{"+2d", due.AddDate(0, 0, 2)},
+		{"-3h", due.Add(-3 * time.Hour)},
+		{"1w", due.AddDate(0, 0, 7)},
+		{"+1d12h", due.AddDate(0, 0, 1).Add(12 * time.Hour)},
+		{"-30m", due.Add(-30 * time.Minute)},
+	}
+	for _, tt := range tests {
+		shift, err := utils.ParseShift(tt.text)
+		if err != nil {
+			t.Errorf("ParseShift(%q): %v", tt.text, err)
+			continue
+		}
+		if got := shift.Apply(due); !got.Equal(tt.want) {
+			t.Errorf("ParseShift(%q).Apply = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+
+	for _, text := range []string{"", "+", "2", "+2y", "tomorrow"} {
+		if _, err := utils.ParseShift(text); err == nil {
+			t.Errorf("ParseShift(%q) should fail", text)
+		}
+	}
+}
+
+func TestValidateReminderInputLimits(t *testing.T) {
+	defer utils.SetDueLimits(time.Hour, 10)
+	now := time.Now()
+
+	if err := utils.ValidateReminderInput("Old", now.Add(-2*time.Hour), false); err == nil {
+		t.Error("a due time 2 hours ago should be rejected by default")
+	}
+	if err := utils.ValidateReminderInput("Old", now.AddDate(-1, 0, 0), true); err != nil {
+		t.Errorf("pastOK should allow old due times: %v", err)
+	}
+
+	utils.SetDueLimits(3*time.Hour, 1)
+	if err := utils.ValidateReminderInput("Old", now.Add(-2*time.Hour), false); err != nil {
+		t.Errorf("a 3 hour grace period should allow 2 hours ago: %v", err)
+	}
+	if err := utils.ValidateReminderInput("Far", now.AddDate(2, 0, 0), false); err == nil {
+		t.Error("2 years ahead should be rejected with a 1 year limit")
+	}
+
+	utils.SetDueLimits(time.Hour, 0)
+	if err := utils.ValidateReminderInput("Far", now.AddDate(50, 0, 0), false); err != nil {
+		t.Errorf("0 years should mean no limit: %v", err)
+	}
+}
+
+func TestParseReminderPriority(t *testing.T) {
+	parsed, err := utils.ParseReminder("Water the plants", models.High)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Priority != models.High || parsed.HasPriority {
+		t.Errorf("without a keyword: priority %s, HasPriority %v; want the default", parsed.Priority, parsed.HasPriority)
+	}
+
+	parsed, _ = utils.ParseReminder("Pay rent urgent", models.Low)
+	if parsed.Priority != models.High || !parsed.HasPriority || parsed.Title != "Pay rent" {
+		t.Errorf("with a keyword: %+v", parsed)
+	}
+}
+
+func TestInferPriority(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local)
+	tests := []struct {
+		due      time.Time
+		priority models.Priority
+		inferred bool
+	}{
+		{now.Add(30 * time.Minute), models.High, true},
+		{now.Add(-time.Hour), models.High, true},
+		{now.Add(3 * 24 * time.Hour), models.Medium, false},
+		{now.AddDate(0, 1, 0), models.Low, true},
+	}
+	for _, tt := range tests {
+		if priority, inferred := utils.InferPriority(tt.due, now); priority != tt.priority || inferred != tt.inferred {
+			t.Errorf("InferPriority(%s) = %s, %v; want %s, %v", tt.due.Sub(now), priority, inferred, tt.priority, tt.inferred)
+		}
+	}
+}
+
+func TestParseReminderAt(t *testing.T) {
+	added := time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local)
+	parsed, err := utils.ParseReminderAt("Stretch in 2 hours", models.Medium, added)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.HasTime || !parsed.DueTime.Equal(added.Add(2*time.Hour)) {
+		t.Errorf("due %s, want 2 hours after %s", parsed.DueTime, added)
+	}
+}
+
+func TestParseDueRange(t *testing.T) {
+	today, _ := utils.ParseDateString("today")
+	yesterday := today.AddDate(0, 0, -1)
+	tomorrow := today.AddDate(0, 0, 1)
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		text         string
+		from, before time.Time
+	}{
+		{"due before today", time.Time{}, today},
+		{"everything from yesterday", yesterday, today},
+		{"yesterday", yesterday, today},
+		{"until today", time.Time{}, tomorrow},
+		{"after today", tomorrow, time.Time{}},
+		{"since 2024-03-01", march, time.Time{}},
+		{"between 2024-03-01 and 2024-03-10", march, march.AddDate(0, 0, 10)},
+	}
+	for _, tt := range tests {
+		from, before, err := utils.ParseDueRange(tt.text)
+		if err != nil {
+			t.Errorf("ParseDueRange(%q): %v", tt.text, err)
+			continue
+		}
+		if !from.Equal(tt.from) || !before.Equal(tt.before) {
+			t.Errorf("ParseDueRange(%q) = %v..%v, want %v..%v", tt.text, from, before, tt.from, tt.before)
+		}
+	}
+
+	for _, text := range []string{"", "due", "before someday", "between today"} {
+		if _, _, err := utils.ParseDueRange(text); err == nil {
+			t.Errorf("ParseDueRange(%q) should fail", text)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestPlanDay(t *testing.T) {
+	now := time.Date(2024, 3, 20, 8, 30, 0, 0, time.Local)
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.Local) }
+	completedAt := at(20, 8)
+
+	reminders := []*models.Reminder{
+		{ID: "late", Title: "File taxes", DueTime: at(18, 9)},
+		{ID: "noon", Title: "Lunch with Sam", DueTime: at(20, 12)},
+		{ID: "nine", Title: "Standup", DueTime: at(20, 9)},
+		{ID: "tomorrow", Title: "Dentist", DueTime: at(21, 9)},
+		{ID: "done", Title: "Walk the dog", DueTime: at(20, 7), Completed: true, CompletedAt: &completedAt},
+		{ID: "paused", Title: "Gym", DueTime: at(20, 10), Recurring: &models.RecurringRule{Frequency: "daily", Paused: true}},
+		{ID: "pills", Title: "Take pills", DueTime: at(21, 8), Recurring: &models.RecurringRule{Frequency: "daily"},
+			History: []models.Occurrence{{DueTime: at(20, 8), At: at(20, 8), Status: models.OccurrenceOnTime}}},
+	}
+
+	overdue, today := utils.PlanDay(reminders, now)
+	if len(overdue) != 1 || overdue[0].ID != "late" {
+		t.Errorf("overdue = %v, want [late]", overdue)
+	}
+	if len(today) != 2 || today[0].ID != "nine" || today[1].ID != "noon" {
+		t.Errorf("today = %v, want [nine noon]", today)
+	}
+	if done := utils.DoneToday(reminders, now); done != 2 {
+		t.Errorf("DoneToday() = %d, want 2 (one completed, one occurrence)", done)
+	}
+
+	plan := utils.PlanMessage(reminders, now, nil, 0)
+	for _, want := range []string{"2 due today", "1 overdue", "First up: Standup", "nancy review --today"} {
+		if !strings.Contains(plan, want) {
+			t.Errorf("PlanMessage() = %q, missing %q", plan, want)
+		}
+	}
+
+	evening := time.Date(2024, 3, 20, 18, 0, 0, 0, time.Local)
+	wrapUp := utils.WrapUpMessage(reminders, evening)
+	for _, want := range []string{"2 done today", "3 rolling over", "File taxes, Standup, Lunch with Sam"} {
+		if !strings.Contains(wrapUp, want) {
+			t.Errorf("WrapUpMessage() = %q, missing %q", wrapUp, want)
+		}
+	}
+	if wrapUp := utils.WrapUpMessage(reminders[3:], evening); !strings.Contains(wrapUp, "Nothing left over") {
+		t.Errorf("WrapUpMessage() with nothing left = %q", wrapUp)
+	}
+}
+
+func TestPreferredHour(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 15, 0, 0, time.Local) }
+	done := func(tag string, hour int) *models.Reminder {
+		completed := at(10, hour)
+		return &models.Reminder{Tags: []string{tag}, Completed: true, CompletedAt: &completed}
+	}
+	// A recurring reminder whose last occurrence was completed for good has
+	// it both in its history and as its completion time
+	final := at(12, 7)
+	habit := &models.Reminder{Tags: []string{"health"}, Completed: true, CompletedAt: &final,
+		Recurring: &models.RecurringRule{Frequency: "daily", Final: true},
+		History: []models.Occurrence{
+			{At: at(11, 7), Status: models.OccurrenceOnTime},
+			{At: at(11, 18), Status: models.OccurrenceSkipped},
+			{At: final, Status: models.OccurrenceOnTime},
+		}}
+
+	tests := []struct {
+		name      string
+		reminders []*models.Reminder
+		tags      []string
+		hour      int
+		count     int
+		ok        bool
+	}{
+		{"most completions", []*models.Reminder{done("admin", 16), done("admin", 16), done("admin", 9)}, []string{"admin"}, 16, 2, true},
+		{"other tags don't count", []*models.Reminder{done("admin", 16), done("admin", 16), done("work", 16)}, []string{"admin"}, 0, 0, false},
+		{"no tags", []*models.Reminder{done("admin", 16), done("admin", 16), done("admin", 16)}, nil, 0, 0, false},
+		{"earlier hour wins ties", []*models.Reminder{done("admin", 16), done("admin", 9), done("admin", 16), done("admin", 9)}, []string{"admin"}, 9, 2, true},
+		{"last occurrence counted once", []*models.Reminder{habit}, []string{"health"}, 0, 0, false},
+		{"occurrences and completions", []*models.Reminder{habit, done("health", 7)}, []string{"health"}, 7, 3, true},
+	}
+	for _, tt := range tests {
+		hour, count, ok := utils.PreferredHour(tt.reminders, tt.tags, time.Local)
+		if hour != tt.hour || count != tt.count || ok != tt.ok {
+			t.Errorf("%s: PreferredHour() = %d, %d, %v; want %d, %d, %v", tt.name, hour, count, ok, tt.hour, tt.count, tt.ok)
+		}
+	}
+}
+
+func TestSuggestDueTime(t *testing.T) {
+	// March 4th, 2024 is a Monday
+	at := func(day, hour, minute int) time.Time { return time.Date(2024, 3, day, hour, minute, 0, 0, time.Local) }
+	due := func(times ...time.Time) []*models.Reminder {
+		var reminders []*models.Reminder
+		for _, t := range times {
+			reminders = append(reminders, &models.Reminder{DueTime: t})
+		}
+		return reminders
+	}
+	// A reminder at noon on every weekday, so no day is quieter than another
+	noons := due(at(4, 12, 0), at(5, 12, 0), at(6, 12, 0), at(7, 12, 0), at(8, 12, 0))
+	done := at(4, 9, 0)
+
+	tests := []struct {
+		name       string
+		existing   []*models.Reminder
+		start, end string
+		estimate   time.Duration
+		from       time.Time
+		want       time.Time
+	}{
+		{"first free hour", nil, "09:00", "17:00", 0, at(4, 8, 20), at(4, 9, 0)},
+		{"next full hour", nil, "09:00", "17:00", 0, at(4, 10, 5), at(4, 11, 0)},
+		{"crowded day", due(at(4, 15, 0)), "09:00", "17:00", 0, at(4, 8, 20), at(5, 9, 0)},
+		{"crowded hour", noons, "09:00", "17:00", 0, at(4, 11, 30), at(4, 13, 0)},
+		{"crowded days", append(due(at(4, 9, 0), at(5, 9, 0), at(6, 9, 0), at(7, 9, 0), at(8, 9, 0)), noons...), "09:00", "17:00", 0, at(4, 8, 20), at(4, 10, 0)},
+		{"completed reminders are free", []*models.Reminder{{DueTime: done, Completed: true, CompletedAt: &done}}, "09:00", "17:00", 0, at(4, 8, 20), at(4, 9, 0)},
+		{"weekend", nil, "09:00", "17:00", 0, at(9, 10, 0), at(11, 9, 0)},
+		{"estimate fits before a crowded hour", noons, "09:00", "17:00", 3 * time.Hour, at(4, 8, 20), at(4, 9, 0)},
+		{"estimate overlapping a crowded hour", noons, "09:00", "17:00", 4 * time.Hour, at(4, 8, 20), at(4, 13, 0)},
+		{"part of an hour still occupies it", noons, "09:00", "17:00", 3*time.Hour + time.Minute, at(4, 8, 20), at(4, 13, 0)},
+		{"estimate longer than the working day", nil, "09:00", "17:00", 10 * time.Hour, at(4, 8, 20), at(4, 9, 0)},
+		{"estimate past the end of the day", nil, "09:00", "17:00", 2 * time.Hour, at(4, 15, 10), at(5, 9, 0)},
+		{"work starts on the half hour", nil, "08:30", "17:30", 0, at(4, 7, 0), at(4, 8, 30)},
+		{"last hour before work ends", nil, "08:30", "17:30", 0, at(4, 15, 10), at(4, 16, 0)},
+		{"no room for an hour before work ends", nil, "08:30", "17:30", 0, at(4, 16, 10), at(5, 8, 30)},
+		{"room for half an hour before work ends", nil, "08:30", "17:30", 30 * time.Minute, at(4, 16, 10), at(4, 17, 0)},
+		{"invalid working hours", nil, "18:00", "08:00", 0, at(4, 7, 0), at(4, 9, 0)},
+	}
+	for _, tt := range tests {
+		got := utils.SuggestDueTime(tt.existing, tt.start, tt.end, tt.estimate, tt.from)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: SuggestDueTime() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestParserPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugin is a shell script")
+	}
+	plugin := filepath.Join(t.TempDir(), utils.ParserPluginFile)
+	script := `#!/bin/sh
+read text
+case "$text" in
+  *p1*) echo '{"title": "Fix login tomorrow at 10am", "priority": "high", "tags": ["#triage", "work"]}' ;;
+  *eod*) echo '{"due": "2025-03-10T17:00:00Z"}' ;;
+  *bad*) echo 'not json' ;;
+esac
+`
+	if err := os.WriteFile(plugin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !utils.HasParserPlugin(plugin) || utils.HasParserPlugin(filepath.Join(t.TempDir(), "missing")) {
+		t.Fatal("HasParserPlugin should find only the executable plugin")
+	}
+
+	result, err := utils.RunParserPlugin(plugin, "Fix login p1 tomorrow at 10am #work")
+	if err != nil {
+		t.Fatalf("RunParserPlugin: %v", err)
+	}
+	parsed, err := utils.ParseReminder(result.Title, models.Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result.Apply(parsed)
+	if parsed.Title != "Fix login" || parsed.Priority != models.High || !parsed.HasTime || len(parsed.Tags) != 2 {
+		t.Errorf("merged result = %+v", parsed)
+	}
+
+	// The plugin's due time wins over the built-in parser's
+	result, _ = utils.RunParserPlugin(plugin, "Send report eod")
+	parsed, _ = utils.ParseReminder("Send report eod", models.Medium)
+	result.Apply(parsed)
+	if want := time.Date(2025, 3, 10, 17, 0, 0, 0, time.UTC); !parsed.DueTime.Equal(want) || !parsed.HasTime {
+		t.Errorf("due = %s, want %s", parsed.DueTime, want)
+	}
+
+	// Printing nothing leaves the text alone; invalid JSON is an error
+	if result, err := utils.RunParserPlugin(plugin, "Water the plants"); err != nil || result.Title != "" || result.Due != nil {
+		t.Errorf("RunParserPlugin without output = %+v, %v", result, err)
+	}
+	if _, err := utils.RunParserPlugin(plugin, "bad"); err == nil {
+		t.Error("invalid JSON should be an error")
+	}
+
+	// 'nancy add' and the REST API parse text through the plugin first, and
+	// ignore it when it fails
+	var warned error
+	parser := utils.TextParser{Plugin: plugin, DefaultPriority: models.Low, Warn: func(err error) { warned = err }}
+	if parsed, err := parser.Parse("Fix login p1", time.Now()); err != nil || parsed.Priority != models.High || !parsed.HasTime {
+		t.Errorf("Parse with the plugin = %+v, %v", parsed, err)
+	}
+	if parsed, err := parser.Parse("bad idea", time.Now()); err != nil || parsed.Title != "bad idea" || parsed.Priority != models.Low || warned == nil {
+		t.Errorf("Parse with a failing plugin = %+v, %v; warned %v", parsed, err, warned)
+	}
+
+	store := newTestStore(t)
+	api := httptest.NewServer(utils.NewAPIHandler(store, "", parser))
+	defer api.Close()
+	resp, err := http.Post(api.URL+utils.APIPrefix+"/reminders", "application/json", strings.NewReader(`{"input": "Fix login p1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if r, err := store.GetByShortID(1); err != nil || r.Priority != models.High || !r.HasTag("triage") {
+		t.Errorf("POST text through the plugin added %+v, %v", r, err)
+	}
+}
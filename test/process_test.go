@@ -0,0 +1,90 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestRuntimeDir(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if got := utils.RuntimeDir("/home/me/.config/nancy"); got != filepath.Join("/run/user/1000", "nancy") {
+		t.Errorf("RuntimeDir = %q", got)
+	}
+
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if got := utils.RuntimeDir("/home/me/.config/nancy"); got != "/home/me/.config/nancy" {
+		t.Errorf("RuntimeDir without XDG_RUNTIME_DIR = %q", got)
+	}
+}
+
+func TestStateDir(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		if got := utils.StateDir("/config/nancy"); got != "/config/nancy" {
+			t.Errorf("StateDir = %q, want the fallback", got)
+		}
+		return
+	}
+
+	t.Setenv("XDG_STATE_HOME", "/home/me/state")
+	if got := utils.StateDir("/home/me/.config/nancy"); got != filepath.Join("/home/me/state", "nancy") {
+		t.Errorf("StateDir = %q", got)
+	}
+
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("HOME", "/home/me")
+	if got := utils.StateDir("/home/me/.config/nancy"); got != filepath.Join("/home/me", ".local", "state", "nancy") {
+		t.Errorf("StateDir without XDG_STATE_HOME = %q", got)
+	}
+}
+
+func TestCheckProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process owners are only checked on Unix")
+	}
+
+	if running, err := utils.CheckProcess(os.Getpid()); !running || err != nil {
+		t.Errorf("CheckProcess(self) = %v, %v", running, err)
+	}
+
+	// A process that has exited and been reaped is gone
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("can't run true: %v", err)
+	}
+	if running, err := utils.CheckProcess(cmd.Process.Pid); running || err != nil {
+		t.Errorf("CheckProcess(exited) = %v, %v", running, err)
+	}
+
+	// init belongs to root, so it's off limits to everyone else
+	if os.Getuid() != 0 {
+		if _, err := utils.CheckProcess(1); !errors.Is(err, utils.ErrForeignProcess) {
+			t.Errorf("CheckProcess(1) = %v, want ErrForeignProcess", err)
+		}
+	}
+}
+
+func TestIsDaemonCommand(t *testing.T) {
+	exe := "/usr/local/bin/nancy"
+	for _, tc := range []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"/usr/local/bin/nancy", "daemon", "start", "--foreground"}, true},
+		{[]string{"nancy", "--config", "x.yaml", "daemon", "start"}, true},
+		{[]string{"/opt/nancy-1.2/nancy.exe", "daemon", "start"}, true},
+		{[]string{"/usr/local/bin/nancy", "daemon", "status"}, false},
+		{[]string{"/usr/bin/sleep", "daemon", "start"}, false},
+		{[]string{"nancy"}, false},
+		{nil, false},
+	} {
+		if got := utils.IsDaemonCommand(tc.args, exe); got != tc.want {
+			t.Errorf("IsDaemonCommand(%q) = %v, want %v", tc.args, got, tc.want)
+		}
+	}
+}
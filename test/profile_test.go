@@ -0,0 +1,45 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestProfiler(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), utils.ProfileDir)
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)
+
+	p, err := utils.StartProfile(dir, "daemon start", now)
+	if err != nil {
+		t.Fatalf("StartProfile: %v", err)
+	}
+	p.Phase("store load", 30*time.Millisecond)
+	p.Phase("run", 12*time.Millisecond)
+
+	path, err := p.Stop()
+	if err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	prefix := filepath.Join(dir, "20250303-090000-daemon-start")
+	for _, suffix := range []string{"-cpu.pprof", "-heap.pprof", "-timings.txt"} {
+		if _, err := os.Stat(prefix + suffix); err != nil {
+			t.Errorf("missing %s: %v", suffix, err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"nancy daemon start", "store load", "run", "total"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("timings lack %q:\n%s", want, data)
+		}
+	}
+	if !strings.Contains(string(data), "42ms") {
+		t.Errorf("total should be 42ms:\n%s", data)
+	}
+}
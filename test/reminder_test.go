@@ -1 +1,515 @@
 package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestPriorityMarshalsAsString(t *testing.T) {
+	for priority, want := range map[models.Priority]string{
+		models.Low:    `"low"`,
+		models.Medium: `"medium"`,
+		models.High:   `"high"`,
+	} {
+		data, err := json.Marshal(priority)
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", priority, err)
+		}
+		if string(data) != want {
+			t.Errorf("Marshal(%v) = %s, want %s", priority, data, want)
+		}
+	}
+}
+
+func TestPriorityUnmarshal(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    models.Priority
+		wantErr bool
+	}{
+		{`"low"`, models.Low, false},
+		{`"medium"`, models.Medium, false},
+		{`"high"`, models.High, false},
+		{`0`, models.Low, false},
+		{`1`, models.Medium, false},
+		{`2`, models.High, false},
+		{`"urgent"`, 0, true},
+		{`7`, 0, true},
+		{`true`, 0, true},
+	}
+
+	for _, tt := range tests {
+		var got models.Priority
+		err := json.Unmarshal([]byte(tt.input), &got)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Unmarshal(%s): expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestStoreMigratesLegacyPriorities(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "reminders.json")
+	legacy := `[{"id": "a", "title": "Legacy", "due_time": "2030-01-01T09:00:00Z", "priority": 2}]`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	reminder, err := store.Get("a")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if reminder.Priority != models.High {
+		t.Errorf("priority = %v, want high", reminder.Priority)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"priority": "high"`) {
+		t.Errorf("file was not migrated:\n%s", data)
+	}
+}
+
+func TestRecurringCountStopsAfterLastOccurrence(t *testing.T) {
+	due := time.Now().Add(time.Hour)
+	reminder := models.NewReminder("Stretch", due, models.Low)
+	reminder.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 1, Count: 3, Occurrence: 1}
+
+	for occurrence := 2; occurrence <= 3; occurrence++ {
+		reminder.Complete()
+		if reminder.Completed {
+			t.Fatalf("occurrence %d: reminder completed instead of rolling forward", occurrence)
+		}
+		if reminder.Recurring.Occurrence != occurrence {
+			t.Errorf("occurrence = %d, want %d", reminder.Recurring.Occurrence, occurrence)
+		}
+	}
+	if !reminder.Recurring.Final {
+		t.Error("third occurrence should be marked as the last one")
+	}
+
+	reminder.Complete()
+	if !reminder.Completed {
+		t.Error("completing the last occurrence should not respawn it")
+	}
+}
+
+func TestRecurringUntilStopsAtEndDate(t *testing.T) {
+	due := time.Now().Add(time.Hour)
+	until := due.AddDate(0, 0, 14)
+	reminder := models.NewReminder("Review", due, models.Medium)
+	reminder.Recurring = &models.RecurringRule{Frequency: "weekly", Interval: 1, EndDate: &until}
+
+	reminder.Complete()
+	reminder.Complete()
+	if reminder.Completed || !reminder.Recurring.Final {
+		t.Fatalf("expected the third weekly occurrence to be the last, got completed=%v final=%v",
+			reminder.Completed, reminder.Recurring.Final)
+	}
+	if want := due.AddDate(0, 0, 14); !reminder.DueTime.Equal(want) {
+		t.Errorf("due = %v, want %v", reminder.DueTime, want)
+	}
+
+	reminder.Complete()
+	if !reminder.Completed {
+		t.Error("reminder should complete once the end date is reached")
+	}
+}
+
+func TestRecurringMonthlyKeepsDay(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name     string
+		due      time.Time
+		interval int
+		want     []time.Time
+	}{
+		{"31st", date(2030, time.January, 31), 1, []time.Time{
+			date(2030, time.February, 28), date(2030, time.March, 31), date(2030, time.April, 30), date(2030, time.May, 31),
+		}},
+		{"30th", date(2030, time.January, 30), 1, []time.Time{
+			date(2030, time.February, 28), date(2030, time.March, 30),
+		}},
+		{"leap day", date(2032, time.February, 29), 1, []time.Time{
+			date(2032, time.March, 29), date(2032, time.April, 29),
+		}},
+		{"leap day yearly", date(2032, time.February, 29), 12, []time.Time{
+			date(2033, time.February, 28), date(2034, time.February, 28), date(2035, time.February, 28), date(2036, time.February, 29),
+		}},
+		{"30th across the year", date(2030, time.November, 30), 3, []time.Time{
+			date(2031, time.February, 28), date(2031, time.May, 30),
+		}},
+	}
+	for _, tt := range tests {
+		reminder := models.NewReminder("Pay rent", tt.due, models.High)
+		reminder.Recurring = &models.RecurringRule{Frequency: "monthly", Interval: tt.interval}
+		for i, want := range tt.want {
+			reminder.Complete()
+			if !reminder.DueTime.Equal(want) {
+				t.Errorf("%s: occurrence %d due %s, want %s", tt.name, i+2, reminder.DueTime.Format("2006-01-02"), want.Format("2006-01-02"))
+				break
+			}
+		}
+	}
+
+	// Moving the due time to another day moves the later occurrences with it
+	reminder := models.NewReminder("Pay rent", date(2030, time.January, 31), models.High)
+	reminder.Recurring = &models.RecurringRule{Frequency: "monthly", Interval: 1}
+	reminder.Complete()
+	reminder.DueTime = date(2030, time.February, 15)
+	reminder.Complete()
+	if want := date(2030, time.March, 15); !reminder.DueTime.Equal(want) {
+		t.Errorf("after moving to the 15th: due %s, want %s", reminder.DueTime.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+}
+
+func TestRecurringSkipAndPause(t *testing.T) {
+	due := time.Now().Add(time.Hour)
+	reminder := models.NewReminder("Standup", due, models.Medium)
+
+	if err := reminder.Skip(); err == nil {
+		t.Error("skipping a non-recurring reminder should fail")
+	}
+
+	reminder.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 1}
+	if err := reminder.Skip(); err != nil {
+		t.Fatalf("Skip: %v", err)
+	}
+	if reminder.Completed || !reminder.DueTime.Equal(due.AddDate(0, 0, 1)) {
+		t.Errorf("skip should move to tomorrow without completing, got due=%v completed=%v",
+			reminder.DueTime, reminder.Completed)
+	}
+
+	if err := reminder.SetPaused(true); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if err := reminder.Skip(); err == nil {
+		t.Error("skipping a paused reminder should fail")
+	}
+	reminder.Complete()
+	if !reminder.Completed {
+		t.Error("completing a paused reminder should not roll it forward")
+	}
+	reminder.Uncomplete()
+
+	// Resuming an overdue reminder jumps to the next upcoming occurrence
+	reminder.DueTime = time.Now().AddDate(0, 0, -3)
+	if err := reminder.SetPaused(false); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if !reminder.DueTime.After(time.Now()) {
+		t.Errorf("resumed reminder is still overdue: %v", reminder.DueTime)
+	}
+}
+
+func TestRecurringExclusions(t *testing.T) {
+	due := time.Date(2030, time.July, 3, 9, 0, 0, 0, time.Local) // a Wednesday
+	rule := &models.RecurringRule{Frequency: "weekdays", Count: 3}
+	if err := rule.AddExclusion("2030-07-04"); err != nil {
+		t.Fatalf("AddExclusion: %v", err)
+	}
+	if err := rule.AddExclusion("July 4th"); err == nil {
+		t.Error("expected an error for a malformed date")
+	}
+
+	// Thursday is excluded and the weekend is skipped, neither counting
+	// towards the three occurrences
+	next, ok := rule.NextOccurrence(due, due)
+	if want := time.Date(2030, time.July, 5, 9, 0, 0, 0, time.Local); !ok || !next.Equal(want) {
+		t.Fatalf("next = %v, %v; want %v", next, ok, want)
+	}
+
+	reminder := models.NewReminder("Standup", due.AddDate(0, 0, 1), models.Medium)
+	reminder.Recurring = rule
+	if !reminder.SkipExcludedDates() {
+		t.Fatal("reminder on an excluded date should move")
+	}
+	if reminder.DueTime.Day() != 5 {
+		t.Errorf("due = %v, want July 5", reminder.DueTime)
+	}
+
+	if err := reminder.SetExclusions([]string{"2030-07-08"}, []string{"2030-07-04"}); err != nil {
+		t.Fatalf("SetExclusions: %v", err)
+	}
+	if len(rule.Exclude) != 1 || rule.Exclude[0] != "2030-07-08" {
+		t.Errorf("exclusions = %v, want [2030-07-08]", rule.Exclude)
+	}
+	reminder.Complete()
+	if reminder.DueTime.Day() != 9 {
+		t.Errorf("due after completing = %v, want July 9", reminder.DueTime)
+	}
+}
+
+func TestRecurringHistory(t *testing.T) {
+	due := time.Now().Add(-2 * time.Hour)
+	reminder := models.NewReminder("Pills", due, models.High)
+	reminder.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 1, Count: 3}
+
+	reminder.Complete() // late
+	reminder.Complete() // early, so on time
+	if err := reminder.Skip(); err == nil {
+		t.Fatal("skipping the last occurrence should fail")
+	}
+	reminder.Complete() // last occurrence
+
+	onTime, late, skipped, missed := reminder.Adherence()
+	if onTime != 2 || late != 1 || skipped != 0 || missed != 0 {
+		t.Errorf("adherence = %d/%d/%d/%d, want 2 on time, 1 late, 0 skipped, 0 missed", onTime, late, skipped, missed)
+	}
+	if !reminder.History[0].DueTime.Equal(due) || reminder.History[0].Status != models.OccurrenceLate {
+		t.Errorf("first history entry = %+v", reminder.History[0])
+	}
+
+	// Reopening the last occurrence forgets its entry
+	reminder.Uncomplete()
+	if len(reminder.History) != 2 {
+		t.Errorf("history length after reopening = %d, want 2", len(reminder.History))
+	}
+}
+
+func TestRecurringMissed(t *testing.T) {
+	due := time.Now().Add(-3*24*time.Hour - 12*time.Hour)
+	statuses := func(history []models.Occurrence) []string {
+		var out []string
+		for _, occurrence := range history {
+			out = append(out, occurrence.Status)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		action func(r *models.Reminder)
+		want   []string
+	}{
+		{"complete", func(r *models.Reminder) { r.Complete() },
+			[]string{models.OccurrenceLate, models.OccurrenceMissed, models.OccurrenceMissed, models.OccurrenceMissed}},
+		{"skip", func(r *models.Reminder) { r.Skip() },
+			[]string{models.OccurrenceSkipped, models.OccurrenceMissed, models.OccurrenceMissed, models.OccurrenceMissed}},
+		{"resume", func(r *models.Reminder) { r.SetPaused(true); r.SetPaused(false) }, nil},
+	}
+	for _, tt := range tests {
+		reminder := models.NewReminder("Stretch", due, models.Medium)
+		reminder.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 1}
+		tt.action(reminder)
+
+		if got := statuses(reminder.History); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: history = %v, want %v", tt.name, got, tt.want)
+		}
+		if !reminder.DueTime.Equal(due.AddDate(0, 0, 4)) {
+			t.Errorf("%s: due = %v, want %v", tt.name, reminder.DueTime, due.AddDate(0, 0, 4))
+		}
+		for i, occurrence := range reminder.History {
+			if !occurrence.DueTime.Equal(due.AddDate(0, 0, i)) {
+				t.Errorf("%s: occurrence %d due %v, want %v", tt.name, i, occurrence.DueTime, due.AddDate(0, 0, i))
+			}
+		}
+	}
+
+	reminder := models.NewReminder("Stretch", due, models.Medium)
+	reminder.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 1}
+	reminder.Complete()
+	if onTime, late, skipped, missed := reminder.Adherence(); onTime != 0 || late != 1 || skipped != 0 || missed != 3 {
+		t.Errorf("adherence = %d/%d/%d/%d, want 1 late, 3 missed", onTime, late, skipped, missed)
+	}
+}
+
+func TestDueSoonWindow(t *testing.T) {
+	defer models.SetDueSoonWindows(time.Hour, nil)
+	models.SetDueSoonWindows(30*time.Minute, map[models.Priority]time.Duration{
+		models.High: 2 * time.Hour,
+	})
+
+	in90 := time.Now().Add(90 * time.Minute)
+	in45 := time.Now().Add(45 * time.Minute)
+
+	if !models.NewReminder("high", in90, models.High).IsDueSoon() {
+		t.Error("high priority reminder should use its 2h window")
+	}
+	if models.NewReminder("medium", in45, models.Medium).IsDueSoon() {
+		t.Error("medium priority reminder should use the 30m global window")
+	}
+
+	custom := models.NewReminder("custom", in45, models.Medium)
+	custom.NotifyBefore = 60
+	if !custom.IsDueSoon() {
+		t.Error("NotifyBefore should override the global window")
+	}
+	custom.NotifyBefore = 10
+	custom.Priority = models.High
+	if custom.IsDueSoon() {
+		t.Error("NotifyBefore should override the priority window")
+	}
+}
+
+func TestNagInterval(t *testing.T) {
+	reminder := models.NewReminder("Take pills", time.Now().Add(-time.Minute), models.High)
+	if got := reminder.NagInterval(); got != time.Hour {
+		t.Errorf("default NagInterval = %v, want 1h", got)
+	}
+
+	reminder.Nag = 30
+	if got := reminder.NagInterval(); got != 30*time.Minute {
+		t.Errorf("NagInterval = %v, want 30m", got)
+	}
+
+	data, err := json.Marshal([]*models.Reminder{reminder})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := models.ValidateReminders(data); err != nil {
+		t.Errorf("a reminder with a nag cadence doesn't validate: %v", err)
+	}
+	var decoded []*models.Reminder
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded[0].Nag != 30 {
+		t.Errorf("round trip gave %v, %v", decoded, err)
+	}
+}
+
+func TestIsCritical(t *testing.T) {
+	defer models.SetHighPriorityCritical(false)
+
+	due := time.Now().Add(time.Hour)
+	high := models.NewReminder("high", due, models.High)
+	flagged := models.NewReminder("medication", due, models.Low)
+	flagged.Critical = true
+
+	if high.IsCritical() || !flagged.IsCritical() {
+		t.Error("only the flagged reminder should be critical by default")
+	}
+
+	models.SetHighPriorityCritical(true)
+	if !high.IsCritical() {
+		t.Error("high priority reminders should be critical with the priority rule")
+	}
+	if models.NewReminder("medium", due, models.Medium).IsCritical() {
+		t.Error("the priority rule should only cover high priority")
+	}
+}
+
+func TestLabelColor(t *testing.T) {
+	models.SetTagColors(map[string]string{"work": "blue", "home": "green"})
+	defer models.SetTagColors(nil)
+
+	r := models.NewReminder("Report", time.Now(), models.Medium)
+	if r.LabelColor() != "" {
+		t.Errorf("untagged reminder has color %q", r.LabelColor())
+	}
+
+	r.Tags = []string{"misc", "Work", "home"}
+	if r.LabelColor() != "blue" {
+		t.Errorf("LabelColor = %q, want the first tag rule (blue)", r.LabelColor())
+	}
+
+	r.Color = "red"
+	if r.LabelColor() != "red" {
+		t.Errorf("LabelColor = %q, own color should win", r.LabelColor())
+	}
+
+	for input, want := range map[string]string{"Blue": "blue", "grey": "gray", "none": "", "": ""} {
+		if got, err := models.ParseColor(input); err != nil || got != want {
+			t.Errorf("ParseColor(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := models.ParseColor("teal"); err == nil {
+		t.Error("ParseColor accepted teal")
+	}
+}
+
+func TestCheckIn(t *testing.T) {
+	now := time.Now()
+	r := models.NewReminder("Write thesis", now.AddDate(0, 3, 0), models.Medium)
+	r.CreatedAt = now.AddDate(0, 0, -8)
+	r.UpdatedAt = r.CreatedAt
+
+	if r.CheckInDue(now) {
+		t.Error("a reminder without check-ins shouldn't ask for one")
+	}
+
+	r.CheckIn = "weekly"
+	if !r.CheckInDue(now) {
+		t.Error("a weekly check-in should be due 8 days after creation")
+	}
+	if !r.IsStale(7) {
+		t.Error("a reminder untouched for 8 days should be stale")
+	}
+
+	r.RecordCheckIn(now)
+	if r.CheckInDue(now) || r.CheckInDue(now.AddDate(0, 0, 6)) {
+		t.Error("the check-in shouldn't be due again within a week")
+	}
+	if !r.CheckInDue(now.AddDate(0, 0, 7)) {
+		t.Error("the check-in should be due a week after the last one")
+	}
+	if r.IsStale(7) {
+		t.Error("a check-in should keep the reminder from going stale")
+	}
+
+	r.Complete()
+	if r.CheckInDue(now.AddDate(0, 1, 0)) {
+		t.Error("completed reminders shouldn't ask for check-ins")
+	}
+
+	for input, want := range map[string]string{"Weekly": "weekly", "biweekly": "biweekly", "off": "", "": ""} {
+		if got, err := models.ParseCheckIn(input); err != nil || got != want {
+			t.Errorf("ParseCheckIn(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := models.ParseCheckIn("hourly"); err == nil {
+		t.Error("ParseCheckIn accepted hourly")
+	}
+}
+
+func TestLink(t *testing.T) {
+	tests := []struct {
+		url         string
+		description string
+		want        string
+	}{
+		{"https://go.dev/blog/", "", "https://go.dev/blog/"},
+		{"HTTP://example.com", "", "HTTP://example.com"},
+		{"", "See https://example.com/notes for details", "https://example.com/notes"},
+		{"file:///etc/passwd", "", ""},
+		{"javascript:alert(1)", "or https://example.com", "https://example.com"},
+		{"--help", "", ""},
+		{"https://", "", ""},
+	}
+	for _, tt := range tests {
+		r := models.NewReminder("Read later", time.Now(), models.Low)
+		r.URL, r.Description = tt.url, tt.description
+		if got := r.Link(); got != tt.want {
+			t.Errorf("Link() with url %q = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+
+	if err := utils.OpenURL("-a Calculator"); err == nil {
+		t.Error("OpenURL accepted something that isn't a link")
+	}
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/tui/components"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	if _, ok := components.FuzzyMatch("bgr", "Buy groceries"); !ok {
+		t.Error("letters in order should match")
+	}
+	if _, ok := components.FuzzyMatch("rgb", "Buy groceries"); ok {
+		t.Error("letters out of order shouldn't match")
+	}
+	if _, ok := components.FuzzyMatch("BUY GRO", "buy groceries"); !ok {
+		t.Error("matching should ignore case and spaces")
+	}
+
+	groceries, _ := components.FuzzyMatch("gro", "Buy groceries")
+	car, _ := components.FuzzyMatch("gro", "Sign the car over")
+	if groceries <= car {
+		t.Errorf("a word match should outrank scattered letters: %d <= %d", groceries, car)
+	}
+}
+
+func TestReminderList(t *testing.T) {
+	due := time.Now().Add(time.Hour)
+	var reminders []*models.Reminder
+	for _, title := range []string{"Call mom", "Buy groceries", "Pay rent", "Water plants", "Go for a run"} {
+		reminders = append(reminders, models.NewReminder(title, due, models.Medium))
+	}
+
+	list := components.NewReminderList()
+	list.SetItems(reminders)
+	list.CursorDown()
+	list.CursorDown()
+	if got := list.Selected().Title; got != "Pay rent" {
+		t.Fatalf("Selected = %q, want Pay rent", got)
+	}
+
+	// Reloading in another order keeps the same reminder selected
+	list.SetItems([]*models.Reminder{reminders[4], reminders[2], reminders[0]})
+	if got := list.Selected().Title; got != "Pay rent" {
+		t.Errorf("Selected after reordering = %q, want Pay rent", got)
+	}
+
+	// Deleting the selected reminder selects the one in its place, and the
+	// last one when it was at the end
+	list.SetItems([]*models.Reminder{reminders[4], reminders[0]})
+	if got := list.Selected().Title; got != "Call mom" {
+		t.Errorf("Selected after deleting = %q, want Call mom", got)
+	}
+	list.SetItems(nil)
+	if list.Selected() != nil || list.Index() != 0 {
+		t.Errorf("empty list selects %v at %d", list.Selected(), list.Index())
+	}
+
+	// Pages follow the cursor
+	list.SetItems(reminders)
+	list.SetHeight(2)
+	list.GoToEnd()
+	if start, end := list.Page(); start != 4 || end != 5 {
+		t.Errorf("last page = %d..%d, want 4..5", start, end)
+	}
+	list.PrevPage()
+	if start, end := list.Page(); start != 2 || end != 4 {
+		t.Errorf("previous page = %d..%d, want 2..4", start, end)
+	}
+	if list.PageView() == "" {
+		t.Error("a list over several pages should show where it is")
+	}
+
+	// Searching narrows the list to the matches, best first
+	list.StartSearch()
+	for _, r := range "pa" {
+		list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	list.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if list.Searching() || list.Query() != "pa" {
+		t.Fatalf("after enter: searching %v, query %q", list.Searching(), list.Query())
+	}
+	items := list.Items()
+	if len(items) != 2 || items[0].Title != "Pay rent" || items[1].Title != "Water plants" {
+		t.Errorf("matches for pa = %v", titles(items))
+	}
+
+	list.ClearSearch()
+	if len(list.Items()) != len(reminders) {
+		t.Errorf("clearing the search shows %d reminders, want %d", len(list.Items()), len(reminders))
+	}
+}
+
+// titles lists the titles of reminders
+func titles(reminders []*models.Reminder) []string {
+	var result []string
+	for _, reminder := range reminders {
+		result = append(result, reminder.Title)
+	}
+	return result
+}
@@ -0,0 +1,333 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestRemoteStore(t *testing.T) {
+	server := newTestStore(t)
+	existing := models.NewReminder("On the server", time.Now().Add(time.Hour), models.Low)
+	if err := server.Add(existing); err != nil {
+		t.Fatal(err)
+	}
+
+	api := httptest.NewServer(utils.NewAPIHandler(server, "s3cret", utils.TextParser{DefaultPriority: models.Medium}))
+	defer api.Close()
+
+	if _, err := utils.NewRemoteClient("ftp://example.com", ""); err == nil {
+		t.Error("NewRemoteClient accepted an ftp URL")
+	}
+	wrong, _ := utils.NewRemoteClient(api.URL, "guess")
+	if _, err := models.NewRemoteStore(wrong); err == nil || !strings.Contains(err.Error(), "token") {
+		t.Errorf("a wrong token gave %v", err)
+	}
+
+	client, err := utils.NewRemoteClient(api.URL, "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := models.NewRemoteStore(client)
+	if err != nil {
+		t.Fatalf("NewRemoteStore: %v", err)
+	}
+	if !store.IsRemote() {
+		t.Error("IsRemote = false")
+	}
+	if r, err := store.GetByShortID(1); err != nil || r.Title != "On the server" {
+		t.Fatalf("GetByShortID(1) = %v, %v", r, err)
+	}
+
+	// Another client takes the next short ID in the meantime; the server's
+	// number wins
+	other := models.NewReminder("From another laptop", time.Now().Add(time.Hour), models.Medium)
+	if err := server.Add(other); err != nil {
+		t.Fatal(err)
+	}
+	added := models.NewReminder("From this laptop", time.Now().Add(2*time.Hour), models.High)
+	if err := store.Add(added); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if added.ShortID != 3 {
+		t.Errorf("ShortID = %d, want the server's 3", added.ShortID)
+	}
+	if r, err := server.Get(added.ID); err != nil || r.Title != "From this laptop" || r.Priority != models.High {
+		t.Errorf("server has %v, %v", r, err)
+	}
+
+	if err := store.CompleteReminder(existing.ID); err != nil {
+		t.Fatalf("CompleteReminder: %v", err)
+	}
+	if r, _ := server.Get(existing.ID); !r.Completed {
+		t.Error("the server didn't get the completion")
+	}
+
+	if err := store.Delete(added.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := server.Get(added.ID); err == nil {
+		t.Error("the server still has the deleted reminder")
+	}
+
+	// A client that missed the delete can't bring it back, unless it changed
+	// the reminder since
+	stale := *added
+	if _, err := client.Put(&stale); err == nil || !strings.Contains(err.Error(), "deleted") {
+		t.Errorf("putting back a stale copy gave %v", err)
+	}
+	if _, err := server.Get(added.ID); err == nil {
+		t.Error("the stale copy brought the deleted reminder back")
+	}
+	stale.UpdatedAt = time.Now().Add(time.Second)
+	if _, err := client.Put(&stale); err != nil {
+		t.Errorf("putting back a copy changed after the delete: %v", err)
+	}
+
+	if _, err := store.Compact(); err != models.ErrRemote {
+		t.Errorf("Compact = %v, want ErrRemote", err)
+	}
+}
+
+func TestAPIHandler(t *testing.T) {
+	store := newTestStore(t)
+	api := httptest.NewServer(utils.NewAPIHandler(store, "", utils.TextParser{DefaultPriority: models.Medium}))
+	defer api.Close()
+
+	post := func(body string) *http.Response {
+		t.Helper()
+		resp, err := http.Post(api.URL+utils.APIPrefix+"/reminders", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	if resp := post(`{"title": "Water plants", "due_time": "2030-01-02T09:00:00Z", "priority": "low"}`); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST = %s", resp.Status)
+	}
+	for _, body := range []string{
+		`{"title": "", "due_time": "2030-01-02T09:00:00Z"}`,
+		`{"title": "No due time"}`,
+		`{"title": "Bad priority", "due_time": "2030-01-02T09:00:00Z", "priority": "urgent"}`,
+		`not json`,
+	} {
+		if resp := post(body); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("POST %s = %s, want 400", body, resp.Status)
+		}
+	}
+
+	resp, err := http.Post(api.URL+utils.APIPrefix+"/reminders/1/complete", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if r, err := store.GetByShortID(1); resp.StatusCode != http.StatusOK || err != nil || !r.Completed {
+		t.Errorf("complete = %s, reminder %v, %v", resp.Status, r, err)
+	}
+
+	// The server picks the ID, so a create can't reuse one, deleted or not
+	first, _ := store.GetByShortID(1)
+	if resp := post(`{"id": "` + first.ID + `", "title": "Copy", "due_time": "2030-01-02T09:00:00Z"}`); resp.StatusCode != http.StatusCreated {
+		t.Errorf("POST with a taken ID = %s", resp.Status)
+	}
+	if r, err := store.GetByShortID(2); err != nil || r.ID == first.ID || r.Title != "Copy" {
+		t.Errorf("POST with a taken ID added %v, %v", r, err)
+	}
+	if r, _ := store.Get(first.ID); r.Title != "Water plants" {
+		t.Errorf("POST with a taken ID replaced %q", r.Title)
+	}
+
+	resp, err = http.Get(api.URL + utils.APIPrefix + "/reminders/42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET of a missing reminder = %s", resp.Status)
+	}
+}
+
+func TestAPIEvents(t *testing.T) {
+	store := newTestStore(t)
+	api := httptest.NewServer(utils.NewAPIHandler(store, "s3cret", utils.TextParser{DefaultPriority: models.Medium}))
+	defer api.Close()
+
+	resp, err := http.Get(api.URL + utils.APIPrefix + "/events?token=guess")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("GET /events with a wrong token = %s", resp.Status)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, api.URL+utils.APIPrefix+"/events?token=s3cret", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); resp.StatusCode != http.StatusOK || ct != "text/event-stream" {
+		t.Fatalf("GET /events = %s, %s", resp.Status, ct)
+	}
+
+	// The event names of the stream, in order
+	kinds := make(chan string, 16)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if kind, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+				kinds <- kind
+			}
+		}
+		close(kinds)
+	}()
+	expect := func(want ...string) {
+		t.Helper()
+		for _, kind := range want {
+			select {
+			case got := <-kinds:
+				if got != kind {
+					t.Fatalf("event %q, want %q", got, kind)
+				}
+			case <-ctx.Done():
+				t.Fatalf("no %q event", kind)
+			}
+		}
+	}
+
+	// The stream is up once the headers are in, so nothing is missed
+	soon := models.NewReminder("Call mum", time.Now().Add(5*time.Minute), models.Medium)
+	if err := store.Add(soon); err != nil {
+		t.Fatal(err)
+	}
+	expect(models.EventAdded, utils.EventDue)
+
+	late := models.NewReminder("Pay rent", time.Now().Add(-time.Hour), models.High)
+	if err := store.Add(late); err != nil {
+		t.Fatal(err)
+	}
+	expect(models.EventAdded, utils.EventOverdue)
+
+	if err := store.CompleteReminder(late.ID); err != nil {
+		t.Fatal(err)
+	}
+	expect(models.EventCompleted)
+}
+
+func TestWebUI(t *testing.T) {
+	store := newTestStore(t)
+	api := httptest.NewServer(utils.NewAPIHandler(store, "", utils.TextParser{DefaultPriority: models.Medium}))
+	defer api.Close()
+	page := httptest.NewServer(utils.NewWebUIHandler())
+	defer page.Close()
+
+	resp, err := http.Get(page.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") || !strings.Contains(string(body), "/api/v1") {
+		t.Errorf("GET / = %s, %d bytes", resp.Header.Get("Content-Type"), len(body))
+	}
+
+	// The add box sends what was typed, parsed like 'nancy add'
+	resp, err = http.Post(api.URL+utils.APIPrefix+"/reminders", "application/json; charset=utf-8", strings.NewReader(`{"input": "Call mum in 2 hours #family urgent"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	r, err := store.GetByShortID(1)
+	if resp.StatusCode != http.StatusCreated || err != nil {
+		t.Fatalf("POST text = %s, %v", resp.Status, err)
+	}
+	if r.Title != "Call mum" || r.Priority != models.High || !r.HasTag("family") || time.Until(r.DueTime) < 110*time.Minute {
+		t.Errorf("parsed into %q, %s, %v, due %v", r.Title, r.Priority, r.Tags, r.DueTime)
+	}
+
+	snooze := func(query string) int {
+		t.Helper()
+		resp, err := http.Post(api.URL+utils.APIPrefix+"/reminders/1/snooze"+query, "application/json", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if status := snooze("?for=30m"); status != http.StatusOK {
+		t.Fatalf("snooze = %d", status)
+	}
+	if r, _ := store.GetByShortID(1); time.Until(r.DueTime) > 31*time.Minute || time.Until(r.DueTime) < 29*time.Minute {
+		t.Errorf("snoozed until %v, want in 30 minutes", r.DueTime)
+	}
+	if status := snooze("?for=soon"); status != http.StatusBadRequest {
+		t.Errorf("snooze for=soon = %d, want 400", status)
+	}
+}
+
+func TestAPICrossSite(t *testing.T) {
+	store := newTestStore(t)
+	api := httptest.NewServer(utils.HostCheck("localhost:8080", utils.NewAPIHandler(store, "", utils.TextParser{DefaultPriority: models.Medium})))
+	defer api.Close()
+	body := `{"title": "Water plants", "due_time": "2030-01-02T09:00:00Z"}`
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		host        string
+		origin      string
+		contentType string
+		want        int
+	}{
+		{"same origin", http.MethodPost, "/reminders", "", "", "application/json", http.StatusCreated},
+		{"own origin", http.MethodGet, "/reminders", "", "own", "", http.StatusOK},
+		{"foreign origin", http.MethodGet, "/reminders", "", "http://evil.example.com", "", http.StatusForbidden},
+		{"foreign origin posting", http.MethodPost, "/reminders", "", "http://evil.example.com", "application/json", http.StatusForbidden},
+		{"rebound host", http.MethodGet, "/reminders", "evil.example.com:8080", "", "", http.StatusForbidden},
+		{"localhost", http.MethodGet, "/reminders", "localhost:8080", "", "", http.StatusOK},
+		{"form post", http.MethodPost, "/reminders", "", "", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"text post", http.MethodPost, "/reminders/1/complete", "", "", "text/plain", http.StatusUnsupportedMediaType},
+		{"no content type", http.MethodDelete, "/reminders/1", "", "", "", http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, api.URL+utils.APIPrefix+tt.path, strings.NewReader(body))
+		if tt.host != "" {
+			req.Host = tt.host
+		}
+		if tt.origin == "own" {
+			req.Header.Set("Origin", api.URL)
+		} else if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		} else {
+			req.Body, req.ContentLength = nil, 0
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: %s %s = %d, want %d", tt.name, tt.method, tt.path, resp.StatusCode, tt.want)
+		}
+	}
+	if len(store.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})) != 1 {
+		t.Errorf("the refused requests changed the store")
+	}
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+func TestParseRule(t *testing.T) {
+	for _, text := range []string{
+		"completed > 7d -> archive",
+		"tag=errand and overdue > 30d → delete",
+		"priority=low and untouched > 2w -> archive",
+		"completed -> delete",
+	} {
+		if _, err := models.ParseRule(text); err != nil {
+			t.Errorf("ParseRule(%q): %v", text, err)
+		}
+	}
+
+	for _, text := range []string{
+		"completed > 7d",             // No action
+		"completed > 7d -> shred",    // Unknown action
+		"colour=red -> archive",      // Unknown condition
+		"overdue > soon -> delete",   // Bad age
+		"priority=urgent -> archive", // Unknown priority
+	} {
+		if _, err := models.ParseRule(text); err == nil {
+			t.Errorf("ParseRule(%q) should fail", text)
+		}
+	}
+}
+
+func TestMatchRules(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.Local)
+	weekAgo := now.AddDate(0, 0, -8)
+
+	done := &models.Reminder{Title: "Done", Completed: true, CompletedAt: &weekAgo, UpdatedAt: weekAgo}
+	errand := &models.Reminder{Title: "Errand", Tags: []string{"errand/shop"}, DueTime: now.AddDate(0, 0, -40), UpdatedAt: now}
+	fresh := &models.Reminder{Title: "Fresh", Tags: []string{"errand"}, DueTime: now.AddDate(0, 0, -2), UpdatedAt: now}
+
+	rules, err := models.ParseRules([]string{
+		"tag=errand and overdue > 30d -> delete",
+		"completed > 7d -> archive",
+	})
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+
+	matches := models.MatchRules([]*models.Reminder{done, errand, fresh}, rules, now)
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2", len(matches))
+	}
+	if matches[0].Reminder != done || matches[0].Rule.Action != models.RuleArchive {
+		t.Errorf("first match = %s → %s", matches[0].Reminder.Title, matches[0].Rule.Action)
+	}
+	if matches[1].Reminder != errand || matches[1].Rule.Action != models.RuleDelete {
+		t.Errorf("second match = %s → %s", matches[1].Reminder.Title, matches[1].Rule.Action)
+	}
+
+	// Already archived reminders aren't archived again
+	done.Archived = true
+	if matches := models.MatchRules([]*models.Reminder{done}, rules, now); len(matches) != 0 {
+		t.Errorf("archived reminder matched %+v", matches)
+	}
+}
+
+func TestApplyRules(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+
+	done := models.NewReminder("Old report", now.Add(-20*24*time.Hour), models.Medium)
+	done.Complete()
+	completedAt := now.Add(-10 * 24 * time.Hour)
+	done.CompletedAt = &completedAt
+	errand := models.NewReminder("Buy stamps", now.Add(-40*24*time.Hour), models.Low)
+	errand.AddTag("errand")
+	for _, r := range []*models.Reminder{done, errand} {
+		if err := store.Add(r); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	rules, err := models.ParseRules([]string{"completed > 7d -> archive", "tag=errand and overdue > 30d -> delete"})
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+	matches, err := store.ApplyRules(rules, now)
+	if err != nil || len(matches) != 2 {
+		t.Fatalf("ApplyRules = %d matches, %v", len(matches), err)
+	}
+
+	if got, err := store.Get(done.ID); err != nil || !got.Archived {
+		t.Errorf("completed reminder should be archived in the store, got %+v, %v", got, err)
+	}
+	if _, err := store.Get(errand.ID); err == nil {
+		t.Error("errand should be deleted")
+	}
+}
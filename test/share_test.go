@@ -0,0 +1,142 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestShareRoundTrip(t *testing.T) {
+	due := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	original := models.NewReminder("Dinner at Luigi's", due, models.High)
+	original.Tags = []string{"food", "friends"}
+	original.Description = "Table for 4\nBring the voucher"
+
+	link, err := utils.EncodeShare(original)
+	if err != nil {
+		t.Fatalf("EncodeShare: %v", err)
+	}
+	if !strings.HasPrefix(link, utils.SharePrefix) {
+		t.Fatalf("link = %q, want the %s prefix", link, utils.SharePrefix)
+	}
+
+	// Bare blobs and blobs wrapped when copied decode too
+	blob := strings.TrimPrefix(link, utils.SharePrefix)
+	for _, input := range []string{link, blob, blob[:20] + "\n  " + blob[20:]} {
+		shared, err := utils.DecodeShare(input)
+		if err != nil {
+			t.Fatalf("DecodeShare(%q): %v", input, err)
+		}
+		if shared.Title != original.Title || !shared.Due.Equal(due) || shared.Notes != original.Description ||
+			shared.Priority != models.High || strings.Join(shared.Tags, ",") != "food,friends" {
+			t.Errorf("DecodeShare(%q) = %+v", input, shared)
+		}
+	}
+
+	h := newHarness(t)
+	out := h.mustRun("add", "--from-share", link, "--tags", "family")
+	if !strings.Contains(out, "Added reminder: Dinner at Luigi's") {
+		t.Fatalf("add --from-share printed:\n%s", out)
+	}
+	added := h.reminder("Dinner at Luigi's")
+	if !added.DueTime.Equal(due) || added.Description != original.Description || added.Priority != models.High ||
+		!added.HasTag("food") || !added.HasTag("family") {
+		t.Errorf("added %+v", added)
+	}
+
+	shared := h.mustRun("share", added.DisplayID())
+	if !strings.HasPrefix(shared, utils.SharePrefix) {
+		t.Errorf("nancy share printed %q", shared)
+	}
+	if out, err := h.run("add", "Something else", "--from-share", link); err == nil {
+		t.Errorf("text with --from-share was accepted:\n%s", out)
+	}
+}
+
+func TestDecodeShareRejects(t *testing.T) {
+	untitled, _ := utils.EncodeShare(models.NewReminder("   ", time.Now(), models.Medium))
+	for name, input := range map[string]string{
+		"garbage":  "not a share link",
+		"empty":    "",
+		"untitled": untitled,
+		"truncated": func() string {
+			link, _ := utils.EncodeShare(models.NewReminder("Call mom", time.Now(), models.Medium))
+			return link[:len(link)-10]
+		}(),
+	} {
+		if _, err := utils.DecodeShare(input); err == nil {
+			t.Errorf("%s: DecodeShare accepted %q", name, input)
+		}
+	}
+}
+
+func TestEncodeQR(t *testing.T) {
+	for _, test := range []struct {
+		length int
+		size   int
+	}{
+		{14, 21},    // Version 1 at level M
+		{15, 25},    // One byte more needs version 2
+		{150, 49},   // A typical share link
+		{2331, 177}, // Version 40, the largest
+	} {
+		code, err := utils.EncodeQR([]byte(strings.Repeat("x", test.length)))
+		if err != nil {
+			t.Fatalf("%d bytes: %v", test.length, err)
+		}
+		if len(code) != test.size {
+			t.Errorf("%d bytes: %d modules across, want %d", test.length, len(code), test.size)
+			continue
+		}
+
+		// Finder patterns in three corners: dark ring, light ring, dark core
+		for _, corner := range [][2]int{{0, 0}, {test.size - 7, 0}, {0, test.size - 7}} {
+			x, y := corner[0], corner[1]
+			if !code[y][x] || code[y+1][x+1] || !code[y+3][x+3] || !code[y+6][x+6] {
+				t.Errorf("%d bytes: no finder pattern at %d,%d", test.length, x, y)
+			}
+		}
+
+		// Both copies of the format information agree and say level M
+		var first, second int
+		for i := 0; i <= 5; i++ {
+			first |= b2i(code[i][8]) << i
+		}
+		first |= b2i(code[7][8])<<6 | b2i(code[8][8])<<7 | b2i(code[8][7])<<8
+		for i := 9; i < 15; i++ {
+			first |= b2i(code[8][14-i]) << i
+		}
+		for i := 0; i < 8; i++ {
+			second |= b2i(code[8][test.size-1-i]) << i
+		}
+		for i := 8; i < 15; i++ {
+			second |= b2i(code[test.size-15+i][8]) << i
+		}
+		if first != second {
+			t.Errorf("%d bytes: format copies %015b and %015b differ", test.length, first, second)
+		}
+		if level := (first ^ 0x5412) >> 13; level != 0 {
+			t.Errorf("%d bytes: error correction level bits %02b, want 00 (M)", test.length, level)
+		}
+	}
+
+	if _, err := utils.EncodeQR(make([]byte, 2332)); err == nil {
+		t.Error("2332 bytes were accepted")
+	}
+
+	code, _ := utils.EncodeQR([]byte("hi"))
+	lines := strings.Split(strings.TrimSuffix(code.Terminal(), "\n"), "\n")
+	if len(lines) != 13 || len([]rune(lines[0])) != 25 {
+		t.Errorf("terminal rendering is %d lines of %d, want 13 of 25", len(lines), len([]rune(lines[0])))
+	}
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
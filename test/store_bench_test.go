@@ -0,0 +1,104 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+)
+
+// largeStoreSize is about ten years of a busy user's reminders
+const largeStoreSize = 50000
+
+// writeSyntheticStore writes a store of n synthetic reminders to a
+// temporary data directory and returns it
+func writeSyntheticStore(tb testing.TB, n int) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	writeSyntheticStoreTo(tb, dir, n)
+	return dir
+}
+
+// writeSyntheticStoreTo writes a store of n reminders to the data directory
+// dir. One in fifty is active; the rest are history, completed or archived
+// over the past years.
+func writeSyntheticStoreTo(tb testing.TB, dir string, n int) {
+	tb.Helper()
+	now := time.Now()
+	priorities := []models.Priority{models.Low, models.Medium, models.High}
+
+	reminders := make([]*models.Reminder, n)
+	for i := range reminders {
+		r := models.NewReminder(fmt.Sprintf("Reminder %d", i), now.Add(-time.Duration(n-i)*2*time.Hour), priorities[i%3])
+		r.ShortID = i + 1
+		r.AddTag([]string{"work", "home", "errand", "work/reviews"}[i%4])
+		if i%50 == 0 {
+			r.DueTime = now.Add(time.Duration(i) * time.Minute)
+		} else {
+			r.SetDescription("Synthetic history to make the store realistically large")
+			completedAt := r.DueTime.Add(time.Hour)
+			r.Completed, r.CompletedAt = true, &completedAt
+			r.Archived = i%3 == 0
+		}
+		r.UpdatedAt = r.DueTime
+		reminders[i] = r
+	}
+
+	data, err := json.MarshalIndent(reminders, "", "  ")
+	if err != nil {
+		tb.Fatalf("marshal: %v", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		tb.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "reminders.json"), data, 0644); err != nil {
+		tb.Fatalf("write: %v", err)
+	}
+}
+
+func BenchmarkLoadLargeStore(b *testing.B) {
+	dir := writeSyntheticStore(b, largeStoreSize)
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := models.NewStore(dir); err != nil {
+			b.Fatalf("NewStore: %v", err)
+		}
+	}
+}
+
+func BenchmarkGetAllActiveLargeStore(b *testing.B) {
+	store, err := models.NewStore(writeSyntheticStore(b, largeStoreSize))
+	if err != nil {
+		b.Fatalf("NewStore: %v", err)
+	}
+	b.ResetTimer()
+	for b.Loop() {
+		store.GetAll(&models.FilterOptions{})
+	}
+}
+
+func BenchmarkGetAllPageLargeStore(b *testing.B) {
+	store, err := models.NewStore(writeSyntheticStore(b, largeStoreSize))
+	if err != nil {
+		b.Fatalf("NewStore: %v", err)
+	}
+	b.ResetTimer()
+	for b.Loop() {
+		store.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true, Offset: 100, Limit: 50})
+	}
+}
+
+func BenchmarkCountLargeStore(b *testing.B) {
+	store, err := models.NewStore(writeSyntheticStore(b, largeStoreSize))
+	if err != nil {
+		b.Fatalf("NewStore: %v", err)
+	}
+	b.ResetTimer()
+	for b.Loop() {
+		store.Count()
+	}
+}
@@ -1 +1,1065 @@
 package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/tui/components"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+// newTestStore creates a store backed by a temporary directory
+func newTestStore(t *testing.T) *models.Store {
+	t.Helper()
+	store, err := models.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	return store
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	source := newTestStore(t)
+
+	due := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	first := models.NewReminder("Call mom", due, models.High)
+	first.AddTag("family")
+	first.SetDescription("Ask about the weekend")
+	second := models.NewReminder("Water plants", due.Add(time.Hour), models.Low)
+	second.Recurring = &models.RecurringRule{Frequency: "weekly", Interval: 1}
+	second.Complete()
+
+	for _, r := range []*models.Reminder{first, second} {
+		if err := source.Add(r); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	data, err := source.Export()
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if err := models.ValidateReminders(data); err != nil {
+		t.Fatalf("exported data does not match schema: %v", err)
+	}
+
+	target := newTestStore(t)
+	if _, err := target.Import(data); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	for _, want := range []*models.Reminder{first, second} {
+		got, err := target.Get(want.ID)
+		if err != nil {
+			t.Fatalf("Get(%s): %v", want.ID, err)
+		}
+		if got.Title != want.Title || got.Priority != want.Priority ||
+			!got.DueTime.Equal(want.DueTime) || got.Completed != want.Completed ||
+			got.Description != want.Description || len(got.Tags) != len(want.Tags) {
+			t.Errorf("round trip mismatch:\n got  %+v\n want %+v", got, want)
+		}
+	}
+
+	// Importing the same data again must not duplicate anything
+	if _, err := target.Import(data); err != nil {
+		t.Fatalf("second Import: %v", err)
+	}
+	if total, _, _, _ := target.Count(); total != 2 {
+		t.Errorf("expected 2 reminders after re-import, got %d", total)
+	}
+}
+
+func TestImportKeepsLocalShortIDs(t *testing.T) {
+	due := time.Now().Add(2 * time.Hour)
+	source := newTestStore(t)
+	older := models.NewReminder("Renew passport", due, models.Medium)
+	older.CreatedAt = time.Now().AddDate(0, 0, -7)
+	if err := source.Add(older); err != nil {
+		t.Fatal(err)
+	}
+	data, err := source.Export()
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	// The local reminder is newer but was here first, so it stays #1
+	target := newTestStore(t)
+	local := models.NewReminder("Call mom", due, models.High)
+	if err := target.Add(local); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := target.Import(data); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if r, err := target.Get(local.ID); err != nil || r.ShortID != 1 {
+		t.Errorf("local reminder renumbered to %v, %v", r, err)
+	}
+	if r, err := target.Get(older.ID); err != nil || r.ShortID != 2 {
+		t.Errorf("imported reminder got %v, %v", r, err)
+	}
+}
+
+func TestReminderSchemaIsValidJSON(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(models.ReminderSchema, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	if schema["type"] != "array" {
+		t.Errorf("expected top-level array schema, got %v", schema["type"])
+	}
+}
+
+func TestImportRejectsInvalidData(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "not an array",
+			data: `{"id": "x"}`,
+			want: "line 1: expected an array",
+		},
+		{
+			name: "missing title",
+			data: "[\n  {\n    \"id\": \"a\",\n    \"due_time\": \"2024-03-20T15:04:05Z\",\n    \"priority\": 1\n  }\n]",
+			want: `line 2: reminder 1: missing required field "title"`,
+		},
+		{
+			name: "bad date on its own line",
+			data: "[\n  {\n    \"id\": \"a\",\n    \"title\": \"t\",\n    \"due_time\": \"tomorrow\",\n    \"priority\": 1\n  }\n]",
+			want: "line 5: reminder 1: due_time: invalid date-time",
+		},
+		{
+			name: "unknown field",
+			data: "[\n  {\"id\": \"a\", \"title\": \"t\", \"due_time\": \"2024-03-20T15:04:05Z\", \"priority\": 1},\n  {\"id\": \"b\", \"title\": \"t\", \"due_time\": \"2024-03-20T15:04:05Z\", \"priority\": 1, \"colour\": \"red\"}\n]",
+			want: `line 3: reminder 2: unknown field "colour"`,
+		},
+		{
+			name: "url that isn't a web link",
+			data: "[\n  {\"id\": \"a\", \"title\": \"t\", \"due_time\": \"2024-03-20T15:04:05Z\", \"priority\": 1, \"url\": \"file:///etc/passwd\"}\n]",
+			want: "line 2: reminder 1: url:",
+		},
+		{
+			name: "tombstone without a date",
+			data: "[\n  {\"id\": \"a\", \"deleted_at\": \"yesterday\"}\n]",
+			want: "line 2: reminder 1: deleted_at: invalid date-time",
+		},
+		{
+			name: "syntax error",
+			data: "[\n  {\"id\": \"a\",,}\n]",
+			want: "line 2:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			_, err := store.Import([]byte(tt.data))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not contain %q", err, tt.want)
+			}
+			if total, _, _, _ := store.Count(); total != 0 {
+				t.Errorf("invalid data should not be imported, got %d reminders", total)
+			}
+		})
+	}
+}
+
+func TestShortIDs(t *testing.T) {
+	store := newTestStore(t)
+
+	due := time.Now().Add(time.Hour)
+	first := models.NewReminder("First", due, models.Medium)
+	second := models.NewReminder("Second", due, models.Medium)
+	for _, r := range []*models.Reminder{first, second} {
+		if err := store.Add(r); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	if first.ShortID != 1 || second.ShortID != 2 {
+		t.Fatalf("short IDs = %d, %d; want 1, 2", first.ShortID, second.ShortID)
+	}
+
+	got, err := store.GetByShortID(2)
+	if err != nil {
+		t.Fatalf("GetByShortID: %v", err)
+	}
+	if got.ID != second.ID {
+		t.Errorf("GetByShortID(2) returned %q, want %q", got.Title, second.Title)
+	}
+
+	// Numbers are never reused while a higher one exists
+	if err := store.Delete(first.ID); err != nil {
+		t.Fatal(err)
+	}
+	third := models.NewReminder("Third", due, models.Medium)
+	if err := store.Add(third); err != nil {
+		t.Fatal(err)
+	}
+	if third.ShortID != 3 {
+		t.Errorf("third short ID = %d, want 3", third.ShortID)
+	}
+}
+
+func TestShortIDsSkipUndecodedReminders(t *testing.T) {
+	dir := t.TempDir()
+	old := `[{"id":"done","short_id":1,"title":"Done","priority":"low","completed":true,` +
+		`"due_time":"2024-01-01T09:00:00Z","created_at":"2024-01-01T08:00:00Z","updated_at":"2024-01-01T09:00:00Z"},` +
+		`{"id":"new","title":"New","priority":"low","due_time":"2030-01-01T09:00:00Z","created_at":"2024-02-01T09:00:00Z"}]`
+	if err := os.WriteFile(filepath.Join(dir, "reminders.json"), []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if r, err := store.Get("new"); err != nil || r.ShortID != 2 {
+		t.Errorf("short ID next to a completed reminder's 1 = %d, %v; want 2", r.ShortID, err)
+	}
+	if r, err := store.GetByShortID(1); err != nil || r.ID != "done" {
+		t.Errorf("GetByShortID(1) = %+v, %v", r, err)
+	}
+}
+
+func TestSortByOverdueAge(t *testing.T) {
+	now := time.Now()
+	recent := models.NewReminder("Recent", now.Add(-time.Hour), models.High)
+	neglected := models.NewReminder("Neglected", now.AddDate(0, 0, -10), models.Low)
+	upcoming := models.NewReminder("Upcoming", now.Add(time.Hour), models.Medium)
+	done := models.NewReminder("Done", now.AddDate(0, 0, -20), models.Medium)
+	done.Complete()
+
+	reminders := []*models.Reminder{upcoming, done, recent, neglected}
+	models.SortByOverdueAge(reminders)
+
+	want := []string{"Neglected", "Recent", "Upcoming", "Done"}
+	for i, r := range reminders {
+		if r.Title != want[i] {
+			t.Fatalf("position %d = %q, want order %v", i, r.Title, want)
+		}
+	}
+
+	if upcoming.OverdueAge() != 0 || done.OverdueAge() != 0 {
+		t.Error("only active overdue reminders have an overdue age")
+	}
+}
+
+func TestGroupByDue(t *testing.T) {
+	now := time.Date(2024, 3, 20, 10, 0, 0, 0, time.Local) // a Wednesday
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.Local) }
+	completedAt := at(20, 9)
+
+	tests := []struct {
+		title string
+		due   time.Time
+		done  bool
+		group string
+	}{
+		{"last week", at(13, 9), false, models.GroupOverdue},
+		{"an hour ago", at(20, 9), false, models.GroupOverdue},
+		{"now", now, false, models.GroupToday},
+		{"tonight", at(20, 23), false, models.GroupToday},
+		{"tomorrow at midnight", at(21, 0), false, models.GroupTomorrow},
+		{"tomorrow night", at(21, 23), false, models.GroupTomorrow},
+		{"the day after", at(22, 0), false, models.GroupThisWeek},
+		{"in six days", at(26, 23), false, models.GroupThisWeek},
+		{"in a week", at(27, 0), false, models.GroupLater},
+		{"done yesterday", at(19, 9), true, models.GroupCompleted},
+		{"done next week", at(27, 9), true, models.GroupCompleted},
+	}
+	var reminders []*models.Reminder
+	want := make(map[string]string)
+	for _, tt := range tests {
+		reminder := &models.Reminder{Title: tt.title, DueTime: tt.due, Completed: tt.done}
+		if tt.done {
+			reminder.CompletedAt = &completedAt
+		}
+		reminders = append(reminders, reminder)
+		want[tt.title] = tt.group
+	}
+
+	groups := models.GroupByDue(reminders, now)
+	order := []string{models.GroupOverdue, models.GroupToday, models.GroupTomorrow, models.GroupThisWeek, models.GroupLater, models.GroupCompleted}
+	if len(groups) != len(order) {
+		t.Fatalf("got %d groups, want %d", len(groups), len(order))
+	}
+	for i, group := range groups {
+		if group.Name != order[i] {
+			t.Errorf("group %d = %q, want %q", i, group.Name, order[i])
+		}
+		for _, reminder := range group.Reminders {
+			if want[reminder.Title] != group.Name {
+				t.Errorf("%q is in %q, want %q", reminder.Title, group.Name, want[reminder.Title])
+			}
+		}
+	}
+
+	// Order within a group is kept and empty groups are left out
+	groups = models.GroupByDue([]*models.Reminder{reminders[1], reminders[0], reminders[8]}, now)
+	if len(groups) != 2 || groups[0].Reminders[0].Title != "an hour ago" || groups[1].Name != models.GroupLater {
+		t.Errorf("groups = %+v", groups)
+	}
+}
+
+func TestReplaceTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []string
+		old         []string
+		replacement string
+		want        []string
+		changed     int
+	}{
+		{"rename", []string{"wrok"}, []string{"wrok"}, "work", []string{"work"}, 1},
+		{"rename keeps the order", []string{"urgent", "wrok", "q3"}, []string{"wrok"}, "work", []string{"urgent", "work", "q3"}, 1},
+		{"merge", []string{"home", "house"}, []string{"home", "house"}, "home", []string{"home"}, 1},
+		{"merge into a tag already there", []string{"house", "home"}, []string{"house"}, "home", []string{"home"}, 1},
+		{"rename a parent", []string{"work/acme"}, []string{"work"}, "job", []string{"job/acme"}, 1},
+		{"a longer tag is not a child", []string{"workshop"}, []string{"work"}, "job", []string{"workshop"}, 0},
+		{"remove", []string{"someday", "home"}, []string{"someday"}, "", []string{"home"}, 1},
+		{"remove a parent", []string{"work/acme", "work"}, []string{"work"}, "", []string{}, 1},
+		{"no match", []string{"home"}, []string{"work"}, "job", []string{"home"}, 0},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		store, err := models.NewStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reminder := models.NewReminder("Tagged", time.Now().Add(time.Hour), models.Medium)
+		reminder.Tags = tt.tags
+		if err := store.Add(reminder); err != nil {
+			t.Fatal(err)
+		}
+
+		changed, err := store.ReplaceTags(tt.old, tt.replacement)
+		if err != nil || changed != tt.changed {
+			t.Errorf("%s: ReplaceTags() = %d, %v; want %d", tt.name, changed, err, tt.changed)
+		}
+
+		// The change is saved
+		reloaded, err := models.NewStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := reloaded.Get(reminder.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(got.Tags, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: tags = %v, want %v", tt.name, got.Tags, tt.want)
+		}
+	}
+}
+
+func TestFilterDateRange(t *testing.T) {
+	store := newTestStore(t)
+	tomorrow, err := utils.ParseDateString("tomorrow")
+	if err != nil {
+		t.Fatalf("ParseDateString: %v", err)
+	}
+
+	for title, due := range map[string]time.Time{
+		"Yesterday":     tomorrow.AddDate(0, 0, -2).Add(12 * time.Hour),
+		"Tomorrow noon": tomorrow.Add(12 * time.Hour),
+		"Next week":     tomorrow.AddDate(0, 0, 6),
+	} {
+		if err := store.Add(models.NewReminder(title, due, models.Medium)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	// "to" includes the whole day
+	var filter models.FilterOptions
+	values := components.FilterValues{From: "today", To: "tomorrow"}
+	if err := values.Apply(&filter); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	got := store.GetAll(&filter)
+	if len(got) != 1 || got[0].Title != "Tomorrow noon" {
+		t.Errorf("today..tomorrow matched %d reminders, want only 'Tomorrow noon'", len(got))
+	}
+
+	values = components.FilterValues{From: "soon"}
+	if err := values.Apply(&filter); err == nil {
+		t.Error("Apply accepted an invalid date")
+	}
+}
+
+func TestFilterTags(t *testing.T) {
+	store := newTestStore(t)
+	for title, tags := range map[string][]string{
+		"Report":  {"work", "urgent"},
+		"Standup": {"work"},
+		"Dentist": {"health", "urgent"},
+	} {
+		reminder := models.NewReminder(title, time.Now().Add(time.Hour), models.Medium)
+		reminder.Tags = tags
+		if err := store.Add(reminder); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	var filter models.FilterOptions
+	values := components.FilterValues{Tags: []string{"work", "urgent"}}
+	values.Apply(&filter)
+	if got := store.GetAll(&filter); len(got) != 3 {
+		t.Errorf("any of work, urgent matched %d reminders, want 3", len(got))
+	}
+
+	values.AllTags = true
+	values.Apply(&filter)
+	if got := store.GetAll(&filter); len(got) != 1 || got[0].Title != "Report" {
+		t.Errorf("all of work, urgent matched %d reminders, want only 'Report'", len(got))
+	}
+}
+
+func TestTodayProgress(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.Local)
+
+	done := models.NewReminder("Done", today, models.Medium)
+	done.Complete()
+	open := models.NewReminder("Open", today, models.Medium)
+	later := models.NewReminder("Later", today.AddDate(0, 0, 3), models.Medium)
+
+	// Completing today's occurrence moves the reminder to tomorrow
+	daily := models.NewReminder("Daily", today, models.Medium)
+	daily.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 1}
+	daily.Complete()
+
+	for _, r := range []*models.Reminder{done, open, later, daily} {
+		if err := store.Add(r); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	if gotDone, gotTotal := store.TodayProgress(""); gotDone != 2 || gotTotal != 3 {
+		t.Errorf("TodayProgress = %d/%d, want 2/3", gotDone, gotTotal)
+	}
+}
+
+func TestTimeTracking(t *testing.T) {
+	store := newTestStore(t)
+	first := models.NewReminder("First", time.Now().Add(time.Hour), models.Medium)
+	second := models.NewReminder("Second", time.Now().Add(time.Hour), models.Medium)
+	for _, r := range []*models.Reminder{first, second} {
+		if err := store.Add(r); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	if _, _, err := store.StopTimer(); err == nil {
+		t.Error("StopTimer with no timer running should fail")
+	}
+	if stopped, err := store.StartTimer(first.ID); err != nil || stopped != nil {
+		t.Fatalf("StartTimer(first) = %v, %v", stopped, err)
+	}
+	if _, err := store.StartTimer(first.ID); err == nil {
+		t.Error("starting a running timer again should fail")
+	}
+
+	// Only one timer runs at a time
+	stopped, err := store.StartTimer(second.ID)
+	if err != nil || stopped == nil || stopped.ID != first.ID {
+		t.Fatalf("StartTimer(second) stopped %v, %v; want first", stopped, err)
+	}
+	if running := store.RunningTimer(); running == nil || running.ID != second.ID {
+		t.Errorf("RunningTimer = %v, want second", running)
+	}
+
+	reminder, _, err := store.StopTimer()
+	if err != nil || reminder.ID != second.ID {
+		t.Fatalf("StopTimer = %v, %v; want second", reminder, err)
+	}
+	if store.RunningTimer() != nil {
+		t.Error("no timer should be running after StopTimer")
+	}
+}
+
+func TestTrackedTime(t *testing.T) {
+	start := time.Date(2024, 3, 20, 9, 0, 0, 0, time.Local)
+	r := models.NewReminder("Invoice", start, models.Medium)
+
+	r.StartTimer(start)
+	if d, ok := r.StopTimer(start.Add(90 * time.Minute)); !ok || d != 90*time.Minute {
+		t.Errorf("StopTimer = %v, %v; want 1h30m", d, ok)
+	}
+	r.StartTimer(start.Add(2 * time.Hour))
+
+	// The running entry counts up to now
+	if got := r.TrackedTime(start.Add(2*time.Hour + 15*time.Minute)); got != 105*time.Minute {
+		t.Errorf("TrackedTime = %v, want 1h45m", got)
+	}
+}
+
+func TestActivity(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now()
+	today := now.Format("2006-01-02")
+
+	done := models.NewReminder("Done", now, models.Medium)
+	done.Complete()
+	open := models.NewReminder("Open", now.Add(time.Hour), models.Medium)
+
+	// Each completed occurrence counts, skipped ones don't
+	daily := models.NewReminder("Daily", now, models.Medium)
+	daily.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 1}
+	daily.Complete()
+	daily.Complete()
+	daily.Skip()
+
+	for _, r := range []*models.Reminder{done, open, daily} {
+		if err := store.Add(r); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	got := store.Activity()[today]
+	if got.Created != 3 || got.Completed != 3 {
+		t.Errorf("Activity()[today] = %+v, want 3 created, 3 completed", got)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	store := newTestStore(t)
+	day := time.Now().AddDate(0, 0, 2)
+	at := func(hour, minute int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, time.Local)
+	}
+
+	standup := models.NewReminder("Standup", at(10, 0), models.Medium)
+	lunch := models.NewReminder("Lunch", at(12, 0), models.Medium)
+	done := models.NewReminder("Done", at(10, 5), models.Medium)
+	done.Complete()
+	for _, r := range []*models.Reminder{standup, lunch, done} {
+		if err := store.Add(r); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	nearby, sameDay := store.Conflicts(at(10, 10), models.ConflictWindow)
+	if len(nearby) != 1 || nearby[0].ID != standup.ID {
+		t.Errorf("Conflicts nearby = %v, want only Standup", nearby)
+	}
+	if sameDay != 2 {
+		t.Errorf("Conflicts sameDay = %d, want 2", sameDay)
+	}
+
+	// Free slots avoid both reminders, and full days when there is a limit
+	slots := utils.AlternateSlots(store.GetActive(), at(10, 10), models.ConflictWindow, 2, time.Now())
+	if len(slots) == 0 {
+		t.Fatal("AlternateSlots found no slots")
+	}
+	for _, slot := range slots {
+		if slot.YearDay() == day.YearDay() {
+			t.Errorf("slot %v is on a day that is already full", slot)
+		}
+	}
+}
+
+func TestDayLoads(t *testing.T) {
+	store := newTestStore(t)
+	from := time.Now().AddDate(0, 0, 1)
+	at := func(days, hour int) time.Time {
+		return time.Date(from.Year(), from.Month(), from.Day()+days, hour, 0, 0, 0, time.Local)
+	}
+
+	done := models.NewReminder("Done", at(0, 9), models.Medium)
+	done.Complete()
+	for _, r := range []*models.Reminder{
+		models.NewReminder("Early", at(0, 0), models.Medium),
+		models.NewReminder("Late", at(0, 23), models.Medium),
+		models.NewReminder("Day after", at(1, 12), models.Medium),
+		models.NewReminder("Beyond", at(3, 12), models.Medium),
+		done,
+	} {
+		if err := store.Add(r); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	if loads := store.DayLoads(at(0, 15), 3); fmt.Sprint(loads) != "[2 1 0]" {
+		t.Errorf("DayLoads = %v, want [2 1 0]", loads)
+	}
+	if days := utils.OverloadedDays([]int{2, 1, 0}, at(0, 15), 1); len(days) != 1 || !strings.HasSuffix(days[0], "(2)") {
+		t.Errorf("OverloadedDays = %q, want just the first day", days)
+	}
+	if days := utils.OverloadedDays([]int{2, 1, 0}, at(0, 15), 0); days != nil {
+		t.Errorf("OverloadedDays without a limit = %q", days)
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	store := newTestStore(t)
+	due := time.Now().AddDate(0, 0, 1)
+
+	existing := models.NewReminder("Call mom", due, models.Medium)
+	if err := store.Add(existing); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	tests := []struct {
+		title string
+		due   time.Time
+		want  bool
+	}{
+		{"call Mom!", due.Add(time.Hour), true},
+		{"Call mum", due, true},
+		{"Call dad about the car", due, false},
+		{"Call mom", due.AddDate(0, 0, 1), false}, // another day
+	}
+	for _, tt := range tests {
+		got := len(store.FindDuplicates(tt.title, tt.due, "")) > 0
+		if got != tt.want {
+			t.Errorf("FindDuplicates(%q) = %v, want %v", tt.title, got, tt.want)
+		}
+	}
+
+	// A reminder isn't a duplicate of itself
+	if d := store.FindDuplicates(existing.Title, existing.DueTime, existing.ID); len(d) != 0 {
+		t.Errorf("FindDuplicates excluding itself = %v, want none", d)
+	}
+}
+
+func TestLazyLoading(t *testing.T) {
+	dir := writeSyntheticStore(t, 500)
+	store, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	// History is counted without being decoded
+	total, active, completed, _ := store.Count()
+	if active != 10 || completed != 327 || total != active+completed {
+		t.Errorf("Count() = %d total, %d active, %d completed", total, active, completed)
+	}
+	if got := len(store.GetAll(&models.FilterOptions{})); got != 10 {
+		t.Errorf("active reminders = %d, want 10", got)
+	}
+
+	// Cold reminders can still be looked up
+	if r, err := store.GetByShortID(2); err != nil || r.Title != "Reminder 1" || !r.Completed {
+		t.Errorf("GetByShortID(2) = %+v, %v", r, err)
+	}
+
+	// New short IDs don't collide with theirs, and saving keeps them
+	added := models.NewReminder("New", time.Now().Add(time.Hour), models.Low)
+	if err := store.Add(added); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if added.ShortID != 501 {
+		t.Errorf("new short ID = %d, want 501", added.ShortID)
+	}
+	reloaded, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	all := reloaded.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true})
+	if len(all) != 501 {
+		t.Fatalf("reminders after save = %d, want 501", len(all))
+	}
+
+	// Pages split the sorted list
+	page := reloaded.GetAll(&models.FilterOptions{ShowCompleted: true, ShowArchived: true, Offset: 20, Limit: 5})
+	if len(page) != 5 || page[0].ID != all[20].ID || page[4].ID != all[24].ID {
+		t.Errorf("page at offset 20 = %v", page)
+	}
+	if beyond := reloaded.GetAll(&models.FilterOptions{ShowCompleted: true, Offset: 1000}); len(beyond) != 0 {
+		t.Errorf("page past the end = %d reminders", len(beyond))
+	}
+}
+
+func TestReadOnlyStoreLeavesOldFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "reminders.json")
+	old := `[{"id":"a","title":"Old","priority":1,"due_time":"2030-01-01T09:00:00Z","created_at":"2024-01-01T09:00:00Z"}]`
+	if err := os.WriteFile(path, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := models.NewReadOnlyStore(dir)
+	if err != nil {
+		t.Fatalf("NewReadOnlyStore: %v", err)
+	}
+	if r, err := store.Get("a"); err != nil || r.ShortID != 1 || r.Priority != models.Medium {
+		t.Errorf("migrated in memory: %+v, %v", r, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != old {
+		t.Errorf("a read-only store rewrote the file:\n%s", data)
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Error("a read-only store made a backup")
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	records := `[
+  {"id": "b", "short_id": 2, "title": "Old title", "due_time": "2030-01-02T09:00:00+02:00", "priority": "low", "completed": false, "created_at": "2029-12-01T10:00:00+02:00", "updated_at": "2029-12-01T10:00:00+02:00"},
+  {"id": "a", "short_id": 1, "title": "Call mom", "due_time": "2030-01-01T09:00:00Z", "priority": "high", "completed": false, "created_at": "2029-12-01T10:00:00Z", "updated_at": "2029-12-01T10:00:00Z"},
+  {"id": "b", "short_id": 2, "title": "New title", "due_time": "2030-01-02T09:00:00+02:00", "priority": "low", "completed": false, "created_at": "2029-12-01T10:00:00+02:00", "updated_at": "2029-12-02T10:00:00+02:00"},
+  {"id": "a", "short_id": 1, "title": "Call mom", "due_time": "2030-01-01T09:00:00Z", "priority": "high", "completed": false, "created_at": "2029-12-01T10:00:00Z", "updated_at": "2029-12-01T10:00:00Z"}
+]`
+	path := filepath.Join(dir, "reminders.json")
+	if err := os.WriteFile(path, []byte(records), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	store, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if total, _, _, _ := store.Count(); total != 2 {
+		t.Errorf("loaded %d reminders, want 2", total)
+	}
+	b, err := store.Get("b")
+	if err != nil || b.Title != "New title" {
+		t.Errorf("conflicting copies should resolve to the newest, got %+v, %v", b, err)
+	}
+	if b.DueTime.Location() != time.Local {
+		t.Errorf("loaded times should be local, got %v", b.DueTime.Location())
+	}
+
+	report, err := store.Compact()
+	if err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if report.Duplicates != 1 || report.Conflicts != 1 || report.Timestamps != 6 || !report.Changed() {
+		t.Errorf("report = %+v", report)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if strings.Contains(string(data), "+02:00") {
+		t.Errorf("times not stored in UTC:\n%s", data)
+	}
+	if strings.Index(string(data), `"id": "a"`) > strings.Index(string(data), `"id": "b"`) {
+		t.Errorf("records not in short ID order:\n%s", data)
+	}
+
+	// A second run has nothing left to do
+	store, err = models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if report, err := store.Compact(); err != nil || report.Changed() {
+		t.Errorf("second Compact = %+v, %v", report, err)
+	}
+}
+
+func TestLastCheckInTimeZone(t *testing.T) {
+	dir := t.TempDir()
+	records := `[
+  {"id": "a", "short_id": 1, "title": "Write report", "due_time": "2030-01-01T09:00:00Z", "priority": "medium", "completed": false, "created_at": "2029-12-01T10:00:00Z", "updated_at": "2029-12-01T10:00:00Z", "check_in": "daily", "last_check_in": "2029-12-02T10:00:00+02:00"}
+]`
+	path := filepath.Join(dir, "reminders.json")
+	if err := os.WriteFile(path, []byte(records), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	store, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	want := time.Date(2029, 12, 2, 8, 0, 0, 0, time.UTC)
+	r, err := store.Get("a")
+	if err != nil || r.LastCheckIn.Location() != time.Local || !r.LastCheckIn.Equal(want) {
+		t.Fatalf("loaded last check-in %v, %v", r.LastCheckIn, err)
+	}
+
+	if _, err := store.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"last_check_in": "2029-12-02T08:00:00Z"`) {
+		t.Errorf("last check-in not stored in UTC:\n%s", data)
+	}
+	// Saving leaves the reminders in memory in local time
+	if r, _ := store.Get("a"); r.LastCheckIn.Location() != time.Local {
+		t.Errorf("last check-in after saving is in %v", r.LastCheckIn.Location())
+	}
+}
+
+func TestBatchSaves(t *testing.T) {
+	dir := t.TempDir()
+	store, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	due := time.Now().Add(time.Hour)
+	batch := make([]*models.Reminder, 100)
+	for i := range batch {
+		batch[i] = models.NewReminder(fmt.Sprintf("Imported %d", i), due, models.Low)
+	}
+	if err := store.AddAll(batch); err != nil {
+		t.Fatalf("AddAll: %v", err)
+	}
+	if batch[99].ShortID != 100 {
+		t.Errorf("last short ID = %d, want 100", batch[99].ShortID)
+	}
+
+	for _, reminder := range batch {
+		reminder.Priority = models.High
+	}
+	if err := store.UpdateAll(batch); err != nil {
+		t.Fatalf("UpdateAll: %v", err)
+	}
+
+	// A missing reminder fails the whole batch
+	missing := models.NewReminder("Not stored", due, models.Low)
+	batch[0].Priority = models.Medium
+	if err := store.UpdateAll([]*models.Reminder{batch[0], missing}); err == nil {
+		t.Error("UpdateAll with a missing reminder should fail")
+	}
+
+	reloaded, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	all := reloaded.GetAll(nil)
+	if len(all) != 100 {
+		t.Fatalf("reloaded %d reminders, want 100", len(all))
+	}
+	for _, reminder := range all {
+		if reminder.Priority != models.High {
+			t.Fatalf("%s has priority %s, want high", reminder.Title, reminder.Priority)
+		}
+	}
+}
+
+func TestSaveKeepsFileMode(t *testing.T) {
+	dir := t.TempDir()
+	store, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	file := filepath.Join(dir, "reminders.json")
+	if err := store.Add(models.NewReminder("Private", time.Now().Add(time.Hour), models.Medium)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(file, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Add(models.NewReminder("Still private", time.Now().Add(time.Hour), models.Medium)); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{file, file + ".1"} {
+		if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+			t.Errorf("%s: %v, %v; want mode 0600", filepath.Base(path), info.Mode().Perm(), err)
+		}
+	}
+}
+
+func TestConcurrentSaves(t *testing.T) {
+	dir := t.TempDir()
+	store, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := store.Add(models.NewReminder("Busy", time.Now().Add(time.Hour), models.Medium)); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 20)
+	for range 20 {
+		go func() { errs <- store.Save() }()
+	}
+	for range 20 {
+		if err := <-errs; err != nil {
+			t.Errorf("Save: %v", err)
+		}
+	}
+	if len(store.Backups()) != 1 {
+		t.Errorf("%d backups after saving within the hour, want 1", len(store.Backups()))
+	}
+	if reloaded, err := models.NewStore(dir); err != nil || len(reloaded.GetAll(nil)) != 1 {
+		t.Errorf("reloading after concurrent saves: %v", err)
+	}
+}
+
+func TestStoreBackups(t *testing.T) {
+	dir := t.TempDir()
+	store, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	file := filepath.Join(dir, "reminders.json")
+	add := func(title string) {
+		t.Helper()
+		if err := store.Add(models.NewReminder(title, time.Now().Add(time.Hour), models.Medium)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	age := func(path string) {
+		t.Helper()
+		old := time.Now().Add(-2 * time.Hour)
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Nothing to keep before the first save, then one copy an hour
+	add("One")
+	if backups := store.Backups(); len(backups) != 0 {
+		t.Fatalf("backups after the first save: %+v", backups)
+	}
+	add("Two")
+	add("Three")
+	if backups := store.Backups(); len(backups) != 1 || backups[0].Reminders != 1 {
+		t.Fatalf("backups within the hour: %+v, want just the one-reminder file", backups)
+	}
+	age(file + ".1")
+	add("Four")
+	backups := store.Backups()
+	if len(backups) != 2 || backups[0].Reminders != 3 || backups[1].Reminders != 1 {
+		t.Fatalf("backups after an hour: %+v, want 3 then 1 reminders", backups)
+	}
+
+	// Saves leave no temporary files behind
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			t.Errorf("left behind %s", entry.Name())
+		}
+	}
+
+	if err := store.RestoreBackup(2); err != nil {
+		t.Fatalf("RestoreBackup: %v", err)
+	}
+	if total, _, _, _ := store.Count(); total != 1 {
+		t.Errorf("%d reminders after restoring backup 2, want 1", total)
+	}
+	// The reminders from before the restore are backup 1 now
+	if backups := store.Backups(); len(backups) != 3 || backups[0].Reminders != 4 {
+		t.Errorf("backups after restoring: %+v", backups)
+	}
+	if err := store.RestoreBackup(9); err == nil {
+		t.Error("restored a backup that doesn't exist")
+	}
+
+	// Some keep no backups at all
+	store.SetBackups(0)
+	age(file + ".1")
+	add("Five")
+	if backups := store.Backups(); len(backups) != 3 || backups[0].Reminders != 4 {
+		t.Errorf("backups with backups: 0: %+v, want them left as they were", backups)
+	}
+}
+
+func TestImportTombstones(t *testing.T) {
+	dir := t.TempDir()
+	laptop, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	gone := models.NewReminder("Deleted on the laptop", time.Now().Add(time.Hour), models.Medium)
+	kept := models.NewReminder("Still there", time.Now().Add(2*time.Hour), models.Medium)
+	for _, r := range []*models.Reminder{gone, kept} {
+		if err := laptop.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before, err := laptop.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileBefore, err := os.ReadFile(filepath.Join(dir, "reminders.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := laptop.Delete(gone.ID); err != nil {
+		t.Fatal(err)
+	}
+	after, err := laptop.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(after), `"deleted_at"`) {
+		t.Fatalf("export has no tombstone:\n%s", after)
+	}
+
+	// An older export doesn't bring the reminder back
+	report, err := laptop.Import(before)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if report.Skipped != 1 || report.Added != 0 {
+		t.Errorf("importing the older export: %+v, want 1 skipped", report)
+	}
+	if _, err := laptop.Get(gone.ID); err == nil {
+		t.Error("the older export brought the deleted reminder back")
+	}
+
+	// The newer export deletes it where the older one was imported, unless
+	// it was changed there since
+	desktop := newTestStore(t)
+	if _, err := desktop.Import(before); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := desktop.Import(after); err != nil || report.Deleted != 1 {
+		t.Errorf("importing the newer export: %+v, %v; want 1 deleted", report, err)
+	}
+	if _, err := desktop.Get(gone.ID); err == nil {
+		t.Error("the tombstone didn't delete the reminder")
+	}
+	phone := newTestStore(t)
+	if _, err := phone.Import(before); err != nil {
+		t.Fatal(err)
+	}
+	edited, _ := phone.Get(gone.ID)
+	edited.Title = "Edited on the phone"
+	if err := phone.Update(edited); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := phone.Import(after); err != nil || report.Deleted != 0 {
+		t.Errorf("importing the newer export over an edit: %+v, %v; want nothing deleted", report, err)
+	}
+
+	// Tombstones are saved, and win over older copies in a file merged by hand
+	fileAfter, err := os.ReadFile(filepath.Join(dir, "reminders.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var merged []json.RawMessage
+	for _, file := range [][]byte{fileBefore, fileAfter} {
+		var records []json.RawMessage
+		if err := json.Unmarshal(file, &records); err != nil {
+			t.Fatal(err)
+		}
+		merged = append(merged, records...)
+	}
+	data, _ := json.Marshal(merged)
+	if err := os.WriteFile(filepath.Join(dir, "reminders.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := models.NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if _, err := reloaded.Get(gone.ID); err == nil {
+		t.Error("an older copy in the merged file brought the deleted reminder back")
+	}
+	if _, err := reloaded.Get(kept.ID); err != nil {
+		t.Errorf("lost a reminder merging: %v", err)
+	}
+	if tombstones := reloaded.Tombstones(); len(tombstones) != 1 || tombstones[0].ID != gone.ID {
+		t.Errorf("Tombstones = %+v", tombstones)
+	}
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestSunEventTime(t *testing.T) {
+	utils.SetLocation(52.52, 13.405) // Berlin
+	defer utils.SetLocation(0, 0)
+
+	day := time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		event string
+		want  time.Time
+	}{
+		{utils.Sunrise, time.Date(2025, time.June, 21, 2, 43, 0, 0, time.UTC)},
+		{utils.Sunset, time.Date(2025, time.June, 21, 19, 33, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := utils.SunEventTime(tt.event, day)
+		if err != nil {
+			t.Fatalf("SunEventTime(%s): %v", tt.event, err)
+		}
+		if diff := got.Sub(tt.want); diff < -3*time.Minute || diff > 3*time.Minute {
+			t.Errorf("SunEventTime(%s) = %s, want about %s", tt.event, got.Format("15:04"), tt.want.Format("15:04"))
+		}
+	}
+
+	// Midsummer north of the Arctic Circle: the sun never sets
+	utils.SetLocation(78.22, 15.65)
+	if _, err := utils.SunEventTime(utils.Sunset, day); err == nil {
+		t.Error("SunEventTime should fail during the midnight sun")
+	}
+}
+
+func TestParseSunsetReminder(t *testing.T) {
+	defer utils.SetLocation(0, 0)
+
+	utils.SetLocation(0, 0)
+	if _, err := utils.ParseReminder("close the blinds at sunset", models.Medium); err == nil {
+		t.Error("'at sunset' without a location should fail")
+	}
+
+	utils.SetLocation(52.52, 13.405)
+	parsed, err := utils.ParseReminder("close the blinds at sunset", models.Medium)
+	if err != nil {
+		t.Fatalf("ParseReminder: %v", err)
+	}
+	if parsed.Title != "close the blinds" || parsed.SunEvent != utils.Sunset || !parsed.HasTime {
+		t.Errorf("got title %q, sun event %q, has time %v", parsed.Title, parsed.SunEvent, parsed.HasTime)
+	}
+
+	parsed, err = utils.ParseReminder("watch the sunrise tomorrow at 6am", models.Medium)
+	if err != nil {
+		t.Fatalf("ParseReminder: %v", err)
+	}
+	if parsed.SunEvent != "" {
+		t.Errorf("an explicit time should not anchor to the sun, got %q", parsed.SunEvent)
+	}
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestWindowsToastXML(t *testing.T) {
+	toast := utils.WindowsToastXML("Overdue Reminder", "Fix <b> & \"quotes\"\nDue: Today", models.High, "abc-123", false)
+
+	// Must be well-formed even with markup in the reminder title
+	decoder := xml.NewDecoder(strings.NewReader(toast))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if err.Error() != "EOF" {
+				t.Fatalf("toast XML is malformed: %v\n%s", err, toast)
+			}
+			break
+		}
+	}
+
+	for _, want := range []string{
+		`scenario="reminder"`,
+		`arguments="nancy://complete/abc-123"`,
+		`arguments="nancy://snooze/abc-123"`,
+		"<text>Due: Today</text>",
+	} {
+		if !strings.Contains(toast, want) {
+			t.Errorf("toast XML missing %s:\n%s", want, toast)
+		}
+	}
+
+	urgent := utils.WindowsToastXML("Overdue Reminder", "Take medication", models.Low, "abc-123", true)
+	if !strings.Contains(urgent, `scenario="urgent"`) {
+		t.Errorf("critical reminders should use the urgent scenario:\n%s", urgent)
+	}
+
+	plain := utils.WindowsToastXML("Nancy", "Hello", models.Medium, "", false)
+	if strings.Contains(plain, "<actions>") || strings.Contains(plain, "scenario") {
+		t.Errorf("a toast without a reminder should have no buttons:\n%s", plain)
+	}
+}
+
+func TestParseToastAction(t *testing.T) {
+	verb, id, err := utils.ParseToastAction("nancy://snooze/abc-123/")
+	if err != nil || verb != "snooze" || id != "abc-123" {
+		t.Errorf("ParseToastAction = %q, %q, %v", verb, id, err)
+	}
+
+	for _, bad := range []string{"", "nancy://", "nancy://complete", "https://complete/abc"} {
+		if _, _, err := utils.ParseToastAction(bad); err == nil {
+			t.Errorf("ParseToastAction(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestCleanTranscript(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Call mom tomorrow at 5pm\n", "Call mom tomorrow at 5pm"},
+		{"[00:00:00.000 --> 00:00:02.000]  Call the plumber\n[00:00:02.000 --> 00:00:03.500]  tomorrow at 3pm\n", "Call the plumber tomorrow at 3pm"},
+		{"  \n\t ", ""},
+	}
+	for _, tt := range tests {
+		if got := utils.CleanTranscript(tt.input); got != tt.want {
+			t.Errorf("CleanTranscript(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
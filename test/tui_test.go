@@ -0,0 +1,43 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/models"
+	"github.com/ivyascorp-net/nagging-nancy/internal/tui"
+)
+
+func TestTUIToggleRecurring(t *testing.T) {
+	h := newHarness(t)
+	due := time.Now().Add(time.Hour).Truncate(time.Second)
+	reminder := models.NewReminder("Take vitamins", due, models.Medium)
+	reminder.Recurring = &models.RecurringRule{Frequency: "daily", Interval: 1}
+	if err := h.app.GetStore().Add(reminder); err != nil {
+		t.Fatal(err)
+	}
+
+	var model tea.Model = tui.NewModel(h.app.GetStore(), h.app.GetConfig())
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	space := tea.KeyMsg{Type: tea.KeySpace}
+
+	// Space completes today's occurrence and moves on to tomorrow's
+	model, _ = model.Update(space)
+	if r := h.reminder("Take vitamins"); !r.DueTime.Equal(due.AddDate(0, 0, 1)) || len(r.History) != 1 || r.Completed {
+		t.Fatalf("after space: due %v, history %+v, completed %v", r.DueTime, r.History, r.Completed)
+	}
+
+	// Space again takes it back
+	model, _ = model.Update(space)
+	if r := h.reminder("Take vitamins"); !r.DueTime.Equal(due) || len(r.History) != 0 || r.Completed {
+		t.Errorf("after space again: due %v, history %+v, completed %v", r.DueTime, r.History, r.Completed)
+	}
+
+	// And a third time completes it again, rather than undoing twice
+	model.Update(space)
+	if r := h.reminder("Take vitamins"); !r.DueTime.Equal(due.AddDate(0, 0, 1)) || len(r.History) != 1 {
+		t.Errorf("after a third space: due %v, history %+v", r.DueTime, r.History)
+	}
+}
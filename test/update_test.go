@@ -0,0 +1,133 @@
+package test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestNewerVersion(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"1.2.3", "1.2.4", true},
+		{"v1.2.3", "1.10.0", true},
+		{"1.2.3", "1.2.3", false},
+		{"2.0.0", "1.9.9", false},
+		{"1.2.3-rc1", "v1.2.3", false},
+	}
+	for _, tt := range tests {
+		got, err := utils.NewerVersion(tt.current, tt.latest)
+		if err != nil || got != tt.want {
+			t.Errorf("NewerVersion(%s, %s) = %v, %v; want %v", tt.current, tt.latest, got, err, tt.want)
+		}
+	}
+
+	if _, err := utils.NewerVersion("dev", "1.0.0"); err == nil {
+		t.Error("development builds can't be compared")
+	}
+}
+
+func TestReleaseAssetName(t *testing.T) {
+	for _, tt := range []struct{ goos, goarch, goarm, want string }{
+		{"linux", "amd64", "", "nancy_Linux_x86_64.tar.gz"},
+		{"darwin", "arm64", "", "nancy_Darwin_arm64.tar.gz"},
+		{"linux", "arm", "6", "nancy_Linux_armv6.tar.gz"},
+		{"linux", "386", "", "nancy_Linux_i386.tar.gz"},
+	} {
+		if got, err := utils.ReleaseAssetName(tt.goos, tt.goarch, tt.goarm); err != nil || got != tt.want {
+			t.Errorf("ReleaseAssetName(%s, %s) = %q, %v", tt.goos, tt.goarch, got, err)
+		}
+	}
+	if _, err := utils.ReleaseAssetName("windows", "amd64", ""); err == nil {
+		t.Error("there are no Windows release builds")
+	}
+}
+
+func TestVerifyAndExtractRelease(t *testing.T) {
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	binary := []byte("#!/bin/sh\necho new\n")
+	tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0644, Size: 2, Typeflag: tar.TypeReg})
+	tw.Write([]byte("hi"))
+	tw.WriteHeader(&tar.Header{Name: "nancy", Mode: 0755, Size: int64(len(binary)), Typeflag: tar.TypeReg})
+	tw.Write(binary)
+	tw.Close()
+	gz.Close()
+
+	name := "nancy_Linux_x86_64.tar.gz"
+	checksums := []byte(fmt.Sprintf("%x  %s\n%x  other.tar.gz\n", sha256.Sum256(archive.Bytes()), name, sha256.Sum256(nil)))
+	if err := utils.VerifyChecksum(archive.Bytes(), name, checksums); err != nil {
+		t.Fatalf("VerifyChecksum: %v", err)
+	}
+	if err := utils.VerifyChecksum(append(archive.Bytes(), 0), name, checksums); err == nil {
+		t.Error("a damaged download should fail verification")
+	}
+	if err := utils.VerifyChecksum(archive.Bytes(), "nancy_Darwin_arm64.tar.gz", checksums); err == nil {
+		t.Error("an archive without a checksum should fail verification")
+	}
+
+	extracted, err := utils.ExtractBinary(archive.Bytes(), "nancy")
+	if err != nil || !bytes.Equal(extracted, binary) {
+		t.Fatalf("ExtractBinary = %q, %v", extracted, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "nancy")
+	os.WriteFile(path, []byte("old"), 0755)
+	if err := utils.ReplaceExecutable(path, extracted); err != nil {
+		t.Fatalf("ReplaceExecutable: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !bytes.Equal(data, binary) {
+		t.Errorf("executable = %q after the update", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("update left %d files behind", len(entries)-1)
+	}
+
+	// When the swap fails, whatever was there stays put
+	blocked := filepath.Join(t.TempDir(), "nancy")
+	os.MkdirAll(filepath.Join(blocked, "keep"), 0755)
+	if err := utils.ReplaceExecutable(blocked, extracted); err == nil {
+		t.Error("ReplaceExecutable over a directory should fail")
+	}
+	if _, err := os.Stat(filepath.Join(blocked, "keep")); err != nil {
+		t.Errorf("a failed update touched the old one: %v", err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(blocked)); len(entries) != 1 {
+		t.Errorf("a failed update left %d files behind", len(entries)-1)
+	}
+}
+
+func TestLatestRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/"+utils.ReleaseRepo+"/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"tag_name": "v1.5.0", "html_url": "https://example.com/v1.5.0",
+			"assets": [{"name": "checksums.txt", "browser_download_url": "https://example.com/checksums.txt"}]}`)
+	}))
+	defer server.Close()
+
+	defer func(api string) { utils.GitHubAPI = api }(utils.GitHubAPI)
+	utils.GitHubAPI = server.URL
+
+	release, err := utils.LatestRelease()
+	if err != nil {
+		t.Fatalf("LatestRelease: %v", err)
+	}
+	if release.Version != "1.5.0" || release.Assets["checksums.txt"] == "" {
+		t.Errorf("release = %+v", release)
+	}
+}
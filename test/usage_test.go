@@ -0,0 +1,53 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivyascorp-net/nagging-nancy/internal/utils"
+)
+
+func TestUsageLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), utils.UsageFile)
+	monday := time.Date(2025, 3, 3, 9, 15, 0, 0, time.Local)
+
+	for _, event := range []utils.UsageEvent{
+		{At: monday, Command: "add"},
+		{At: monday.Add(20 * time.Minute), Command: "add"},
+		{At: monday.AddDate(0, 0, 1), Command: "add"},
+		{At: monday.AddDate(0, 0, 1), Command: "list"},
+		{At: monday.AddDate(0, 0, 2), Command: "daemon start"},
+	} {
+		if err := utils.RecordUsage(path, event.Command, event.At); err != nil {
+			t.Fatalf("RecordUsage: %v", err)
+		}
+	}
+
+	// A damaged line doesn't lose the rest of the log
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	file.WriteString("{not json\n")
+	file.Close()
+
+	events, err := utils.LoadUsage(path)
+	if err != nil {
+		t.Fatalf("LoadUsage: %v", err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("got %d events, want 5", len(events))
+	}
+
+	counts := utils.CommandCounts(events)
+	if counts[0] != (utils.CommandCount{Command: "add", Count: 3}) || len(counts) != 3 {
+		t.Errorf("CommandCounts = %+v", counts)
+	}
+
+	day, hour, count := utils.BusiestSlot(events, "add")
+	if day != time.Monday || hour != 9 || count != 2 {
+		t.Errorf("BusiestSlot = %v %d:00 (%d)", day, hour, count)
+	}
+	if _, _, count := utils.BusiestSlot(events, "edit"); count != 0 {
+		t.Errorf("BusiestSlot for an unused command = %d", count)
+	}
+}